@@ -1,94 +1,290 @@
 package interfaces
 
-//IEthernetSwitchManager is the interface is needed to manage ethernet switch
+import (
+	"context"
+	"rol/domain"
+)
+
+//IEthernetSwitchManager is the interface is needed to manage ethernet switch.
+//All methods must be safe for concurrent use and must respect the context deadline.
 type IEthernetSwitchManager interface {
 	//GetVLANs gets all VLANs on switch
 	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
 	//Return:
 	//	[]int - slice of VLANs
 	//	error - if an error occurs, otherwise nil
-	GetVLANs() ([]int, error)
+	GetVLANs(ctx context.Context) ([]int, error)
 	//GetVLANsOnPort gets all VLANs on given port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
 	//Return:
 	//	int - untagged VLAN ID
 	//	[]int - slice of tagged VLANs IDs
 	//	error - if an error occurs, otherwise nil
-	GetVLANsOnPort(portName string) (int, []int, error)
+	GetVLANsOnPort(ctx context.Context, portName string) (int, []int, error)
 	//AddTaggedVLANOnPort add tagged VLAN on given port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
 	//	vlanID - vlan ID
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	AddTaggedVLANOnPort(portName string, vlanID int) error
+	AddTaggedVLANOnPort(ctx context.Context, portName string, vlanID int) error
 	//AddUntaggedVLANOnPort add untagged VLAN on given port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
 	//	vlanID - vlan ID
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	AddUntaggedVLANOnPort(portName string, vlanID int) error
+	AddUntaggedVLANOnPort(ctx context.Context, portName string, vlanID int) error
 	//RemoveVLANFromPort remove VLAN from given port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - name of port
 	//	vlanID	- vlan ID
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	RemoveVLANFromPort(portName string, vlanID int) error
+	RemoveVLANFromPort(ctx context.Context, portName string, vlanID int) error
 	//SetPortPVID sets port PVID
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
 	//	vlanID - vlan ID
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	SetPortPVID(portName string, vlanID int) error
+	SetPortPVID(ctx context.Context, portName string, vlanID int) error
 	//DeleteVLAN delete VLAN by id
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	vlanID - vlan ID
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	DeleteVLAN(vlanID int) error
+	DeleteVLAN(ctx context.Context, vlanID int) error
 	//CreateVLAN create vlan on switch
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	vlanID	- vlan ID
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	CreateVLAN(vlanID int) error
+	CreateVLAN(ctx context.Context, vlanID int) error
 	//GetPOEPortStatus gets poe status on given port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
 	//Return:
 	//	string - poe port status "enable" or "disable"
 	//	error - if an error occurs, otherwise nil
-	GetPOEPortStatus(portName string) (string, error)
+	GetPOEPortStatus(ctx context.Context, portName string) (string, error)
 	//EnablePOEPort enable poe on give port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
 	//	poeType - poe type: "poe", "poe+" etc
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	EnablePOEPort(portName, poeType string) error
+	EnablePOEPort(ctx context.Context, portName, poeType string) error
 	//DisablePOEPort disable poe on given port
 	//
 	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	portName - port name
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	DisablePOEPort(ctx context.Context, portName string) error
+	//SetPortShutdown sets administrative state of the port
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	portName - port name
+	//	shutdown - true to disable the port, false to enable it
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	SetPortShutdown(ctx context.Context, portName string, shutdown bool) error
+	//SetPortDescription sets port description
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	portName - port name
+	//	description - port description, empty to remove it
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	SetPortDescription(ctx context.Context, portName, description string) error
+	//SetPortSpeed sets port speed and duplex
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
 	//	portName - port name
+	//	speed - port speed: "auto", "10M", "100M", "1000M", empty to keep the current one
+	//	duplex - port duplex: "auto", "full", "half", empty to keep the current one
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	DisablePOEPort(portName string) error
+	SetPortSpeed(ctx context.Context, portName, speed, duplex string) error
+	//CreateLAG creates link aggregation group with given member ports.
+	//LAG can be used as a port in other methods by the name from domain.LAGPortName.
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	groupID - port channel number
+	//	mode - LACP mode: "active", "passive" or "static"
+	//	portNames - member ports names
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	CreateLAG(ctx context.Context, groupID int, mode string, portNames []string) error
+	//AddLAGMembers adds ports to the link aggregation group
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	groupID - port channel number
+	//	mode - LACP mode: "active", "passive" or "static"
+	//	portNames - ports names
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	AddLAGMembers(ctx context.Context, groupID int, mode string, portNames []string) error
+	//RemoveLAGMembers removes ports from their link aggregation group
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	portNames - ports names
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	RemoveLAGMembers(ctx context.Context, portNames []string) error
+	//DeleteLAG deletes link aggregation group
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	groupID - port channel number
+	//	portNames - member ports names
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	DeleteLAG(ctx context.Context, groupID int, portNames []string) error
 	//SaveConfig save current settings on switch
 	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	SaveConfig(ctx context.Context) error
+	//GetRunningConfig gets full running configuration of the switch as text
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	string - running configuration
+	//	error - if an error occurs, otherwise nil
+	GetRunningConfig(ctx context.Context) (string, error)
+	//RestoreRunningConfig applies configuration previously got by GetRunningConfig to the switch, so VLANs,
+	//LAG members and port settings changed after it was got are reverted.
+	//The configuration is not saved to the startup configuration.
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	config - running configuration
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	RestoreRunningConfig(ctx context.Context, config string) error
+	//GetFirmwareInfo gets firmware version and hardware revision of the switch
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	domain.EthernetSwitchFirmwareInfo - switch firmware information
+	//	error - if an error occurs, otherwise nil
+	GetFirmwareInfo(ctx context.Context) (domain.EthernetSwitchFirmwareInfo, error)
+	//UpgradeFirmware makes the switch to download the firmware image from the TFTP server, install it
+	//and reboot. The method returns when the switch starts rebooting.
+	//
+	//Params:
+	//	ctx - context with deadline for the image download and installation
+	//	tftpAddress - IP address of the TFTP server
+	//	fileName - image file name on the TFTP server
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	UpgradeFirmware(ctx context.Context, tftpAddress, fileName string) error
+	//GetConfig gets configuration of the switch: VLANs and all physical ports with their PVID, VLANs, POE status,
+	//administrative state, description, speed and duplex
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	domain.EthernetSwitchConfig - switch configuration
+	//	error - if an error occurs, otherwise nil
+	GetConfig(ctx context.Context) (domain.EthernetSwitchConfig, error)
+	//GetLLDPNeighbors gets neighbors discovered by LLDP on all switch ports
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	[]domain.EthernetSwitchLLDPNeighbor - LLDP neighbors
+	//	error - if an error occurs, otherwise nil
+	GetLLDPNeighbors(ctx context.Context) ([]domain.EthernetSwitchLLDPNeighbor, error)
+	//GetMACTable gets MAC address table of the switch
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	[]domain.EthernetSwitchMACEntry - MAC address table entries
+	//	error - if an error occurs, otherwise nil
+	GetMACTable(ctx context.Context) ([]domain.EthernetSwitchMACEntry, error)
+	//GetPortStatus gets actual state of the port: link status, speed, counters and POE power
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	portName - port name
+	//Return:
+	//	domain.EthernetSwitchPortStatus - port status
+	//	error - if an error occurs, otherwise nil
+	GetPortStatus(ctx context.Context, portName string) (domain.EthernetSwitchPortStatus, error)
+	//Begin starts the configuration transaction. Transaction holds one switch session until
+	//it is committed or rolled back, current configuration of the changed VLANs and ports is
+	//captured before changes are applied.
+	//
+	//Params:
+	//	ctx - context with deadline for waiting of the free switch session
+	//Return:
+	//	IEthernetSwitchTransaction - started transaction
+	//	error - if an error occurs, otherwise nil
+	Begin(ctx context.Context) (IEthernetSwitchTransaction, error)
+	//Close closes all switch sessions and stops the sessions keepalive, the manager can't be used after closing
+	Close()
+}
+
+//IEthernetSwitchTransaction is the interface for the ethernet switch configuration transaction
+type IEthernetSwitchTransaction interface {
+	//Apply applies changeset to the running configuration of the switch
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//	changeset - changes to apply
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	Apply(ctx context.Context, changeset domain.EthernetSwitchChangeset) error
+	//Commit saves the running configuration of the switch and finishes the transaction,
+	//the transaction is not finished if saving fails, so it must be rolled back
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	Commit(ctx context.Context) error
+	//Rollback reverts changed VLANs and ports to the configuration captured
+	//before changes and finishes the transaction
+	//
+	//Params:
+	//	ctx - context with deadline for the switch operation
 	//Return:
 	//	error - if an error occurs, otherwise nil
-	SaveConfig() error
+	Rollback(ctx context.Context) error
 }
@@ -9,4 +9,9 @@ import (
 type IEthernetSwitchManagerProvider interface {
 	//Get ethernet switch manager
 	Get(ctx context.Context, switchID uuid.UUID) (IEthernetSwitchManager, error)
+	//Invalidate closes the switch manager, so the new one will be created with the actual switch
+	//address and credentials on the next Get
+	Invalidate(switchID uuid.UUID)
+	//Close closes all switch managers
+	Close()
 }
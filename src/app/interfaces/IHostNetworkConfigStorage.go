@@ -1,26 +1,45 @@
 package interfaces
 
-import "rol/domain"
+import (
+	"github.com/google/uuid"
+	"rol/domain"
+)
 
 //IHostNetworkConfigStorage interface for network config management
 type IHostNetworkConfigStorage interface {
-	//SaveConfig Save host network configuration to storage
+	//SaveConfig Save host network configuration to storage as a new revision
 	//
 	//Params
 	//	config - configuration to save
+	//	author - who saved the configuration
+	//	requestID - ID of the API request that saved the configuration, uuid.Nil if there is no request
 	//Return
 	//	error - if an error occurs, otherwise nil
-	SaveConfig(config domain.HostNetworkConfig) error
+	SaveConfig(config domain.HostNetworkConfig, author string, requestID uuid.UUID) error
 	//GetConfig Get host network configuration from storage
 	//
 	//Return
 	//	domain.HostNetworkConfig - configuration to save
 	//	error - if an error occurs, otherwise nil
 	GetConfig() (domain.HostNetworkConfig, error)
-	//GetBackupConfig  Get backup of host network configuration from storage
+	//GetBackupConfig  Get backup of host network configuration from storage, it's the previous revision
 	//
 	//Return
 	//	domain.HostNetworkConfig - configuration to save
 	//	error - if an error occurs, otherwise nil
 	GetBackupConfig() (domain.HostNetworkConfig, error)
+	//GetRevisions Get saved configuration revisions from the latest to the oldest
+	//
+	//Return
+	//	[]domain.HostNetworkConfigRevision - configuration revisions
+	//	error - if an error occurs, otherwise nil
+	GetRevisions() ([]domain.HostNetworkConfigRevision, error)
+	//GetRevision Get saved configuration revision by ID
+	//
+	//Params
+	//	id - revision ID
+	//Return
+	//	domain.HostNetworkConfigRevision - configuration revision
+	//	error - if an error occurs, otherwise nil
+	GetRevision(id uuid.UUID) (domain.HostNetworkConfigRevision, error)
 }
@@ -1,8 +1,10 @@
 package interfaces
 
 import (
+	"github.com/google/uuid"
 	"net"
 	"rol/domain"
+	"time"
 )
 
 //IHostNetworkManager is an interface for network manager
@@ -38,6 +40,96 @@ type IHostNetworkManager interface {
 	//	string - new bridge name that will be rol.br.{name}
 	//	error - if an error occurs, otherwise nil
 	CreateBridge(name string) (string, error)
+	//CreateBond creates bonding interface on host, slaves are added by SetLinkMaster
+	//
+	//Params:
+	//	name - new bond name
+	//	mode - bonding mode: "active-backup", "802.3ad" or "balance-alb"
+	//	miimon - MII link monitoring frequency in milliseconds
+	//Return:
+	//	string - new bond name that will be rol.bond.{name}
+	//	error - if an error occurs, otherwise nil
+	CreateBond(name, mode string, miimon int) (string, error)
+	//CreateVeth creates virtual ethernet pair on host, both ends are created on the host
+	//
+	//Params:
+	//	name - new veth pair name
+	//Return:
+	//	string - new veth name that will be rol.veth.{name}, its peer name will be rol.peer.{name}
+	//	error - if an error occurs, otherwise nil
+	CreateVeth(name string) (string, error)
+	//GetNamespaces gets list of RoL network namespaces with their links
+	//
+	//Return:
+	//	[]domain.HostNetworkNamespace - list of namespaces
+	//	error - if an error occurs, otherwise nil
+	GetNamespaces() ([]domain.HostNetworkNamespace, error)
+	//CreateNamespace creates network namespace on host
+	//
+	//Params:
+	//	name - new namespace name
+	//Return:
+	//	string - new namespace name that will be rol.ns.{name}
+	//	error - if an error occurs, otherwise nil
+	CreateNamespace(name string) (string, error)
+	//DeleteNamespace deletes network namespace on host, its links are moved back to the host before
+	//
+	//Params:
+	//	name - namespace name
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	DeleteNamespace(name string) error
+	//SetLinkNamespace moves the host link into the network namespace and sets it up there
+	//
+	//Params:
+	//	linkName - name of the link
+	//	namespace - name of the namespace
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	SetLinkNamespace(linkName, namespace string) error
+	//UnsetLinkNamespace moves the link from the network namespace back to the host and sets it up
+	//
+	//Params:
+	//	linkName - name of the link
+	//	namespace - name of the namespace
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	UnsetLinkNamespace(linkName, namespace string) error
+	//NamespaceAddrAdd Add new ip address for network interface in the network namespace
+	//
+	//Params:
+	//	namespace - name of the namespace
+	//	linkName - name of the interface
+	//	addr - ip address with mask net.IPNet
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	NamespaceAddrAdd(namespace, linkName string, addr net.IPNet) error
+	//NamespaceAddrDelete Delete ip address from network interface in the network namespace
+	//
+	//Params:
+	//	namespace - name of the namespace
+	//	linkName - name of the interface
+	//	addr - ip address with mask net.IPNet
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	NamespaceAddrDelete(namespace, linkName string, addr net.IPNet) error
+	//SetBridgeNat gives the bridge networks an access to the upstream interface networks,
+	//creates masquerade and forward rules in the RoL chains and enables IPv4 forwarding.
+	//Masquerade rules follow the current bridge IPv4 addresses, so the method is called again after addresses are changed
+	//
+	//Params:
+	//	bridgeName - name of the bridge
+	//	upstreamInterface - name of the interface through which NAT traffic is sent
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	SetBridgeNat(bridgeName, upstreamInterface string) error
+	//UnsetBridgeNat removes NAT rules of the bridge
+	//
+	//Params:
+	//	bridgeName - name of the bridge
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	UnsetBridgeNat(bridgeName string) error
 	//SetLinkUp enables the link
 	//
 	//Params:
@@ -83,7 +175,27 @@ type IHostNetworkManager interface {
 	//Return:
 	//	error - if an error occurs, otherwise nil
 	AddrDelete(linkName string, addr net.IPNet) error
-	//CreateTrafficRule Create netfilter traffic rule for specified table
+	//GetRoutes gets static IPv4 and IPv6 routes of all routing tables that are managed by RoL
+	//
+	//Return:
+	//	[]domain.HostNetworkRoute - slice of routes
+	//	error - if an error occurs, otherwise nil
+	GetRoutes() ([]domain.HostNetworkRoute, error)
+	//CreateRoute creates static route that is managed by RoL
+	//
+	//Params:
+	//	route - route entity
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	CreateRoute(route domain.HostNetworkRoute) error
+	//DeleteRoute deletes static route that is managed by RoL
+	//
+	//Params:
+	//	route - route entity
+	//Return:
+	//	error - errors.NotFound if the route does not exist, otherwise nil if no other errors occur
+	DeleteRoute(route domain.HostNetworkRoute) error
+	//CreateTrafficRule Create netfilter traffic rule for specified table in the RoL chain ROL-{chain}
 	//
 	//Params:
 	//	table - table to create a rule
@@ -100,7 +212,7 @@ type IHostNetworkManager interface {
 	//Return:
 	//	error - if an error occurs, otherwise nil
 	DeleteTrafficRule(table string, rule domain.HostNetworkTrafficRule) error
-	//GetChainRules Get selected netfilter chain rules at specified table
+	//GetChainRules Get selected netfilter RoL chain rules at specified table
 	//
 	//Params:
 	//	table - table to get a rules
@@ -109,7 +221,7 @@ type IHostNetworkManager interface {
 	//	[]domain.HostNetworkTrafficRule - slice of rules
 	//	error - if an error occurs, otherwise nil
 	GetChainRules(table string, chain string) ([]domain.HostNetworkTrafficRule, error)
-	//GetTableRules Get specified netfilter table rules
+	//GetTableRules Get specified netfilter table rules of the RoL chains
 	//
 	//Params:
 	//	table - table to get a rules
@@ -117,11 +229,20 @@ type IHostNetworkManager interface {
 	//	[]domain.HostNetworkTrafficRule - slice of rules
 	//	error - if an error occurs, otherwise nil
 	GetTableRules(table string) ([]domain.HostNetworkTrafficRule, error)
-	//SaveConfiguration save current host network configuration to the configuration storage
+	//GetConfiguration Gets current host network configuration
 	//
 	//Return:
+	//	domain.HostNetworkConfig - current host network configuration
 	//	error - if an error occurs, otherwise nil
-	SaveConfiguration() error
+	GetConfiguration() (domain.HostNetworkConfig, error)
+	//SaveConfiguration save current host network configuration to the configuration storage as a new revision
+	//
+	//Params:
+	//	author - who saved the configuration
+	//	requestID - ID of the API request that saved the configuration, uuid.Nil if there is no request
+	//Return:
+	//	error - if an error occurs, otherwise nil
+	SaveConfiguration(author string, requestID uuid.UUID) error
 	//RestoreFromBackup restore and apply host network configuration from backup configuration
 	//
 	//Return:
@@ -137,4 +258,33 @@ type IHostNetworkManager interface {
 	//Return:
 	//	bool - if unsaved changes exist - true, otherwise false
 	HasUnsavedChanges() bool
+	//GetConfirmDeadline Gets the time when unconfirmed changes will be reset to the saved configuration,
+	//changes are confirmed by SaveConfiguration
+	//
+	//Return:
+	//	time.Time - deadline of the changes confirmation, zero time if there is no pending reset
+	GetConfirmDeadline() time.Time
+	//GetConfirmResetError Gets the error of the last failed automatic reset of unconfirmed changes,
+	//the failed reset is retried with the new confirm deadline
+	//
+	//Return:
+	//	error - error of the failed reset, nil if the reset didn't fail or the changes are saved or reset since then
+	GetConfirmResetError() error
+	//PlanConfiguration Compares the config with the host network without changing it
+	//
+	//Params:
+	//	config - desired host network configuration
+	//Return:
+	//	domain.HostNetworkPlan - changes that converge the host network to the config
+	//	error - if an error occurs, otherwise nil
+	PlanConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error)
+	//ApplyConfiguration Converges the host network to the config with the same changes as PlanConfiguration returns,
+	//applied changes are unsaved until they are confirmed
+	//
+	//Params:
+	//	config - desired host network configuration
+	//Return:
+	//	domain.HostNetworkPlan - applied changes
+	//	error - if an error occurs, otherwise nil
+	ApplyConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error)
 }
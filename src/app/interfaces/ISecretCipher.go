@@ -0,0 +1,28 @@
+package interfaces
+
+//ISecretCipher is the interface is used to encrypt secrets (passwords, keys) that are stored at rest
+type ISecretCipher interface {
+	//Encrypt secret with the current key
+	//
+	//Params:
+	//	plaintext - secret to encrypt
+	//Return:
+	//	string - encrypted secret that can be stored
+	//	error - if an error occurs, otherwise nil
+	Encrypt(plaintext string) (string, error)
+	//Decrypt secret encrypted with the current or one of the previous keys, not encrypted secret is returned as is
+	//
+	//Params:
+	//	secret - stored secret
+	//Return:
+	//	string - decrypted secret
+	//	error - if an error occurs, otherwise nil
+	Decrypt(secret string) (string, error)
+	//NeedsReEncryption checks that the stored secret is not encrypted or encrypted with one of the previous keys
+	//
+	//Params:
+	//	secret - stored secret
+	//Return:
+	//	bool - true if the secret must be encrypted again with the current key
+	NeedsReEncryption(secret string) bool
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import "context"
+
+//ISecretsReEncryptor is the interface of the repository that stores entity secrets encrypted
+type ISecretsReEncryptor interface {
+	//ReEncryptSecrets encrypts with the current key all stored secrets that are not encrypted
+	//or encrypted with one of the previous keys
+	//
+	//Params:
+	//	ctx - context
+	//Return:
+	//	int - count of updated entities
+	//	error - if an error occurs, otherwise nil
+	ReEncryptSecrets(ctx context.Context) (int, error)
+}
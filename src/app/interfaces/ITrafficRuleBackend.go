@@ -0,0 +1,49 @@
+package interfaces
+
+import "rol/domain"
+
+//ITrafficRuleBackend interface for netfilter traffic rules management,
+//tables are netfilter tables names: filter, nat, mangle, raw and security
+type ITrafficRuleBackend interface {
+	//CreateChain Create user chain in specified table if it does not exist
+	//
+	//Params
+	//	table - table to create a chain
+	//	chain - chain name
+	//Return
+	//	error - if an error occurs, otherwise nil
+	CreateChain(table string, chain string) error
+	//CreateRule Create traffic rule in specified table if the same rule does not exist
+	//
+	//Params
+	//	table - table to create a rule
+	//	rule - rule entity, rule is inserted at the rule position or appended if position is 0
+	//Return
+	//	error - if an error occurs, otherwise nil
+	CreateRule(table string, rule domain.HostNetworkTrafficRule) error
+	//DeleteRule Delete traffic rule in specified table
+	//
+	//Params
+	//	table - table to delete a rule
+	//	rule - rule entity, rule position is ignored
+	//Return
+	//	error - errors.NotFound if the rule does not exist, otherwise nil if no other errors occur
+	DeleteRule(table string, rule domain.HostNetworkTrafficRule) error
+	//GetChainRules Get selected chain rules at specified table
+	//
+	//Params
+	//	table - table to get a rules
+	//	chain - chain where we get the rules
+	//Return
+	//	[]domain.HostNetworkTrafficRule - slice of rules with positions in the chain
+	//	error - if an error occurs, otherwise nil
+	GetChainRules(table string, chain string) ([]domain.HostNetworkTrafficRule, error)
+	//GetTableRules Get all chains rules at specified table
+	//
+	//Params
+	//	table - table to get a rules
+	//Return
+	//	[]domain.HostNetworkTrafficRule - slice of rules with positions in their chains
+	//	error - if an error occurs, otherwise nil
+	GetTableRules(table string) ([]domain.HostNetworkTrafficRule, error)
+}
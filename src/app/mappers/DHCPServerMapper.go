@@ -15,6 +15,7 @@ func MapDHCP4ServerToDto(entity domain.DHCP4Config, dto *dtos.DHCP4ServerDto) {
 	dto.CreatedAt = entity.CreatedAt
 	dto.UpdatedAt = entity.UpdatedAt
 	dto.Interface = entity.Interface
+	dto.Namespace = entity.Namespace
 	dto.DNS = entity.DNS
 	dto.NTP = entity.NTP
 	dto.Range = entity.Range
@@ -34,6 +35,7 @@ func MapDHCP4ServerToDto(entity domain.DHCP4Config, dto *dtos.DHCP4ServerDto) {
 func MapDHCP4ServerCreateDtoToEntity(dto dtos.DHCP4ServerCreateDto, entity *domain.DHCP4Config) {
 	entity.DNS = dto.DNS
 	entity.Interface = dto.Interface
+	entity.Namespace = dto.Namespace
 	entity.NTP = dto.NTP
 	entity.Range = dto.Range
 	entity.Mask = dto.Mask
@@ -50,6 +52,7 @@ func MapDHCP4ServerCreateDtoToEntity(dto dtos.DHCP4ServerCreateDto, entity *doma
 // 	dto - DHCP v4 server create dto
 //	entity - DHCP v4 config entity
 func MapDHCP4ServerUpdateDtoToEntity(dto dtos.DHCP4ServerUpdateDto, entity *domain.DHCP4Config) {
+	entity.Namespace = dto.Namespace
 	entity.DNS = dto.DNS
 	entity.NTP = dto.NTP
 	entity.Port = dto.Port
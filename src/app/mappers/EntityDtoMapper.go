@@ -41,6 +41,11 @@ func MapDtoToEntity(dto interface{}, entity interface{}) error {
 		MapEthernetSwitchPortCreateDto(dto.(dtos.EthernetSwitchPortCreateDto), entity.(*domain.EthernetSwitchPort))
 	case dtos.EthernetSwitchPortUpdateDto:
 		MapEthernetSwitchPortUpdateDto(dto.(dtos.EthernetSwitchPortUpdateDto), entity.(*domain.EthernetSwitchPort))
+	//EthernetSwitchLAG
+	case dtos.EthernetSwitchLAGCreateDto:
+		MapEthernetSwitchLAGCreateDto(dto.(dtos.EthernetSwitchLAGCreateDto), entity.(*domain.EthernetSwitchLAG))
+	case dtos.EthernetSwitchLAGUpdateDto:
+		MapEthernetSwitchLAGUpdateDto(dto.(dtos.EthernetSwitchLAGUpdateDto), entity.(*domain.EthernetSwitchLAG))
 	//HostNetworkVlan
 	case dtos.HostNetworkVlanCreateDto:
 		MapHostNetworkVlanCreateDtoToEntity(dto.(dtos.HostNetworkVlanCreateDto), entity.(*domain.HostNetworkVlan))
@@ -51,11 +56,17 @@ func MapDtoToEntity(dto interface{}, entity interface{}) error {
 		MapHostNetworkBridgeCreateDtoToEntity(dto.(dtos.HostNetworkBridgeCreateDto), entity.(*domain.HostNetworkBridge))
 	case dtos.HostNetworkBridgeUpdateDto:
 		MapHostNetworkBridgeUpdateDtoToEntity(dto.(dtos.HostNetworkBridgeUpdateDto), entity.(*domain.HostNetworkBridge))
+	//HostNetworkConfig
+	case dtos.HostNetworkConfigDto:
+		MapHostNetworkConfigDtoToEntity(dto.(dtos.HostNetworkConfigDto), entity.(*domain.HostNetworkConfig))
 	//EthernetSwitchVLAN
 	case dtos.EthernetSwitchVLANCreateDto:
 		MapEthernetSwitchVLANCreateDto(dto.(dtos.EthernetSwitchVLANCreateDto), entity.(*domain.EthernetSwitchVLAN))
 	case dtos.EthernetSwitchVLANUpdateDto:
 		MapEthernetSwitchVLANUpdateDto(dto.(dtos.EthernetSwitchVLANUpdateDto), entity.(*domain.EthernetSwitchVLAN))
+	//FabricVLAN
+	case dtos.FabricVLANCreateDto:
+		MapFabricVLANCreateDto(dto.(dtos.FabricVLANCreateDto), entity.(*domain.FabricVLAN))
 	//DHCPServer
 	case dtos.DHCP4ServerCreateDto:
 		MapDHCP4ServerCreateDtoToEntity(dto.(dtos.DHCP4ServerCreateDto), entity.(*domain.DHCP4Config))
@@ -91,6 +102,12 @@ func MapEntityToDto(entity interface{}, dto interface{}) error {
 	//EthernetSwitchPort
 	case domain.EthernetSwitchPort:
 		MapEthernetSwitchPortToDto(entity.(domain.EthernetSwitchPort), dto.(*dtos.EthernetSwitchPortDto))
+	//EthernetSwitchLAG
+	case domain.EthernetSwitchLAG:
+		MapEthernetSwitchLAGToDto(entity.(domain.EthernetSwitchLAG), dto.(*dtos.EthernetSwitchLAGDto))
+	//EthernetSwitchConfigBackup
+	case domain.EthernetSwitchConfigBackup:
+		MapEthernetSwitchConfigBackupToDto(entity.(domain.EthernetSwitchConfigBackup), dto.(*dtos.EthernetSwitchConfigBackupDto))
 	//HTTPLog
 	case domain.HTTPLog:
 		MapHTTPLogEntityToDto(entity.(domain.HTTPLog), dto.(*dtos.HTTPLogDto))
@@ -106,9 +123,32 @@ func MapEntityToDto(entity interface{}, dto interface{}) error {
 	//HostNetworkBridge
 	case domain.HostNetworkBridge:
 		MapHostNetworkBridgeToDto(entity.(domain.HostNetworkBridge), dto.(*dtos.HostNetworkBridgeDto))
+	//HostNetworkBond
+	case domain.HostNetworkBond:
+		MapHostNetworkBondToDto(entity.(domain.HostNetworkBond), dto.(*dtos.HostNetworkBondDto))
+	//HostNetworkVeth
+	case domain.HostNetworkVeth:
+		MapHostNetworkVethToDto(entity.(domain.HostNetworkVeth), dto.(*dtos.HostNetworkVethDto))
+	//HostNetworkNamespace
+	case domain.HostNetworkNamespace:
+		MapHostNetworkNamespaceToDto(entity.(domain.HostNetworkNamespace), dto.(*dtos.HostNetworkNamespaceDto))
+	//HostNetworkConfig
+	case domain.HostNetworkConfig:
+		MapHostNetworkConfigToDto(entity.(domain.HostNetworkConfig), dto.(*dtos.HostNetworkConfigDto))
+	//HostNetworkConfigRevision
+	case domain.HostNetworkConfigRevision:
+		MapHostNetworkConfigRevisionToDto(entity.(domain.HostNetworkConfigRevision), dto.(*dtos.HostNetworkConfigRevisionDto))
+	//HostNetworkPlan
+	case domain.HostNetworkPlan:
+		MapHostNetworkPlanToDto(entity.(domain.HostNetworkPlan), dto.(*dtos.HostNetworkPlanDto))
 	//EthernetSwitchVLAN
 	case domain.EthernetSwitchVLAN:
 		MapEthernetSwitchVLANToDto(entity.(domain.EthernetSwitchVLAN), dto.(*dtos.EthernetSwitchVLANDto))
+	//FabricVLAN
+	case domain.FabricVLAN:
+		MapFabricVLANToDto(entity.(domain.FabricVLAN), dto.(*dtos.FabricVLANDto))
+	case domain.FabricVLANMember:
+		MapFabricVLANMemberToDto(entity.(domain.FabricVLANMember), dto.(*dtos.FabricVLANMemberDto))
 	//DHCP4Server
 	case domain.DHCP4Config:
 		MapDHCP4ServerToDto(entity.(domain.DHCP4Config), dto.(*dtos.DHCP4ServerDto))
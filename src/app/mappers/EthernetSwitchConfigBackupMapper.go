@@ -0,0 +1,19 @@
+package mappers
+
+import (
+	"rol/domain"
+	"rol/dtos"
+)
+
+//MapEthernetSwitchConfigBackupToDto writes ethernet switch configuration backup entity to dto
+//Params
+//	entity - ethernet switch configuration backup entity
+//	dto - dest ethernet switch configuration backup dto
+func MapEthernetSwitchConfigBackupToDto(entity domain.EthernetSwitchConfigBackup, dto *dtos.EthernetSwitchConfigBackupDto) {
+	dto.ID = entity.ID
+	dto.CreatedAt = entity.CreatedAt
+	dto.UpdatedAt = entity.UpdatedAt
+	dto.EthernetSwitchID = entity.EthernetSwitchID
+	dto.Hash = entity.Hash
+	dto.Size = len(entity.Config)
+}
@@ -0,0 +1,51 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+	"rol/domain"
+	"rol/dtos"
+)
+
+//MapEthernetSwitchLAGCreateDto writes ethernet switch LAG create dto fields to entity
+//Params
+//	dto - ethernet switch LAG create dto
+//	entity - dest ethernet switch LAG entity
+func MapEthernetSwitchLAGCreateDto(dto dtos.EthernetSwitchLAGCreateDto, entity *domain.EthernetSwitchLAG) {
+	entity.GroupID = dto.GroupID
+	entity.Mode = dto.Mode
+	entity.MemberPorts = ""
+	if len(dto.MemberPorts) > 0 {
+		entity.MemberPorts = uuidSliceToString(dto.MemberPorts)
+	}
+}
+
+//MapEthernetSwitchLAGUpdateDto writes ethernet switch LAG update dto fields to entity
+//Params
+//	dto - ethernet switch LAG update dto
+//	entity - dest ethernet switch LAG entity
+func MapEthernetSwitchLAGUpdateDto(dto dtos.EthernetSwitchLAGUpdateDto, entity *domain.EthernetSwitchLAG) {
+	entity.Mode = dto.Mode
+	entity.MemberPorts = ""
+	if len(dto.MemberPorts) > 0 {
+		entity.MemberPorts = uuidSliceToString(dto.MemberPorts)
+	}
+}
+
+//MapEthernetSwitchLAGToDto writes ethernet switch LAG entity to dto
+//Params
+//	entity - ethernet switch LAG entity
+//	dto - dest ethernet switch LAG dto
+func MapEthernetSwitchLAGToDto(entity domain.EthernetSwitchLAG, dto *dtos.EthernetSwitchLAGDto) {
+	dto.ID = entity.ID
+	dto.CreatedAt = entity.CreatedAt
+	dto.UpdatedAt = entity.UpdatedAt
+	dto.GroupID = entity.GroupID
+	dto.Name = entity.GetPortName()
+	dto.Mode = entity.Mode
+	dto.EthernetSwitchID = entity.EthernetSwitchID
+	if entity.MemberPorts != "" {
+		dto.MemberPorts = uuidsStringToSlice(entity.MemberPorts)
+	} else {
+		dto.MemberPorts = []uuid.UUID{}
+	}
+}
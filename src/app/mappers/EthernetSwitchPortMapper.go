@@ -14,6 +14,10 @@ func MapEthernetSwitchPortCreateDto(dto dtos.EthernetSwitchPortCreateDto, entity
 	entity.POEType = dto.POEType
 	entity.POEEnabled = dto.POEEnabled
 	entity.PVID = dto.PVID
+	entity.Shutdown = dto.Shutdown
+	entity.Description = dto.Description
+	entity.Speed = dto.Speed
+	entity.Duplex = dto.Duplex
 }
 
 //MapEthernetSwitchPortUpdateDto writes ethernet switch port update dto fields to entity
@@ -25,6 +29,10 @@ func MapEthernetSwitchPortUpdateDto(dto dtos.EthernetSwitchPortUpdateDto, entity
 	entity.POEType = dto.POEType
 	entity.POEEnabled = dto.POEEnabled
 	entity.PVID = dto.PVID
+	entity.Shutdown = dto.Shutdown
+	entity.Description = dto.Description
+	entity.Speed = dto.Speed
+	entity.Duplex = dto.Duplex
 }
 
 //MapEthernetSwitchPortToDto writes ethernet switch port entity to dto
@@ -37,6 +45,10 @@ func MapEthernetSwitchPortToDto(entity domain.EthernetSwitchPort, dto *dtos.Ethe
 	dto.POEType = entity.POEType
 	dto.POEEnabled = entity.POEEnabled
 	dto.PVID = entity.PVID
+	dto.Shutdown = entity.Shutdown
+	dto.Description = entity.Description
+	dto.Speed = entity.Speed
+	dto.Duplex = entity.Duplex
 	dto.CreatedAt = entity.CreatedAt
 	dto.UpdatedAt = entity.UpdatedAt
 }
@@ -0,0 +1,51 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+	"rol/domain"
+	"rol/dtos"
+	"strings"
+)
+
+//MapFabricVLANCreateDto writes fabric VLAN create dto fields to entity, members are not mapped
+//Params
+//	dto - fabric VLAN create dto
+//	entity - dest fabric VLAN entity
+func MapFabricVLANCreateDto(dto dtos.FabricVLANCreateDto, entity *domain.FabricVLAN) {
+	entity.Name = dto.Name
+	entity.VlanID = dto.VlanID
+	entity.HostParent = dto.HostParent
+	entity.HostAddresses = strings.Join(dto.HostAddresses, ";")
+}
+
+//MapFabricVLANToDto writes fabric VLAN entity to dto, members and status are not mapped
+//Params
+//	entity - fabric VLAN entity
+//	dto - dest fabric VLAN dto
+func MapFabricVLANToDto(entity domain.FabricVLAN, dto *dtos.FabricVLANDto) {
+	mapEntityToBaseDto[uuid.UUID](entity, &dto.BaseDto)
+	dto.Name = entity.Name
+	dto.VlanID = entity.VlanID
+	dto.HostParent = entity.HostParent
+	dto.HostAddresses = []string{}
+	if entity.HostAddresses != "" {
+		dto.HostAddresses = strings.Split(entity.HostAddresses, ";")
+	}
+	dto.HostInterface = entity.HostInterface
+	dto.HostStatus = entity.HostStatus
+	dto.HostError = entity.HostError
+	dto.Members = []dtos.FabricVLANMemberDto{}
+}
+
+//MapFabricVLANMemberToDto writes fabric VLAN member entity to dto
+//Params
+//	entity - fabric VLAN member entity
+//	dto - dest fabric VLAN member dto
+func MapFabricVLANMemberToDto(entity domain.FabricVLANMember, dto *dtos.FabricVLANMemberDto) {
+	mapEntityToBaseDto[uuid.UUID](entity, &dto.BaseDto)
+	dto.EthernetSwitchID = entity.EthernetSwitchID
+	dto.PortID = entity.PortID
+	dto.Tagged = entity.Tagged
+	dto.Status = entity.Status
+	dto.Error = entity.Error
+}
@@ -0,0 +1,17 @@
+package mappers
+
+import (
+	"rol/domain"
+	"rol/dtos"
+)
+
+//MapHostNetworkBondToDto map HostNetworkBond entity to dto
+func MapHostNetworkBondToDto(entity domain.HostNetworkBond, dto *dtos.HostNetworkBondDto) {
+	dto.Name = entity.Name
+	dto.Mode = entity.Mode
+	dto.Miimon = entity.Miimon
+	for _, addr := range entity.Addresses {
+		dto.Addresses = append(dto.Addresses, addr.String())
+	}
+	dto.Slaves = entity.Slaves
+}
@@ -13,6 +13,8 @@ func MapHostNetworkBridgeToDto(entity domain.HostNetworkBridge, dto *dtos.HostNe
 		dto.Addresses = append(dto.Addresses, addr.String())
 	}
 	dto.Slaves = entity.Slaves
+	dto.Nat = entity.Nat
+	dto.UpstreamInterface = entity.UpstreamInterface
 }
 
 //MapHostNetworkBridgeCreateDtoToEntity map HostNetworkCreateDto dto to entity
@@ -28,6 +30,8 @@ func MapHostNetworkBridgeCreateDtoToEntity(dto dtos.HostNetworkBridgeCreateDto,
 	}
 	entity.Slaves = dto.Slaves
 	entity.Name = dto.Name
+	entity.Nat = dto.Nat
+	entity.UpstreamInterface = dto.UpstreamInterface
 }
 
 //MapHostNetworkBridgeUpdateDtoToEntity map HostNetworkUpdateDto dto to entity
@@ -42,4 +46,6 @@ func MapHostNetworkBridgeUpdateDtoToEntity(dto dtos.HostNetworkBridgeUpdateDto,
 		entity.Addresses = append(entity.Addresses, *address)
 	}
 	entity.Slaves = dto.Slaves
+	entity.Nat = dto.Nat
+	entity.UpstreamInterface = dto.UpstreamInterface
 }
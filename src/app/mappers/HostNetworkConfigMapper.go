@@ -0,0 +1,224 @@
+package mappers
+
+import (
+	"net"
+	"rol/domain"
+	"rol/dtos"
+)
+
+func mapCidrStringsToAddresses(addresses []string) []net.IPNet {
+	out := []net.IPNet{}
+	for _, addr := range addresses {
+		ip, address, err := net.ParseCIDR(addr)
+		if err != nil {
+			continue
+		}
+		address.IP = ip
+		out = append(out, *address)
+	}
+	return out
+}
+
+func mapHostNetworkTrafficRuleDtosToEntities(dtoRules []dtos.HostNetworkTrafficRuleDto) []domain.HostNetworkTrafficRule {
+	var rules []domain.HostNetworkTrafficRule
+	for _, dto := range dtoRules {
+		rule := domain.HostNetworkTrafficRule{}
+		mapHostNetworkTrafficRuleBaseDtoToEntity(dto.HostNetworkTrafficRuleBaseDto, &rule)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+//MapHostNetworkConfigDtoToEntity map HostNetworkConfigDto dto to entity
+func MapHostNetworkConfigDtoToEntity(dto dtos.HostNetworkConfigDto, entity *domain.HostNetworkConfig) {
+	for _, vlanDto := range dto.Vlans {
+		vlan := domain.HostNetworkVlan{}
+		vlan.Name = vlanDto.Name
+		vlan.Type = "vlan"
+		vlan.Addresses = mapCidrStringsToAddresses(vlanDto.Addresses)
+		vlan.VlanID = vlanDto.VlanID
+		vlan.Parent = vlanDto.Parent
+		entity.Vlans = append(entity.Vlans, vlan)
+	}
+	for _, bridgeDto := range dto.Bridges {
+		bridge := domain.HostNetworkBridge{}
+		bridge.Name = bridgeDto.Name
+		bridge.Type = "bridge"
+		bridge.Addresses = mapCidrStringsToAddresses(bridgeDto.Addresses)
+		bridge.Slaves = bridgeDto.Slaves
+		bridge.Nat = bridgeDto.Nat
+		bridge.UpstreamInterface = bridgeDto.UpstreamInterface
+		entity.Bridges = append(entity.Bridges, bridge)
+	}
+	for _, bondDto := range dto.Bonds {
+		bond := domain.HostNetworkBond{}
+		bond.Name = bondDto.Name
+		bond.Type = "bond"
+		bond.Addresses = mapCidrStringsToAddresses(bondDto.Addresses)
+		bond.Slaves = bondDto.Slaves
+		bond.Mode = bondDto.Mode
+		bond.Miimon = bondDto.Miimon
+		entity.Bonds = append(entity.Bonds, bond)
+	}
+	for _, vethDto := range dto.Veths {
+		veth := domain.HostNetworkVeth{}
+		veth.Name = vethDto.Name
+		veth.Type = "veth"
+		veth.Addresses = mapCidrStringsToAddresses(vethDto.Addresses)
+		veth.Peer = vethDto.Peer
+		entity.Veths = append(entity.Veths, veth)
+	}
+	for _, namespaceDto := range dto.Namespaces {
+		namespace := domain.HostNetworkNamespace{Name: namespaceDto.Name}
+		for _, linkDto := range namespaceDto.Links {
+			namespace.Links = append(namespace.Links, domain.HostNetworkLink{
+				Name:      linkDto.Name,
+				Addresses: mapCidrStringsToAddresses(linkDto.Addresses),
+			})
+		}
+		entity.Namespaces = append(entity.Namespaces, namespace)
+	}
+	for _, routeDto := range dto.Routes {
+		route := domain.HostNetworkRoute{}
+		mapHostNetworkRouteBaseDtoToEntity(routeDto.HostNetworkRouteBaseDto, &route)
+		entity.Routes = append(entity.Routes, route)
+	}
+	entity.TrafficRules.Filter = mapHostNetworkTrafficRuleDtosToEntities(dto.TrafficRules.Filter)
+	entity.TrafficRules.NAT = mapHostNetworkTrafficRuleDtosToEntities(dto.TrafficRules.NAT)
+	entity.TrafficRules.Mangle = mapHostNetworkTrafficRuleDtosToEntities(dto.TrafficRules.Mangle)
+	entity.TrafficRules.Raw = mapHostNetworkTrafficRuleDtosToEntities(dto.TrafficRules.Raw)
+	entity.TrafficRules.Security = mapHostNetworkTrafficRuleDtosToEntities(dto.TrafficRules.Security)
+}
+
+func mapHostNetworkRouteEntitiesToDtos(routes []domain.HostNetworkRoute) []dtos.HostNetworkRouteDto {
+	out := []dtos.HostNetworkRouteDto{}
+	for _, route := range routes {
+		dto := dtos.HostNetworkRouteDto{}
+		MapHostNetworkRouteEntityToDto(route, &dto)
+		out = append(out, dto)
+	}
+	return out
+}
+
+func mapHostNetworkLinkChangeToDto(entity domain.HostNetworkLinkChange) dtos.HostNetworkLinkChangeDto {
+	return dtos.HostNetworkLinkChangeDto{
+		Name:   entity.Name,
+		Type:   entity.Type,
+		Parent: entity.Parent,
+		VlanID: entity.VlanID,
+		Mode:   entity.Mode,
+		Miimon: entity.Miimon,
+	}
+}
+
+//MapHostNetworkPlanToDto map HostNetworkPlan entity to dto
+func MapHostNetworkPlanToDto(entity domain.HostNetworkPlan, dto *dtos.HostNetworkPlanDto) {
+	dto.DeleteRoutes = mapHostNetworkRouteEntitiesToDtos(entity.DeleteRoutes)
+	dto.ReplaceLinks = []dtos.HostNetworkLinkChangeDto{}
+	for _, change := range entity.ReplaceLinks {
+		dto.ReplaceLinks = append(dto.ReplaceLinks, mapHostNetworkLinkChangeToDto(change))
+	}
+	dto.CreateLinks = []dtos.HostNetworkLinkChangeDto{}
+	for _, change := range entity.CreateLinks {
+		dto.CreateLinks = append(dto.CreateLinks, mapHostNetworkLinkChangeToDto(change))
+	}
+	dto.CreateNamespaces = append([]string{}, entity.CreateNamespaces...)
+	dto.NamespaceLinks = []dtos.HostNetworkNamespaceLinkChangeDto{}
+	for _, change := range entity.NamespaceLinks {
+		dto.NamespaceLinks = append(dto.NamespaceLinks, dtos.HostNetworkNamespaceLinkChangeDto{
+			Action:    change.Action,
+			LinkName:  change.LinkName,
+			Namespace: change.Namespace,
+		})
+	}
+	dto.Addresses = []dtos.HostNetworkAddressChangeDto{}
+	for _, change := range entity.Addresses {
+		dto.Addresses = append(dto.Addresses, dtos.HostNetworkAddressChangeDto{
+			Action:    change.Action,
+			LinkName:  change.LinkName,
+			Namespace: change.Namespace,
+			Address:   change.Address.String(),
+		})
+	}
+	dto.Slaves = []dtos.HostNetworkSlaveChangeDto{}
+	for _, change := range entity.Slaves {
+		dto.Slaves = append(dto.Slaves, dtos.HostNetworkSlaveChangeDto{
+			Action: change.Action,
+			Slave:  change.Slave,
+			Master: change.Master,
+		})
+	}
+	dto.CreateRoutes = mapHostNetworkRouteEntitiesToDtos(entity.CreateRoutes)
+	dto.DeleteLinks = []dtos.HostNetworkLinkChangeDto{}
+	for _, change := range entity.DeleteLinks {
+		dto.DeleteLinks = append(dto.DeleteLinks, mapHostNetworkLinkChangeToDto(change))
+	}
+	dto.DeleteNamespaces = append([]string{}, entity.DeleteNamespaces...)
+	dto.TrafficRules = []dtos.HostNetworkTrafficRuleChangeDto{}
+	for _, change := range entity.TrafficRules {
+		ruleChange := dtos.HostNetworkTrafficRuleChangeDto{
+			Action: change.Action,
+			Table:  change.Table,
+		}
+		MapHostNetworkTrafficRuleEntityToDto(change.Rule, &ruleChange.Rule)
+		dto.TrafficRules = append(dto.TrafficRules, ruleChange)
+	}
+}
+
+func mapHostNetworkTrafficRuleEntitiesToDtos(rules []domain.HostNetworkTrafficRule) []dtos.HostNetworkTrafficRuleDto {
+	out := []dtos.HostNetworkTrafficRuleDto{}
+	for _, rule := range rules {
+		dto := dtos.HostNetworkTrafficRuleDto{}
+		MapHostNetworkTrafficRuleEntityToDto(rule, &dto)
+		out = append(out, dto)
+	}
+	return out
+}
+
+//MapHostNetworkConfigToDto map HostNetworkConfig entity to dto, devices are not mapped
+func MapHostNetworkConfigToDto(entity domain.HostNetworkConfig, dto *dtos.HostNetworkConfigDto) {
+	dto.Vlans = []dtos.HostNetworkVlanDto{}
+	for _, vlan := range entity.Vlans {
+		vlanDto := dtos.HostNetworkVlanDto{}
+		MapHostNetworkVlanToDto(vlan, &vlanDto)
+		dto.Vlans = append(dto.Vlans, vlanDto)
+	}
+	dto.Bridges = []dtos.HostNetworkBridgeDto{}
+	for _, bridge := range entity.Bridges {
+		bridgeDto := dtos.HostNetworkBridgeDto{}
+		MapHostNetworkBridgeToDto(bridge, &bridgeDto)
+		dto.Bridges = append(dto.Bridges, bridgeDto)
+	}
+	dto.Bonds = []dtos.HostNetworkBondDto{}
+	for _, bond := range entity.Bonds {
+		bondDto := dtos.HostNetworkBondDto{}
+		MapHostNetworkBondToDto(bond, &bondDto)
+		dto.Bonds = append(dto.Bonds, bondDto)
+	}
+	dto.Veths = []dtos.HostNetworkVethDto{}
+	for _, veth := range entity.Veths {
+		vethDto := dtos.HostNetworkVethDto{}
+		MapHostNetworkVethToDto(veth, &vethDto)
+		dto.Veths = append(dto.Veths, vethDto)
+	}
+	dto.Namespaces = []dtos.HostNetworkNamespaceDto{}
+	for _, namespace := range entity.Namespaces {
+		namespaceDto := dtos.HostNetworkNamespaceDto{}
+		MapHostNetworkNamespaceToDto(namespace, &namespaceDto)
+		dto.Namespaces = append(dto.Namespaces, namespaceDto)
+	}
+	dto.Routes = mapHostNetworkRouteEntitiesToDtos(entity.Routes)
+	dto.TrafficRules.Filter = mapHostNetworkTrafficRuleEntitiesToDtos(entity.TrafficRules.Filter)
+	dto.TrafficRules.NAT = mapHostNetworkTrafficRuleEntitiesToDtos(entity.TrafficRules.NAT)
+	dto.TrafficRules.Mangle = mapHostNetworkTrafficRuleEntitiesToDtos(entity.TrafficRules.Mangle)
+	dto.TrafficRules.Raw = mapHostNetworkTrafficRuleEntitiesToDtos(entity.TrafficRules.Raw)
+	dto.TrafficRules.Security = mapHostNetworkTrafficRuleEntitiesToDtos(entity.TrafficRules.Security)
+}
+
+//MapHostNetworkConfigRevisionToDto map HostNetworkConfigRevision entity to dto
+func MapHostNetworkConfigRevisionToDto(entity domain.HostNetworkConfigRevision, dto *dtos.HostNetworkConfigRevisionDto) {
+	dto.ID = entity.ID
+	dto.CreatedAt = entity.CreatedAt
+	dto.Author = entity.Author
+	dto.RequestID = entity.RequestID
+}
@@ -0,0 +1,19 @@
+package mappers
+
+import (
+	"rol/domain"
+	"rol/dtos"
+)
+
+//MapHostNetworkNamespaceToDto map HostNetworkNamespace entity to dto
+func MapHostNetworkNamespaceToDto(entity domain.HostNetworkNamespace, dto *dtos.HostNetworkNamespaceDto) {
+	dto.Name = entity.Name
+	dto.Links = []dtos.HostNetworkNamespaceLinkDto{}
+	for _, link := range entity.Links {
+		linkDto := dtos.HostNetworkNamespaceLinkDto{Name: link.Name}
+		for _, addr := range link.Addresses {
+			linkDto.Addresses = append(linkDto.Addresses, addr.String())
+		}
+		dto.Links = append(dto.Links, linkDto)
+	}
+}
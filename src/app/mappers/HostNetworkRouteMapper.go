@@ -0,0 +1,42 @@
+// Package mappers uses for entity <--> dto conversions
+package mappers
+
+import (
+	"net"
+	"rol/domain"
+	"rol/dtos"
+)
+
+func mapHostNetworkRouteBaseDtoToEntity(dto dtos.HostNetworkRouteBaseDto, entity *domain.HostNetworkRoute) {
+	//the kernel keeps the destination network address, so the host bits are dropped
+	_, destination, err := net.ParseCIDR(dto.Destination)
+	if err == nil {
+		entity.Destination = *destination
+	}
+	entity.Gateway = net.ParseIP(dto.Gateway)
+	entity.Interface = dto.Interface
+	entity.Metric = dto.Metric
+	entity.Table = dto.Table
+}
+
+//MapHostNetworkRouteEntityToDto map HostNetworkRoute entity to dto
+func MapHostNetworkRouteEntityToDto(entity domain.HostNetworkRoute, dto *dtos.HostNetworkRouteDto) {
+	dto.Destination = entity.Destination.String()
+	dto.Gateway = ""
+	if entity.Gateway != nil {
+		dto.Gateway = entity.Gateway.String()
+	}
+	dto.Interface = entity.Interface
+	dto.Metric = entity.Metric
+	dto.Table = entity.Table
+}
+
+//MapHostNetworkRouteCreateDtoToEntity map HostNetworkRouteCreateDto dto to entity
+func MapHostNetworkRouteCreateDtoToEntity(dto dtos.HostNetworkRouteCreateDto, entity *domain.HostNetworkRoute) {
+	mapHostNetworkRouteBaseDtoToEntity(dto.HostNetworkRouteBaseDto, entity)
+}
+
+//MapHostNetworkRouteDeleteDtoToEntity map HostNetworkRouteDeleteDto dto to entity
+func MapHostNetworkRouteDeleteDtoToEntity(dto dtos.HostNetworkRouteDeleteDto, entity *domain.HostNetworkRoute) {
+	mapHostNetworkRouteBaseDtoToEntity(dto.HostNetworkRouteBaseDto, entity)
+}
@@ -0,0 +1,15 @@
+package mappers
+
+import (
+	"rol/domain"
+	"rol/dtos"
+)
+
+//MapHostNetworkVethToDto map HostNetworkVeth entity to dto
+func MapHostNetworkVethToDto(entity domain.HostNetworkVeth, dto *dtos.HostNetworkVethDto) {
+	dto.Name = entity.Name
+	dto.Peer = entity.Peer
+	for _, addr := range entity.Addresses {
+		dto.Addresses = append(dto.Addresses, addr.String())
+	}
+}
@@ -2,16 +2,24 @@
 package mappers
 
 import (
-	"github.com/coreos/go-iptables/iptables"
 	"rol/domain"
 	"rol/dtos"
 )
 
-//MapStatToTrafficRule map iptables.Stat to domain.HostNetworkTrafficRule
-func MapStatToTrafficRule(stat iptables.Stat, rule *domain.HostNetworkTrafficRule) {
-	rule.Source = stat.Source.String()
-	rule.Destination = stat.Destination.String()
-	rule.Action = stat.Target
+func mapHostNetworkTrafficRuleBaseDtoToEntity(dto dtos.HostNetworkTrafficRuleBaseDto, entity *domain.HostNetworkTrafficRule) {
+	entity.Chain = dto.Chain
+	entity.Action = dto.Action
+	entity.Source = dto.Source
+	entity.Destination = dto.Destination
+	entity.Protocol = dto.Protocol
+	entity.SourcePorts = dto.SourcePorts
+	entity.DestinationPorts = dto.DestinationPorts
+	entity.InInterface = dto.InInterface
+	entity.OutInterface = dto.OutInterface
+	entity.State = dto.State
+	entity.Comment = dto.Comment
+	entity.To = dto.To
+	entity.Position = dto.Position
 }
 
 //MapHostNetworkTrafficRuleEntityToDto map HostNetworkTrafficRule entity to dto
@@ -20,20 +28,23 @@ func MapHostNetworkTrafficRuleEntityToDto(entity domain.HostNetworkTrafficRule,
 	dto.Action = entity.Action
 	dto.Source = entity.Source
 	dto.Destination = entity.Destination
+	dto.Protocol = entity.Protocol
+	dto.SourcePorts = entity.SourcePorts
+	dto.DestinationPorts = entity.DestinationPorts
+	dto.InInterface = entity.InInterface
+	dto.OutInterface = entity.OutInterface
+	dto.State = entity.State
+	dto.Comment = entity.Comment
+	dto.To = entity.To
+	dto.Position = entity.Position
 }
 
 //MapHostNetworkTrafficRuleCreateDtoToEntity map HostNetworkTrafficRuleCreateDto dto to entity
 func MapHostNetworkTrafficRuleCreateDtoToEntity(dto dtos.HostNetworkTrafficRuleCreateDto, entity *domain.HostNetworkTrafficRule) {
-	entity.Chain = dto.Chain
-	entity.Action = dto.Action
-	entity.Source = dto.Source
-	entity.Destination = dto.Destination
+	mapHostNetworkTrafficRuleBaseDtoToEntity(dto.HostNetworkTrafficRuleBaseDto, entity)
 }
 
 //MapHostNetworkTrafficRuleDeleteDtoToEntity map HostNetworkTrafficRuleDeleteDto dto to entity
 func MapHostNetworkTrafficRuleDeleteDtoToEntity(dto dtos.HostNetworkTrafficRuleDeleteDto, entity *domain.HostNetworkTrafficRule) {
-	entity.Chain = dto.Chain
-	entity.Action = dto.Action
-	entity.Source = dto.Source
-	entity.Destination = dto.Destination
+	mapHostNetworkTrafficRuleBaseDtoToEntity(dto.HostNetworkTrafficRuleBaseDto, entity)
 }
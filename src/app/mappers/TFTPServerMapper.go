@@ -14,6 +14,7 @@ import (
 func MapTFTPConfigToDto(entity domain.TFTPConfig, dto *dtos.TFTPServerDto) {
 	dto.Address = entity.Address
 	dto.Port = entity.Port
+	dto.Namespace = entity.Namespace
 	dto.ID = entity.ID
 	dto.UpdatedAt = entity.UpdatedAt
 	dto.CreatedAt = entity.CreatedAt
@@ -28,6 +29,7 @@ func MapTFTPConfigToDto(entity domain.TFTPConfig, dto *dtos.TFTPServerDto) {
 func MapTFTPServerCreateDtoToEntity(dto dtos.TFTPServerCreateDto, entity *domain.TFTPConfig) {
 	entity.Port = dto.Port
 	entity.Address = dto.Address
+	entity.Namespace = dto.Namespace
 	entity.Enabled = dto.Enabled
 }
 
@@ -39,5 +41,6 @@ func MapTFTPServerCreateDtoToEntity(dto dtos.TFTPServerCreateDto, entity *domain
 func MapTFTPServerUpdateDtoToEntity(dto dtos.TFTPServerUpdateDto, entity *domain.TFTPConfig) {
 	entity.Port = dto.Port
 	entity.Address = dto.Address
+	entity.Namespace = dto.Namespace
 	entity.Enabled = dto.Enabled
 }
@@ -16,6 +16,7 @@ type DHCP4ServerService struct {
 	leasesRepo  interfaces.IGenericRepository[uuid.UUID, domain.DHCP4Lease]
 	factory     interfaces.IDHCP4ServerFactory
 	servers     map[uuid.UUID]interfaces.IDHCP4Server
+	hostManager interfaces.IHostNetworkManager
 }
 
 //NewDHCP4ServerService constructor for DHCPServerService service
@@ -23,18 +24,22 @@ type DHCP4ServerService struct {
 //Params:
 //	servers - repository with domain.DHCPServer entity
 //	leases - repository with domain.DHCPLease entity
+//	dhcp4factory - DHCP v4 server factory
+//	hostManager - host network manager, network namespaces of the servers are restored by it before the servers start
 //Return:
 //	*DHCPServerService - New DHCP servers service
 func NewDHCP4ServerService(
 	configs interfaces.IGenericRepository[uuid.UUID, domain.DHCP4Config],
 	leases interfaces.IGenericRepository[uuid.UUID, domain.DHCP4Lease],
 	dhcp4factory interfaces.IDHCP4ServerFactory,
+	hostManager interfaces.IHostNetworkManager,
 ) *DHCP4ServerService {
 	return &DHCP4ServerService{
 		configsRepo: configs,
 		leasesRepo:  leases,
 		servers:     map[uuid.UUID]interfaces.IDHCP4Server{},
 		factory:     dhcp4factory,
+		hostManager: hostManager,
 	}
 }
 
@@ -93,7 +98,8 @@ func (s *DHCP4ServerService) GetServerList(ctx context.Context, search, orderBy,
 	return paginatedItems, nil
 }
 
-//DHCP4ServerServiceInit starts all enabled DHCP servers
+//DHCP4ServerServiceInit starts all enabled DHCP servers, network namespaces of the servers are already restored
+//by the host network manager of the service
 func DHCP4ServerServiceInit(s *DHCP4ServerService) error {
 	ctx := context.Background()
 	queryBuilder := s.configsRepo.NewQueryBuilder(ctx)
@@ -150,6 +156,10 @@ func (s *DHCP4ServerService) CreateServer(ctx context.Context, createDto dtos.DH
 	if err != nil {
 		return dto, err
 	}
+	err = checkServerNamespaceExistence(s.hostManager, createDto.Namespace)
+	if err != nil {
+		return dto, err
+	}
 
 	//Save dhcp v4 configuration
 	dto, err = Create[dtos.DHCP4ServerDto](ctx, s.configsRepo, createDto)
@@ -196,6 +206,10 @@ func (s *DHCP4ServerService) UpdateServer(ctx context.Context, id uuid.UUID, upd
 	if err != nil {
 		return dto, err
 	}
+	err = checkServerNamespaceExistence(s.hostManager, updateDto.Namespace)
+	if err != nil {
+		return dto, err
+	}
 
 	//Update configuration
 	dto, err = Update[dtos.DHCP4ServerDto](ctx, s.configsRepo, updateDto, id, nil)
@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"os"
+	"reflect"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFirmwareUpgradeTimeout = 1200
+	firmwareRebootPollInterval    = 10 * time.Second
+	firmwareRebootPollTimeout     = 10 * time.Second
+	//firmwareRebootFailedPolls - count of the consecutive failed polls after which the switch is considered rebooting,
+	//so a single transient error is not taken for the reboot
+	firmwareRebootFailedPolls = 3
+)
+
+const (
+	firmwareStageStaging      = "staging"
+	firmwareStageTransferring = "transferring"
+	firmwareStageRebooting    = "rebooting"
+	firmwareStageVerifying    = "verifying"
+	firmwareStageCompleted    = "completed"
+	firmwareStageFailed       = "failed"
+)
+
+//EthernetSwitchFirmwareService service for ethernet switches firmware inventory and upgrade.
+//Firmware image is staged on the RoL TFTP server, so the switch can download it.
+type EthernetSwitchFirmwareService struct {
+	switchRepo  interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch]
+	managers    interfaces.IEthernetSwitchManagerProvider
+	tftpService *TFTPServerService
+	//jobs - last firmware upgrade job by switch ID
+	jobs           map[uuid.UUID]*dtos.EthernetSwitchFirmwareJobDto
+	jobsMutex      sync.RWMutex
+	upgradeTimeout time.Duration
+	//rebootPollInterval - interval of the switch polling while waiting for the reboot
+	rebootPollInterval time.Duration
+	//rebootPollTimeout - timeout of one switch poll while waiting for the reboot
+	rebootPollTimeout time.Duration
+	logger            *logrus.Logger
+	//logSourceName - logger recording source
+	logSourceName string
+}
+
+//NewEthernetSwitchFirmwareService constructor for ethernet switch firmware service
+//Params
+//	switchRepo - generic repository with domain.EthernetSwitch entity
+//	managersProvider - ethernet switch managers provider
+//	tftpService - TFTP server service for the firmware images staging
+//	config - application configuration
+//	logger - logrus logger
+//Return
+//	New ethernet switch firmware service
+func NewEthernetSwitchFirmwareService(switchRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch],
+	managersProvider interfaces.IEthernetSwitchManagerProvider, tftpService *TFTPServerService, config *domain.AppConfig,
+	logger *logrus.Logger) (*EthernetSwitchFirmwareService, error) {
+	upgradeTimeout := config.EthernetSwitch.FirmwareUpgradeTimeout
+	if upgradeTimeout <= 0 {
+		upgradeTimeout = defaultFirmwareUpgradeTimeout
+	}
+	return NewEthernetSwitchFirmwareServiceWithTimeouts(switchRepo, managersProvider, tftpService,
+		time.Duration(upgradeTimeout)*time.Second, firmwareRebootPollInterval, firmwareRebootPollTimeout, logger)
+}
+
+//NewEthernetSwitchFirmwareServiceWithTimeouts constructor for ethernet switch firmware service with the upgrade
+//timeout and the reboot polling intervals that are not rounded to seconds
+//Params
+//	switchRepo - generic repository with domain.EthernetSwitch entity
+//	managersProvider - ethernet switch managers provider
+//	tftpService - TFTP server service for the firmware images staging
+//	upgradeTimeout - timeout of the whole firmware upgrade job
+//	rebootPollInterval - interval of the switch polling while waiting for the reboot
+//	rebootPollTimeout - timeout of one switch poll while waiting for the reboot
+//	logger - logrus logger
+//Return
+//	New ethernet switch firmware service
+func NewEthernetSwitchFirmwareServiceWithTimeouts(switchRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch],
+	managersProvider interfaces.IEthernetSwitchManagerProvider, tftpService *TFTPServerService, upgradeTimeout,
+	rebootPollInterval, rebootPollTimeout time.Duration, logger *logrus.Logger) (*EthernetSwitchFirmwareService, error) {
+	return &EthernetSwitchFirmwareService{
+		switchRepo:         switchRepo,
+		managers:           managersProvider,
+		tftpService:        tftpService,
+		jobs:               map[uuid.UUID]*dtos.EthernetSwitchFirmwareJobDto{},
+		upgradeTimeout:     upgradeTimeout,
+		rebootPollInterval: rebootPollInterval,
+		rebootPollTimeout:  rebootPollTimeout,
+		logger:             logger,
+		logSourceName:      reflect.TypeOf(EthernetSwitchFirmwareService{}).Name(),
+	}, nil
+}
+
+func (e *EthernetSwitchFirmwareService) log(ctx context.Context, level, message string) {
+	logWithAction(ctx, e.logger, e.logSourceName, level, message)
+}
+
+//getConfigurableManager get manager of the switch that supports remote management
+func (e *EthernetSwitchFirmwareService) getConfigurableManager(ctx context.Context, switchID uuid.UUID) (interfaces.IEthernetSwitchManager, error) {
+	_, err := e.switchRepo.GetByID(ctx, switchID)
+	if err != nil {
+		return nil, err
+	}
+	switchManager, err := e.managers.Get(ctx, switchID)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, errorGetManager)
+	}
+	if switchManager == nil {
+		return nil, errors.Validation.New("switch model doesn't support remote management")
+	}
+	return switchManager, nil
+}
+
+//GetFirmware get firmware version and hardware revision of the switch
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchFirmwareDto - switch firmware information
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchFirmwareService) GetFirmware(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchFirmwareDto, error) {
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchFirmwareDto{}, err
+	}
+	info, err := switchManager.GetFirmwareInfo(ctx)
+	if err != nil {
+		return dtos.EthernetSwitchFirmwareDto{}, errors.Internal.Wrap(err, "failed to get switch firmware information")
+	}
+	return dtos.EthernetSwitchFirmwareDto{
+		FirmwareVersion: info.FirmwareVersion,
+		HardwareVersion: info.HardwareVersion,
+	}, nil
+}
+
+//GetUpgradeJob get the last firmware upgrade job of the switch
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchFirmwareJobDto - firmware upgrade job
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchFirmwareService) GetUpgradeJob(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchFirmwareJobDto, error) {
+	_, err := e.switchRepo.GetByID(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchFirmwareJobDto{}, err
+	}
+	e.jobsMutex.RLock()
+	defer e.jobsMutex.RUnlock()
+	job, found := e.jobs[switchID]
+	if !found {
+		return dtos.EthernetSwitchFirmwareJobDto{}, errors.NotFound.New("switch firmware wasn't upgraded")
+	}
+	return *job, nil
+}
+
+//StartUpgrade stage the firmware image on the TFTP server and start the firmware upgrade job.
+//The job makes the switch to download the image, waits for the switch reboot and checks the new firmware version.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//	upgradeDto - firmware upgrade parameters
+//Return
+//	dtos.EthernetSwitchFirmwareJobDto - started firmware upgrade job
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchFirmwareService) StartUpgrade(ctx context.Context, switchID uuid.UUID,
+	upgradeDto dtos.EthernetSwitchFirmwareUpgradeDto) (dtos.EthernetSwitchFirmwareJobDto, error) {
+	err := validators.ValidateEthernetSwitchFirmwareUpgradeDto(upgradeDto)
+	if err != nil {
+		return dtos.EthernetSwitchFirmwareJobDto{}, err //we already wrap error in validators
+	}
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchFirmwareJobDto{}, err
+	}
+	if _, err = os.Stat(upgradeDto.ImagePath); err != nil {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dtos.EthernetSwitchFirmwareJobDto{}, errors.AddErrorContext(err, "ImagePath", "firmware image file is not found")
+	}
+	tftpServer, err := e.tftpService.GetServerByID(ctx, upgradeDto.TFTPServerID)
+	if err != nil {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dtos.EthernetSwitchFirmwareJobDto{}, errors.AddErrorContext(err, "TFTPServerID", "TFTP server is not found")
+	}
+	if tftpServer.State != domain.TFTPStateLaunched.String() {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dtos.EthernetSwitchFirmwareJobDto{}, errors.AddErrorContext(err, "TFTPServerID", "TFTP server is not launched")
+	}
+	tftpAddress := upgradeDto.TFTPAddress
+	if tftpAddress == "" {
+		tftpAddress = tftpServer.Address
+	}
+	if tftpAddress == "" || tftpAddress == "0.0.0.0" {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dtos.EthernetSwitchFirmwareJobDto{}, errors.AddErrorContext(err, "TFTPAddress",
+			"TFTP server listens on all addresses, address reachable from the switch is required")
+	}
+
+	e.jobsMutex.Lock()
+	if job, found := e.jobs[switchID]; found && job.FinishedAt.IsZero() {
+		e.jobsMutex.Unlock()
+		return dtos.EthernetSwitchFirmwareJobDto{}, errors.Validation.New("switch firmware upgrade is already running")
+	}
+	job := &dtos.EthernetSwitchFirmwareJobDto{
+		ID:               uuid.New(),
+		EthernetSwitchID: switchID,
+		Stage:            firmwareStageStaging,
+		StartedAt:        time.Now(),
+	}
+	e.jobs[switchID] = job
+	jobCopy := *job
+	e.jobsMutex.Unlock()
+
+	go e.runUpgrade(context.Background(), switchManager, job, tftpServer.ID, tftpAddress, upgradeDto.ImagePath)
+	return jobCopy, nil
+}
+
+//updateJob changes the job under the lock
+func (e *EthernetSwitchFirmwareService) updateJob(job *dtos.EthernetSwitchFirmwareJobDto, update func(job *dtos.EthernetSwitchFirmwareJobDto)) {
+	e.jobsMutex.Lock()
+	defer e.jobsMutex.Unlock()
+	update(job)
+}
+
+func (e *EthernetSwitchFirmwareService) setJobStage(job *dtos.EthernetSwitchFirmwareJobDto, stage string, progress int) {
+	e.updateJob(job, func(job *dtos.EthernetSwitchFirmwareJobDto) {
+		job.Stage = stage
+		job.Progress = progress
+	})
+}
+
+func (e *EthernetSwitchFirmwareService) failJob(ctx context.Context, job *dtos.EthernetSwitchFirmwareJobDto, err error) {
+	e.log(ctx, "error", fmt.Sprintf("firmware upgrade of the switch %s failed: %s", job.EthernetSwitchID, err.Error()))
+	e.updateJob(job, func(job *dtos.EthernetSwitchFirmwareJobDto) {
+		job.Stage = firmwareStageFailed
+		job.Error = err.Error()
+		job.FinishedAt = time.Now()
+	})
+}
+
+//runUpgrade runs all stages of the firmware upgrade job and finishes it
+func (e *EthernetSwitchFirmwareService) runUpgrade(ctx context.Context, switchManager interfaces.IEthernetSwitchManager,
+	job *dtos.EthernetSwitchFirmwareJobDto, tftpServerID uuid.UUID, tftpAddress, imagePath string) {
+	ctx, cancel := context.WithTimeout(ctx, e.upgradeTimeout)
+	defer cancel()
+	//job is finished only after the staged image is removed
+	info, err := e.upgrade(ctx, switchManager, job, tftpServerID, tftpAddress, imagePath)
+	if err != nil {
+		e.failJob(ctx, job, err)
+		return
+	}
+	e.log(ctx, "info", fmt.Sprintf("firmware of the switch %s upgraded from %s to %s",
+		job.EthernetSwitchID, job.PreviousVersion, info.FirmwareVersion))
+	e.updateJob(job, func(job *dtos.EthernetSwitchFirmwareJobDto) {
+		job.Stage = firmwareStageCompleted
+		job.Progress = 100
+		job.FinishedAt = time.Now()
+	})
+}
+
+//upgrade stages the firmware image, upgrades the switch and verifies the new firmware version
+func (e *EthernetSwitchFirmwareService) upgrade(ctx context.Context, switchManager interfaces.IEthernetSwitchManager,
+	job *dtos.EthernetSwitchFirmwareJobDto, tftpServerID uuid.UUID, tftpAddress, imagePath string) (domain.EthernetSwitchFirmwareInfo, error) {
+	info, err := switchManager.GetFirmwareInfo(ctx)
+	if err != nil {
+		return info, errors.Internal.Wrap(err, "failed to get switch firmware version")
+	}
+	e.updateJob(job, func(job *dtos.EthernetSwitchFirmwareJobDto) {
+		job.PreviousVersion = info.FirmwareVersion
+		job.Progress = 5
+	})
+	fileName := fmt.Sprintf("rol-firmware-%s.bin", job.ID)
+	path, err := e.tftpService.CreatePath(ctx, tftpServerID, dtos.TFTPPathCreateDto{TFTPPathBaseDto: dtos.TFTPPathBaseDto{
+		ActualPath:  imagePath,
+		VirtualPath: fileName,
+	}})
+	if err != nil {
+		return info, errors.Internal.Wrap(err, "failed to stage firmware image on the TFTP server")
+	}
+	defer func() {
+		err := e.tftpService.DeletePath(context.Background(), tftpServerID, path.ID)
+		if err != nil {
+			e.log(ctx, "error", fmt.Sprintf("failed to remove staged firmware image from the TFTP server: %s", err.Error()))
+		}
+	}()
+
+	e.setJobStage(job, firmwareStageTransferring, 10)
+	err = switchManager.UpgradeFirmware(ctx, tftpAddress, fileName)
+	if err != nil {
+		return info, errors.Internal.Wrap(err, "failed to upgrade switch firmware")
+	}
+
+	e.setJobStage(job, firmwareStageRebooting, 60)
+	info, err = e.waitForReboot(ctx, switchManager, job)
+	if err != nil {
+		return info, err
+	}
+
+	e.setJobStage(job, firmwareStageVerifying, 90)
+	e.updateJob(job, func(job *dtos.EthernetSwitchFirmwareJobDto) {
+		job.CurrentVersion = info.FirmwareVersion
+	})
+	if info.FirmwareVersion == job.PreviousVersion {
+		return info, errors.Internal.New("firmware version didn't change after the upgrade")
+	}
+	return info, nil
+}
+
+//waitForReboot polls the switch until it becomes unreachable and then reachable again, or until its firmware
+//version is changed, because the reboot can be faster than the poll interval
+func (e *EthernetSwitchFirmwareService) waitForReboot(ctx context.Context, switchManager interfaces.IEthernetSwitchManager,
+	job *dtos.EthernetSwitchFirmwareJobDto) (domain.EthernetSwitchFirmwareInfo, error) {
+	ticker := time.NewTicker(e.rebootPollInterval)
+	defer ticker.Stop()
+	rebootDetected := false
+	failedPolls := 0
+	setRebootDetected := func() {
+		rebootDetected = true
+		e.updateJob(job, func(job *dtos.EthernetSwitchFirmwareJobDto) {
+			job.RebootDetected = true
+			job.Progress = 70
+		})
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if rebootDetected {
+				return domain.EthernetSwitchFirmwareInfo{}, errors.Internal.New("switch is unreachable after the reboot")
+			}
+			return domain.EthernetSwitchFirmwareInfo{}, errors.Internal.New("switch reboot is not detected")
+		case <-ticker.C:
+		}
+		pollCtx, cancel := context.WithTimeout(ctx, e.rebootPollTimeout)
+		info, err := switchManager.GetFirmwareInfo(pollCtx)
+		cancel()
+		if err != nil {
+			failedPolls++
+			if !rebootDetected && failedPolls >= firmwareRebootFailedPolls {
+				setRebootDetected()
+			}
+			continue
+		}
+		failedPolls = 0
+		if !rebootDetected && info.FirmwareVersion != job.PreviousVersion {
+			setRebootDetected()
+		}
+		if rebootDetected {
+			return info, nil
+		}
+	}
+}
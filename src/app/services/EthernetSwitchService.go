@@ -4,12 +4,22 @@ import (
 	"context"
 	"github.com/Azure/go-asynctask"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/fx"
+	"reflect"
 	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/app/mappers"
 	"rol/app/validators"
 	"rol/domain"
 	"rol/dtos"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDriftCheckInterval = 600
+	defaultPortStatusCacheTTL = 5
 )
 
 //EthernetSwitchService service structure for EthernetSwitch entity
@@ -17,26 +27,70 @@ type EthernetSwitchService struct {
 	switchRepo    interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch]
 	portRepo      interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchPort]
 	vlanRepo      interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchVLAN]
+	lagRepo       interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchLAG]
+	backupRepo    interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchConfigBackup]
+	leasesRepo    interfaces.IGenericRepository[uuid.UUID, domain.DHCP4Lease]
 	supportedList *[]domain.EthernetSwitchModel
 	managers      interfaces.IEthernetSwitchManagerProvider
+	//drifts - last drift check results by switch ID
+	drifts             map[uuid.UUID]dtos.EthernetSwitchDriftDto
+	driftsMutex        sync.RWMutex
+	driftCheckInterval time.Duration
+	//stopDriftReconciler - stops the periodic drift check
+	stopDriftReconciler context.CancelFunc
+	//portStatuses - recently read ports statuses by port ID
+	portStatuses       map[uuid.UUID]dtos.EthernetSwitchPortStatusDto
+	portStatusesMutex  sync.Mutex
+	portStatusCacheTTL time.Duration
+	logger             *logrus.Logger
+	//logSourceName - logger recording source
+	logSourceName string
 }
 
 //NewEthernetSwitchService constructor for domain.EthernetSwitch service
 //Params
-//	rep - generic repository with domain.EthernetSwitch repository
-//	log - logrus logger
+//	switchRepo - generic repository with domain.EthernetSwitch entity
+//	portRepo - generic repository with domain.EthernetSwitchPort entity
+//	vlanRepo - generic repository with domain.EthernetSwitchVLAN entity
+//	lagRepo - generic repository with domain.EthernetSwitchLAG entity
+//	backupRepo - generic repository with domain.EthernetSwitchConfigBackup entity
+//	leasesRepo - generic repository with domain.DHCP4Lease entity
+//	managersProvider - ethernet switch managers provider
+//	config - application configuration
+//	logger - logrus logger
 //Return
 //	New ethernet switch service
 func NewEthernetSwitchService(switchRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch],
 	portRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchPort],
 	vlanRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchVLAN],
-	managersProvider interfaces.IEthernetSwitchManagerProvider) (*EthernetSwitchService, error) {
+	lagRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchLAG],
+	backupRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchConfigBackup],
+	leasesRepo interfaces.IGenericRepository[uuid.UUID, domain.DHCP4Lease],
+	managersProvider interfaces.IEthernetSwitchManagerProvider, config *domain.AppConfig,
+	logger *logrus.Logger) (*EthernetSwitchService, error) {
+	driftCheckInterval := config.EthernetSwitch.DriftCheckInterval
+	if driftCheckInterval <= 0 {
+		driftCheckInterval = defaultDriftCheckInterval
+	}
+	portStatusCacheTTL := config.EthernetSwitch.PortStatusCacheTTL
+	if portStatusCacheTTL <= 0 {
+		portStatusCacheTTL = defaultPortStatusCacheTTL
+	}
 	ethernetSwitchService := &EthernetSwitchService{
-		switchRepo:    switchRepo,
-		portRepo:      portRepo,
-		vlanRepo:      vlanRepo,
-		supportedList: &[]domain.EthernetSwitchModel{},
-		managers:      managersProvider,
+		switchRepo:         switchRepo,
+		portRepo:           portRepo,
+		vlanRepo:           vlanRepo,
+		lagRepo:            lagRepo,
+		backupRepo:         backupRepo,
+		leasesRepo:         leasesRepo,
+		supportedList:      &[]domain.EthernetSwitchModel{},
+		managers:           managersProvider,
+		drifts:             map[uuid.UUID]dtos.EthernetSwitchDriftDto{},
+		driftCheckInterval: time.Duration(driftCheckInterval) * time.Second,
+		portStatuses:       map[uuid.UUID]dtos.EthernetSwitchPortStatusDto{},
+		portStatusCacheTTL: time.Duration(portStatusCacheTTL) * time.Second,
+		logger:             logger,
+		logSourceName:      reflect.TypeOf(EthernetSwitchService{}).Name(),
 	}
 	return ethernetSwitchService, nil
 }
@@ -44,9 +98,28 @@ func NewEthernetSwitchService(switchRepo interfaces.IGenericRepository[uuid.UUID
 //EthernetSwitchServiceInit do all that we need to do after dependency init
 func EthernetSwitchServiceInit(service *EthernetSwitchService) error {
 	service.initSupportedList()
+	ctx, cancel := context.WithCancel(context.Background())
+	service.stopDriftReconciler = cancel
+	go service.runDriftReconciler(ctx)
 	return nil
 }
 
+//RegisterEthernetSwitchServiceHooks stops the switch configuration drift reconciler when the application stops
+func RegisterEthernetSwitchServiceHooks(lifecycle fx.Lifecycle, service *EthernetSwitchService) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			if service.stopDriftReconciler != nil {
+				service.stopDriftReconciler()
+			}
+			return nil
+		},
+	})
+}
+
+func (e *EthernetSwitchService) log(ctx context.Context, level, message string) {
+	logWithAction(ctx, e.logger, e.logSourceName, level, message)
+}
+
 func (e *EthernetSwitchService) initSupportedList() {
 
 	//Ubiquity UniFi Switch US-24-250W
@@ -56,6 +129,14 @@ func (e *EthernetSwitchService) initSupportedList() {
 		Code:         "unifi_switch_us-24-250w",
 	}
 	*e.supportedList = append(*e.supportedList, ubiquityUnifiSwitchUs24250W)
+
+	//TP-Link TL-SG2210MP
+	tpLinkTlSg2210mp := domain.EthernetSwitchModel{
+		Model:        "TL-SG2210MP",
+		Manufacturer: "TP-Link",
+		Code:         "tl-sg2210mp",
+	}
+	*e.supportedList = append(*e.supportedList, tpLinkTlSg2210mp)
 }
 
 func (e *EthernetSwitchService) modelIsSupported(model string) bool {
@@ -158,7 +239,7 @@ func (e *EthernetSwitchService) GetByID(ctx context.Context, id uuid.UUID) (dtos
 	return GetByID[dtos.EthernetSwitchDto](ctx, e.switchRepo, id, nil)
 }
 
-//Update save the changes to the existing ethernet switch
+//Update save the changes to the existing ethernet switch, switch connections are reopened with the new settings
 //Params
 //	ctx - context is used only for logging
 //	updateDto - ethernet switch update dto
@@ -180,7 +261,13 @@ func (e *EthernetSwitchService) Update(ctx context.Context, updateDto dtos.Ether
 	if err != nil {
 		return dto, err
 	}
-	return Update[dtos.EthernetSwitchDto](ctx, e.switchRepo, updateDto, id, nil)
+	dto, err = Update[dtos.EthernetSwitchDto](ctx, e.switchRepo, updateDto, id, nil)
+	if err != nil {
+		return dto, err
+	}
+	//switch address or credentials can be changed, so the connections must be reopened
+	e.managers.Invalidate(id)
+	return dto, nil
 }
 
 //Create add new ethernet switch
@@ -211,7 +298,7 @@ func (e *EthernetSwitchService) Create(ctx context.Context, createDto dtos.Ether
 	return dto, nil
 }
 
-//Delete mark ethernet switch as deleted
+//Delete mark ethernet switch as deleted and close its connections
 //Params
 //	ctx - context is used only for logging
 //	id - ethernet switch id
@@ -222,14 +309,24 @@ func (e *EthernetSwitchService) Delete(ctx context.Context, id uuid.UUID) error
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to remove switch VLANs")
 	}
+	err = e.deleteAllLAGsBySwitchID(ctx, id)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to remove switch LAGs")
+	}
 	err = e.deleteAllPortsBySwitchID(ctx, id)
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to remove switch ports")
 	}
+	err = e.deleteAllConfigBackupsBySwitchID(ctx, id)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to remove switch configuration backups")
+	}
 	err = e.switchRepo.Delete(ctx, id)
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to delete entity from repository")
 	}
+	e.managers.Invalidate(id)
+	e.forgetDrift(id)
 	return nil
 }
 
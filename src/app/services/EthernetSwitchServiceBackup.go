@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/pmezard/go-difflib/difflib"
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/domain"
+	"rol/dtos"
+)
+
+func (e *EthernetSwitchService) getLatestConfigBackup(ctx context.Context, switchID uuid.UUID) (domain.EthernetSwitchConfigBackup, bool, error) {
+	queryBuilder := e.backupRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	backups, err := e.backupRepo.GetList(ctx, "CreatedAt", "desc", 1, 1, queryBuilder)
+	if err != nil {
+		return domain.EthernetSwitchConfigBackup{}, false, errors.Internal.Wrap(err, "failed to get switch configuration backups")
+	}
+	if len(backups) == 0 {
+		return domain.EthernetSwitchConfigBackup{}, false, nil
+	}
+	return backups[0], true, nil
+}
+
+func (e *EthernetSwitchService) getConfigBackupEntity(ctx context.Context, switchID, id uuid.UUID) (domain.EthernetSwitchConfigBackup, error) {
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return domain.EthernetSwitchConfigBackup{}, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return domain.EthernetSwitchConfigBackup{}, errors.NotFound.New(errorSwitchNotFound)
+	}
+	queryBuilder := e.backupRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	return e.backupRepo.GetByIDExtended(ctx, id, queryBuilder)
+}
+
+//BackupConfig download running configuration from the switch and store it as a new version.
+//New version isn't created if the configuration is the same as in the latest version.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchConfigBackupDto - latest configuration version
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) BackupConfig(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchConfigBackupDto, error) {
+	dto := dtos.EthernetSwitchConfigBackupDto{}
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dto, err
+	}
+	config, err := switchManager.GetRunningConfig(ctx)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to get switch running configuration")
+	}
+	hash := sha256.Sum256([]byte(config))
+	entity := domain.EthernetSwitchConfigBackup{
+		EthernetSwitchID: switchID,
+		Hash:             hex.EncodeToString(hash[:]),
+		Config:           config,
+	}
+	latest, found, err := e.getLatestConfigBackup(ctx, switchID)
+	if err != nil {
+		return dto, err
+	}
+	if !found || latest.Hash != entity.Hash {
+		latest, err = e.backupRepo.Insert(ctx, entity)
+		if err != nil {
+			return dto, errors.Internal.Wrap(err, "repository failed to insert switch configuration backup")
+		}
+	}
+	err = mappers.MapEntityToDto(latest, &dto)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to map switch configuration backup entity to dto")
+	}
+	return dto, nil
+}
+
+//GetConfigBackups Get list of the switch configuration versions with pagination
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	orderBy - order by configuration backup field name
+//	orderDirection - ascending or descending order
+//	page - page number
+//	pageSize - page size
+//Return
+//	dtos.PaginatedItemsDto[dtos.EthernetSwitchConfigBackupDto] - paginated list of configuration versions
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetConfigBackups(ctx context.Context, switchID uuid.UUID, orderBy, orderDirection string,
+	page, pageSize int) (dtos.PaginatedItemsDto[dtos.EthernetSwitchConfigBackupDto], error) {
+	dto := dtos.NewEmptyPaginatedItemsDto[dtos.EthernetSwitchConfigBackupDto]()
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return dto, errors.NotFound.New(errorSwitchNotFound)
+	}
+	queryBuilder := e.backupRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	return GetListExtended[dtos.EthernetSwitchConfigBackupDto](ctx, e.backupRepo, queryBuilder, orderBy, orderDirection, page, pageSize)
+}
+
+//GetConfigBackupByID Get the switch configuration version with configuration text
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	id - configuration backup ID
+//Return
+//	dtos.EthernetSwitchConfigBackupContentDto - configuration version
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetConfigBackupByID(ctx context.Context, switchID, id uuid.UUID) (dtos.EthernetSwitchConfigBackupContentDto, error) {
+	dto := dtos.EthernetSwitchConfigBackupContentDto{}
+	entity, err := e.getConfigBackupEntity(ctx, switchID, id)
+	if err != nil {
+		return dto, err
+	}
+	err = mappers.MapEntityToDto(entity, &dto.EthernetSwitchConfigBackupDto)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to map switch configuration backup entity to dto")
+	}
+	dto.Config = entity.Config
+	return dto, nil
+}
+
+//DiffConfigBackups compare two versions of the switch configuration
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	fromID - original configuration backup ID
+//	toID - changed configuration backup ID
+//Return
+//	dtos.EthernetSwitchConfigBackupDiffDto - unified diff of the configurations
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) DiffConfigBackups(ctx context.Context, switchID, fromID, toID uuid.UUID) (dtos.EthernetSwitchConfigBackupDiffDto, error) {
+	dto := dtos.EthernetSwitchConfigBackupDiffDto{FromID: fromID, ToID: toID}
+	from, err := e.getConfigBackupEntity(ctx, switchID, fromID)
+	if err != nil {
+		return dto, err
+	}
+	to, err := e.getConfigBackupEntity(ctx, switchID, toID)
+	if err != nil {
+		return dto, err
+	}
+	dto.Equal = from.Hash == to.Hash
+	if dto.Equal {
+		return dto, nil
+	}
+	dto.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from.Config),
+		B:        difflib.SplitLines(to.Config),
+		FromFile: from.CreatedAt.Format("2006-01-02 15:04:05") + " " + from.Hash[:8],
+		ToFile:   to.CreatedAt.Format("2006-01-02 15:04:05") + " " + to.Hash[:8],
+		Context:  3,
+	})
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to compare switch configurations")
+	}
+	return dto, nil
+}
+
+//RestoreConfigBackup apply the switch configuration version to the switch and save it to the startup configuration.
+//Current configuration is backed up before restoring, so the restore can be undone.
+//Stored ports and VLANs are updated from the switch after restoring.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//	id - configuration backup ID
+//Return
+//	dtos.EthernetSwitchConfigBackupDto - configuration version after restore
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) RestoreConfigBackup(ctx context.Context, switchID, id uuid.UUID) (dtos.EthernetSwitchConfigBackupDto, error) {
+	backup, err := e.getConfigBackupEntity(ctx, switchID, id)
+	if err != nil {
+		return dtos.EthernetSwitchConfigBackupDto{}, err
+	}
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchConfigBackupDto{}, err
+	}
+	_, err = e.BackupConfig(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchConfigBackupDto{}, errors.Internal.Wrap(err, "failed to backup current switch configuration")
+	}
+	err = switchManager.RestoreRunningConfig(ctx, backup.Config)
+	if err != nil {
+		return dtos.EthernetSwitchConfigBackupDto{}, errors.Internal.Wrap(err, "failed to restore switch configuration")
+	}
+	err = switchManager.SaveConfig(ctx)
+	if err != nil {
+		return dtos.EthernetSwitchConfigBackupDto{}, errors.Internal.Wrap(err, "save switch config failed")
+	}
+	_, err = e.AdoptSwitchIntoDB(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchConfigBackupDto{}, errors.Internal.Wrap(err, "failed to update stored switch configuration")
+	}
+	return e.BackupConfig(ctx, switchID)
+}
+
+func (e *EthernetSwitchService) deleteAllConfigBackupsBySwitchID(ctx context.Context, switchID uuid.UUID) error {
+	queryBuilder := e.backupRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	err := e.backupRepo.DeleteAll(ctx, queryBuilder)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to delete switch configuration backups")
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/utils"
+	"rol/domain"
+	"rol/dtos"
+	"time"
+)
+
+//restoreFunc restores the repository entities changed by the persist function to their previous state
+type restoreFunc func(ctx context.Context) error
+
+//restoreUpdated returns function that saves the entities captured before the update back to the repository
+//
+//Params:
+//	repo - repository of the entities
+//	previous - entities captured before the update
+//Return:
+//	restoreFunc - function that restores the entities
+func restoreUpdated[EntityType interfaces.IEntityModel[uuid.UUID]](repo interfaces.IGenericRepository[uuid.UUID, EntityType],
+	previous ...EntityType) restoreFunc {
+	return func(ctx context.Context) error {
+		for _, entity := range previous {
+			_, err := repo.Update(ctx, entity)
+			if err != nil {
+				return errors.Internal.Wrap(err, "failed to restore entity in the repository")
+			}
+		}
+		return nil
+	}
+}
+
+//restoreInserted returns function that deletes the inserted entity from the repository
+//
+//Params:
+//	repo - repository of the entity
+//	id - inserted entity ID
+//Return:
+//	restoreFunc - function that restores the repository
+func restoreInserted[EntityType interfaces.IEntityModel[uuid.UUID]](repo interfaces.IGenericRepository[uuid.UUID, EntityType],
+	id uuid.UUID) restoreFunc {
+	return func(ctx context.Context) error {
+		err := repo.Delete(ctx, id)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to delete inserted entity from the repository")
+		}
+		return nil
+	}
+}
+
+//applyChangesetOnSwitch applies changeset on the switch within one transaction and persists changes
+//to the repository. If applying or persisting fails, the switch configuration is rolled back,
+//so the switch and the repository stay in sync. If saving of the switch configuration fails, the switch
+//configuration is rolled back too and the persisted entities are restored by the function returned from persist.
+func (e *EthernetSwitchService) applyChangesetOnSwitch(ctx context.Context, switchID uuid.UUID,
+	changeset domain.EthernetSwitchChangeset, persist func() (restoreFunc, error)) error {
+	return e.applyChangesetsOnSwitch(ctx, switchID, []domain.EthernetSwitchChangeset{changeset}, 0, persist)
+}
+
+//applyChangesetsOnSwitch applies changesets one by one on the switch within one transaction with the pause
+//between them, then persists changes to the repository. If applying, persisting or saving fails, the switch
+//configuration is rolled back and the persisted entities are restored.
+func (e *EthernetSwitchService) applyChangesetsOnSwitch(ctx context.Context, switchID uuid.UUID,
+	changesets []domain.EthernetSwitchChangeset, pause time.Duration, persist func() (restoreFunc, error)) error {
+	switchManager, err := e.managers.Get(ctx, switchID)
+	if err != nil {
+		return errors.Internal.Wrap(err, errorGetManager)
+	}
+	nonEmpty := []domain.EthernetSwitchChangeset{}
+	for _, changeset := range changesets {
+		if !changeset.IsEmpty() {
+			nonEmpty = append(nonEmpty, changeset)
+		}
+	}
+	if switchManager == nil || len(nonEmpty) == 0 {
+		_, err = persist()
+		return err
+	}
+	transaction, err := switchManager.Begin(ctx)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to begin switch configuration transaction")
+	}
+	for i, changeset := range nonEmpty {
+		if i > 0 && pause > 0 {
+			select {
+			case <-ctx.Done():
+				err = errors.Internal.Wrap(ctx.Err(), "switch configuration is interrupted")
+			case <-time.After(pause):
+			}
+			if err != nil {
+				break
+			}
+		}
+		err = transaction.Apply(ctx, changeset)
+		if err != nil {
+			break
+		}
+	}
+	var restore restoreFunc
+	if err == nil {
+		restore, err = persist()
+	}
+	if err != nil {
+		//partially persisted changes are restored too
+		return e.rollbackChangesets(transaction, restore, err)
+	}
+	err = transaction.Commit(ctx)
+	if err != nil {
+		//changes are already persisted, so only the entities changed by this operation are restored
+		return e.rollbackChangesets(transaction, restore, errors.Internal.Wrap(err, "save switch config failed"))
+	}
+	return nil
+}
+
+//rollbackChangesets rolls back the switch configuration transaction and restores the persisted entities
+//
+//Params:
+//	transaction - switch configuration transaction
+//	restore - function that restores the persisted entities, nil if nothing was persisted
+//	err - error that caused the rollback
+//Return:
+//	error - err with the rollback error if it occurs
+func (e *EthernetSwitchService) rollbackChangesets(transaction interfaces.IEthernetSwitchTransaction, restore restoreFunc, err error) error {
+	//rollback must be done even if the request context is already done
+	rollbackErr := transaction.Rollback(context.Background())
+	if rollbackErr != nil {
+		return errors.Internal.Wrapf(err, "switch configuration rollback failed: %s", rollbackErr.Error())
+	}
+	if restore != nil {
+		restoreErr := restore(context.Background())
+		if restoreErr != nil {
+			return errors.Internal.Wrapf(err, "stored switch configuration restore failed: %s", restoreErr.Error())
+		}
+	}
+	return err
+}
+
+//vlanChangeset computes switch changes for the VLAN
+//
+//Params:
+//	ctx - context
+//	vlanID - VLAN ID
+//	current - current VLAN ports, nil if VLAN doesn't exist
+//	desired - desired VLAN ports, nil if VLAN should be deleted
+//Return:
+//	domain.EthernetSwitchChangeset - changes for the switch
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) vlanChangeset(ctx context.Context, vlanID int, current, desired *dtos.EthernetSwitchVLANBaseDto) (domain.EthernetSwitchChangeset, error) {
+	portIDs := []uuid.UUID{}
+	for _, dto := range []*dtos.EthernetSwitchVLANBaseDto{current, desired} {
+		if dto == nil {
+			continue
+		}
+		for _, portID := range append(append([]uuid.UUID{}, dto.TaggedPorts...), dto.UntaggedPorts...) {
+			if !utils.SliceContainsElement(portIDs, portID) {
+				portIDs = append(portIDs, portID)
+			}
+		}
+	}
+	portNames := map[uuid.UUID]string{}
+	for _, portID := range portIDs {
+		portName, err := e.getVLANPortName(ctx, portID)
+		if err != nil {
+			return domain.EthernetSwitchChangeset{}, err
+		}
+		portNames[portID] = portName
+	}
+	//both configurations contain all affected ports, so only VLAN membership is compared
+	toConfig := func(dto *dtos.EthernetSwitchVLANBaseDto) domain.EthernetSwitchConfig {
+		config := domain.EthernetSwitchConfig{VLANs: []int{}, Ports: []domain.EthernetSwitchPortConfig{}}
+		if dto != nil {
+			config.VLANs = append(config.VLANs, vlanID)
+		}
+		for _, portID := range portIDs {
+			portConfig := domain.EthernetSwitchPortConfig{
+				Name:          portNames[portID],
+				TaggedVLANs:   []int{},
+				UntaggedVLANs: []int{},
+			}
+			if dto != nil && utils.SliceContainsElement(dto.TaggedPorts, portID) {
+				portConfig.TaggedVLANs = append(portConfig.TaggedVLANs, vlanID)
+			}
+			if dto != nil && utils.SliceContainsElement(dto.UntaggedPorts, portID) {
+				portConfig.UntaggedVLANs = append(portConfig.UntaggedVLANs, vlanID)
+			}
+			config.Ports = append(config.Ports, portConfig)
+		}
+		return config
+	}
+	return toConfig(current).ChangesetTo(toConfig(desired)), nil
+}
+
+//getVLANPortName get name of the port or LAG by its ID, VLANs can contain both of them
+func (e *EthernetSwitchService) getVLANPortName(ctx context.Context, portID uuid.UUID) (string, error) {
+	port, err := e.portRepo.GetByID(ctx, portID)
+	if err == nil {
+		return port.Name, nil
+	}
+	if !errors.As(err, errors.NotFound) {
+		return "", errors.Internal.Wrap(err, errorGetPortByID)
+	}
+	lag, err := e.lagRepo.GetByID(ctx, portID)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, errorGetPortByID)
+	}
+	return lag.GetPortName(), nil
+}
+
+//portChangeset computes switch changes for the port
+//
+//Params:
+//	current - current port, nil if port is new
+//	desired - desired port
+//Return:
+//	domain.EthernetSwitchChangeset - changes for the switch
+func (e *EthernetSwitchService) portChangeset(current *dtos.EthernetSwitchPortDto, desired dtos.EthernetSwitchPortDto) domain.EthernetSwitchChangeset {
+	toPortConfig := func(dto dtos.EthernetSwitchPortDto) domain.EthernetSwitchPortConfig {
+		return domain.EthernetSwitchPortConfig{
+			Name:        dto.Name,
+			PVID:        dto.PVID,
+			POEEnabled:  dto.POEEnabled,
+			POEType:     dto.POEType,
+			Shutdown:    dto.Shutdown,
+			Description: dto.Description,
+			Speed:       dto.Speed,
+			Duplex:      dto.Duplex,
+		}
+	}
+	currentConfig := domain.EthernetSwitchConfig{Ports: []domain.EthernetSwitchPortConfig{}}
+	if current != nil {
+		currentConfig.Ports = append(currentConfig.Ports, toPortConfig(*current))
+	}
+	desiredConfig := domain.EthernetSwitchConfig{Ports: []domain.EthernetSwitchPortConfig{toPortConfig(desired)}}
+	changeset := currentConfig.ChangesetTo(desiredConfig)
+	//VLANs are not managed by the port
+	changeset.CreateVLANs = []int{}
+	changeset.DeleteVLANs = []int{}
+	return changeset
+}
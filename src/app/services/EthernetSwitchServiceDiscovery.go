@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/app/utils"
+	"rol/domain"
+	"rol/dtos"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	diffEntityPort = "port"
+	diffEntityVLAN = "vlan"
+	diffFieldExist = "Existence"
+	diffPresent    = "present"
+	diffAbsent     = "absent"
+)
+
+//Discover imports ports and VLANs from the switch configuration. Ports and VLANs that already
+//exist are not changed, all differences with them are reported as conflicts.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchDiscoveryDto - imported entities and conflicts
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) Discover(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchDiscoveryDto, error) {
+	result := dtos.EthernetSwitchDiscoveryDto{
+		ImportedPorts: []dtos.EthernetSwitchPortDto{},
+		ImportedVLANs: []dtos.EthernetSwitchVLANDto{},
+		Conflicts:     []dtos.EthernetSwitchConfigDiffDto{},
+	}
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return result, err
+	}
+	switchConfig, err := switchManager.GetConfig(ctx)
+	if err != nil {
+		return result, errors.Internal.Wrap(err, "failed to get switch configuration")
+	}
+	portIDs, err := e.discoverPorts(ctx, switchID, switchConfig, &result)
+	if err != nil {
+		return result, err
+	}
+	err = e.discoverVLANs(ctx, switchID, switchConfig, portIDs, &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+//discoverPorts imports switch ports and returns IDs of all switch ports by names
+func (e *EthernetSwitchService) discoverPorts(ctx context.Context, switchID uuid.UUID, switchConfig domain.EthernetSwitchConfig,
+	result *dtos.EthernetSwitchDiscoveryDto) (map[string]uuid.UUID, error) {
+	storedPorts, err := e.getAllPorts(ctx, switchID)
+	if err != nil {
+		return nil, err
+	}
+	portIDs := map[string]uuid.UUID{}
+	for _, storedPort := range storedPorts {
+		portIDs[storedPort.Name] = storedPort.ID
+		if _, found := switchConfig.GetPort(storedPort.Name); !found {
+			result.Conflicts = append(result.Conflicts, dtos.EthernetSwitchConfigDiffDto{
+				EntityType:  diffEntityPort,
+				EntityID:    storedPort.ID,
+				Name:        storedPort.Name,
+				Field:       diffFieldExist,
+				SwitchValue: diffAbsent,
+				StoredValue: diffPresent,
+			})
+		}
+	}
+	for _, portConfig := range switchConfig.Ports {
+		storedID, stored := portIDs[portConfig.Name]
+		if !stored {
+			entity := domain.EthernetSwitchPort{
+				Name:             portConfig.Name,
+				EthernetSwitchID: switchID,
+				POEType:          portConfig.POEType,
+				POEEnabled:       portConfig.POEEnabled,
+				PVID:             portConfig.PVID,
+				Shutdown:         portConfig.Shutdown,
+				Description:      portConfig.Description,
+				Speed:            portConfig.Speed,
+				Duplex:           portConfig.Duplex,
+			}
+			createdPort, err := e.portRepo.Insert(ctx, entity)
+			if err != nil {
+				return nil, errors.Internal.Wrap(err, "create switch port in repository failed")
+			}
+			portDto := dtos.EthernetSwitchPortDto{}
+			err = mappers.MapEntityToDto(createdPort, &portDto)
+			if err != nil {
+				return nil, errors.Internal.Wrap(err, "failed to map entity to dto")
+			}
+			portIDs[portConfig.Name] = createdPort.ID
+			result.ImportedPorts = append(result.ImportedPorts, portDto)
+			continue
+		}
+		for _, storedPort := range storedPorts {
+			if storedPort.ID != storedID {
+				continue
+			}
+			if storedPort.PVID != portConfig.PVID {
+				result.Conflicts = append(result.Conflicts, newPortDiff(storedPort, "PVID",
+					strconv.Itoa(portConfig.PVID), strconv.Itoa(storedPort.PVID)))
+			}
+			if storedPort.POEEnabled != portConfig.POEEnabled {
+				result.Conflicts = append(result.Conflicts, newPortDiff(storedPort, "POEEnabled",
+					strconv.FormatBool(portConfig.POEEnabled), strconv.FormatBool(storedPort.POEEnabled)))
+			}
+		}
+	}
+	return portIDs, nil
+}
+
+//discoverVLANs imports switch VLANs with their ports
+func (e *EthernetSwitchService) discoverVLANs(ctx context.Context, switchID uuid.UUID, switchConfig domain.EthernetSwitchConfig,
+	portIDs map[string]uuid.UUID, result *dtos.EthernetSwitchDiscoveryDto) error {
+	storedVLANs, err := e.getAllVLANs(ctx, switchID)
+	if err != nil {
+		return err
+	}
+	//default VLAN is not imported, otherwise it becomes managed and can be deleted from the switch
+	switchConfig = managedSwitchConfig(switchConfig, storedVLANs)
+	portNames := map[uuid.UUID]string{}
+	for name, id := range portIDs {
+		portNames[id] = name
+	}
+	for _, storedVLAN := range storedVLANs {
+		if !utils.SliceContainsElement(switchConfig.VLANs, storedVLAN.VlanID) {
+			result.Conflicts = append(result.Conflicts, dtos.EthernetSwitchConfigDiffDto{
+				EntityType:  diffEntityVLAN,
+				EntityID:    storedVLAN.ID,
+				Name:        strconv.Itoa(storedVLAN.VlanID),
+				Field:       diffFieldExist,
+				SwitchValue: diffAbsent,
+				StoredValue: diffPresent,
+			})
+		}
+	}
+	for _, vlanID := range switchConfig.VLANs {
+		createDto := dtos.EthernetSwitchVLANCreateDto{
+			VlanID: vlanID,
+			EthernetSwitchVLANBaseDto: dtos.EthernetSwitchVLANBaseDto{
+				TaggedPorts:   []uuid.UUID{},
+				UntaggedPorts: []uuid.UUID{},
+			},
+		}
+		for _, portConfig := range switchConfig.Ports {
+			if utils.SliceContainsElement(portConfig.TaggedVLANs, vlanID) {
+				createDto.TaggedPorts = append(createDto.TaggedPorts, portIDs[portConfig.Name])
+			}
+			if utils.SliceContainsElement(portConfig.UntaggedVLANs, vlanID) {
+				createDto.UntaggedPorts = append(createDto.UntaggedPorts, portIDs[portConfig.Name])
+			}
+		}
+		storedVLAN, stored := findVLANByVlanID(storedVLANs, vlanID)
+		if stored {
+			switchTagged := portNamesString(createDto.TaggedPorts, portNames)
+			storedTagged := portNamesString(storedVLAN.TaggedPorts, portNames)
+			if switchTagged != storedTagged {
+				result.Conflicts = append(result.Conflicts, newVLANDiff(storedVLAN, "TaggedPorts", switchTagged, storedTagged))
+			}
+			switchUntagged := portNamesString(createDto.UntaggedPorts, portNames)
+			storedUntagged := portNamesString(storedVLAN.UntaggedPorts, portNames)
+			if switchUntagged != storedUntagged {
+				result.Conflicts = append(result.Conflicts, newVLANDiff(storedVLAN, "UntaggedPorts", switchUntagged, storedUntagged))
+			}
+			continue
+		}
+		entity := new(domain.EthernetSwitchVLAN)
+		err = mappers.MapDtoToEntity(createDto, entity)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to map ethernet switch VLAN dto to entity")
+		}
+		entity.EthernetSwitchID = switchID
+		newVLAN, err := e.vlanRepo.Insert(ctx, *entity)
+		if err != nil {
+			return errors.Internal.Wrap(err, "repository failed to insert VLAN")
+		}
+		vlanDto := dtos.EthernetSwitchVLANDto{}
+		err = mappers.MapEntityToDto(newVLAN, &vlanDto)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to map vlan entity to dto")
+		}
+		result.ImportedVLANs = append(result.ImportedVLANs, vlanDto)
+	}
+	return nil
+}
+
+func (e *EthernetSwitchService) getAllPorts(ctx context.Context, switchID uuid.UUID) ([]domain.EthernetSwitchPort, error) {
+	queryBuilder := e.portRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	count, err := e.portRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "ports counting failed")
+	}
+	if count == 0 {
+		return []domain.EthernetSwitchPort{}, nil
+	}
+	ports, err := e.portRepo.GetList(ctx, "Name", "asc", 1, int(count), queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get ports")
+	}
+	return ports, nil
+}
+
+func (e *EthernetSwitchService) getAllVLANs(ctx context.Context, switchID uuid.UUID) ([]dtos.EthernetSwitchVLANDto, error) {
+	queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	count, err := e.vlanRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to count VLANs")
+	}
+	if count == 0 {
+		return []dtos.EthernetSwitchVLANDto{}, nil
+	}
+	entities, err := e.vlanRepo.GetList(ctx, "VlanID", "asc", 1, int(count), queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get VLANs")
+	}
+	vlans := []dtos.EthernetSwitchVLANDto{}
+	for _, entity := range entities {
+		vlan := dtos.EthernetSwitchVLANDto{}
+		err = mappers.MapEntityToDto(entity, &vlan)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to map vlan entity to dto")
+		}
+		vlans = append(vlans, vlan)
+	}
+	return vlans, nil
+}
+
+func findVLANByVlanID(vlans []dtos.EthernetSwitchVLANDto, vlanID int) (dtos.EthernetSwitchVLANDto, bool) {
+	for _, vlan := range vlans {
+		if vlan.VlanID == vlanID {
+			return vlan, true
+		}
+	}
+	return dtos.EthernetSwitchVLANDto{}, false
+}
+
+//portNamesString converts ports IDs to the sorted comma separated port names
+func portNamesString(portIDs []uuid.UUID, portNames map[uuid.UUID]string) string {
+	names := []string{}
+	for _, id := range portIDs {
+		if name, ok := portNames[id]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, id.String())
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func newPortDiff(port domain.EthernetSwitchPort, field, switchValue, storedValue string) dtos.EthernetSwitchConfigDiffDto {
+	return dtos.EthernetSwitchConfigDiffDto{
+		EntityType:  diffEntityPort,
+		EntityID:    port.ID,
+		Name:        port.Name,
+		Field:       field,
+		SwitchValue: switchValue,
+		StoredValue: storedValue,
+	}
+}
+
+func newVLANDiff(vlan dtos.EthernetSwitchVLANDto, field, switchValue, storedValue string) dtos.EthernetSwitchConfigDiffDto {
+	return dtos.EthernetSwitchConfigDiffDto{
+		EntityType:  diffEntityVLAN,
+		EntityID:    vlan.ID,
+		Name:        strconv.Itoa(vlan.VlanID),
+		Field:       field,
+		SwitchValue: switchValue,
+		StoredValue: storedValue,
+	}
+}
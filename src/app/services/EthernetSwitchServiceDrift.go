@@ -0,0 +1,481 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/mappers"
+	"rol/app/utils"
+	"rol/domain"
+	"rol/dtos"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//defaultVLANID - ID of the default switch VLAN, it can't be deleted and is not managed if it isn't stored
+const defaultVLANID = 1
+
+//GetDrift get the last result of the comparison of the switch configuration with the stored one.
+//If the switch wasn't checked yet, the comparison is done immediately.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchDriftDto - drift of the switch configuration
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetDrift(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchDriftDto, error) {
+	_, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	e.driftsMutex.RLock()
+	drift, checked := e.drifts[switchID]
+	e.driftsMutex.RUnlock()
+	if checked {
+		return drift, nil
+	}
+	return e.CheckDrift(ctx, switchID)
+}
+
+//CheckDrift compare the switch configuration with the stored VLANs and ports.
+//Error of the switch configuration reading is returned inside the result.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchDriftDto - drift of the switch configuration
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) CheckDrift(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchDriftDto, error) {
+	drift := dtos.EthernetSwitchDriftDto{
+		CheckedAt:   time.Now(),
+		Differences: []dtos.EthernetSwitchConfigDiffDto{},
+	}
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return drift, err
+	}
+	ports, err := e.getAllPorts(ctx, switchID)
+	if err != nil {
+		return drift, err
+	}
+	vlans, err := e.getAllVLANs(ctx, switchID)
+	if err != nil {
+		return drift, err
+	}
+	switchConfig, err := switchManager.GetConfig(ctx)
+	if err != nil {
+		drift.Error = err.Error()
+	} else {
+		switchConfig = managedSwitchConfig(switchConfig, vlans)
+		drift.Differences = configDifferences(switchConfig, storedSwitchConfig(ports, vlans), ports, vlans)
+		drift.InSync = len(drift.Differences) == 0
+	}
+	e.driftsMutex.Lock()
+	e.drifts[switchID] = drift
+	e.driftsMutex.Unlock()
+	return drift, nil
+}
+
+//ApplyDBToSwitch change the switch configuration to match the stored VLANs and ports.
+//Ports that are not stored are not changed.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchDriftDto - drift of the switch configuration after changes
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) ApplyDBToSwitch(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchDriftDto, error) {
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	ports, err := e.getAllPorts(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	vlans, err := e.getAllVLANs(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	switchConfig, err := switchManager.GetConfig(ctx)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, errors.Internal.Wrap(err, "failed to get switch configuration")
+	}
+	managedConfig := managedSwitchConfig(switchConfig, vlans)
+	desired := storedSwitchConfig(ports, vlans)
+	//ports that are absent on the switch can't be configured
+	desiredPorts := []domain.EthernetSwitchPortConfig{}
+	for _, port := range desired.Ports {
+		if _, found := managedConfig.GetPort(port.Name); found {
+			desiredPorts = append(desiredPorts, port)
+		}
+	}
+	desired.Ports = desiredPorts
+	changeset := managedConfig.ChangesetTo(desired)
+	err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+		return nil, nil
+	})
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err //we already wrap error
+	}
+	return e.CheckDrift(ctx, switchID)
+}
+
+//AdoptSwitchIntoDB change the stored VLANs and ports to match the switch configuration.
+//Ports that are not stored are not imported, use discovery for this.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//Return
+//	dtos.EthernetSwitchDriftDto - drift of the switch configuration after changes
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) AdoptSwitchIntoDB(ctx context.Context, switchID uuid.UUID) (dtos.EthernetSwitchDriftDto, error) {
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	ports, err := e.getAllPorts(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	vlans, err := e.getAllVLANs(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	switchConfig, err := switchManager.GetConfig(ctx)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, errors.Internal.Wrap(err, "failed to get switch configuration")
+	}
+	switchConfig = managedSwitchConfig(switchConfig, vlans)
+	err = e.adoptPorts(ctx, switchConfig, ports)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	err = e.adoptVLANs(ctx, switchID, switchConfig, ports, vlans)
+	if err != nil {
+		return dtos.EthernetSwitchDriftDto{}, err
+	}
+	return e.CheckDrift(ctx, switchID)
+}
+
+//adoptPorts updates settings of the stored ports from the switch configuration
+func (e *EthernetSwitchService) adoptPorts(ctx context.Context, switchConfig domain.EthernetSwitchConfig, ports []domain.EthernetSwitchPort) error {
+	for _, port := range ports {
+		portConfig, found := switchConfig.GetPort(port.Name)
+		if !found {
+			continue
+		}
+		updated := port
+		updated.PVID = portConfig.PVID
+		updated.POEEnabled = portConfig.POEEnabled
+		updated.Shutdown = portConfig.Shutdown
+		updated.Description = portConfig.Description
+		updated.Speed = portConfig.Speed
+		updated.Duplex = portConfig.Duplex
+		if updated == port {
+			continue
+		}
+		port = updated
+		_, err := e.portRepo.Update(ctx, port)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to update switch port in repository")
+		}
+	}
+	return nil
+}
+
+//adoptVLANs creates, updates and deletes stored VLANs according to the switch configuration
+func (e *EthernetSwitchService) adoptVLANs(ctx context.Context, switchID uuid.UUID, switchConfig domain.EthernetSwitchConfig,
+	ports []domain.EthernetSwitchPort, vlans []dtos.EthernetSwitchVLANDto) error {
+	lags, err := e.getAllLAGs(ctx, switchID)
+	if err != nil {
+		return err
+	}
+	for _, vlan := range vlans {
+		if utils.SliceContainsElement(switchConfig.VLANs, vlan.VlanID) {
+			continue
+		}
+		err = e.vlanRepo.Delete(ctx, vlan.ID)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to delete VLAN from repository")
+		}
+	}
+	for _, vlanID := range switchConfig.VLANs {
+		baseDto := dtos.EthernetSwitchVLANBaseDto{
+			TaggedPorts:   []uuid.UUID{},
+			UntaggedPorts: []uuid.UUID{},
+		}
+		for _, port := range ports {
+			portConfig, found := switchConfig.GetPort(port.Name)
+			if !found {
+				continue
+			}
+			if utils.SliceContainsElement(portConfig.TaggedVLANs, vlanID) {
+				baseDto.TaggedPorts = append(baseDto.TaggedPorts, port.ID)
+			}
+			if utils.SliceContainsElement(portConfig.UntaggedVLANs, vlanID) {
+				baseDto.UntaggedPorts = append(baseDto.UntaggedPorts, port.ID)
+			}
+		}
+		storedVLAN, stored := findVLANByVlanID(vlans, vlanID)
+		if stored {
+			//LAGs membership is not read from the switch, so it is kept as is
+			for _, lag := range lags {
+				if utils.SliceContainsElement(storedVLAN.TaggedPorts, lag.ID) {
+					baseDto.TaggedPorts = append(baseDto.TaggedPorts, lag.ID)
+				}
+				if utils.SliceContainsElement(storedVLAN.UntaggedPorts, lag.ID) {
+					baseDto.UntaggedPorts = append(baseDto.UntaggedPorts, lag.ID)
+				}
+			}
+			updateDto := dtos.EthernetSwitchVLANUpdateDto{EthernetSwitchVLANBaseDto: baseDto}
+			queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
+			queryBuilder.Where("EthernetSwitchID", "==", switchID)
+			_, err := Update[dtos.EthernetSwitchVLANDto](ctx, e.vlanRepo, updateDto, storedVLAN.ID, queryBuilder)
+			if err != nil {
+				return err //we already wrap error
+			}
+			continue
+		}
+		createDto := dtos.EthernetSwitchVLANCreateDto{VlanID: vlanID, EthernetSwitchVLANBaseDto: baseDto}
+		entity := new(domain.EthernetSwitchVLAN)
+		err := mappers.MapDtoToEntity(createDto, entity)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to map ethernet switch VLAN dto to entity")
+		}
+		entity.EthernetSwitchID = switchID
+		_, err = e.vlanRepo.Insert(ctx, *entity)
+		if err != nil {
+			return errors.Internal.Wrap(err, "repository failed to insert VLAN")
+		}
+	}
+	return nil
+}
+
+//getConfigurableManager get manager of the switch that supports configuration reading
+func (e *EthernetSwitchService) getConfigurableManager(ctx context.Context, switchID uuid.UUID) (interfaces.IEthernetSwitchManager, error) {
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return nil, errors.NotFound.New(errorSwitchNotFound)
+	}
+	switchManager, err := e.managers.Get(ctx, switchID)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, errorGetManager)
+	}
+	if switchManager == nil {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return nil, errors.AddErrorContext(err, "SwitchModel", "configuration reading is not supported for this model")
+	}
+	return switchManager, nil
+}
+
+//runDriftReconciler periodically checks the drift of all switches until the context is done
+func (e *EthernetSwitchService) runDriftReconciler(ctx context.Context) {
+	ticker := time.NewTicker(e.driftCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.checkAllDrifts(ctx)
+		}
+	}
+}
+
+//forgetDrift removes the last drift check result of the deleted switch
+func (e *EthernetSwitchService) forgetDrift(switchID uuid.UUID) {
+	e.driftsMutex.Lock()
+	delete(e.drifts, switchID)
+	e.driftsMutex.Unlock()
+}
+
+//checkAllDrifts compares configuration of all switches that support configuration reading
+func (e *EthernetSwitchService) checkAllDrifts(ctx context.Context) {
+	queryBuilder := e.switchRepo.NewQueryBuilder(ctx)
+	count, err := e.switchRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		e.log(ctx, "error", fmt.Sprintf("failed to count ethernet switches: %s", err.Error()))
+		return
+	}
+	if count == 0 {
+		return
+	}
+	switches, err := e.switchRepo.GetList(ctx, "", "asc", 1, int(count), queryBuilder)
+	if err != nil {
+		e.log(ctx, "error", fmt.Sprintf("failed to get ethernet switches: %s", err.Error()))
+		return
+	}
+	for _, ethernetSwitch := range switches {
+		switchManager, err := e.managers.Get(ctx, ethernetSwitch.ID)
+		if err != nil || switchManager == nil {
+			continue
+		}
+		drift, err := e.CheckDrift(ctx, ethernetSwitch.ID)
+		if err != nil {
+			e.log(ctx, "error", fmt.Sprintf("failed to check drift of the switch %s: %s", ethernetSwitch.Name, err.Error()))
+			continue
+		}
+		if drift.Error != "" {
+			e.log(ctx, "warning", fmt.Sprintf("failed to read configuration of the switch %s: %s", ethernetSwitch.Name, drift.Error))
+		} else if !drift.InSync {
+			e.log(ctx, "warning", fmt.Sprintf("configuration of the switch %s differs from the stored one in %d places",
+				ethernetSwitch.Name, len(drift.Differences)))
+		}
+	}
+}
+
+//managedSwitchConfig removes the default VLAN from the switch configuration if it isn't stored
+func managedSwitchConfig(switchConfig domain.EthernetSwitchConfig, vlans []dtos.EthernetSwitchVLANDto) domain.EthernetSwitchConfig {
+	if _, stored := findVLANByVlanID(vlans, defaultVLANID); stored {
+		return switchConfig
+	}
+	without := func(vlanIDs []int) []int {
+		out := []int{}
+		for _, vlanID := range vlanIDs {
+			if vlanID != defaultVLANID {
+				out = append(out, vlanID)
+			}
+		}
+		return out
+	}
+	managed := domain.EthernetSwitchConfig{
+		VLANs: without(switchConfig.VLANs),
+		Ports: []domain.EthernetSwitchPortConfig{},
+	}
+	for _, port := range switchConfig.Ports {
+		port.TaggedVLANs = without(port.TaggedVLANs)
+		port.UntaggedVLANs = without(port.UntaggedVLANs)
+		managed.Ports = append(managed.Ports, port)
+	}
+	return managed
+}
+
+//storedSwitchConfig builds the switch configuration from the stored ports and VLANs
+func storedSwitchConfig(ports []domain.EthernetSwitchPort, vlans []dtos.EthernetSwitchVLANDto) domain.EthernetSwitchConfig {
+	config := domain.EthernetSwitchConfig{
+		VLANs: []int{},
+		Ports: []domain.EthernetSwitchPortConfig{},
+	}
+	for _, vlan := range vlans {
+		config.VLANs = append(config.VLANs, vlan.VlanID)
+	}
+	for _, port := range ports {
+		portConfig := domain.EthernetSwitchPortConfig{
+			Name:          port.Name,
+			PVID:          port.PVID,
+			POEEnabled:    port.POEEnabled,
+			POEType:       port.POEType,
+			Shutdown:      port.Shutdown,
+			Description:   port.Description,
+			Speed:         port.Speed,
+			Duplex:        port.Duplex,
+			TaggedVLANs:   []int{},
+			UntaggedVLANs: []int{},
+		}
+		for _, vlan := range vlans {
+			if utils.SliceContainsElement(vlan.TaggedPorts, port.ID) {
+				portConfig.TaggedVLANs = append(portConfig.TaggedVLANs, vlan.VlanID)
+			}
+			if utils.SliceContainsElement(vlan.UntaggedPorts, port.ID) {
+				portConfig.UntaggedVLANs = append(portConfig.UntaggedVLANs, vlan.VlanID)
+			}
+		}
+		config.Ports = append(config.Ports, portConfig)
+	}
+	return config
+}
+
+//configDifferences compares the switch configuration with the stored one, only stored ports are compared
+func configDifferences(switchConfig, storedConfig domain.EthernetSwitchConfig, ports []domain.EthernetSwitchPort,
+	vlans []dtos.EthernetSwitchVLANDto) []dtos.EthernetSwitchConfigDiffDto {
+	differences := []dtos.EthernetSwitchConfigDiffDto{}
+	absentVLANs, notStoredVLANs := intSliceDiffSorted(storedConfig.VLANs, switchConfig.VLANs)
+	for _, vlanID := range absentVLANs {
+		vlan, _ := findVLANByVlanID(vlans, vlanID)
+		differences = append(differences, newVLANDiff(vlan, diffFieldExist, diffAbsent, diffPresent))
+	}
+	for _, vlanID := range notStoredVLANs {
+		differences = append(differences, newVLANDiff(dtos.EthernetSwitchVLANDto{VlanID: vlanID}, diffFieldExist, diffPresent, diffAbsent))
+	}
+	for _, port := range ports {
+		storedPort, _ := storedConfig.GetPort(port.Name)
+		switchPort, found := switchConfig.GetPort(port.Name)
+		if !found {
+			differences = append(differences, newPortDiff(port, diffFieldExist, diffAbsent, diffPresent))
+			continue
+		}
+		if switchPort.PVID != storedPort.PVID {
+			differences = append(differences, newPortDiff(port, "PVID",
+				strconv.Itoa(switchPort.PVID), strconv.Itoa(storedPort.PVID)))
+		}
+		if switchPort.POEEnabled != storedPort.POEEnabled {
+			differences = append(differences, newPortDiff(port, "POEEnabled",
+				strconv.FormatBool(switchPort.POEEnabled), strconv.FormatBool(storedPort.POEEnabled)))
+		}
+		if switchPort.Shutdown != storedPort.Shutdown {
+			differences = append(differences, newPortDiff(port, "Shutdown",
+				strconv.FormatBool(switchPort.Shutdown), strconv.FormatBool(storedPort.Shutdown)))
+		}
+		if switchPort.Description != storedPort.Description {
+			differences = append(differences, newPortDiff(port, "Description", switchPort.Description, storedPort.Description))
+		}
+		//empty speed and duplex are not managed
+		if storedPort.Speed != "" && switchPort.Speed != storedPort.Speed {
+			differences = append(differences, newPortDiff(port, "Speed", switchPort.Speed, storedPort.Speed))
+		}
+		if storedPort.Duplex != "" && switchPort.Duplex != storedPort.Duplex {
+			differences = append(differences, newPortDiff(port, "Duplex", switchPort.Duplex, storedPort.Duplex))
+		}
+		switchTagged, storedTagged := vlanIDsString(switchPort.TaggedVLANs), vlanIDsString(storedPort.TaggedVLANs)
+		if switchTagged != storedTagged {
+			differences = append(differences, newPortDiff(port, "TaggedVLANs", switchTagged, storedTagged))
+		}
+		switchUntagged, storedUntagged := vlanIDsString(switchPort.UntaggedVLANs), vlanIDsString(storedPort.UntaggedVLANs)
+		if switchUntagged != storedUntagged {
+			differences = append(differences, newPortDiff(port, "UntaggedVLANs", switchUntagged, storedUntagged))
+		}
+	}
+	return differences
+}
+
+//intSliceDiffSorted returns sorted elements that exist only in the first or only in the second slice
+func intSliceDiffSorted(first, second []int) ([]int, []int) {
+	onlyFirst := []int{}
+	for _, elem := range first {
+		if !utils.SliceContainsElement(second, elem) {
+			onlyFirst = append(onlyFirst, elem)
+		}
+	}
+	onlySecond := []int{}
+	for _, elem := range second {
+		if !utils.SliceContainsElement(first, elem) {
+			onlySecond = append(onlySecond, elem)
+		}
+	}
+	sort.Ints(onlyFirst)
+	sort.Ints(onlySecond)
+	return onlyFirst, onlySecond
+}
+
+//vlanIDsString converts VLAN IDs to the sorted comma separated string
+func vlanIDsString(vlanIDs []int) string {
+	sorted := append([]int{}, vlanIDs...)
+	sort.Ints(sorted)
+	out := []string{}
+	for _, vlanID := range sorted {
+		out = append(out, strconv.Itoa(vlanID))
+	}
+	return strings.Join(out, ", ")
+}
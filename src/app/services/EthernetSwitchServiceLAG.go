@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/mappers"
+	"rol/app/utils"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+)
+
+func (e *EthernetSwitchService) lagGroupIDIsUniqueWithinTheSwitch(ctx context.Context, groupID int, switchID uuid.UUID) (bool, error) {
+	queryBuilder := e.lagRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("GroupID", "==", groupID)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	count, err := e.lagRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return false, errors.Internal.Wrap(err, "failed to count switch LAGs")
+	}
+	return count == 0, nil
+}
+
+func (e *EthernetSwitchService) getAllLAGs(ctx context.Context, switchID uuid.UUID) ([]dtos.EthernetSwitchLAGDto, error) {
+	queryBuilder := e.lagRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	count, err := e.lagRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to count switch LAGs")
+	}
+	entities, err := e.lagRepo.GetList(ctx, "GroupID", "asc", 1, int(count), queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get switch LAGs")
+	}
+	lags := []dtos.EthernetSwitchLAGDto{}
+	for _, entity := range entities {
+		lag := dtos.EthernetSwitchLAGDto{}
+		err = mappers.MapEntityToDto(entity, &lag)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to map LAG entity to dto")
+		}
+		lags = append(lags, lag)
+	}
+	return lags, nil
+}
+
+//getLAGOfPort get LAG containing the port, returns false if the port is not a LAG member
+func (e *EthernetSwitchService) getLAGOfPort(ctx context.Context, switchID, portID uuid.UUID) (dtos.EthernetSwitchLAGDto, bool, error) {
+	lags, err := e.getAllLAGs(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchLAGDto{}, false, err
+	}
+	for _, lag := range lags {
+		if utils.SliceContainsElement(lag.MemberPorts, portID) {
+			return lag, true, nil
+		}
+	}
+	return dtos.EthernetSwitchLAGDto{}, false, nil
+}
+
+//checkLAGMembers checks that member ports exist and are not members of another LAG
+func (e *EthernetSwitchService) checkLAGMembers(ctx context.Context, switchID, lagID uuid.UUID, memberPorts []uuid.UUID) error {
+	nonexistentPorts, err := e.getNonexistentPorts(ctx, switchID, memberPorts)
+	if err != nil {
+		return errors.Internal.Wrap(err, errorPortExistence)
+	}
+	if len(nonexistentPorts) > 0 {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		for _, port := range nonexistentPorts {
+			err = errors.AddErrorContext(err, "MemberPorts", fmt.Sprintf("port %s doesn't exist", port.String()))
+		}
+		return err
+	}
+	lags, err := e.getAllLAGs(ctx, switchID)
+	if err != nil {
+		return err
+	}
+	for _, lag := range lags {
+		if lag.ID == lagID {
+			continue
+		}
+		for _, portID := range memberPorts {
+			if utils.SliceContainsElement(lag.MemberPorts, portID) {
+				err = errors.Validation.New(errors.ValidationErrorMessage)
+				return errors.AddErrorContext(err, "MemberPorts",
+					fmt.Sprintf("port %s is already a member of %s", portID.String(), lag.Name))
+			}
+		}
+	}
+	return nil
+}
+
+func (e *EthernetSwitchService) getPortNames(ctx context.Context, portIDs []uuid.UUID) ([]string, error) {
+	names := []string{}
+	for _, portID := range portIDs {
+		port, err := e.portRepo.GetByID(ctx, portID)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, errorGetPortByID)
+		}
+		names = append(names, port.Name)
+	}
+	return names, nil
+}
+
+//configureLAGOnSwitch runs LAG configuration on the switch, if it's configurable, and then persists changes.
+//If persisting fails, the undo configuration is applied to the switch.
+func (e *EthernetSwitchService) configureLAGOnSwitch(ctx context.Context, switchID uuid.UUID,
+	configure, undo func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error, persist func() error) error {
+	switchManager, err := e.managers.Get(ctx, switchID)
+	if err != nil {
+		return errors.Internal.Wrap(err, errorGetManager)
+	}
+	if switchManager == nil {
+		return persist()
+	}
+	err = configure(switchManager, ctx)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to configure LAG on the switch")
+	}
+	err = persist()
+	if err != nil {
+		//undo must be done even if the request context is already done
+		undoErr := undo(switchManager, context.Background())
+		if undoErr != nil {
+			return errors.Internal.Wrapf(err, "switch LAG configuration undo failed: %s", undoErr.Error())
+		}
+		return err
+	}
+	err = switchManager.SaveConfig(ctx)
+	if err != nil {
+		return errors.Internal.Wrap(err, "save switch config failed")
+	}
+	return nil
+}
+
+//GetLAGByID Get ethernet switch LAG by switch ID and LAG ID
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	id - LAG ID
+//Return
+//	dtos.EthernetSwitchLAGDto - ethernet switch LAG dto
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetLAGByID(ctx context.Context, switchID, id uuid.UUID) (dtos.EthernetSwitchLAGDto, error) {
+	dto := dtos.EthernetSwitchLAGDto{}
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return dto, errors.NotFound.New(errorSwitchNotFound)
+	}
+	queryBuilder := e.lagRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	return GetByID[dtos.EthernetSwitchLAGDto, uuid.UUID, domain.EthernetSwitchLAG](ctx, e.lagRepo, id, queryBuilder)
+}
+
+//GetLAGs Get list of ethernet switch LAGs with filtering and pagination
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - uuid of the ethernet switch
+//	search - string for search in ethernet switch LAG string fields
+//	orderBy - order by ethernet switch LAG field name
+//	orderDirection - ascending or descending order
+//	page - page number
+//	pageSize - page size
+//Return
+//	dtos.PaginatedItemsDto[dtos.EthernetSwitchLAGDto] - paginated list of ethernet switch LAGs
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetLAGs(ctx context.Context, switchID uuid.UUID, search, orderBy, orderDirection string,
+	page, pageSize int) (dtos.PaginatedItemsDto[dtos.EthernetSwitchLAGDto], error) {
+	dto := dtos.NewEmptyPaginatedItemsDto[dtos.EthernetSwitchLAGDto]()
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return dto, errors.NotFound.New(errorSwitchNotFound)
+	}
+	queryBuilder := e.lagRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	if len(search) > 3 {
+		AddSearchInAllFields(search, e.lagRepo, queryBuilder)
+	}
+	return GetListExtended[dtos.EthernetSwitchLAGDto](ctx, e.lagRepo, queryBuilder, orderBy, orderDirection, page, pageSize)
+}
+
+//CreateLAG Create ethernet switch LAG by EthernetSwitchLAGCreateDto, the LAG can be used as a VLAN port after creation
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	createDto - EthernetSwitchLAGCreateDto
+//Return
+//	dtos.EthernetSwitchLAGDto - created LAG
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) CreateLAG(ctx context.Context, switchID uuid.UUID, createDto dtos.EthernetSwitchLAGCreateDto) (dtos.EthernetSwitchLAGDto, error) {
+	dto := dtos.EthernetSwitchLAGDto{}
+	err := validators.ValidateEthernetSwitchLAGCreateDto(createDto)
+	if err != nil {
+		return dto, err //we already wrap error in validators
+	}
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return dto, errors.NotFound.New(errorSwitchNotFound)
+	}
+	uniqGroupID, err := e.lagGroupIDIsUniqueWithinTheSwitch(ctx, createDto.GroupID, switchID)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "LAG group ID uniqueness check error")
+	}
+	if !uniqGroupID {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dto, errors.AddErrorContext(err, "GroupID", "LAG with this group id already exist")
+	}
+	err = e.checkLAGMembers(ctx, switchID, uuid.UUID{}, createDto.MemberPorts)
+	if err != nil {
+		return dto, err //we already wrap error
+	}
+	memberNames, err := e.getPortNames(ctx, createDto.MemberPorts)
+	if err != nil {
+		return dto, err
+	}
+
+	entity := new(domain.EthernetSwitchLAG)
+	err = mappers.MapDtoToEntity(createDto, entity)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to map ethernet switch LAG dto to entity")
+	}
+	entity.EthernetSwitchID = switchID
+	err = e.configureLAGOnSwitch(ctx, switchID,
+		func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error {
+			return manager.CreateLAG(ctx, createDto.GroupID, createDto.Mode, memberNames)
+		},
+		func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error {
+			return manager.DeleteLAG(ctx, createDto.GroupID, memberNames)
+		},
+		func() error {
+			newLAG, err := e.lagRepo.Insert(ctx, *entity)
+			if err != nil {
+				return errors.Internal.Wrap(err, "repository failed to insert LAG")
+			}
+			err = mappers.MapEntityToDto(newLAG, &dto)
+			if err != nil {
+				return errors.Internal.Wrap(err, "failed to map LAG entity to dto")
+			}
+			return nil
+		})
+	if err != nil {
+		return dtos.EthernetSwitchLAGDto{}, err //we already wrap error
+	}
+	return dto, nil
+}
+
+//UpdateLAG Update ethernet switch LAG mode and member ports
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	id - LAG ID
+//	updateDto - dtos.EthernetSwitchLAGUpdateDto DTO for updating entity
+//Return
+//	dtos.EthernetSwitchLAGDto - updated LAG
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) UpdateLAG(ctx context.Context, switchID, id uuid.UUID, updateDto dtos.EthernetSwitchLAGUpdateDto) (dtos.EthernetSwitchLAGDto, error) {
+	dto := dtos.EthernetSwitchLAGDto{}
+	err := validators.ValidateEthernetSwitchLAGUpdateDto(updateDto)
+	if err != nil {
+		return dto, err //we already wrap error in validators
+	}
+	lag, err := e.GetLAGByID(ctx, switchID, id)
+	if err != nil {
+		return dto, err
+	}
+	err = e.checkLAGMembers(ctx, switchID, id, updateDto.MemberPorts)
+	if err != nil {
+		return dto, err //we already wrap error
+	}
+	removedPorts, addedPorts := utils.SliceDiffElements(lag.MemberPorts, updateDto.MemberPorts)
+	//mode can't be changed for the existing members, so they are re-added
+	if lag.Mode != updateDto.Mode {
+		removedPorts, addedPorts = lag.MemberPorts, updateDto.MemberPorts
+	}
+	removedNames, err := e.getPortNames(ctx, removedPorts)
+	if err != nil {
+		return dto, err
+	}
+	addedNames, err := e.getPortNames(ctx, addedPorts)
+	if err != nil {
+		return dto, err
+	}
+	restoredNames, err := e.getPortNames(ctx, lag.MemberPorts)
+	if err != nil {
+		return dto, err
+	}
+	err = e.configureLAGOnSwitch(ctx, switchID,
+		func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error {
+			if len(removedNames) > 0 {
+				err := manager.RemoveLAGMembers(ctx, removedNames)
+				if err != nil {
+					return err
+				}
+			}
+			if len(addedNames) > 0 {
+				return manager.AddLAGMembers(ctx, lag.GroupID, updateDto.Mode, addedNames)
+			}
+			return nil
+		},
+		func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error {
+			err := manager.RemoveLAGMembers(ctx, append(append([]string{}, removedNames...), addedNames...))
+			if err != nil {
+				return err
+			}
+			return manager.AddLAGMembers(ctx, lag.GroupID, lag.Mode, restoredNames)
+		},
+		func() error {
+			queryBuilder := e.lagRepo.NewQueryBuilder(ctx)
+			queryBuilder.Where("EthernetSwitchID", "==", switchID)
+			dto, err = Update[dtos.EthernetSwitchLAGDto](ctx, e.lagRepo, updateDto, id, queryBuilder)
+			return err // we already wrap error in Update()
+		})
+	if err != nil {
+		return dtos.EthernetSwitchLAGDto{}, err //we already wrap error
+	}
+	return dto, nil
+}
+
+//DeleteLAG delete ethernet switch LAG, the LAG is removed from VLANs before deletion
+//
+//Params
+//	ctx - context is used only for logging
+//	switchID - ethernet switch ID
+//	id - LAG ID
+//Return
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) DeleteLAG(ctx context.Context, switchID, id uuid.UUID) error {
+	lag, err := e.GetLAGByID(ctx, switchID, id)
+	if err != nil {
+		return err
+	}
+	err = e.removePortFromVLANs(ctx, switchID, id)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to remove LAG from VLANs")
+	}
+	memberNames, err := e.getPortNames(ctx, lag.MemberPorts)
+	if err != nil {
+		return err
+	}
+	return e.configureLAGOnSwitch(ctx, switchID,
+		func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error {
+			return manager.DeleteLAG(ctx, lag.GroupID, memberNames)
+		},
+		func(manager interfaces.IEthernetSwitchManager, ctx context.Context) error {
+			return manager.CreateLAG(ctx, lag.GroupID, lag.Mode, memberNames)
+		},
+		func() error {
+			err := e.lagRepo.Delete(ctx, id)
+			if err != nil {
+				return errors.Internal.Wrap(err, "failed to delete LAG")
+			}
+			return nil
+		})
+}
+
+func (e *EthernetSwitchService) deleteAllLAGsBySwitchID(ctx context.Context, switchID uuid.UUID) error {
+	lags, err := e.getAllLAGs(ctx, switchID)
+	if err != nil {
+		return err
+	}
+	for _, lag := range lags {
+		err = e.DeleteLAG(ctx, switchID, lag.ID)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to remove one or more LAG that linked with this switch")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"net"
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/dtos"
+	"strings"
+)
+
+const (
+	suggestionConfidenceHigh = "high"
+	suggestionConfidenceLow  = "low"
+)
+
+//GetPortNeighbors get devices connected to the switch port from the LLDP neighbors and MAC address tables
+//of the switch. MAC addresses are correlated with DHCP v4 leases to suggest which devices are connected to the port.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//	portID - ethernet switch port ID
+//Return
+//	dtos.EthernetSwitchPortNeighborsDto - port neighbors and suggestions
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetPortNeighbors(ctx context.Context, switchID, portID uuid.UUID) (dtos.EthernetSwitchPortNeighborsDto, error) {
+	result := dtos.EthernetSwitchPortNeighborsDto{
+		LLDPNeighbors: []dtos.EthernetSwitchLLDPNeighborDto{},
+		MACAddresses:  []dtos.EthernetSwitchMACAddressDto{},
+		Suggestions:   []dtos.EthernetSwitchPortSuggestionDto{},
+	}
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return result, err
+	}
+	port, err := e.GetPortByID(ctx, switchID, portID)
+	if err != nil {
+		return result, err
+	}
+	result.PortID = port.ID
+	result.PortName = port.Name
+	neighbors, err := switchManager.GetLLDPNeighbors(ctx)
+	if err != nil {
+		return result, errors.Internal.Wrap(err, "failed to get switch lldp neighbors")
+	}
+	macTable, err := switchManager.GetMACTable(ctx)
+	if err != nil {
+		return result, errors.Internal.Wrap(err, "failed to get switch mac address table")
+	}
+	for _, entry := range macTable {
+		if entry.PortName != port.Name {
+			continue
+		}
+		leases, err := e.getLeasesByMAC(ctx, entry.MAC)
+		if err != nil {
+			return result, err
+		}
+		result.MACAddresses = append(result.MACAddresses, dtos.EthernetSwitchMACAddressDto{
+			MAC:    normalizeMAC(entry.MAC),
+			VLANID: entry.VLANID,
+			Type:   entry.Type,
+			Leases: leases,
+		})
+	}
+	for _, neighbor := range neighbors {
+		if neighbor.PortName != port.Name {
+			continue
+		}
+		result.LLDPNeighbors = append(result.LLDPNeighbors, dtos.EthernetSwitchLLDPNeighborDto{
+			ChassisID:         neighbor.ChassisID,
+			PortID:            neighbor.PortID,
+			SystemName:        neighbor.SystemName,
+			PortDescription:   neighbor.PortDescription,
+			ManagementAddress: neighbor.ManagementAddress,
+		})
+	}
+	result.Suggestions, err = e.portSuggestions(ctx, result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+//portSuggestions suggests devices connected to the port. LLDP neighbors are reliable, MAC addresses are reliable
+//only if one address is learned on the port, otherwise the port is probably connected to another switch.
+func (e *EthernetSwitchService) portSuggestions(ctx context.Context, neighbors dtos.EthernetSwitchPortNeighborsDto) ([]dtos.EthernetSwitchPortSuggestionDto, error) {
+	suggestions := []dtos.EthernetSwitchPortSuggestionDto{}
+	suggested := map[uuid.UUID]bool{}
+	for _, neighbor := range neighbors.LLDPNeighbors {
+		for _, neighborMAC := range []string{neighbor.ChassisID, neighbor.PortID} {
+			if normalizeMAC(neighborMAC) == "" {
+				continue
+			}
+			leases, err := e.getLeasesByMAC(ctx, neighborMAC)
+			if err != nil {
+				return nil, err
+			}
+			for _, lease := range leases {
+				if suggested[lease.ID] {
+					continue
+				}
+				suggested[lease.ID] = true
+				suggestions = append(suggestions, dtos.EthernetSwitchPortSuggestionDto{
+					MAC:        lease.MAC,
+					IP:         lease.IP,
+					LeaseID:    lease.ID,
+					Confidence: suggestionConfidenceHigh,
+					Reason:     fmt.Sprintf("LLDP neighbor %s has MAC address of the DHCP lease", neighbor.SystemName),
+				})
+			}
+		}
+	}
+	confidence := suggestionConfidenceHigh
+	reason := "the only MAC address learned on the port"
+	if len(neighbors.MACAddresses) > 1 {
+		confidence = suggestionConfidenceLow
+		reason = fmt.Sprintf("one of %d MAC addresses learned on the port, port can be connected to another switch",
+			len(neighbors.MACAddresses))
+	}
+	for _, address := range neighbors.MACAddresses {
+		for _, lease := range address.Leases {
+			if suggested[lease.ID] {
+				continue
+			}
+			suggested[lease.ID] = true
+			suggestions = append(suggestions, dtos.EthernetSwitchPortSuggestionDto{
+				MAC:        lease.MAC,
+				IP:         lease.IP,
+				LeaseID:    lease.ID,
+				Confidence: confidence,
+				Reason:     reason,
+			})
+		}
+	}
+	return suggestions, nil
+}
+
+func (e *EthernetSwitchService) getLeasesByMAC(ctx context.Context, mac string) ([]dtos.DHCP4LeaseDto, error) {
+	leases := []dtos.DHCP4LeaseDto{}
+	normalizedMAC := normalizeMAC(mac)
+	if normalizedMAC == "" {
+		return leases, nil
+	}
+	queryBuilder := e.leasesRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("MAC", "==", normalizedMAC)
+	count, err := e.leasesRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to count DHCP v4 leases")
+	}
+	if count == 0 {
+		return leases, nil
+	}
+	entities, err := e.leasesRepo.GetList(ctx, "", "asc", 1, int(count), queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get DHCP v4 leases")
+	}
+	for _, entity := range entities {
+		lease := dtos.DHCP4LeaseDto{}
+		err = mappers.MapEntityToDto(entity, &lease)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to map DHCP v4 lease entity to dto")
+		}
+		leases = append(leases, lease)
+	}
+	return leases, nil
+}
+
+//normalizeMAC converts MAC address to the format used by DHCP v4 leases, returns empty string if it isn't MAC address
+func normalizeMAC(mac string) string {
+	hardwareAddr, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil {
+		return ""
+	}
+	return hardwareAddr.String()
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"rol/app/errors"
+	"rol/dtos"
+	"time"
+)
+
+//GetPortStatus get actual state of the switch port: link status, speed, counters and POE power.
+//Status is read from the switch only if the previously read one is outdated.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//	portID - ethernet switch port ID
+//Return
+//	dtos.EthernetSwitchPortStatusDto - port status
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) GetPortStatus(ctx context.Context, switchID, portID uuid.UUID) (dtos.EthernetSwitchPortStatusDto, error) {
+	switchManager, err := e.getConfigurableManager(ctx, switchID)
+	if err != nil {
+		return dtos.EthernetSwitchPortStatusDto{}, err
+	}
+	port, err := e.GetPortByID(ctx, switchID, portID)
+	if err != nil {
+		return dtos.EthernetSwitchPortStatusDto{}, err
+	}
+	e.portStatusesMutex.Lock()
+	cached, found := e.portStatuses[portID]
+	e.portStatusesMutex.Unlock()
+	if found && time.Since(cached.ReadAt) < e.portStatusCacheTTL {
+		return cached, nil
+	}
+	status, err := switchManager.GetPortStatus(ctx, port.Name)
+	if err != nil {
+		return dtos.EthernetSwitchPortStatusDto{}, errors.Internal.Wrap(err, "failed to get port status from the switch")
+	}
+	dto := dtos.EthernetSwitchPortStatusDto{
+		ReadAt:   time.Now(),
+		AdminUp:  status.AdminUp,
+		OperUp:   status.OperUp,
+		Speed:    status.Speed,
+		Duplex:   status.Duplex,
+		RxBytes:  status.RxBytes,
+		TxBytes:  status.TxBytes,
+		RxErrors: status.RxErrors,
+		TxErrors: status.TxErrors,
+		POEPower: status.POEPower,
+	}
+	e.portStatusesMutex.Lock()
+	e.portStatuses[portID] = dto
+	e.portStatusesMutex.Unlock()
+	return dto, nil
+}
+
+//forgetPortStatus removes previously read port status, so it will be read again after port changes
+func (e *EthernetSwitchService) forgetPortStatus(portID uuid.UUID) {
+	e.portStatusesMutex.Lock()
+	delete(e.portStatuses, portID)
+	e.portStatusesMutex.Unlock()
+}
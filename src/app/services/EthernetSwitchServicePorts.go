@@ -11,52 +11,6 @@ import (
 	"rol/dtos"
 )
 
-func (e *EthernetSwitchService) syncPortPOEStatus(ctx context.Context, switchID uuid.UUID, portName, POEType string, POEEnabled bool) error {
-	switchManager, err := e.managers.Get(ctx, switchID)
-	if err != nil {
-		return errors.Internal.Wrap(err, errorGetManager)
-	}
-	if switchManager == nil {
-		return nil
-	}
-	if POEEnabled {
-		err = switchManager.EnablePOEPort(portName, POEType)
-		if err != nil {
-			return errors.Internal.Wrap(err, "enable poe on port failed")
-		}
-	} else {
-		err = switchManager.DisablePOEPort(portName)
-		if err != nil {
-			return errors.Internal.Wrap(err, "disable poe on port failed")
-		}
-	}
-	return nil
-}
-
-func (e *EthernetSwitchService) syncPVIDOnSwitchPort(ctx context.Context, switchID uuid.UUID, portName string, portPVID int) error {
-	switchManager, err := e.managers.Get(ctx, switchID)
-	if err != nil {
-		return errors.Internal.Wrap(err, errorGetManager)
-	}
-	if switchManager == nil {
-		return nil
-	}
-	err = switchManager.SetPortPVID(portName, portPVID)
-	if err != nil {
-		return errors.Internal.Wrap(err, "failed to setup PVID on switch port")
-	}
-	return nil
-}
-
-func (e *EthernetSwitchService) syncPortConfOnSwitch(ctx context.Context, switchID uuid.UUID, portName string,
-	POEType string, POEEnabled bool, portPVID int) error {
-	err := e.syncPortPOEStatus(ctx, switchID, portName, POEType, POEEnabled)
-	if err != nil {
-		return err
-	}
-	return e.syncPVIDOnSwitchPort(ctx, switchID, portName, portPVID)
-}
-
 func (e *EthernetSwitchService) portNameIsUniqueWithinTheSwitch(ctx context.Context, name string, switchID, id uuid.UUID) (bool, error) {
 	uniqueNameQueryBuilder := e.portRepo.NewQueryBuilder(ctx)
 	uniqueNameQueryBuilder.Where("Name", "==", name)
@@ -235,15 +189,22 @@ func (e *EthernetSwitchService) CreatePort(ctx context.Context, switchID uuid.UU
 	if err != nil {
 		return dto, errors.Internal.Wrap(err, "error map dto to entity")
 	}
-	createdPort, err := e.portRepo.Insert(ctx, *entity)
-	if err != nil {
-		return dto, errors.Internal.Wrap(err, "create switch port in repository failed")
-	}
-	err = mappers.MapEntityToDto(createdPort, &dto)
+	changeset := e.portChangeset(nil, dtos.EthernetSwitchPortDto{EthernetSwitchPortBaseDto: createDto.EthernetSwitchPortBaseDto})
+	err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+		createdPort, err := e.portRepo.Insert(ctx, *entity)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "create switch port in repository failed")
+		}
+		err = mappers.MapEntityToDto(createdPort, &dto)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to map entity to dto")
+		}
+		return restoreInserted[domain.EthernetSwitchPort](e.portRepo, createdPort.ID), nil
+	})
 	if err != nil {
-		return dto, errors.Internal.Wrap(err, "failed to map entity to dto")
+		return dtos.EthernetSwitchPortDto{}, err
 	}
-	return dto, e.syncPortConfOnSwitch(ctx, switchID, dto.Name, dto.POEType, dto.POEEnabled, dto.PVID)
+	return dto, nil
 }
 
 //UpdatePort Update ethernet switch port
@@ -265,13 +226,31 @@ func (e *EthernetSwitchService) UpdatePort(ctx context.Context, switchID, id uui
 	if err != nil {
 		return dto, err // we already wrap error
 	}
-	queryBuilder := e.portRepo.NewQueryBuilder(ctx)
-	queryBuilder.Where("EthernetSwitchId", "==", switchID)
-	updatedPort, err := Update[dtos.EthernetSwitchPortDto](ctx, e.portRepo, updateDto, id, queryBuilder)
+	currentPort, err := e.GetPortByID(ctx, switchID, id)
+	if err != nil {
+		return dto, err
+	}
+	previousPort, err := e.portRepo.GetByID(ctx, id)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, errorGetPortByID)
+	}
+	desiredPort := currentPort
+	desiredPort.EthernetSwitchPortBaseDto = updateDto.EthernetSwitchPortBaseDto
+	changeset := e.portChangeset(&currentPort, desiredPort)
+	err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+		queryBuilder := e.portRepo.NewQueryBuilder(ctx)
+		queryBuilder.Where("EthernetSwitchId", "==", switchID)
+		dto, err = Update[dtos.EthernetSwitchPortDto](ctx, e.portRepo, updateDto, id, queryBuilder)
+		if err != nil {
+			return nil, err // we already wrap error in Update()
+		}
+		return restoreUpdated(e.portRepo, previousPort), nil
+	})
 	if err != nil {
-		return dto, err // we already wrap error in Update()
+		return dtos.EthernetSwitchPortDto{}, err
 	}
-	return updatedPort, e.syncPortConfOnSwitch(ctx, switchID, dto.Name, dto.POEType, dto.POEEnabled, dto.PVID)
+	e.forgetPortStatus(id)
+	return dto, nil
 }
 
 //GetPorts Get list of ethernet switch ports with filtering and pagination
@@ -324,6 +303,13 @@ func (e *EthernetSwitchService) DeletePort(ctx context.Context, switchID, id uui
 	if err != nil {
 		return err
 	}
+	lag, isMember, err := e.getLAGOfPort(ctx, switchID, id)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return errors.Validation.Newf("port is a member of %s, remove it from the LAG first", lag.Name)
+	}
 	err = e.removePortFromVLANs(ctx, switchID, id)
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to remove port from VLANs")
@@ -332,5 +318,6 @@ func (e *EthernetSwitchService) DeletePort(ctx context.Context, switchID, id uui
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to delete port")
 	}
+	e.forgetPortStatus(id)
 	return nil
 }
@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"path"
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/app/utils"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+	"strings"
+	"time"
+)
+
+//poeCyclePause time between disabling and enabling POE during the POE cycle
+const poeCyclePause = 3 * time.Second
+
+const (
+	bulkStatusChanged   = "changed"
+	bulkStatusUnchanged = "unchanged"
+	bulkStatusSkipped   = "skipped"
+	bulkStatusFailed    = "failed"
+)
+
+//bulkPortChange desired state of one port selected by the bulk operation
+type bulkPortChange struct {
+	result  dtos.EthernetSwitchPortBulkResultDto
+	current dtos.EthernetSwitchPortDto
+	desired dtos.EthernetSwitchPortDto
+}
+
+//getVLANByVlanID get switch VLAN by its 802.1Q ID, returns false if the VLAN is not found
+func (e *EthernetSwitchService) getVLANByVlanID(ctx context.Context, switchID uuid.UUID, vlanID int) (dtos.EthernetSwitchVLANDto, bool, error) {
+	dto := dtos.EthernetSwitchVLANDto{}
+	queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("EthernetSwitchID", "==", switchID)
+	queryBuilder.Where("VlanID", "==", vlanID)
+	vlans, err := e.vlanRepo.GetList(ctx, "", "asc", 1, 1, queryBuilder)
+	if err != nil {
+		return dto, false, errors.Internal.Wrap(err, "failed to get switch VLANs")
+	}
+	if len(vlans) == 0 {
+		return dto, false, nil
+	}
+	err = mappers.MapEntityToDto(vlans[0], &dto)
+	if err != nil {
+		return dto, false, errors.Internal.Wrap(err, "failed to map VLAN entity to dto")
+	}
+	return dto, true, nil
+}
+
+//selectPorts get switch ports matching all conditions of the selector
+func (e *EthernetSwitchService) selectPorts(ctx context.Context, switchID uuid.UUID, selector dtos.EthernetSwitchPortBulkSelectorDto) ([]dtos.EthernetSwitchPortDto, error) {
+	entities, err := e.getAllPorts(ctx, switchID)
+	if err != nil {
+		return nil, err
+	}
+	vlan := dtos.EthernetSwitchVLANDto{}
+	if selector.MemberOfVLAN != 0 {
+		var found bool
+		vlan, found, err = e.getVLANByVlanID(ctx, switchID, selector.MemberOfVLAN)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return []dtos.EthernetSwitchPortDto{}, nil
+		}
+	}
+	selected := []dtos.EthernetSwitchPortDto{}
+	for _, entity := range entities {
+		port := dtos.EthernetSwitchPortDto{}
+		err = mappers.MapEntityToDto(entity, &port)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to map port entity to dto")
+		}
+		if len(selector.PortIDs) > 0 && !utils.SliceContainsElement(selector.PortIDs, port.ID) {
+			continue
+		}
+		if selector.NamePattern != "" {
+			matched, _ := path.Match(strings.ToLower(selector.NamePattern), strings.ToLower(port.Name))
+			if !matched {
+				continue
+			}
+		}
+		if selector.MemberOfVLAN != 0 && !e.checkVlanContainsPort(vlan, port.ID) {
+			continue
+		}
+		selected = append(selected, port)
+	}
+	return selected, nil
+}
+
+//bulkPortsChanges computes desired state of the ports for POE and PVID operations
+func bulkPortsChanges(switchID uuid.UUID, ports []dtos.EthernetSwitchPortDto, operation dtos.EthernetSwitchPortBulkOperationDto) []bulkPortChange {
+	changes := []bulkPortChange{}
+	for _, port := range ports {
+		change := bulkPortChange{
+			result: dtos.EthernetSwitchPortBulkResultDto{
+				EthernetSwitchID: switchID,
+				PortID:           port.ID,
+				PortName:         port.Name,
+				Status:           bulkStatusUnchanged,
+			},
+			current: port,
+			desired: port,
+		}
+		switch operation.Operation {
+		case "poe-on", "poe-cycle":
+			if port.POEType == "none" {
+				change.result.Status = bulkStatusSkipped
+				change.result.Error = "port doesn't support POE"
+			} else if operation.Operation == "poe-cycle" && !port.POEEnabled {
+				change.result.Status = bulkStatusSkipped
+				change.result.Error = "POE is disabled on the port"
+			}
+			change.desired.POEEnabled = true
+		case "poe-off":
+			change.desired.POEEnabled = false
+		case "set-pvid":
+			change.desired.PVID = operation.PVID
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+//bulkVLANPorts computes VLAN ports after adding or removing the selected ports
+func bulkVLANPorts(switchID uuid.UUID, vlan dtos.EthernetSwitchVLANBaseDto, ports []dtos.EthernetSwitchPortDto,
+	operation dtos.EthernetSwitchPortBulkOperationDto) (dtos.EthernetSwitchVLANBaseDto, []dtos.EthernetSwitchPortBulkResultDto) {
+	desired := dtos.EthernetSwitchVLANBaseDto{
+		TaggedPorts:   append([]uuid.UUID{}, vlan.TaggedPorts...),
+		UntaggedPorts: append([]uuid.UUID{}, vlan.UntaggedPorts...),
+	}
+	results := []dtos.EthernetSwitchPortBulkResultDto{}
+	for _, port := range ports {
+		result := dtos.EthernetSwitchPortBulkResultDto{
+			EthernetSwitchID: switchID,
+			PortID:           port.ID,
+			PortName:         port.Name,
+			Status:           bulkStatusUnchanged,
+		}
+		isTagged := utils.SliceContainsElement(desired.TaggedPorts, port.ID)
+		isUntagged := utils.SliceContainsElement(desired.UntaggedPorts, port.ID)
+		switch {
+		case operation.Operation == "remove-vlan" && (isTagged || isUntagged):
+			desired.TaggedPorts = utils.RemoveElementFromSlice(desired.TaggedPorts, port.ID)
+			desired.UntaggedPorts = utils.RemoveElementFromSlice(desired.UntaggedPorts, port.ID)
+			result.Status = bulkStatusChanged
+		case operation.Operation == "add-vlan" && operation.Tagged && !isTagged:
+			desired.UntaggedPorts = utils.RemoveElementFromSlice(desired.UntaggedPorts, port.ID)
+			desired.TaggedPorts = append(desired.TaggedPorts, port.ID)
+			result.Status = bulkStatusChanged
+		case operation.Operation == "add-vlan" && !operation.Tagged && !isUntagged:
+			desired.TaggedPorts = utils.RemoveElementFromSlice(desired.TaggedPorts, port.ID)
+			desired.UntaggedPorts = append(desired.UntaggedPorts, port.ID)
+			result.Status = bulkStatusChanged
+		}
+		results = append(results, result)
+	}
+	return desired, results
+}
+
+//failChangedResults marks all changed ports as failed
+func failChangedResults(results []dtos.EthernetSwitchPortBulkResultDto, err error) {
+	for i := range results {
+		if results[i].Status == bulkStatusChanged {
+			results[i].Status = bulkStatusFailed
+			results[i].Error = err.Error()
+		}
+	}
+}
+
+//applyBulkPortsChanges applies POE and PVID changes of the ports on the switch within one transaction
+func (e *EthernetSwitchService) applyBulkPortsChanges(ctx context.Context, switchID uuid.UUID, changes []bulkPortChange,
+	operation string) []dtos.EthernetSwitchPortBulkResultDto {
+	results := []dtos.EthernetSwitchPortBulkResultDto{}
+	changed := []bulkPortChange{}
+	changesets := []domain.EthernetSwitchChangeset{
+		{CreateVLANs: []int{}, DeleteVLANs: []int{}, Ports: []domain.EthernetSwitchPortChanges{}},
+		{CreateVLANs: []int{}, DeleteVLANs: []int{}, Ports: []domain.EthernetSwitchPortChanges{}},
+	}
+	for i := range changes {
+		change := &changes[i]
+		if change.result.Status == bulkStatusSkipped {
+			continue
+		}
+		if operation == "poe-cycle" {
+			disabled := change.current
+			disabled.POEEnabled = false
+			changesets[0].Ports = append(changesets[0].Ports, e.portChangeset(&change.current, disabled).Ports...)
+			changesets[1].Ports = append(changesets[1].Ports, e.portChangeset(&disabled, change.desired).Ports...)
+			change.result.Status = bulkStatusChanged
+			changed = append(changed, *change)
+			continue
+		}
+		portChangeset := e.portChangeset(&change.current, change.desired)
+		if !portChangeset.IsEmpty() {
+			changesets[0].Ports = append(changesets[0].Ports, portChangeset.Ports...)
+			change.result.Status = bulkStatusChanged
+			changed = append(changed, *change)
+		}
+	}
+	err := e.applyChangesetsOnSwitch(ctx, switchID, changesets, poeCyclePause, func() (restoreFunc, error) {
+		previousPorts := []domain.EthernetSwitchPort{}
+		for _, change := range changed {
+			if change.current == change.desired {
+				continue
+			}
+			previousPort, err := e.portRepo.GetByID(ctx, change.current.ID)
+			if err != nil {
+				return restoreUpdated(e.portRepo, previousPorts...), errors.Internal.Wrap(err, errorGetPortByID)
+			}
+			queryBuilder := e.portRepo.NewQueryBuilder(ctx)
+			queryBuilder.Where("EthernetSwitchID", "==", switchID)
+			updateDto := dtos.EthernetSwitchPortUpdateDto{EthernetSwitchPortBaseDto: change.desired.EthernetSwitchPortBaseDto}
+			_, err = Update[dtos.EthernetSwitchPortDto](ctx, e.portRepo, updateDto, change.current.ID, queryBuilder)
+			if err != nil {
+				return restoreUpdated(e.portRepo, previousPorts...), err
+			}
+			previousPorts = append(previousPorts, previousPort)
+		}
+		return restoreUpdated(e.portRepo, previousPorts...), nil
+	})
+	for _, change := range changes {
+		results = append(results, change.result)
+	}
+	for i := range results {
+		if results[i].Status == bulkStatusChanged {
+			e.forgetPortStatus(results[i].PortID)
+		}
+	}
+	if err != nil {
+		failChangedResults(results, err)
+	}
+	return results
+}
+
+//applyBulkVLANChanges adds or removes selected ports to the VLAN on the switch within one transaction
+func (e *EthernetSwitchService) applyBulkVLANChanges(ctx context.Context, switchID uuid.UUID, ports []dtos.EthernetSwitchPortDto,
+	operation dtos.EthernetSwitchPortBulkOperationDto) []dtos.EthernetSwitchPortBulkResultDto {
+	vlan, found, err := e.getVLANByVlanID(ctx, switchID, operation.VLANID)
+	if err == nil && !found {
+		err = errors.NotFound.Newf("VLAN %d is not found on the switch", operation.VLANID)
+	}
+	if err != nil {
+		results := []dtos.EthernetSwitchPortBulkResultDto{}
+		for _, port := range ports {
+			results = append(results, dtos.EthernetSwitchPortBulkResultDto{
+				EthernetSwitchID: switchID,
+				PortID:           port.ID,
+				PortName:         port.Name,
+				Status:           bulkStatusFailed,
+				Error:            err.Error(),
+			})
+		}
+		return results
+	}
+	desired, results := bulkVLANPorts(switchID, vlan.EthernetSwitchVLANBaseDto, ports, operation)
+	changeset, err := e.vlanChangeset(ctx, vlan.VlanID, &vlan.EthernetSwitchVLANBaseDto, &desired)
+	if err == nil {
+		err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+			previousVLAN, err := e.vlanRepo.GetByID(ctx, vlan.ID)
+			if err != nil {
+				return nil, errors.Internal.Wrap(err, "failed to get VLAN by id")
+			}
+			queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
+			queryBuilder.Where("EthernetSwitchID", "==", switchID)
+			updateDto := dtos.EthernetSwitchVLANUpdateDto{EthernetSwitchVLANBaseDto: desired}
+			_, err = Update[dtos.EthernetSwitchVLANDto](ctx, e.vlanRepo, updateDto, vlan.ID, queryBuilder)
+			if err != nil {
+				return nil, err
+			}
+			return restoreUpdated(e.vlanRepo, previousVLAN), nil
+		})
+	}
+	if err != nil {
+		failChangedResults(results, err)
+	}
+	return results
+}
+
+//bulkPortOperationOnSwitch applies bulk operation to the selected ports of one switch
+func (e *EthernetSwitchService) bulkPortOperationOnSwitch(ctx context.Context, switchID uuid.UUID,
+	operation dtos.EthernetSwitchPortBulkOperationDto) ([]dtos.EthernetSwitchPortBulkResultDto, error) {
+	ports, err := e.selectPorts(ctx, switchID, operation.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(ports) == 0 {
+		return []dtos.EthernetSwitchPortBulkResultDto{}, nil
+	}
+	var results []dtos.EthernetSwitchPortBulkResultDto
+	switch operation.Operation {
+	case "add-vlan", "remove-vlan":
+		results = e.applyBulkVLANChanges(ctx, switchID, ports, operation)
+	default:
+		results = e.applyBulkPortsChanges(ctx, switchID, bulkPortsChanges(switchID, ports, operation), operation.Operation)
+	}
+	for _, result := range results {
+		if result.Status == bulkStatusFailed {
+			e.log(ctx, "error", fmt.Sprintf("bulk %s operation failed on the port %s of the switch %s: %s",
+				operation.Operation, result.PortName, switchID, result.Error))
+		}
+	}
+	return results, nil
+}
+
+//SwitchPortsBulkOperation applies operation to the selected ports of the switch. All changes are applied
+//on the switch within one configuration session.
+//
+//Params
+//	ctx - context
+//	switchID - ethernet switch ID
+//	operation - ports selector and operation
+//Return
+//	[]dtos.EthernetSwitchPortBulkResultDto - results for each selected port
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) SwitchPortsBulkOperation(ctx context.Context, switchID uuid.UUID,
+	operation dtos.EthernetSwitchPortBulkOperationDto) ([]dtos.EthernetSwitchPortBulkResultDto, error) {
+	err := validators.ValidateEthernetSwitchPortBulkOperationDto(operation)
+	if err != nil {
+		return nil, err //we already wrap error in validators
+	}
+	switchExist, err := e.switchIsExist(ctx, switchID)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, errorSwitchExistence)
+	}
+	if !switchExist {
+		return nil, errors.NotFound.New(errorSwitchNotFound)
+	}
+	return e.bulkPortOperationOnSwitch(ctx, switchID, operation)
+}
+
+//PortsBulkOperation applies operation to the selected ports of many switches. Switches are configured
+//one by one, each of them within one configuration session.
+//
+//Params
+//	ctx - context
+//	operation - ports selector with switches and operation
+//Return
+//	[]dtos.EthernetSwitchPortBulkResultDto - results for each selected port
+//	error - if an error occurs, otherwise nil
+func (e *EthernetSwitchService) PortsBulkOperation(ctx context.Context, operation dtos.EthernetSwitchPortBulkOperationDto) ([]dtos.EthernetSwitchPortBulkResultDto, error) {
+	err := validators.ValidateEthernetSwitchPortBulkOperationDto(operation)
+	if err != nil {
+		return nil, err //we already wrap error in validators
+	}
+	switchIDs := operation.Selector.SwitchIDs
+	if len(switchIDs) == 0 {
+		count, err := e.switchRepo.Count(ctx, nil)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to count switches")
+		}
+		switches, err := e.switchRepo.GetList(ctx, "Name", "asc", 1, count, nil)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to get switches")
+		}
+		for _, ethSwitch := range switches {
+			switchIDs = append(switchIDs, ethSwitch.ID)
+		}
+	}
+	for _, switchID := range switchIDs {
+		switchExist, err := e.switchIsExist(ctx, switchID)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, errorSwitchExistence)
+		}
+		if !switchExist {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+			return nil, errors.AddErrorContext(err, "Selector.SwitchIDs", fmt.Sprintf("switch %s is not found", switchID))
+		}
+	}
+	results := []dtos.EthernetSwitchPortBulkResultDto{}
+	for _, switchID := range switchIDs {
+		switchResults, err := e.bulkPortOperationOnSwitch(ctx, switchID, operation)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, switchResults...)
+	}
+	return results, nil
+}
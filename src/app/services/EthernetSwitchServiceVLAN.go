@@ -6,7 +6,6 @@ import (
 	"github.com/google/uuid"
 	"rol/app/errors"
 	"rol/app/mappers"
-	"rol/app/utils"
 	"rol/app/validators"
 	"rol/domain"
 	"rol/dtos"
@@ -17,8 +16,6 @@ const (
 	errorPortExistence   = "error when checking the existence of the switch port"
 	errorSwitchNotFound  = "switch is not found"
 	errorGetPortByID     = "get port by id failed"
-	errorAddTaggedVLAN   = "add tagged VLAN on port failed"
-	errorRemoveVLAN      = "failed to remove VLAN from port"
 	errorGetManager      = "can't get ethernet switch manager"
 )
 
@@ -36,8 +33,34 @@ func (e *EthernetSwitchService) vlanIDIsUniqueWithinTheSwitch(ctx context.Contex
 	return true, nil
 }
 
+//getNonexistentVLANPorts get IDs that are neither ports nor LAGs of the switch
+func (e *EthernetSwitchService) getNonexistentVLANPorts(ctx context.Context, switchID uuid.UUID, portsToCheck []uuid.UUID) ([]uuid.UUID, error) {
+	nonexistentPorts, err := e.getNonexistentPorts(ctx, switchID, portsToCheck)
+	if err != nil || len(nonexistentPorts) == 0 {
+		return nonexistentPorts, err
+	}
+	lags, err := e.getAllLAGs(ctx, switchID)
+	if err != nil {
+		return []uuid.UUID{}, err
+	}
+	out := []uuid.UUID{}
+	for _, portID := range nonexistentPorts {
+		isLAG := false
+		for _, lag := range lags {
+			if lag.ID == portID {
+				isLAG = true
+				break
+			}
+		}
+		if !isLAG {
+			out = append(out, portID)
+		}
+	}
+	return out, nil
+}
+
 func (e *EthernetSwitchService) checkNonexistentPorts(ctx context.Context, switchID uuid.UUID, dto dtos.EthernetSwitchVLANBaseDto) error {
-	nonexistentTaggedPorts, err := e.getNonexistentPorts(ctx, switchID, dto.TaggedPorts)
+	nonexistentTaggedPorts, err := e.getNonexistentVLANPorts(ctx, switchID, dto.TaggedPorts)
 	if err != nil {
 		return errors.Internal.Wrap(err, errorPortExistence)
 	}
@@ -49,7 +72,7 @@ func (e *EthernetSwitchService) checkNonexistentPorts(ctx context.Context, switc
 		return err
 	}
 
-	nonexistentUntaggedPorts, err := e.getNonexistentPorts(ctx, switchID, dto.UntaggedPorts)
+	nonexistentUntaggedPorts, err := e.getNonexistentVLANPorts(ctx, switchID, dto.UntaggedPorts)
 	if err != nil {
 		return errors.Internal.Wrap(err, errorPortExistence)
 	}
@@ -78,41 +101,6 @@ func (e *EthernetSwitchService) checkExistenceOfRelatedEntities(ctx context.Cont
 	return nil
 }
 
-func (e *EthernetSwitchService) createVlanOnSwitch(ctx context.Context, switchID uuid.UUID, dto dtos.EthernetSwitchVLANCreateDto) error {
-	switchManager, err := e.managers.Get(ctx, switchID)
-	if err != nil {
-		return errors.Internal.Wrap(err, errorGetManager)
-	}
-	if switchManager == nil {
-		return nil
-	}
-	err = switchManager.CreateVLAN(dto.VlanID)
-	if err != nil {
-		return errors.Internal.Wrap(err, "create VLAN on switch failed")
-	}
-	for _, taggedPortID := range dto.TaggedPorts {
-		switchPort, err := e.portRepo.GetByID(ctx, taggedPortID)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorGetPortByID)
-		}
-		err = switchManager.AddTaggedVLANOnPort(switchPort.Name, dto.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorAddTaggedVLAN)
-		}
-	}
-	for _, untaggedPortID := range dto.UntaggedPorts {
-		switchPort, err := e.portRepo.GetByID(ctx, untaggedPortID)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorGetPortByID)
-		}
-		err = switchManager.AddUntaggedVLANOnPort(switchPort.Name, dto.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorAddTaggedVLAN)
-		}
-	}
-	return nil
-}
-
 //GetVLANByID Get ethernet switch VLAN by switch ID and VLAN ID
 //
 //Params
@@ -194,34 +182,29 @@ func (e *EthernetSwitchService) CreateVLAN(ctx context.Context, switchID uuid.UU
 		return dto, errors.AddErrorContext(err, "VlanID", "vlan with this id already exist")
 	}
 
-	// Create VLAN on Switch
-	err = e.createVlanOnSwitch(ctx, switchID, createDto)
+	// Compute changes for the switch
+	changeset, err := e.vlanChangeset(ctx, createDto.VlanID, nil, &createDto.EthernetSwitchVLANBaseDto)
 	if err != nil {
-		return dto, err
+		return dto, errors.Internal.Wrap(err, "failed to compute switch changes")
 	}
 
-	// Save vlan configuration to repository
+	// Create VLAN on switch and save vlan configuration to repository
 	entity := new(domain.EthernetSwitchVLAN)
 	err = mappers.MapDtoToEntity(createDto, entity)
 	if err != nil {
 		return dto, errors.Internal.Wrap(err, "failed to map ethernet switch port dto to entity")
 	}
 	entity.EthernetSwitchID = switchID
-	newVLAN, err := e.vlanRepo.Insert(ctx, *entity)
-	if err != nil {
-		return dto, errors.Internal.Wrap(err, "repository failed to insert VLAN")
-	}
-
-	// Save configuration on the switch
-	switchManager, err := e.managers.Get(ctx, switchID)
-	if err != nil {
-		return dto, errors.Internal.Wrap(err, errorGetManager)
-	}
-	if switchManager != nil {
-		err = switchManager.SaveConfig()
+	newVLAN := domain.EthernetSwitchVLAN{}
+	err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+		newVLAN, err = e.vlanRepo.Insert(ctx, *entity)
 		if err != nil {
-			return dto, errors.Internal.Wrap(err, "save switch config failed")
+			return nil, errors.Internal.Wrap(err, "repository failed to insert VLAN")
 		}
+		return restoreInserted[domain.EthernetSwitchVLAN](e.vlanRepo, newVLAN.ID), nil
+	})
+	if err != nil {
+		return dto, err //we already wrap error
 	}
 
 	//Convert configuration to dto
@@ -258,26 +241,30 @@ func (e *EthernetSwitchService) UpdateVLAN(ctx context.Context, switchID, id uui
 		return dto, errors.Internal.Wrap(err, "get VLAN by id failed")
 	}
 
-	// Apply all changes and save configuration on the switch
-	err = e.syncVlanChangesOnSwitch(ctx, vlan, updateDto, switchID)
+	// Compute changes for the switch
+	changeset, err := e.vlanChangeset(ctx, vlan.VlanID, &vlan.EthernetSwitchVLANBaseDto, &updateDto.EthernetSwitchVLANBaseDto)
 	if err != nil {
-		return dto, errors.Internal.Wrap(err, "update VLANs on port failed")
+		return dto, errors.Internal.Wrap(err, "failed to compute switch changes")
 	}
-	switchManager, err := e.managers.Get(ctx, switchID)
+
+	// Apply all changes on the switch and update entity
+	previousVLAN, err := e.vlanRepo.GetByID(ctx, vlan.ID)
 	if err != nil {
-		return dto, errors.Internal.Wrap(err, errorGetManager)
+		return dto, errors.Internal.Wrap(err, "get VLAN by id failed")
 	}
-	if switchManager != nil {
-		err = switchManager.SaveConfig()
+	err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+		queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
+		queryBuilder.Where("EthernetSwitchID", "==", switchID)
+		dto, err = Update[dtos.EthernetSwitchVLANDto](ctx, e.vlanRepo, updateDto, vlan.ID, queryBuilder)
 		if err != nil {
-			return dto, errors.Internal.Wrap(err, "save switch config failed")
+			return nil, err
 		}
+		return restoreUpdated(e.vlanRepo, previousVLAN), nil
+	})
+	if err != nil {
+		return dtos.EthernetSwitchVLANDto{}, err //we already wrap error
 	}
-
-	// Map entity to dto
-	queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
-	queryBuilder.Where("EthernetSwitchID", "==", switchID)
-	return Update[dtos.EthernetSwitchVLANDto](ctx, e.vlanRepo, updateDto, vlan.ID, queryBuilder)
+	return dto, nil
 }
 
 //DeleteVLAN delete ethernet switch VLAN
@@ -296,85 +283,24 @@ func (e *EthernetSwitchService) DeleteVLAN(ctx context.Context, switchID, id uui
 	if !switchExist {
 		return errors.NotFound.New(errorSwitchNotFound)
 	}
-	queryBuilder := e.vlanRepo.NewQueryBuilder(ctx)
-	queryBuilder.Where("EthernetSwitchID", "==", switchID)
-	vlanEntity, err := e.vlanRepo.GetByIDExtended(ctx, id, queryBuilder)
+	vlan, err := e.GetVLANByID(ctx, switchID, id)
 	if err != nil {
 		return err
 	}
 
-	// Remove vlan and save configuration on switch
-	switchManager, err := e.managers.Get(ctx, switchID)
+	// Remove vlan from the ports and from the switch, then remove it from repository
+	changeset, err := e.vlanChangeset(ctx, vlan.VlanID, &vlan.EthernetSwitchVLANBaseDto, nil)
 	if err != nil {
-		return errors.Internal.Wrap(err, errorGetManager)
-	}
-	if switchManager != nil {
-		err = switchManager.DeleteVLAN(vlanEntity.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, "failed to delete vlan from switch")
-		}
-		err = switchManager.SaveConfig()
-		if err != nil {
-			return errors.Internal.Wrap(err, "failed to save config")
-		}
+		return errors.Internal.Wrap(err, "failed to compute switch changes")
 	}
-
-	//Remove from repository
-	return e.vlanRepo.Delete(ctx, id)
-}
-
-//syncVlanChangesOnSwitch apply vlan configuration to ethernet switch
-func (e *EthernetSwitchService) syncVlanChangesOnSwitch(ctx context.Context, VLAN dtos.EthernetSwitchVLANDto, updateDto dtos.EthernetSwitchVLANUpdateDto, switchID uuid.UUID) error {
-	switchManager, err := e.managers.Get(ctx, switchID)
+	//deleted VLAN can't be restored in the repository, so it is deleted after the switch configuration is saved
+	err = e.applyChangesetOnSwitch(ctx, switchID, changeset, func() (restoreFunc, error) {
+		return nil, nil
+	})
 	if err != nil {
-		return errors.Internal.Wrap(err, errorGetManager)
-	}
-	if switchManager == nil {
-		return nil
-	}
-	diffTaggedToRemove, diffTaggedToAdd := utils.SliceDiffElements[uuid.UUID](VLAN.TaggedPorts, updateDto.TaggedPorts)
-	for _, id := range diffTaggedToRemove {
-		switchPort, err := e.portRepo.GetByID(ctx, id)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorGetPortByID)
-		}
-		err = switchManager.RemoveVLANFromPort(switchPort.Name, VLAN.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorRemoveVLAN)
-		}
-	}
-	for _, id := range diffTaggedToAdd {
-		switchPort, err := e.portRepo.GetByID(ctx, id)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorGetPortByID)
-		}
-		err = switchManager.AddTaggedVLANOnPort(switchPort.Name, VLAN.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, "failed to add tagged VLAN on port")
-		}
-	}
-	diffUntaggedToRemove, diffUntaggedToAdd := utils.SliceDiffElements[uuid.UUID](VLAN.UntaggedPorts, updateDto.UntaggedPorts)
-	for _, id := range diffUntaggedToRemove {
-		switchPort, err := e.portRepo.GetByID(ctx, id)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorGetPortByID)
-		}
-		err = switchManager.RemoveVLANFromPort(switchPort.Name, VLAN.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorRemoveVLAN)
-		}
-	}
-	for _, id := range diffUntaggedToAdd {
-		switchPort, err := e.portRepo.GetByID(ctx, id)
-		if err != nil {
-			return errors.Internal.Wrap(err, errorGetPortByID)
-		}
-		err = switchManager.AddUntaggedVLANOnPort(switchPort.Name, VLAN.VlanID)
-		if err != nil {
-			return errors.Internal.Wrap(err, "failed to add untagged VLAN on port")
-		}
+		return err //we already wrap error
 	}
-	return nil
+	return e.vlanRepo.Delete(ctx, id)
 }
 
 func (e *EthernetSwitchService) deleteAllVLANsBySwitchID(ctx context.Context, switchID uuid.UUID) error {
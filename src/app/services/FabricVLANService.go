@@ -0,0 +1,555 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"reflect"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/mappers"
+	"rol/app/utils"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+	"sync"
+)
+
+const (
+	fabricVLANStatusPending    = "pending"
+	fabricVLANStatusConfigured = "configured"
+	fabricVLANStatusPartial    = "partial"
+	fabricVLANStatusFailed     = "failed"
+)
+
+const errorFabricVLANNotFound = "fabric VLAN is not found"
+
+//FabricVLANService service for VLANs that span several ethernet switches and the host.
+//Switch VLANs are created through EthernetSwitchService and the host VLAN through HostNetworkService,
+//so all usual checks and switch transactions are applied to each part of the fabric VLAN.
+type FabricVLANService struct {
+	vlanRepo      interfaces.IGenericRepository[uuid.UUID, domain.FabricVLAN]
+	memberRepo    interfaces.IGenericRepository[uuid.UUID, domain.FabricVLANMember]
+	switchService *EthernetSwitchService
+	hostService   *HostNetworkService
+	//mutex - fabric VLANs are changed one at a time
+	mutex  sync.Mutex
+	logger *logrus.Logger
+	//logSourceName - logger recording source
+	logSourceName string
+}
+
+//NewFabricVLANService constructor for fabric VLAN service
+//Params
+//	vlanRepo - generic repository with domain.FabricVLAN entity
+//	memberRepo - generic repository with domain.FabricVLANMember entity
+//	switchService - ethernet switch service
+//	hostService - host network service
+//	logger - logrus logger
+//Return
+//	New fabric VLAN service
+func NewFabricVLANService(vlanRepo interfaces.IGenericRepository[uuid.UUID, domain.FabricVLAN],
+	memberRepo interfaces.IGenericRepository[uuid.UUID, domain.FabricVLANMember], switchService *EthernetSwitchService,
+	hostService *HostNetworkService, logger *logrus.Logger) *FabricVLANService {
+	return &FabricVLANService{
+		vlanRepo:      vlanRepo,
+		memberRepo:    memberRepo,
+		switchService: switchService,
+		hostService:   hostService,
+		logger:        logger,
+		logSourceName: reflect.TypeOf(FabricVLANService{}).Name(),
+	}
+}
+
+func (f *FabricVLANService) log(ctx context.Context, level, message string) {
+	logWithAction(ctx, f.logger, f.logSourceName, level, message)
+}
+
+func (f *FabricVLANService) getMembers(ctx context.Context, fabricVlanID uuid.UUID) ([]domain.FabricVLANMember, error) {
+	queryBuilder := f.memberRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("FabricVlanID", "==", fabricVlanID)
+	count, err := f.memberRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to count fabric VLAN members")
+	}
+	members, err := f.memberRepo.GetList(ctx, "CreatedAt", "asc", 1, count, queryBuilder)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get fabric VLAN members")
+	}
+	return members, nil
+}
+
+//getFabricVLANStatus get summary status of the fabric VLAN from the statuses of its parts
+func getFabricVLANStatus(dto dtos.FabricVLANDto) string {
+	statuses := []string{}
+	for _, member := range dto.Members {
+		statuses = append(statuses, member.Status)
+	}
+	if dto.HostParent != "" {
+		statuses = append(statuses, dto.HostStatus)
+	}
+	configured, failed := 0, 0
+	for _, status := range statuses {
+		switch status {
+		case fabricVLANStatusConfigured:
+			configured++
+		case fabricVLANStatusFailed:
+			failed++
+		default:
+			return fabricVLANStatusPending
+		}
+	}
+	if failed == 0 {
+		return fabricVLANStatusConfigured
+	}
+	if configured == 0 {
+		return fabricVLANStatusFailed
+	}
+	return fabricVLANStatusPartial
+}
+
+//fillMembers sets members and summary status of the fabric VLAN dto
+func (f *FabricVLANService) fillMembers(ctx context.Context, dto *dtos.FabricVLANDto) error {
+	members, err := f.getMembers(ctx, dto.ID)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		memberDto := dtos.FabricVLANMemberDto{}
+		err = mappers.MapEntityToDto(member, &memberDto)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to map fabric VLAN member entity to dto")
+		}
+		dto.Members = append(dto.Members, memberDto)
+	}
+	dto.Status = getFabricVLANStatus(*dto)
+	return nil
+}
+
+func (f *FabricVLANService) toDto(ctx context.Context, entity domain.FabricVLAN) (dtos.FabricVLANDto, error) {
+	dto := dtos.FabricVLANDto{}
+	err := mappers.MapEntityToDto(entity, &dto)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to map fabric VLAN entity to dto")
+	}
+	err = f.fillMembers(ctx, &dto)
+	if err != nil {
+		return dtos.FabricVLANDto{}, err
+	}
+	return dto, nil
+}
+
+func (f *FabricVLANService) vlanIDIsUnique(ctx context.Context, vlanID int) (bool, error) {
+	queryBuilder := f.vlanRepo.NewQueryBuilder(ctx)
+	queryBuilder.Where("VlanID", "==", vlanID)
+	count, err := f.vlanRepo.Count(ctx, queryBuilder)
+	if err != nil {
+		return false, errors.Internal.Wrap(err, "failed to count fabric VLANs")
+	}
+	return count == 0, nil
+}
+
+//checkRelatedEntities checks that all switches, ports and the host parent interface exist
+func (f *FabricVLANService) checkRelatedEntities(ctx context.Context, createDto dtos.FabricVLANCreateDto) error {
+	var err error
+	addError := func(field, message string) {
+		if err == nil {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+		}
+		err = errors.AddErrorContext(err, field, message)
+	}
+	for i, member := range createDto.Members {
+		switchExist, err1 := f.switchService.switchIsExist(ctx, member.EthernetSwitchID)
+		if err1 != nil {
+			return errors.Internal.Wrap(err1, errorSwitchExistence)
+		}
+		if !switchExist {
+			addError(fmt.Sprintf("Members[%d].EthernetSwitchID", i), errorSwitchNotFound)
+			continue
+		}
+		nonexistentPorts, err1 := f.switchService.getNonexistentVLANPorts(ctx, member.EthernetSwitchID, []uuid.UUID{member.PortID})
+		if err1 != nil {
+			return errors.Internal.Wrap(err1, errorPortExistence)
+		}
+		if len(nonexistentPorts) > 0 {
+			addError(fmt.Sprintf("Members[%d].PortID", i), fmt.Sprintf("port %s doesn't exist", member.PortID.String()))
+		}
+	}
+	if createDto.HostParent != "" {
+		parentExist, err1 := f.hostService.linkIsExist(createDto.HostParent)
+		if err1 != nil {
+			return errors.Internal.Wrap(err1, "failed to check existence of the host parent interface")
+		}
+		if !parentExist {
+			addError("HostParent", parentNotFound)
+		}
+	}
+	return err
+}
+
+//groupMembersBySwitch get members of each switch in the order of the first occurrence of the switch
+func groupMembersBySwitch(members []domain.FabricVLANMember) ([]uuid.UUID, map[uuid.UUID][]*domain.FabricVLANMember) {
+	switchIDs := []uuid.UUID{}
+	groups := map[uuid.UUID][]*domain.FabricVLANMember{}
+	for i := range members {
+		member := &members[i]
+		if _, found := groups[member.EthernetSwitchID]; !found {
+			switchIDs = append(switchIDs, member.EthernetSwitchID)
+		}
+		groups[member.EthernetSwitchID] = append(groups[member.EthernetSwitchID], member)
+	}
+	return switchIDs, groups
+}
+
+//addSwitchMembers adds ports to the switch VLAN, VLAN is created if it doesn't exist on the switch,
+//members are marked if the VLAN is created
+func (f *FabricVLANService) addSwitchMembers(ctx context.Context, switchID uuid.UUID, vlanID int, members []*domain.FabricVLANMember) error {
+	vlan, found, err := f.switchService.getVLANByVlanID(ctx, switchID, vlanID)
+	if err != nil {
+		return err
+	}
+	ports := vlan.EthernetSwitchVLANBaseDto
+	for _, member := range members {
+		ports.TaggedPorts = utils.RemoveElementFromSlice(ports.TaggedPorts, member.PortID)
+		ports.UntaggedPorts = utils.RemoveElementFromSlice(ports.UntaggedPorts, member.PortID)
+		if member.Tagged {
+			ports.TaggedPorts = append(ports.TaggedPorts, member.PortID)
+		} else {
+			ports.UntaggedPorts = append(ports.UntaggedPorts, member.PortID)
+		}
+	}
+	if !found {
+		_, err = f.switchService.CreateVLAN(ctx, switchID, dtos.EthernetSwitchVLANCreateDto{EthernetSwitchVLANBaseDto: ports, VlanID: vlanID})
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			member.SwitchVLANCreated = true
+		}
+		return nil
+	}
+	_, err = f.switchService.UpdateVLAN(ctx, switchID, vlan.ID, dtos.EthernetSwitchVLANUpdateDto{EthernetSwitchVLANBaseDto: ports})
+	return err
+}
+
+//removeSwitchMembers removes ports from the switch VLAN, VLAN is deleted from the switch when it has no ports left
+//and it was created for the fabric VLAN
+func (f *FabricVLANService) removeSwitchMembers(ctx context.Context, switchID uuid.UUID, vlanID int, members []*domain.FabricVLANMember) error {
+	vlan, found, err := f.switchService.getVLANByVlanID(ctx, switchID, vlanID)
+	if err != nil || !found {
+		return err
+	}
+	ports := vlan.EthernetSwitchVLANBaseDto
+	created := false
+	for _, member := range members {
+		ports.TaggedPorts = utils.RemoveElementFromSlice(ports.TaggedPorts, member.PortID)
+		ports.UntaggedPorts = utils.RemoveElementFromSlice(ports.UntaggedPorts, member.PortID)
+		created = created || member.SwitchVLANCreated
+	}
+	if created && len(ports.TaggedPorts) == 0 && len(ports.UntaggedPorts) == 0 {
+		return f.switchService.DeleteVLAN(ctx, switchID, vlan.ID)
+	}
+	_, err = f.switchService.UpdateVLAN(ctx, switchID, vlan.ID, dtos.EthernetSwitchVLANUpdateDto{EthernetSwitchVLANBaseDto: ports})
+	return err
+}
+
+//findHostVLAN get name of the host VLAN interface with VLAN ID on the parent interface
+func (f *FabricVLANService) findHostVLAN(parent string, vlanID int) (string, error) {
+	hostVLANs, err := f.hostService.GetVlanList()
+	if err != nil {
+		return "", err
+	}
+	for _, hostVLAN := range hostVLANs {
+		if hostVLAN.Parent == parent && hostVLAN.VlanID == vlanID {
+			return hostVLAN.Name, nil
+		}
+	}
+	return "", nil
+}
+
+//confirmHostChanges saves the host network configuration, so the host VLAN changes are not reset
+//after the host network confirm timeout
+func (f *FabricVLANService) confirmHostChanges(ctx context.Context) error {
+	requestID := uuid.Nil
+	if ctx != nil && ctx.Value("requestID") != nil {
+		requestID = ctx.Value("requestID").(uuid.UUID)
+	}
+	return f.hostService.Ping(f.logSourceName, requestID)
+}
+
+//createHostVLAN creates the host VLAN interface or sets addresses to the existing one
+func (f *FabricVLANService) createHostVLAN(ctx context.Context, entity *domain.FabricVLAN, addresses []string) error {
+	name, err := f.findHostVLAN(entity.HostParent, entity.VlanID)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		entity.HostInterface = name
+		_, err = f.hostService.UpdateVlan(name, dtos.HostNetworkVlanUpdateDto{Addresses: addresses})
+		if err != nil {
+			return err
+		}
+		return f.confirmHostChanges(ctx)
+	}
+	hostVLAN, err := f.hostService.CreateVlan(dtos.HostNetworkVlanCreateDto{
+		VlanID:    entity.VlanID,
+		Parent:    entity.HostParent,
+		Addresses: addresses,
+	})
+	if err != nil {
+		return err
+	}
+	entity.HostInterface = hostVLAN.Name
+	entity.HostVLANCreated = true
+	return f.confirmHostChanges(ctx)
+}
+
+func setFabricVLANMemberResult(member *domain.FabricVLANMember, err error) {
+	member.Status = fabricVLANStatusConfigured
+	member.Error = ""
+	if err != nil {
+		member.Status = fabricVLANStatusFailed
+		member.Error = err.Error()
+	}
+}
+
+func (f *FabricVLANService) updateMembers(ctx context.Context, members []*domain.FabricVLANMember) error {
+	for _, member := range members {
+		_, err := f.memberRepo.Update(ctx, *member)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to update fabric VLAN member")
+		}
+	}
+	return nil
+}
+
+//GetByID get fabric VLAN with members and their statuses by ID
+//
+//Params
+//	ctx - context is used only for logging
+//	id - fabric VLAN ID
+//Return
+//	dtos.FabricVLANDto - fabric VLAN dto
+//	error - if an error occurs, otherwise nil
+func (f *FabricVLANService) GetByID(ctx context.Context, id uuid.UUID) (dtos.FabricVLANDto, error) {
+	entity, err := f.vlanRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return dtos.FabricVLANDto{}, errors.NotFound.New(errorFabricVLANNotFound)
+		}
+		return dtos.FabricVLANDto{}, errors.Internal.Wrap(err, "failed to get fabric VLAN")
+	}
+	return f.toDto(ctx, entity)
+}
+
+//GetList get list of fabric VLANs with filtering and pagination
+//
+//Params
+//	ctx - context is used only for logging
+//	search - string for search in fabric VLAN string fields
+//	orderBy - order by fabric VLAN field name
+//	orderDirection - ascending or descending order
+//	page - page number
+//	pageSize - page size
+//Return
+//	dtos.PaginatedItemsDto[dtos.FabricVLANDto] - paginated list of fabric VLANs
+//	error - if an error occurs, otherwise nil
+func (f *FabricVLANService) GetList(ctx context.Context, search, orderBy, orderDirection string, page, pageSize int) (dtos.PaginatedItemsDto[dtos.FabricVLANDto], error) {
+	queryBuilder := f.vlanRepo.NewQueryBuilder(ctx)
+	if len(search) > 3 {
+		AddSearchInAllFields(search, f.vlanRepo, queryBuilder)
+	}
+	paginatedList, err := GetListExtended[dtos.FabricVLANDto](ctx, f.vlanRepo, queryBuilder, orderBy, orderDirection, page, pageSize)
+	if err != nil {
+		return paginatedList, err
+	}
+	for i := range paginatedList.Items {
+		err = f.fillMembers(ctx, &paginatedList.Items[i])
+		if err != nil {
+			return dtos.PaginatedItemsDto[dtos.FabricVLANDto]{}, err
+		}
+	}
+	return paginatedList, nil
+}
+
+//Create fabric VLAN. All switches, ports and the host parent interface are checked before any change,
+//then the VLAN is configured on each switch and on the host. Result of each member is saved to its status,
+//so the fabric VLAN is returned even if some members failed.
+//
+//Params
+//	ctx - context is used only for logging
+//	createDto - fabric VLAN create dto
+//Return
+//	dtos.FabricVLANDto - created fabric VLAN with members statuses
+//	error - if an error occurs, otherwise nil
+func (f *FabricVLANService) Create(ctx context.Context, createDto dtos.FabricVLANCreateDto) (dtos.FabricVLANDto, error) {
+	dto := dtos.FabricVLANDto{}
+	err := validators.ValidateFabricVLANCreateDto(createDto)
+	if err != nil {
+		return dto, err //we already wrap error in validators
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	unique, err := f.vlanIDIsUnique(ctx, createDto.VlanID)
+	if err != nil {
+		return dto, err
+	}
+	if !unique {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dto, errors.AddErrorContext(err, "VlanID", "fabric VLAN with this id already exist")
+	}
+	err = f.checkRelatedEntities(ctx, createDto)
+	if err != nil {
+		return dto, err
+	}
+
+	// Save the fabric VLAN with pending members
+	entity := domain.FabricVLAN{}
+	err = mappers.MapDtoToEntity(createDto, &entity)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to map fabric VLAN dto to entity")
+	}
+	if entity.HostParent != "" {
+		entity.HostStatus = fabricVLANStatusPending
+	}
+	entity, err = f.vlanRepo.Insert(ctx, entity)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "repository failed to insert fabric VLAN")
+	}
+	members := []domain.FabricVLANMember{}
+	for _, memberDto := range createDto.Members {
+		member, err := f.memberRepo.Insert(ctx, domain.FabricVLANMember{
+			FabricVlanID:     entity.ID,
+			EthernetSwitchID: memberDto.EthernetSwitchID,
+			PortID:           memberDto.PortID,
+			Tagged:           memberDto.Tagged,
+			Status:           fabricVLANStatusPending,
+		})
+		if err != nil {
+			return dto, errors.Internal.Wrap(err, "repository failed to insert fabric VLAN member")
+		}
+		members = append(members, member)
+	}
+
+	// Configure each switch with one transaction, then the host
+	switchIDs, groups := groupMembersBySwitch(members)
+	for _, switchID := range switchIDs {
+		err = f.addSwitchMembers(ctx, switchID, entity.VlanID, groups[switchID])
+		if err != nil {
+			f.log(ctx, "error", fmt.Sprintf("failed to create VLAN %d on the switch %s: %s", entity.VlanID, switchID, err.Error()))
+		}
+		for _, member := range groups[switchID] {
+			setFabricVLANMemberResult(member, err)
+		}
+		err = f.updateMembers(ctx, groups[switchID])
+		if err != nil {
+			return dto, err
+		}
+	}
+	if entity.HostParent != "" {
+		err = f.createHostVLAN(ctx, &entity, createDto.HostAddresses)
+		entity.HostStatus = fabricVLANStatusConfigured
+		if err != nil {
+			f.log(ctx, "error", fmt.Sprintf("failed to create VLAN %d on the host: %s", entity.VlanID, err.Error()))
+			entity.HostStatus = fabricVLANStatusFailed
+			entity.HostError = err.Error()
+		}
+		entity, err = f.vlanRepo.Update(ctx, entity)
+		if err != nil {
+			return dto, errors.Internal.Wrap(err, "repository failed to update fabric VLAN")
+		}
+	}
+	return f.toDto(ctx, entity)
+}
+
+//Delete fabric VLAN from all switches and from the host. Ports of the fabric VLAN are removed from the switch VLANs,
+//switch VLAN is deleted when it has no ports left. Switch VLANs and the host VLAN interface are deleted only
+//if they were created for the fabric VLAN, reused ones are kept. If some members can't be removed, they are kept
+//with the failed status and the error is returned, so the deletion can be repeated.
+//
+//Params
+//	ctx - context is used only for logging
+//	id - fabric VLAN ID
+//Return
+//	error - if an error occurs, otherwise nil
+func (f *FabricVLANService) Delete(ctx context.Context, id uuid.UUID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	entity, err := f.vlanRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return errors.NotFound.New(errorFabricVLANNotFound)
+		}
+		return errors.Internal.Wrap(err, "failed to get fabric VLAN")
+	}
+	members, err := f.getMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+	failed := false
+	switchIDs, groups := groupMembersBySwitch(members)
+	for _, switchID := range switchIDs {
+		err = f.removeSwitchMembers(ctx, switchID, entity.VlanID, groups[switchID])
+		if err != nil {
+			f.log(ctx, "error", fmt.Sprintf("failed to delete VLAN %d from the switch %s: %s", entity.VlanID, switchID, err.Error()))
+			failed = true
+		}
+		if err != nil {
+			for _, member := range groups[switchID] {
+				setFabricVLANMemberResult(member, err)
+			}
+			err = f.updateMembers(ctx, groups[switchID])
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for _, member := range groups[switchID] {
+			err = f.memberRepo.Delete(ctx, member.ID)
+			if err != nil {
+				return errors.Internal.Wrap(err, "repository failed to delete fabric VLAN member")
+			}
+		}
+	}
+	if entity.HostParent != "" && entity.HostStatus != "" {
+		err = f.deleteHostVLAN(ctx, entity)
+		if err != nil {
+			f.log(ctx, "error", fmt.Sprintf("failed to delete VLAN %d from the host: %s", entity.VlanID, err.Error()))
+			failed = true
+			entity.HostStatus = fabricVLANStatusFailed
+			entity.HostError = err.Error()
+		} else {
+			entity.HostStatus = ""
+			entity.HostError = ""
+		}
+		_, err = f.vlanRepo.Update(ctx, entity)
+		if err != nil {
+			return errors.Internal.Wrap(err, "repository failed to update fabric VLAN")
+		}
+	}
+	if failed {
+		return errors.Internal.New("failed to delete fabric VLAN from one or more members, see members statuses")
+	}
+	err = f.vlanRepo.Delete(ctx, id)
+	if err != nil {
+		return errors.Internal.Wrap(err, "repository failed to delete fabric VLAN")
+	}
+	return nil
+}
+
+//deleteHostVLAN deletes the host VLAN interface if it was created for the fabric VLAN
+func (f *FabricVLANService) deleteHostVLAN(ctx context.Context, entity domain.FabricVLAN) error {
+	if !entity.HostVLANCreated {
+		return nil
+	}
+	name, err := f.findHostVLAN(entity.HostParent, entity.VlanID)
+	if err != nil || name == "" {
+		return err
+	}
+	err = f.hostService.DeleteVlan(name)
+	if err != nil {
+		return err
+	}
+	return f.confirmHostChanges(ctx)
+}
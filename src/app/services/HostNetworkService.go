@@ -1,10 +1,12 @@
 package services
 
 import (
+	"github.com/google/uuid"
 	"net"
 	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/app/utils"
+	"rol/dtos"
 )
 
 const parentNotFound = "parent interface is not exist on the host"
@@ -12,18 +14,21 @@ const setAddressesFailed = "set addresses to the interface fail"
 
 //HostNetworkService is a struct for host network vlan service
 type HostNetworkService struct {
-	manager interfaces.IHostNetworkManager
+	manager       interfaces.IHostNetworkManager
+	configStorage interfaces.IHostNetworkConfigStorage
 }
 
 //NewHostNetworkService is a constructor for HostNetworkService
 //
 //Params:
 //	manager - host network manager
+//	configStorage - host network configuration storage with the saved configuration revisions
 //Return:
 //	HostNetworkService - instance of network vlan service
-func NewHostNetworkService(manager interfaces.IHostNetworkManager) *HostNetworkService {
+func NewHostNetworkService(manager interfaces.IHostNetworkManager, configStorage interfaces.IHostNetworkConfigStorage) *HostNetworkService {
 	return &HostNetworkService{
-		manager: manager,
+		manager:       manager,
+		configStorage: configStorage,
 	}
 }
 
@@ -68,13 +73,89 @@ func (h *HostNetworkService) syncAddresses(link interfaces.IHostNetworkLink, add
 	return nil
 }
 
-//Ping method for checks that the current settings do not break the connection and saves current configuration
-func (h *HostNetworkService) Ping() error {
+func (h *HostNetworkService) linkIsExist(name string) (bool, error) {
+	link, err := h.manager.GetByName(name)
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return link != nil, nil
+}
+
+//Ping method for checks that the current settings do not break the connection and saves current configuration,
+//it confirms the changes, so they are not reset after the confirm timeout
+//
+//Params:
+//	author - who confirms the changes
+//	requestID - ID of the API request that confirms the changes
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) Ping(author string, requestID uuid.UUID) error {
 	if h.manager.HasUnsavedChanges() {
-		err := h.manager.SaveConfiguration()
+		err := h.manager.SaveConfiguration(author, requestID)
 		if err != nil {
 			return errors.Internal.Wrap(err, "failed to save network configuration")
 		}
 	}
 	return nil
 }
+
+//GetStatus gets status of the host network changes
+//
+//Return:
+//	dtos.HostNetworkStatusDto - host network changes status
+func (h *HostNetworkService) GetStatus() dtos.HostNetworkStatusDto {
+	status := dtos.HostNetworkStatusDto{
+		HasUnsavedChanges: h.manager.HasUnsavedChanges(),
+	}
+	deadline := h.manager.GetConfirmDeadline()
+	if !deadline.IsZero() {
+		status.ConfirmDeadline = &deadline
+	}
+	resetErr := h.manager.GetConfirmResetError()
+	if resetErr != nil {
+		status.ResetError = resetErr.Error()
+	}
+	return status
+}
+
+func (h *HostNetworkService) syncSlaves(masterName string, currSlaves, slaves []string) error {
+	deleteSlice, addSlice := utils.SliceDiffElements(currSlaves, slaves)
+	for _, deleteSlave := range deleteSlice {
+		err := h.manager.UnsetLinkMaster(deleteSlave)
+		if err != nil {
+			resetErr := h.manager.ResetChanges()
+			if resetErr != nil {
+				return errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
+			}
+			return errors.Internal.Wrap(err, "set link no master failed")
+		}
+	}
+	for _, addSlave := range addSlice {
+		err := h.manager.SetLinkMaster(addSlave, masterName)
+		if err != nil {
+			resetErr := h.manager.ResetChanges()
+			if resetErr != nil {
+				return errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
+			}
+			return errors.Internal.Wrap(err, "set link master failed")
+		}
+	}
+	return nil
+}
+
+func (h *HostNetworkService) checkSlavesExistence(slaves []string) error {
+	for _, slave := range slaves {
+		_, err := h.manager.GetByName(slave)
+		if err != nil {
+			if !errors.As(err, errors.NotFound) {
+				return errors.Internal.Wrap(err, "failed to check existence of slave interface")
+			}
+			err1 := errors.Validation.New(errors.ValidationErrorMessage)
+			return errors.AddErrorContext(err1, "Slaves", slaveNotFound)
+		}
+	}
+	return nil
+}
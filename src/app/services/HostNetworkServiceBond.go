@@ -0,0 +1,172 @@
+package services
+
+import (
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+	"strings"
+)
+
+const bondNotFound = "bond is not exist on the host"
+
+//GetBondList gets list of host bonds
+//
+//Return:
+//	[]dtos.HostNetworkBondDto - slice of bond dtos
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetBondList() ([]dtos.HostNetworkBondDto, error) {
+	out := []dtos.HostNetworkBondDto{}
+	links, err := h.manager.GetList()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "error getting link list")
+	}
+	for _, link := range links {
+		if link.GetType() == "bond" && strings.Contains(link.GetName(), "rol.bond.") {
+			var dto dtos.HostNetworkBondDto
+			err = mappers.MapEntityToDto(link, &dto)
+			if err != nil {
+				return nil, errors.Internal.Wrap(err, "error mapping bond")
+			}
+			out = append(out, dto)
+		}
+	}
+	return out, nil
+}
+
+//GetBondByName gets bond by name
+//
+//Params:
+//	name - name of the bond
+//Return:
+//	dtos.HostNetworkBondDto - bond dto
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetBondByName(name string) (dtos.HostNetworkBondDto, error) {
+	out := dtos.HostNetworkBondDto{}
+	link, err := h.getBond(name)
+	if err != nil {
+		return out, err
+	}
+	err = mappers.MapEntityToDto(link, &out)
+	if err != nil {
+		return out, errors.Internal.Wrap(err, "error mapping bond")
+	}
+	return out, nil
+}
+
+func (h *HostNetworkService) getBond(name string) (domain.HostNetworkBond, error) {
+	if !strings.Contains(name, "rol.bond.") {
+		return domain.HostNetworkBond{}, errors.NotFound.New(bondNotFound)
+	}
+	link, err := h.manager.GetByName(name)
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return domain.HostNetworkBond{}, errors.NotFound.New(bondNotFound)
+		}
+		return domain.HostNetworkBond{}, errors.Internal.Wrap(err, "error getting bond by name")
+	}
+	if link == nil || link.GetType() != "bond" {
+		return domain.HostNetworkBond{}, errors.NotFound.New(bondNotFound)
+	}
+	return link.(domain.HostNetworkBond), nil
+}
+
+//CreateBond new bond on host
+//
+//Params:
+//	createDto - bond create dto
+//Return:
+//	dtos.HostNetworkBondDto - created host network bond
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) CreateBond(createDto dtos.HostNetworkBondCreateDto) (dtos.HostNetworkBondDto, error) {
+	dto := dtos.HostNetworkBondDto{}
+	err := validators.ValidateHostNetworkBondCreateDto(createDto)
+	if err != nil {
+		return dto, err
+	}
+	err = h.checkSlavesExistence(createDto.Slaves)
+	if err != nil {
+		return dto, err
+	}
+	bondName, err := h.manager.CreateBond(createDto.Name, createDto.Mode, createDto.Miimon)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error creating bond")
+	}
+	err = h.manager.SetLinkUp(bondName)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "set bond up failed")
+	}
+	bond, err := h.getBond(bondName)
+	if err != nil {
+		return dto, err
+	}
+	err = h.syncAddresses(bond, createDto.Addresses)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
+		}
+		return dto, err
+	}
+	err = h.syncSlaves(bondName, bond.GetSlaves(), createDto.Slaves)
+	if err != nil {
+		return dto, err
+	}
+	return h.GetBondByName(bondName)
+}
+
+//UpdateBond update bond addresses and slaves on host
+//
+//Params:
+//	bondName - bond name
+//	updateDto - bond update dto
+//Return:
+//	dtos.HostNetworkBondDto - updated host network bond
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) UpdateBond(bondName string, updateDto dtos.HostNetworkBondUpdateDto) (dtos.HostNetworkBondDto, error) {
+	dto := dtos.HostNetworkBondDto{}
+	err := validators.ValidateHostNetworkBondUpdateDto(updateDto)
+	if err != nil {
+		return dto, err
+	}
+	err = h.checkSlavesExistence(updateDto.Slaves)
+	if err != nil {
+		return dto, err
+	}
+	bond, err := h.getBond(bondName)
+	if err != nil {
+		return dto, err
+	}
+	err = h.syncAddresses(bond, updateDto.Addresses)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
+		}
+		return dto, err
+	}
+	err = h.syncSlaves(bondName, bond.GetSlaves(), updateDto.Slaves)
+	if err != nil {
+		return dto, err
+	}
+	return h.GetBondByName(bondName)
+}
+
+//DeleteBond deletes bond on host by its name, slaves are released by the kernel
+//
+//Params:
+//	bondName - bond name
+//Return
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) DeleteBond(bondName string) error {
+	_, err := h.getBond(bondName)
+	if err != nil {
+		return err
+	}
+	err = h.manager.DeleteLinkByName(bondName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "delete bond failed")
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/app/mappers"
-	"rol/app/utils"
 	"rol/app/validators"
 	"rol/domain"
 	"rol/dtos"
@@ -13,6 +12,7 @@ import (
 
 const slaveNotFound = "slave interface is not exist on the host"
 const bridgeNotFound = "bridge is not exist on the host"
+const upstreamNotFound = "upstream interface is not exist on the host"
 
 //GetBridgeList gets list of host bridges
 //
@@ -66,31 +66,38 @@ func (h *HostNetworkService) syncBridgeSlaves(link interfaces.IHostNetworkLink,
 		return errors.Internal.New("wrong link type received")
 	}
 	bridge := link.(domain.HostNetworkBridge)
-	currSlaves := bridge.GetSlaves()
-	deleteSlice, addSlice := utils.SliceDiffElements(currSlaves, slaves)
-	for _, deleteSlave := range deleteSlice {
-		err := h.manager.UnsetLinkMaster(deleteSlave)
-		if err != nil {
-			resetErr := h.manager.ResetChanges()
-			if resetErr != nil {
-				return errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
-			}
-			return errors.Internal.Wrap(err, "set link no master failed")
-		}
+	return h.syncSlaves(bridge.GetName(), bridge.GetSlaves(), slaves)
+}
+
+//checkBridgeUpstreamInterface checks that NAT upstream interface exists and is not the bridge slave
+func (h *HostNetworkService) checkBridgeUpstreamInterface(baseDto dtos.HostNetworkBridgeBaseDto) error {
+	if !baseDto.Nat {
+		return nil
 	}
-	for _, addSlave := range addSlice {
-		err := h.manager.SetLinkMaster(addSlave, bridge.GetName())
-		if err != nil {
-			resetErr := h.manager.ResetChanges()
-			if resetErr != nil {
-				return errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
-			}
-			return errors.Internal.Wrap(err, "set link master failed")
+	for _, slave := range baseDto.Slaves {
+		if slave == baseDto.UpstreamInterface {
+			err := errors.Validation.New(errors.ValidationErrorMessage)
+			return errors.AddErrorContext(err, "UpstreamInterface", "upstream interface can't be the bridge slave")
 		}
 	}
+	link, err := h.manager.GetByName(baseDto.UpstreamInterface)
+	if err != nil && !errors.As(err, errors.NotFound) {
+		return errors.Internal.Wrap(err, "failed to check existence of upstream interface")
+	}
+	if err != nil || link == nil {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return errors.AddErrorContext(err, "UpstreamInterface", upstreamNotFound)
+	}
 	return nil
 }
 
+func (h *HostNetworkService) syncBridgeNat(bridgeName string, baseDto dtos.HostNetworkBridgeBaseDto) error {
+	if baseDto.Nat {
+		return h.manager.SetBridgeNat(bridgeName, baseDto.UpstreamInterface)
+	}
+	return h.manager.UnsetBridgeNat(bridgeName)
+}
+
 //CreateBridge new bridge on host
 //
 //Params:
@@ -104,15 +111,13 @@ func (h *HostNetworkService) CreateBridge(createDto dtos.HostNetworkBridgeCreate
 	if err != nil {
 		return dto, err
 	}
-	for _, slave := range createDto.Slaves {
-		_, err = h.manager.GetByName(slave)
-		if err != nil {
-			if !errors.As(err, errors.NotFound) {
-				return dto, errors.Internal.Wrap(err, "failed to check existence of slave interface")
-			}
-			err1 := errors.Validation.New(errors.ValidationErrorMessage)
-			return dto, errors.AddErrorContext(err1, "Slaves", slaveNotFound)
-		}
+	err = h.checkSlavesExistence(createDto.Slaves)
+	if err != nil {
+		return dto, err
+	}
+	err = h.checkBridgeUpstreamInterface(createDto.HostNetworkBridgeBaseDto)
+	if err != nil {
+		return dto, err
 	}
 	bridgeName, err := h.manager.CreateBridge(createDto.Name)
 	if err != nil {
@@ -142,6 +147,15 @@ func (h *HostNetworkService) CreateBridge(createDto dtos.HostNetworkBridgeCreate
 		}
 		return dto, err
 	}
+	//NAT is set after addresses, because masquerade rules follow the bridge networks
+	err = h.syncBridgeNat(bridgeName, createDto.HostNetworkBridgeBaseDto)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup NAT")
+		}
+		return dto, errors.Internal.Wrap(err, "failed to setup bridge NAT")
+	}
 	//Get updated bridge from manager
 	bridge, err = h.manager.GetByName(bridgeName)
 	if err != nil {
@@ -168,15 +182,13 @@ func (h *HostNetworkService) UpdateBridge(bridgeName string, updateDto dtos.Host
 	if err != nil {
 		return dto, err
 	}
-	for _, slave := range updateDto.Slaves {
-		_, err = h.manager.GetByName(slave)
-		if err != nil {
-			if !errors.As(err, errors.NotFound) {
-				return dto, errors.Internal.Wrap(err, "failed to check existence of slave interface")
-			}
-			err1 := errors.Validation.New(errors.ValidationErrorMessage)
-			return dto, errors.AddErrorContext(err1, "Slaves", slaveNotFound)
-		}
+	err = h.checkSlavesExistence(updateDto.Slaves)
+	if err != nil {
+		return dto, err
+	}
+	err = h.checkBridgeUpstreamInterface(updateDto.HostNetworkBridgeBaseDto)
+	if err != nil {
+		return dto, err
 	}
 	bridge, err := h.manager.GetByName(bridgeName)
 	if err != nil {
@@ -201,6 +213,14 @@ func (h *HostNetworkService) UpdateBridge(bridgeName string, updateDto dtos.Host
 		}
 		return dto, err
 	}
+	err = h.syncBridgeNat(bridgeName, updateDto.HostNetworkBridgeBaseDto)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup NAT")
+		}
+		return dto, errors.Internal.Wrap(err, "failed to setup bridge NAT")
+	}
 	//Update link from manager
 	bridge, err = h.manager.GetByName(bridgeName)
 	if err != nil {
@@ -233,6 +253,10 @@ func (h *HostNetworkService) DeleteBridge(bridgeName string) error {
 	if link == nil || link.GetType() != "bridge" {
 		return errors.NotFound.New(bridgeNotFound)
 	}
+	err = h.manager.UnsetBridgeNat(bridgeName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to remove bridge NAT")
+	}
 	err = h.manager.DeleteLinkByName(bridgeName)
 	if err != nil {
 		return errors.Internal.Wrap(err, "delete bridge failed")
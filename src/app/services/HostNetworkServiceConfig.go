@@ -0,0 +1,260 @@
+package services
+
+import (
+	"fmt"
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+	"strings"
+)
+
+const linkNotFound = "interface is not exist on the host and in the configuration"
+const linkInNamespace = "interface is moved into the network namespace"
+
+//GetConfiguration gets current RoL host network configuration, it can be applied on the other host
+//
+//Return:
+//	dtos.HostNetworkConfigDto - current host network configuration
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetConfiguration() (dtos.HostNetworkConfigDto, error) {
+	out := dtos.HostNetworkConfigDto{}
+	config, err := h.manager.GetConfiguration()
+	if err != nil {
+		return out, errors.Internal.Wrap(err, "host network manager failed to get configuration")
+	}
+	mappers.MapHostNetworkConfigToDto(h.rolConfig(config), &out)
+	return out, nil
+}
+
+//rolConfig gets the configuration without devices and links of the other tools, they are not managed by RoL
+func (h *HostNetworkService) rolConfig(config domain.HostNetworkConfig) domain.HostNetworkConfig {
+	rolConfig := domain.HostNetworkConfig{Routes: config.Routes, TrafficRules: config.TrafficRules}
+	for _, vlan := range config.Vlans {
+		if strings.Contains(vlan.Name, "rol.") {
+			rolConfig.Vlans = append(rolConfig.Vlans, vlan)
+		}
+	}
+	for _, bridge := range config.Bridges {
+		if strings.Contains(bridge.Name, "rol.br.") {
+			rolConfig.Bridges = append(rolConfig.Bridges, bridge)
+		}
+	}
+	for _, bond := range config.Bonds {
+		if strings.Contains(bond.Name, "rol.bond.") {
+			rolConfig.Bonds = append(rolConfig.Bonds, bond)
+		}
+	}
+	for _, veth := range config.Veths {
+		if strings.HasPrefix(veth.Name, "rol.veth.") || strings.HasPrefix(veth.Name, "rol.peer.") {
+			rolConfig.Veths = append(rolConfig.Veths, veth)
+		}
+	}
+	for _, namespace := range config.Namespaces {
+		if strings.HasPrefix(namespace.Name, "rol.ns.") {
+			rolConfig.Namespaces = append(rolConfig.Namespaces, namespace)
+		}
+	}
+	return rolConfig
+}
+
+//vethPairNames gets names of the both ends of the RoL veth pair
+func vethPairNames(name string) []string {
+	pairName := strings.TrimPrefix(strings.TrimPrefix(name, "rol.veth."), "rol.peer.")
+	return []string{"rol.veth." + pairName, "rol.peer." + pairName}
+}
+
+//checkConfigLinks checks that the configuration links are unique and the links they refer to exist on the host
+//or in the configuration, so the whole configuration can be applied
+func (h *HostNetworkService) checkConfigLinks(configDto dtos.HostNetworkConfigDto) error {
+	hostLinks, err := h.manager.GetList()
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to get list of host network interfaces")
+	}
+	hostNamespaces, err := h.manager.GetNamespaces()
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to get list of host network namespaces")
+	}
+	configNames := map[string]bool{}
+	//both ends of the veth pair exist if any end is in the configuration
+	vethNames := map[string]bool{}
+	namespaceLinks := map[string]string{}
+	var validationErr error
+	addError := func(field, message string) {
+		if validationErr == nil {
+			validationErr = errors.Validation.New(errors.ValidationErrorMessage)
+		}
+		validationErr = errors.AddErrorContext(validationErr, field, message)
+	}
+	addName := func(field, name string) {
+		if configNames[name] {
+			addError(field, "interface is already described in the configuration")
+		}
+		configNames[name] = true
+	}
+	for i, vlan := range configDto.Vlans {
+		addName(fmt.Sprintf("Vlans[%d].Name", i), vlan.Name)
+	}
+	for i, bridge := range configDto.Bridges {
+		addName(fmt.Sprintf("Bridges[%d].Name", i), bridge.Name)
+	}
+	for i, bond := range configDto.Bonds {
+		addName(fmt.Sprintf("Bonds[%d].Name", i), bond.Name)
+	}
+	for i, veth := range configDto.Veths {
+		addName(fmt.Sprintf("Veths[%d].Name", i), veth.Name)
+		for _, name := range vethPairNames(veth.Name) {
+			vethNames[name] = true
+		}
+	}
+	namespaceNames := map[string]bool{}
+	for i, namespace := range configDto.Namespaces {
+		if namespaceNames[namespace.Name] {
+			addError(fmt.Sprintf("Namespaces[%d].Name", i), "network namespace is already described in the configuration")
+		}
+		namespaceNames[namespace.Name] = true
+		for j, link := range namespace.Links {
+			addName(fmt.Sprintf("Namespaces[%d].Links[%d].Name", i, j), link.Name)
+			namespaceLinks[link.Name] = namespace.Name
+			if strings.HasPrefix(link.Name, "rol.veth.") || strings.HasPrefix(link.Name, "rol.peer.") {
+				for _, name := range vethPairNames(link.Name) {
+					vethNames[name] = true
+				}
+			}
+		}
+	}
+	hostLinkExist := func(name string) bool {
+		for _, link := range hostLinks {
+			//RoL links that are not in the configuration are deleted
+			if link.GetName() == name && !strings.HasPrefix(name, "rol.") {
+				return true
+			}
+		}
+		return false
+	}
+	//hostLinkError gets the error of the link that is referred on the host, empty string if the link exists there
+	hostLinkError := func(name string) string {
+		if namespaceLinks[name] != "" {
+			return linkInNamespace
+		}
+		if configNames[name] || vethNames[name] || hostLinkExist(name) {
+			return ""
+		}
+		return linkNotFound
+	}
+	for i, vlan := range configDto.Vlans {
+		if linkErr := hostLinkError(vlan.Parent); linkErr != "" {
+			addError(fmt.Sprintf("Vlans[%d].Parent", i), linkErr)
+		}
+	}
+	for i, namespace := range configDto.Namespaces {
+		for j, link := range namespace.Links {
+			//only RoL veths of the RoL links can be moved into the namespaces
+			linkExist := vethNames[link.Name] || hostLinkExist(link.Name)
+			//links of the other tools that are already moved into the RoL namespaces
+			for _, hostNamespace := range hostNamespaces {
+				for _, nsLink := range hostNamespace.Links {
+					linkExist = linkExist || nsLink.Name == link.Name
+				}
+			}
+			if !linkExist {
+				addError(fmt.Sprintf("Namespaces[%d].Links[%d].Name", i, j), linkNotFound)
+			}
+		}
+	}
+	slaveMasters := map[string]string{}
+	checkSlaves := func(field, master string, slaves []string) {
+		for _, slave := range slaves {
+			if linkErr := hostLinkError(slave); linkErr != "" {
+				addError(field, fmt.Sprintf("%s %s", slave, linkErr))
+			}
+			if slaveMasters[slave] != "" {
+				addError(field, fmt.Sprintf("%s is already slave of %s", slave, slaveMasters[slave]))
+			}
+			slaveMasters[slave] = master
+		}
+	}
+	for i, bridge := range configDto.Bridges {
+		checkSlaves(fmt.Sprintf("Bridges[%d].Slaves", i), bridge.Name, bridge.Slaves)
+		if bridge.Nat {
+			if linkErr := hostLinkError(bridge.UpstreamInterface); linkErr != "" {
+				addError(fmt.Sprintf("Bridges[%d].UpstreamInterface", i), linkErr)
+			}
+		}
+	}
+	for i, bond := range configDto.Bonds {
+		checkSlaves(fmt.Sprintf("Bonds[%d].Slaves", i), bond.Name, bond.Slaves)
+	}
+	for i, route := range configDto.Routes {
+		if route.Interface == "" {
+			continue
+		}
+		if linkErr := hostLinkError(route.Interface); linkErr != "" {
+			addError(fmt.Sprintf("Routes[%d].Interface", i), linkErr)
+		}
+	}
+	return validationErr
+}
+
+func (h *HostNetworkService) configDtoToEntity(configDto dtos.HostNetworkConfigDto) (domain.HostNetworkConfig, error) {
+	config := domain.HostNetworkConfig{}
+	err := validators.ValidateHostNetworkConfigDto(configDto)
+	if err != nil {
+		return config, err
+	}
+	err = h.checkConfigLinks(configDto)
+	if err != nil {
+		return config, err
+	}
+	mappers.MapHostNetworkConfigDtoToEntity(configDto, &config)
+	return config, nil
+}
+
+//PlanConfiguration compares the desired host network configuration with the host network without changing it
+//
+//Params:
+//	configDto - desired host network configuration
+//Return:
+//	dtos.HostNetworkPlanDto - changes that converge the host network to the configuration
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) PlanConfiguration(configDto dtos.HostNetworkConfigDto) (dtos.HostNetworkPlanDto, error) {
+	out := dtos.HostNetworkPlanDto{}
+	config, err := h.configDtoToEntity(configDto)
+	if err != nil {
+		return out, err
+	}
+	plan, err := h.manager.PlanConfiguration(config)
+	if err != nil {
+		return out, errors.Internal.Wrap(err, "host network manager failed to plan configuration")
+	}
+	mappers.MapHostNetworkPlanToDto(plan, &out)
+	return out, nil
+}
+
+//ApplyConfiguration converges the host network to the desired configuration with the changes that
+//PlanConfiguration returns, applying of the same configuration again changes nothing.
+//Applied changes are reset if they are not confirmed before the confirm timeout
+//
+//Params:
+//	configDto - desired host network configuration
+//Return:
+//	dtos.HostNetworkPlanDto - applied changes
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) ApplyConfiguration(configDto dtos.HostNetworkConfigDto) (dtos.HostNetworkPlanDto, error) {
+	out := dtos.HostNetworkPlanDto{}
+	config, err := h.configDtoToEntity(configDto)
+	if err != nil {
+		return out, err
+	}
+	plan, err := h.manager.ApplyConfiguration(config)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return out, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with apply configuration")
+		}
+		return out, errors.Internal.Wrap(err, "host network manager failed to apply configuration")
+	}
+	mappers.MapHostNetworkPlanToDto(plan, &out)
+	return out, nil
+}
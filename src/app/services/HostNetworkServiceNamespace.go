@@ -0,0 +1,267 @@
+package services
+
+import (
+	"net"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/mappers"
+	"rol/app/utils"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+)
+
+const namespaceNotFound = "network namespace is not exist on the host"
+const namespaceLinkNotFound = "link is not exist on the host"
+
+//GetNamespaceList gets list of host RoL network namespaces
+//
+//Return:
+//	[]dtos.HostNetworkNamespaceDto - slice of namespace dtos
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetNamespaceList() ([]dtos.HostNetworkNamespaceDto, error) {
+	out := []dtos.HostNetworkNamespaceDto{}
+	namespaces, err := h.manager.GetNamespaces()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "error getting network namespace list")
+	}
+	for _, namespace := range namespaces {
+		var dto dtos.HostNetworkNamespaceDto
+		err = mappers.MapEntityToDto(namespace, &dto)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "error mapping network namespace")
+		}
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+//getNamespace gets RoL network namespace by name
+func (h *HostNetworkService) getNamespace(name string) (domain.HostNetworkNamespace, error) {
+	namespaces, err := h.manager.GetNamespaces()
+	if err != nil {
+		return domain.HostNetworkNamespace{}, errors.Internal.Wrap(err, "error getting network namespace list")
+	}
+	for _, namespace := range namespaces {
+		if namespace.Name == name {
+			return namespace, nil
+		}
+	}
+	return domain.HostNetworkNamespace{}, errors.NotFound.New(namespaceNotFound)
+}
+
+//checkServerNamespaceExistence checks that the RoL network namespace of the server exists on the host,
+//empty namespace is the host network namespace
+func checkServerNamespaceExistence(manager interfaces.IHostNetworkManager, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	namespaces, err := manager.GetNamespaces()
+	if err != nil {
+		return errors.Internal.Wrap(err, "error getting network namespace list")
+	}
+	for _, hostNamespace := range namespaces {
+		if hostNamespace.Name == namespace {
+			return nil
+		}
+	}
+	err = errors.Validation.New(errors.ValidationErrorMessage)
+	return errors.AddErrorContext(err, "Namespace", namespaceNotFound)
+}
+
+//GetNamespaceByName gets network namespace by name
+//
+//Params:
+//	name - full name of the namespace
+//Return:
+//	dtos.HostNetworkNamespaceDto - namespace dto
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetNamespaceByName(name string) (dtos.HostNetworkNamespaceDto, error) {
+	out := dtos.HostNetworkNamespaceDto{}
+	namespace, err := h.getNamespace(name)
+	if err != nil {
+		return out, err
+	}
+	err = mappers.MapEntityToDto(namespace, &out)
+	if err != nil {
+		return out, errors.Internal.Wrap(err, "error mapping network namespace")
+	}
+	return out, nil
+}
+
+//checkNamespaceLinksExistence checks that the links that are not in the namespace yet exist on the host
+func (h *HostNetworkService) checkNamespaceLinksExistence(namespace domain.HostNetworkNamespace, links []dtos.HostNetworkNamespaceLinkDto) error {
+	for _, link := range links {
+		inNamespace := false
+		for _, nsLink := range namespace.Links {
+			if nsLink.Name == link.Name {
+				inNamespace = true
+			}
+		}
+		if inNamespace {
+			continue
+		}
+		exist, err := h.linkIsExist(link.Name)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to check existence of namespace link")
+		}
+		if !exist {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+			return errors.AddErrorContext(err, "Links", namespaceLinkNotFound)
+		}
+	}
+	return nil
+}
+
+//syncNamespaceLinks moves links into the namespace or back to the host and syncs their addresses in the namespace
+func (h *HostNetworkService) syncNamespaceLinks(namespace domain.HostNetworkNamespace, links []dtos.HostNetworkNamespaceLinkDto) error {
+	for _, nsLink := range namespace.Links {
+		inDto := false
+		for _, link := range links {
+			if link.Name == nsLink.Name {
+				inDto = true
+			}
+		}
+		if inDto {
+			continue
+		}
+		err := h.manager.UnsetLinkNamespace(nsLink.Name, namespace.Name)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to move link back to the host")
+		}
+	}
+	for _, link := range links {
+		currAddresses := []string{}
+		inNamespace := false
+		for _, nsLink := range namespace.Links {
+			if nsLink.Name != link.Name {
+				continue
+			}
+			inNamespace = true
+			for _, address := range nsLink.Addresses {
+				currAddresses = append(currAddresses, address.String())
+			}
+		}
+		if !inNamespace {
+			err := h.manager.SetLinkNamespace(link.Name, namespace.Name)
+			if err != nil {
+				return errors.Internal.Wrap(err, "failed to move link into network namespace")
+			}
+		}
+		deletedCidrSlice, addedCidrSlice := utils.SliceDiffElements(currAddresses, link.Addresses)
+		for _, deletedCidr := range deletedCidrSlice {
+			ip, address, err := net.ParseCIDR(deletedCidr)
+			if err != nil {
+				return errors.Internal.New("failed to parse CIDR")
+			}
+			address.IP = ip
+			err = h.manager.NamespaceAddrDelete(namespace.Name, link.Name, *address)
+			if err != nil {
+				return errors.Internal.Wrap(err, setAddressesFailed)
+			}
+		}
+		for _, addedCidr := range addedCidrSlice {
+			ip, address, err := net.ParseCIDR(addedCidr)
+			if err != nil {
+				return errors.Internal.New("failed to parse CIDR")
+			}
+			address.IP = ip
+			err = h.manager.NamespaceAddrAdd(namespace.Name, link.Name, *address)
+			if err != nil {
+				return errors.Internal.Wrap(err, setAddressesFailed)
+			}
+		}
+	}
+	return nil
+}
+
+//CreateNamespace new network namespace on host, links are moved into it
+//
+//Params:
+//	createDto - namespace create dto
+//Return:
+//	dtos.HostNetworkNamespaceDto - created network namespace
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) CreateNamespace(createDto dtos.HostNetworkNamespaceCreateDto) (dtos.HostNetworkNamespaceDto, error) {
+	dto := dtos.HostNetworkNamespaceDto{}
+	err := validators.ValidateHostNetworkNamespaceCreateDto(createDto)
+	if err != nil {
+		return dto, err
+	}
+	_, err = h.getNamespace("rol.ns." + createDto.Name)
+	if err == nil {
+		err = errors.Validation.New(errors.ValidationErrorMessage)
+		return dto, errors.AddErrorContext(err, "Name", "network namespace with this name already exists")
+	}
+	if !errors.As(err, errors.NotFound) {
+		return dto, err
+	}
+	err = h.checkNamespaceLinksExistence(domain.HostNetworkNamespace{}, createDto.Links)
+	if err != nil {
+		return dto, err
+	}
+	namespaceName, err := h.manager.CreateNamespace(createDto.Name)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error creating network namespace")
+	}
+	err = h.syncNamespaceLinks(domain.HostNetworkNamespace{Name: namespaceName}, createDto.Links)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup namespace links")
+		}
+		return dto, err
+	}
+	return h.GetNamespaceByName(namespaceName)
+}
+
+//UpdateNamespace update network namespace links on host
+//
+//Params:
+//	name - full name of the namespace
+//	updateDto - namespace update dto
+//Return:
+//	dtos.HostNetworkNamespaceDto - updated network namespace
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) UpdateNamespace(name string, updateDto dtos.HostNetworkNamespaceUpdateDto) (dtos.HostNetworkNamespaceDto, error) {
+	dto := dtos.HostNetworkNamespaceDto{}
+	err := validators.ValidateHostNetworkNamespaceUpdateDto(updateDto)
+	if err != nil {
+		return dto, err
+	}
+	namespace, err := h.getNamespace(name)
+	if err != nil {
+		return dto, err
+	}
+	err = h.checkNamespaceLinksExistence(namespace, updateDto.Links)
+	if err != nil {
+		return dto, err
+	}
+	err = h.syncNamespaceLinks(namespace, updateDto.Links)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup namespace links")
+		}
+		return dto, err
+	}
+	return h.GetNamespaceByName(name)
+}
+
+//DeleteNamespace deletes network namespace on host by its name, its links are moved back to the host
+//
+//Params:
+//	name - full name of the namespace
+//Return
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) DeleteNamespace(name string) error {
+	_, err := h.getNamespace(name)
+	if err != nil {
+		return err
+	}
+	err = h.manager.DeleteNamespace(name)
+	if err != nil {
+		return errors.Internal.Wrap(err, "delete network namespace failed")
+	}
+	return nil
+}
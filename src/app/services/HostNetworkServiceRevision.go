@@ -0,0 +1,140 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/domain"
+	"rol/dtos"
+)
+
+func (h *HostNetworkService) getConfigRevision(id uuid.UUID) (domain.HostNetworkConfigRevision, error) {
+	revision, err := h.configStorage.GetRevision(id)
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return revision, err
+		}
+		return revision, errors.Internal.Wrap(err, "failed to get host network config revision")
+	}
+	return revision, nil
+}
+
+//configRevisionYaml gets the revision configuration in the format of the apply request as yaml
+func (h *HostNetworkService) configRevisionYaml(revision domain.HostNetworkConfigRevision) (string, error) {
+	configDto := dtos.HostNetworkConfigDto{}
+	mappers.MapHostNetworkConfigToDto(h.rolConfig(revision.Config), &configDto)
+	out, err := yaml.Marshal(configDto)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "failed to marshal host network config revision")
+	}
+	return string(out), nil
+}
+
+//GetConfigRevisions gets saved host network configuration revisions from the latest to the oldest,
+//the latest revision is the current saved configuration
+//
+//Return:
+//	[]dtos.HostNetworkConfigRevisionDto - configuration revisions
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetConfigRevisions() ([]dtos.HostNetworkConfigRevisionDto, error) {
+	out := []dtos.HostNetworkConfigRevisionDto{}
+	revisions, err := h.configStorage.GetRevisions()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get host network config revisions")
+	}
+	for _, revision := range revisions {
+		dto := dtos.HostNetworkConfigRevisionDto{}
+		mappers.MapHostNetworkConfigRevisionToDto(revision, &dto)
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+//GetConfigRevisionByID gets saved host network configuration revision with the configuration
+//
+//Params:
+//	id - revision ID
+//Return:
+//	dtos.HostNetworkConfigRevisionContentDto - configuration revision
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetConfigRevisionByID(id uuid.UUID) (dtos.HostNetworkConfigRevisionContentDto, error) {
+	out := dtos.HostNetworkConfigRevisionContentDto{}
+	revision, err := h.getConfigRevision(id)
+	if err != nil {
+		return out, err
+	}
+	mappers.MapHostNetworkConfigRevisionToDto(revision, &out.HostNetworkConfigRevisionDto)
+	mappers.MapHostNetworkConfigToDto(h.rolConfig(revision.Config), &out.Config)
+	return out, nil
+}
+
+//DiffConfigRevisions compares two saved host network configuration revisions
+//
+//Params:
+//	fromID - original revision ID
+//	toID - changed revision ID
+//Return:
+//	dtos.HostNetworkConfigRevisionDiffDto - unified diff of the configurations
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) DiffConfigRevisions(fromID, toID uuid.UUID) (dtos.HostNetworkConfigRevisionDiffDto, error) {
+	dto := dtos.HostNetworkConfigRevisionDiffDto{FromID: fromID, ToID: toID}
+	from, err := h.getConfigRevision(fromID)
+	if err != nil {
+		return dto, err
+	}
+	to, err := h.getConfigRevision(toID)
+	if err != nil {
+		return dto, err
+	}
+	fromYaml, err := h.configRevisionYaml(from)
+	if err != nil {
+		return dto, err
+	}
+	toYaml, err := h.configRevisionYaml(to)
+	if err != nil {
+		return dto, err
+	}
+	dto.Equal = fromYaml == toYaml
+	if dto.Equal {
+		return dto, nil
+	}
+	dto.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromYaml),
+		B:        difflib.SplitLines(toYaml),
+		FromFile: from.CreatedAt.Format("2006-01-02 15:04:05") + " " + from.Author,
+		ToFile:   to.CreatedAt.Format("2006-01-02 15:04:05") + " " + to.Author,
+		Context:  3,
+	})
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "failed to compare host network configurations")
+	}
+	return dto, nil
+}
+
+//RestoreConfigRevision applies saved host network configuration revision like ApplyConfiguration,
+//restored configuration is saved as a new revision when the changes are confirmed
+//
+//Params:
+//	id - revision ID
+//Return:
+//	dtos.HostNetworkPlanDto - applied changes
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) RestoreConfigRevision(id uuid.UUID) (dtos.HostNetworkPlanDto, error) {
+	out := dtos.HostNetworkPlanDto{}
+	revision, err := h.getConfigRevision(id)
+	if err != nil {
+		return out, err
+	}
+	plan, err := h.manager.ApplyConfiguration(revision.Config)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return out, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with restore configuration")
+		}
+		return out, errors.Internal.Wrap(err, "host network manager failed to restore configuration")
+	}
+	mappers.MapHostNetworkPlanToDto(plan, &out)
+	return out, nil
+}
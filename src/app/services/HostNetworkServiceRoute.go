@@ -0,0 +1,87 @@
+package services
+
+import (
+	"rol/app/errors"
+	"rol/app/mappers"
+	"rol/app/validators"
+	"rol/domain"
+	"rol/dtos"
+)
+
+const routeInterfaceNotFound = "route interface is not exist on the host"
+const routeNotFound = "route is not exist on the host"
+
+//GetRouteList gets list of host static routes that are managed by RoL
+//
+//Return:
+//	[]dtos.HostNetworkRouteDto - slice of route dtos
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetRouteList() ([]dtos.HostNetworkRouteDto, error) {
+	out := []dtos.HostNetworkRouteDto{}
+	routes, err := h.manager.GetRoutes()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "host network manager failed to get routes")
+	}
+	for _, route := range routes {
+		var dto dtos.HostNetworkRouteDto
+		mappers.MapHostNetworkRouteEntityToDto(route, &dto)
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+//CreateRoute creates new static IPv4 or IPv6 route on host
+//
+//Params:
+//	routeDto - route create dto
+//Return:
+//	dtos.HostNetworkRouteDto - created route
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) CreateRoute(routeDto dtos.HostNetworkRouteCreateDto) (dtos.HostNetworkRouteDto, error) {
+	var route domain.HostNetworkRoute
+	var out dtos.HostNetworkRouteDto
+	err := validators.ValidateHostNetworkRouteCreateDto(routeDto)
+	if err != nil {
+		return out, err
+	}
+	if routeDto.Interface != "" {
+		exist, err := h.linkIsExist(routeDto.Interface)
+		if err != nil {
+			return out, errors.Internal.Wrap(err, "failed to check existence of route interface")
+		}
+		if !exist {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+			return out, errors.AddErrorContext(err, "Interface", routeInterfaceNotFound)
+		}
+	}
+	mappers.MapHostNetworkRouteCreateDtoToEntity(routeDto, &route)
+	err = h.manager.CreateRoute(route)
+	if err != nil {
+		return out, errors.Internal.Wrap(err, "host network manager failed to create route")
+	}
+	mappers.MapHostNetworkRouteEntityToDto(route, &out)
+	return out, nil
+}
+
+//DeleteRoute deletes static route on host
+//
+//Params:
+//	routeDto - route delete dto
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) DeleteRoute(routeDto dtos.HostNetworkRouteDeleteDto) error {
+	var route domain.HostNetworkRoute
+	err := validators.ValidateHostNetworkRouteDeleteDto(routeDto)
+	if err != nil {
+		return err
+	}
+	mappers.MapHostNetworkRouteDeleteDtoToEntity(routeDto, &route)
+	err = h.manager.DeleteRoute(route)
+	if errors.As(err, errors.NotFound) {
+		return errors.NotFound.New(routeNotFound)
+	}
+	if err != nil {
+		return errors.Internal.Wrap(err, "host network manager failed to delete route")
+	}
+	return nil
+}
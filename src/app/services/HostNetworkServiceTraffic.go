@@ -4,6 +4,7 @@ package services
 import (
 	"rol/app/errors"
 	"rol/app/mappers"
+	"rol/app/validators"
 	"rol/domain"
 	"rol/dtos"
 )
@@ -19,6 +20,10 @@ import (
 func (h *HostNetworkService) CreateTrafficRule(table string, ruleDto dtos.HostNetworkTrafficRuleCreateDto) (dtos.HostNetworkTrafficRuleDto, error) {
 	var rule domain.HostNetworkTrafficRule
 	var out dtos.HostNetworkTrafficRuleDto
+	err := validators.ValidateHostNetworkTrafficRuleCreateDto(ruleDto)
+	if err != nil {
+		return out, err
+	}
 	mappers.MapHostNetworkTrafficRuleCreateDtoToEntity(ruleDto, &rule)
 	newRule, err := h.manager.CreateTrafficRule(table, rule)
 	if err != nil {
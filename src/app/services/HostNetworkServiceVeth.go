@@ -0,0 +1,177 @@
+package services
+
+import (
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/mappers"
+	"rol/app/validators"
+	"rol/dtos"
+	"strings"
+)
+
+const vethNotFound = "veth is not exist on the host"
+
+func isRolVeth(link interfaces.IHostNetworkLink) bool {
+	return link.GetType() == "veth" &&
+		(strings.HasPrefix(link.GetName(), "rol.veth.") || strings.HasPrefix(link.GetName(), "rol.peer."))
+}
+
+//GetVethList gets list of host veth pairs ends, ends that are moved into network namespaces are not listed
+//
+//Return:
+//	[]dtos.HostNetworkVethDto - slice of veth dtos
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetVethList() ([]dtos.HostNetworkVethDto, error) {
+	out := []dtos.HostNetworkVethDto{}
+	links, err := h.manager.GetList()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "error getting link list")
+	}
+	for _, link := range links {
+		if isRolVeth(link) {
+			var dto dtos.HostNetworkVethDto
+			err = mappers.MapEntityToDto(link, &dto)
+			if err != nil {
+				return nil, errors.Internal.Wrap(err, "error mapping veth")
+			}
+			out = append(out, dto)
+		}
+	}
+	return out, nil
+}
+
+//getVeth gets RoL veth pair end from the host by name
+func (h *HostNetworkService) getVeth(name string) (interfaces.IHostNetworkLink, error) {
+	link, err := h.manager.GetByName(name)
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return nil, errors.NotFound.New(vethNotFound)
+		}
+		return nil, errors.Internal.Wrap(err, "error getting veth by name")
+	}
+	if link == nil || !isRolVeth(link) {
+		return nil, errors.NotFound.New(vethNotFound)
+	}
+	return link, nil
+}
+
+//GetVethByName gets veth pair end by name
+//
+//Params:
+//	name - name of the veth pair end
+//Return:
+//	dtos.HostNetworkVethDto - veth dto
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) GetVethByName(name string) (dtos.HostNetworkVethDto, error) {
+	out := dtos.HostNetworkVethDto{}
+	link, err := h.getVeth(name)
+	if err != nil {
+		return out, err
+	}
+	err = mappers.MapEntityToDto(link, &out)
+	if err != nil {
+		return out, errors.Internal.Wrap(err, "error mapping veth")
+	}
+	return out, nil
+}
+
+//CreateVeth new veth pair on host, addresses are set to the rol.veth.{name} end
+//
+//Params:
+//	createDto - veth create dto
+//Return:
+//	dtos.HostNetworkVethDto - created veth pair end
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) CreateVeth(createDto dtos.HostNetworkVethCreateDto) (dtos.HostNetworkVethDto, error) {
+	dto := dtos.HostNetworkVethDto{}
+	err := validators.ValidateHostNetworkVethCreateDto(createDto)
+	if err != nil {
+		return dto, err
+	}
+	vethName, err := h.manager.CreateVeth(createDto.Name)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error creating veth")
+	}
+	err = h.manager.SetLinkUp("rol.peer." + createDto.Name)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "set veth peer up failed")
+	}
+	err = h.manager.SetLinkUp(vethName)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "set veth up failed")
+	}
+	link, err := h.manager.GetByName(vethName)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error getting veth by name")
+	}
+	err = h.syncAddresses(link, createDto.Addresses)
+	if err != nil {
+		resetErr := h.manager.ResetChanges()
+		if resetErr != nil {
+			return dto, errors.Internal.Wrap(resetErr, "fatal: failed to reset changes after fail with setup address")
+		}
+		return dto, err
+	}
+	//Update link from manager
+	link, err = h.manager.GetByName(vethName)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error getting veth by name")
+	}
+	err = mappers.MapEntityToDto(link, &dto)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error mapping veth")
+	}
+	return dto, nil
+}
+
+//UpdateVeth update veth pair end on host
+//
+//Params:
+//	name - veth pair end name
+//	updateDto - veth update dto
+//Return:
+//	dtos.HostNetworkVethDto - updated veth pair end
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) UpdateVeth(name string, updateDto dtos.HostNetworkVethUpdateDto) (dtos.HostNetworkVethDto, error) {
+	dto := dtos.HostNetworkVethDto{}
+	err := validators.ValidateHostNetworkVethUpdateDto(updateDto)
+	if err != nil {
+		return dto, err
+	}
+	link, err := h.getVeth(name)
+	if err != nil {
+		return dto, err
+	}
+	err = h.syncAddresses(link, updateDto.Addresses)
+	if err != nil {
+		return dto, err
+	}
+	//Update link from manager
+	link, err = h.manager.GetByName(name)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error getting veth by name")
+	}
+	err = mappers.MapEntityToDto(link, &dto)
+	if err != nil {
+		return dto, errors.Internal.Wrap(err, "error mapping veth")
+	}
+	return dto, nil
+}
+
+//DeleteVeth deletes veth pair on host by the name of its end, the other end is deleted too
+//
+//Params:
+//	name - veth pair end name
+//Return
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkService) DeleteVeth(name string) error {
+	_, err := h.getVeth(name)
+	if err != nil {
+		return err
+	}
+	err = h.manager.DeleteLinkByName(name)
+	if err != nil {
+		return errors.Internal.Wrap(err, "delete veth failed")
+	}
+	return nil
+}
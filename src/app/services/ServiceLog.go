@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+//logWithAction writes the message to the logger with the request action ID and the source name
+//
+//Params:
+//	ctx - context, the message is not written if it is nil
+//	logger - logger
+//	source - logger recording source
+//	level - message level: err, error, info, warn, warning or debug
+//	message - message
+func logWithAction(ctx context.Context, logger *logrus.Logger, source, level, message string) {
+	if ctx != nil {
+		actionID := uuid.UUID{}
+		if ctx.Value("requestID") != nil {
+			actionID = ctx.Value("requestID").(uuid.UUID)
+		}
+
+		entry := logger.WithFields(logrus.Fields{
+			"actionID": actionID,
+			"source":   source,
+		})
+		switch level {
+		case "err", "error":
+			entry.Error(message)
+		case "info":
+			entry.Info(message)
+		case "warn", "warning":
+			entry.Warn(message)
+		case "debug":
+			entry.Debug(message)
+		}
+	}
+}
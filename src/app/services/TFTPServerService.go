@@ -19,6 +19,7 @@ type TFTPServerService struct {
 	pathsRepo   interfaces.IGenericRepository[uuid.UUID, domain.TFTPPathRatio]
 	factory     interfaces.ITFTPServerFactory
 	servers     map[uuid.UUID]interfaces.ITFTPServer
+	hostManager interfaces.IHostNetworkManager
 	logger      *logrus.Logger
 	//logSourceName - logger recording source
 	logSourceName string
@@ -30,16 +31,18 @@ type TFTPServerService struct {
 //	configsRepo - generic repository with domain.TFTPConfig entity
 //	pathsRepo - generic repository with domain.TFTPPathRatio entity
 //	factory - tftp server factory
+//	hostManager - host network manager, network namespaces of the servers are restored by it before the servers start
 //	logger - logrus logger
 //Return
 //	New TFTP server service
 func NewTFTPServerService(configsRepo interfaces.IGenericRepository[uuid.UUID, domain.TFTPConfig],
 	pathsRepo interfaces.IGenericRepository[uuid.UUID, domain.TFTPPathRatio],
-	factory interfaces.ITFTPServerFactory, logger *logrus.Logger) *TFTPServerService {
+	factory interfaces.ITFTPServerFactory, hostManager interfaces.IHostNetworkManager, logger *logrus.Logger) *TFTPServerService {
 	return &TFTPServerService{
 		configsRepo:   configsRepo,
 		pathsRepo:     pathsRepo,
 		factory:       factory,
+		hostManager:   hostManager,
 		logger:        logger,
 		logSourceName: reflect.TypeOf(TFTPServerService{}).Name(),
 		servers:       map[uuid.UUID]interfaces.ITFTPServer{},
@@ -47,27 +50,7 @@ func NewTFTPServerService(configsRepo interfaces.IGenericRepository[uuid.UUID, d
 }
 
 func (s *TFTPServerService) log(ctx context.Context, level, message string) {
-	if ctx != nil {
-		actionID := uuid.UUID{}
-		if ctx.Value("requestID") != nil {
-			actionID = ctx.Value("requestID").(uuid.UUID)
-		}
-
-		entry := s.logger.WithFields(logrus.Fields{
-			"actionID": actionID,
-			"source":   s.logSourceName,
-		})
-		switch level {
-		case "err", "error":
-			entry.Error(message)
-		case "info":
-			entry.Info(message)
-		case "warn", "warning":
-			entry.Warn(message)
-		case "debug":
-			entry.Debug(message)
-		}
-	}
+	logWithAction(ctx, s.logger, s.logSourceName, level, message)
 }
 
 func (s *TFTPServerService) getServerState(configID uuid.UUID) domain.TFTPServerState {
@@ -142,7 +125,8 @@ func (s *TFTPServerService) updateRuntimeServerPaths(ctx context.Context, id uui
 	return nil
 }
 
-//TFTPServerServiceInit initialize TFTP service
+//TFTPServerServiceInit initialize TFTP service, network namespaces of the servers are already restored
+//by the host network manager of the service
 func TFTPServerServiceInit(s *TFTPServerService) error {
 	ctx := context.Background()
 	queryBuilder := s.configsRepo.NewQueryBuilder(ctx)
@@ -219,9 +203,13 @@ func (s *TFTPServerService) GetServerList(ctx context.Context, search, orderBy,
 func (s *TFTPServerService) CreateServer(ctx context.Context, createDto dtos.TFTPServerCreateDto) (dtos.TFTPServerDto, error) {
 	//prepare dto and entity
 	dto := dtos.TFTPServerDto{}
+	err := checkServerNamespaceExistence(s.hostManager, createDto.Namespace)
+	if err != nil {
+		return dto, err
+	}
 	entity := new(domain.TFTPConfig)
 	//map create config dto fields to config entity
-	err := mappers.MapDtoToEntity(createDto, entity)
+	err = mappers.MapDtoToEntity(createDto, entity)
 	if err != nil {
 		return dto, errors.Internal.Wrap(err, "error map entity to dto")
 	}
@@ -255,6 +243,10 @@ func (s *TFTPServerService) CreateServer(ctx context.Context, createDto dtos.TFT
 //	error - if an error occurs, otherwise nil
 func (s *TFTPServerService) UpdateServer(ctx context.Context, updateDto dtos.TFTPServerUpdateDto, id uuid.UUID) (dtos.TFTPServerDto, error) {
 	dto := dtos.TFTPServerDto{}
+	err := checkServerNamespaceExistence(s.hostManager, updateDto.Namespace)
+	if err != nil {
+		return dto, err
+	}
 	config, err := s.configsRepo.GetByID(ctx, id)
 	if err != nil {
 		return dto, err
@@ -16,6 +16,10 @@ func ValidateDHCP4ServerCreateDto(dto dtos.DHCP4ServerCreateDto) error {
 			validation.By(trimValidation),
 			validation.By(containsSpacesValidation),
 		}...),
+		validation.Field(&dto.Namespace, []validation.Rule{
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+		}...),
 		validation.Field(&dto.Enabled, []validation.Rule{
 			validation.Required,
 		}...),
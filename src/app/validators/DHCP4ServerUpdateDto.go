@@ -11,6 +11,10 @@ import (
 //	error - if an error occurs, otherwise nil
 func ValidateDHCP4ServerUpdateDto(dto dtos.DHCP4ServerUpdateDto) error {
 	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Namespace, []validation.Rule{
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+		}...),
 		validation.Field(&dto.Enabled, []validation.Rule{
 			validation.Required,
 		}...),
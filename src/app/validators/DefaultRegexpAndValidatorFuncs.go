@@ -71,6 +71,14 @@ func uuidSliceElemUniqueness(value interface{}) error {
 	return nil
 }
 
+func uuidNotEmptyValidation(value interface{}) error {
+	id, _ := value.(uuid.UUID)
+	if id == uuid.Nil {
+		return errors.Validation.New("cannot be blank")
+	}
+	return nil
+}
+
 func uuidsUniqueWithinSlices(fSlice []uuid.UUID, sSlice []uuid.UUID) error {
 	for _, fElem := range fSlice {
 		for _, sElem := range sSlice {
@@ -0,0 +1,27 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"regexp"
+	"rol/dtos"
+)
+
+//ValidateEthernetSwitchFirmwareUpgradeDto validates ethernet switch firmware upgrade dto
+//
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateEthernetSwitchFirmwareUpgradeDto(dto dtos.EthernetSwitchFirmwareUpgradeDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.TFTPServerID, []validation.Rule{
+			validation.By(uuidNotEmptyValidation),
+		}...),
+		validation.Field(&dto.ImagePath, []validation.Rule{
+			validation.Required,
+		}...),
+		validation.Field(&dto.TFTPAddress, []validation.Rule{
+			validation.Match(regexp.MustCompile(regexpIPv4)).
+				Error(regexpIPv4Desc),
+		}...),
+	)
+	return convertOzzoErrorToValidationError(err)
+}
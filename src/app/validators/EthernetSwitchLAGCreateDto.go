@@ -0,0 +1,27 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/dtos"
+)
+
+//ValidateEthernetSwitchLAGCreateDto validates ethernet switch LAG create dto
+//
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateEthernetSwitchLAGCreateDto(dto dtos.EthernetSwitchLAGCreateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.GroupID, []validation.Rule{
+			validation.Required,
+			validation.Min(1),
+		}...),
+		validation.Field(&dto.Mode, []validation.Rule{
+			validation.Required,
+			validation.In("active", "passive", "static"),
+		}...),
+		validation.Field(&dto.MemberPorts, []validation.Rule{
+			validation.Required,
+			validation.By(uuidSliceElemUniqueness),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
@@ -0,0 +1,23 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/dtos"
+)
+
+//ValidateEthernetSwitchLAGUpdateDto validates ethernet switch LAG update dto
+//
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateEthernetSwitchLAGUpdateDto(dto dtos.EthernetSwitchLAGUpdateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Mode, []validation.Rule{
+			validation.Required,
+			validation.In("active", "passive", "static"),
+		}...),
+		validation.Field(&dto.MemberPorts, []validation.Rule{
+			validation.Required,
+			validation.By(uuidSliceElemUniqueness),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
@@ -0,0 +1,56 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"path"
+	"rol/app/errors"
+	"rol/dtos"
+)
+
+func portNamePatternValidation(value interface{}) error {
+	pattern, _ := value.(string)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errors.Validation.New("invalid port name pattern")
+	}
+	return nil
+}
+
+//ValidateEthernetSwitchPortBulkOperationDto validates ethernet switch ports bulk operation dto
+//
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateEthernetSwitchPortBulkOperationDto(dto dtos.EthernetSwitchPortBulkOperationDto) error {
+	err := convertOzzoErrorToValidationError(validation.ValidateStruct(&dto,
+		validation.Field(&dto.Operation, []validation.Rule{
+			validation.Required,
+			validation.In("poe-on", "poe-off", "poe-cycle", "set-pvid", "add-vlan", "remove-vlan"),
+		}...),
+		validation.Field(&dto.PVID, validation.Min(0), validation.Max(4094)),
+		validation.Field(&dto.VLANID, validation.Min(0), validation.Max(4094))))
+	addError := func(field, message string) {
+		if err == nil {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+		}
+		err = errors.AddErrorContext(err, field, message)
+	}
+	selector := dto.Selector
+	if len(selector.PortIDs) == 0 && selector.NamePattern == "" && selector.MemberOfVLAN == 0 {
+		addError("Selector", "at least one of PortIDs, NamePattern or MemberOfVLAN is required")
+	}
+	if uuidErr := uuidSliceElemUniqueness(selector.PortIDs); uuidErr != nil {
+		addError("Selector.PortIDs", uuidErr.Error())
+	}
+	if patternErr := portNamePatternValidation(selector.NamePattern); patternErr != nil {
+		addError("Selector.NamePattern", patternErr.Error())
+	}
+	if selector.MemberOfVLAN < 0 || selector.MemberOfVLAN > 4094 {
+		addError("Selector.MemberOfVLAN", "must be between 1 and 4094")
+	}
+	if dto.Operation == "set-pvid" && dto.PVID == 0 {
+		addError("PVID", "cannot be blank")
+	}
+	if (dto.Operation == "add-vlan" || dto.Operation == "remove-vlan") && dto.VLANID == 0 {
+		addError("VLANID", "cannot be blank")
+	}
+	return err
+}
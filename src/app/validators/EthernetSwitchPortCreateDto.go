@@ -20,6 +20,37 @@ func validatePOEType(value interface{}) error {
 	return nil
 }
 
+func validatePortSpeed(value interface{}) error {
+	s, _ := value.(string)
+	switch s {
+	case "", "auto", "10M", "100M", "1000M":
+	default:
+		return errors.Validation.New("wrong port speed, expect auto, 10M, 100M or 1000M")
+	}
+	return nil
+}
+
+func validatePortDuplex(value interface{}) error {
+	s, _ := value.(string)
+	switch s {
+	case "", "auto", "full", "half":
+	default:
+		return errors.Validation.New("wrong port duplex, expect auto, full or half")
+	}
+	return nil
+}
+
+//portDescriptionValidation checks that description can be passed to the switch CLI in quotes
+func portDescriptionValidation(value interface{}) error {
+	s, _ := value.(string)
+	for _, char := range s {
+		if char == '"' || char < ' ' || char > '~' {
+			return errors.Validation.New("description can contain only printable ASCII characters except quotes")
+		}
+	}
+	return nil
+}
+
 //ValidateEthernetSwitchPortCreateDto validates ethernet switch port create dto
 //	Return
 //	error - if an error occurs, otherwise nil
@@ -35,7 +66,14 @@ func ValidateEthernetSwitchPortCreateDto(dto dtos.EthernetSwitchPortCreateDto) e
 			validation.By(trimValidation),
 			validation.By(containsSpacesValidation),
 			validation.By(validatePOEType),
-		}...))
+		}...),
+		validation.Field(&dto.Description, []validation.Rule{
+			validation.Length(0, 32),
+			validation.By(trimValidation),
+			validation.By(portDescriptionValidation),
+		}...),
+		validation.Field(&dto.Speed, validation.By(validatePortSpeed)),
+		validation.Field(&dto.Duplex, validation.By(validatePortDuplex)))
 
 	return convertOzzoErrorToValidationError(err)
 }
@@ -20,6 +20,13 @@ func ValidateEthernetSwitchPortUpdateDto(dto dtos.EthernetSwitchPortUpdateDto) e
 			validation.By(trimValidation),
 			validation.By(containsSpacesValidation),
 			validation.By(validatePOEType),
-		}...))
+		}...),
+		validation.Field(&dto.Description, []validation.Rule{
+			validation.Length(0, 32),
+			validation.By(trimValidation),
+			validation.By(portDescriptionValidation),
+		}...),
+		validation.Field(&dto.Speed, validation.By(validatePortSpeed)),
+		validation.Field(&dto.Duplex, validation.By(validatePortDuplex)))
 	return convertOzzoErrorToValidationError(err)
 }
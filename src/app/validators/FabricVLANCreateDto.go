@@ -0,0 +1,59 @@
+package validators
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/app/errors"
+	"rol/dtos"
+)
+
+//ValidateFabricVLANCreateDto validates fabric VLAN create dto
+//
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateFabricVLANCreateDto(dto dtos.FabricVLANCreateDto) error {
+	err := convertOzzoErrorToValidationError(validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, []validation.Rule{
+			validation.Required,
+			validation.By(trimValidation),
+		}...),
+		validation.Field(&dto.VlanID, []validation.Rule{
+			validation.Required,
+			validation.Min(1),
+			validation.Max(4094),
+		}...),
+		validation.Field(&dto.HostParent, []validation.Rule{
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+		}...),
+		validation.Field(&dto.HostAddresses, []validation.Rule{
+			validation.By(sliceOfCidrStringsValidation),
+		}...)))
+	addError := func(field, message string) {
+		if err == nil {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+		}
+		err = errors.AddErrorContext(err, field, message)
+	}
+	if len(dto.Members) == 0 && dto.HostParent == "" {
+		addError("Members", "at least one member or the host parent interface is required")
+	}
+	if dto.HostParent == "" && len(dto.HostAddresses) > 0 {
+		addError("HostAddresses", "addresses can't be set without the host parent interface")
+	}
+	members := map[string]bool{}
+	for i, member := range dto.Members {
+		if uuidErr := uuidNotEmptyValidation(member.EthernetSwitchID); uuidErr != nil {
+			addError(fmt.Sprintf("Members[%d].EthernetSwitchID", i), uuidErr.Error())
+		}
+		if uuidErr := uuidNotEmptyValidation(member.PortID); uuidErr != nil {
+			addError(fmt.Sprintf("Members[%d].PortID", i), uuidErr.Error())
+		}
+		key := member.EthernetSwitchID.String() + member.PortID.String()
+		if members[key] {
+			addError(fmt.Sprintf("Members[%d].PortID", i), "port is already a member of the VLAN")
+		}
+		members[key] = true
+	}
+	return err
+}
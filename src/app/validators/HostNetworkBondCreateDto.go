@@ -0,0 +1,36 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/dtos"
+)
+
+//ValidateHostNetworkBondCreateDto validates host network bond create dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkBondCreateDto(dto dtos.HostNetworkBondCreateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, []validation.Rule{
+			validation.Required,
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+			//linux interface name is limited by 15 characters including the rol.bond. prefix
+			validation.Length(1, 6),
+		}...),
+		validation.Field(&dto.Mode, []validation.Rule{
+			validation.Required,
+			validation.In("active-backup", "802.3ad", "balance-alb"),
+		}...),
+		validation.Field(&dto.Miimon, []validation.Rule{
+			validation.Min(0),
+			validation.Max(10000),
+		}...),
+		validation.Field(&dto.Slaves, validation.Each(
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+		)),
+		validation.Field(&dto.Addresses, []validation.Rule{
+			validation.By(sliceOfCidrStringsValidation),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
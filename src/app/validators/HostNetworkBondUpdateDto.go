@@ -0,0 +1,21 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/dtos"
+)
+
+//ValidateHostNetworkBondUpdateDto validates host network bond update dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkBondUpdateDto(dto dtos.HostNetworkBondUpdateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Slaves, validation.Each(
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+		)),
+		validation.Field(&dto.Addresses, []validation.Rule{
+			validation.By(sliceOfCidrStringsValidation),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
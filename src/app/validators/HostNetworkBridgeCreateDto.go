@@ -2,9 +2,37 @@ package validators
 
 import (
 	validation "github.com/go-ozzo/ozzo-validation"
+	"net"
+	"rol/app/errors"
 	"rol/dtos"
 )
 
+//bridgeNatAddressesValidation checks that the bridge with NAT has IPv4 network to masquerade
+func bridgeNatAddressesValidation(value interface{}, addresses []string) error {
+	nat, _ := value.(bool)
+	if !nat {
+		return nil
+	}
+	for _, address := range addresses {
+		ip, _, err := net.ParseCIDR(address)
+		if err == nil && ip.To4() != nil {
+			return nil
+		}
+	}
+	return errors.Validation.New("NAT requires at least one IPv4 address of the bridge")
+}
+
+func bridgeUpstreamInterfaceValidation(value interface{}, nat bool) error {
+	s, _ := value.(string)
+	if s != "" && !nat {
+		return errors.Validation.New("upstream interface can be set only with NAT")
+	}
+	if s == "" && nat {
+		return errors.Validation.New("upstream interface is required for NAT")
+	}
+	return nil
+}
+
 //ValidateHostNetworkBridgeCreateDto validates host network bridge create dto
 //	Return
 //	error - if an error occurs, otherwise nil
@@ -21,6 +49,17 @@ func ValidateHostNetworkBridgeCreateDto(dto dtos.HostNetworkBridgeCreateDto) err
 		)),
 		validation.Field(&dto.Addresses, []validation.Rule{
 			validation.By(sliceOfCidrStringsValidation),
+		}...),
+		validation.Field(&dto.Nat, []validation.Rule{
+			validation.By(func(value interface{}) error {
+				return bridgeNatAddressesValidation(value, dto.Addresses)
+			}),
+		}...),
+		validation.Field(&dto.UpstreamInterface, []validation.Rule{
+			validation.By(containsSpacesValidation),
+			validation.By(func(value interface{}) error {
+				return bridgeUpstreamInterfaceValidation(value, dto.Nat)
+			}),
 		}...))
 	return convertOzzoErrorToValidationError(err)
 }
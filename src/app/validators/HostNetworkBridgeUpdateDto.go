@@ -16,6 +16,17 @@ func ValidateHostNetworkBridgeUpdateDto(dto dtos.HostNetworkBridgeUpdateDto) err
 		)),
 		validation.Field(&dto.Addresses, []validation.Rule{
 			validation.By(sliceOfCidrStringsValidation),
+		}...),
+		validation.Field(&dto.Nat, []validation.Rule{
+			validation.By(func(value interface{}) error {
+				return bridgeNatAddressesValidation(value, dto.Addresses)
+			}),
+		}...),
+		validation.Field(&dto.UpstreamInterface, []validation.Rule{
+			validation.By(containsSpacesValidation),
+			validation.By(func(value interface{}) error {
+				return bridgeUpstreamInterfaceValidation(value, dto.Nat)
+			}),
 		}...))
 	return convertOzzoErrorToValidationError(err)
 }
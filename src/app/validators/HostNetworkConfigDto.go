@@ -0,0 +1,172 @@
+package validators
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/app/errors"
+	"rol/dtos"
+	"strings"
+)
+
+//trafficRuleRolChainValidation checks that the config rule is in the RoL chain, rules of other chains are not managed
+func trafficRuleRolChainValidation(value interface{}) error {
+	s, _ := value.(string)
+	if s != "" && !strings.HasPrefix(s, "ROL-") {
+		return errors.Validation.New("chain must be RoL chain like ROL-INPUT")
+	}
+	return nil
+}
+
+func linkNamePrefixValidation(prefix string) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		s, _ := value.(string)
+		if !strings.HasPrefix(s, prefix) || len(s) == len(prefix) {
+			return errors.Validation.Newf("name must be %s{name}", prefix)
+		}
+		return nil
+	})
+}
+
+func validateHostNetworkConfigVlanDto(dto dtos.HostNetworkVlanDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, []validation.Rule{
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				s, _ := value.(string)
+				if s != fmt.Sprintf("rol.%s.%d", dto.Parent, dto.VlanID) {
+					return errors.Validation.Newf("name must be rol.%s.%d", dto.Parent, dto.VlanID)
+				}
+				return nil
+			}),
+		}...))
+	if err != nil {
+		return convertOzzoErrorToValidationError(err)
+	}
+	return ValidateHostNetworkVlanCreateDto(dtos.HostNetworkVlanCreateDto{
+		VlanID:    dto.VlanID,
+		Parent:    dto.Parent,
+		Addresses: dto.Addresses,
+	})
+}
+
+func validateHostNetworkConfigBridgeDto(dto dtos.HostNetworkBridgeDto) error {
+	return ValidateHostNetworkBridgeCreateDto(dtos.HostNetworkBridgeCreateDto{
+		Name:                     dto.Name,
+		HostNetworkBridgeBaseDto: dto.HostNetworkBridgeBaseDto,
+	})
+}
+
+func validateHostNetworkConfigBondDto(dto dtos.HostNetworkBondDto) error {
+	return ValidateHostNetworkBondCreateDto(dtos.HostNetworkBondCreateDto{
+		//bond name length is checked without the prefix
+		Name:                   strings.TrimPrefix(dto.Name, "rol.bond."),
+		Mode:                   dto.Mode,
+		Miimon:                 dto.Miimon,
+		HostNetworkBondBaseDto: dto.HostNetworkBondBaseDto,
+	})
+}
+
+func validateHostNetworkConfigVethDto(dto dtos.HostNetworkVethDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, []validation.Rule{
+			validation.By(func(value interface{}) error {
+				s, _ := value.(string)
+				if (strings.HasPrefix(s, "rol.veth.") && len(s) > len("rol.veth.")) ||
+					(strings.HasPrefix(s, "rol.peer.") && len(s) > len("rol.peer.")) {
+					return nil
+				}
+				return errors.Validation.New("name must be rol.veth.{name} or rol.peer.{name}")
+			}),
+		}...))
+	if err != nil {
+		return convertOzzoErrorToValidationError(err)
+	}
+	return ValidateHostNetworkVethCreateDto(dtos.HostNetworkVethCreateDto{
+		//veth name length is checked without the prefix
+		Name:      strings.TrimPrefix(strings.TrimPrefix(dto.Name, "rol.veth."), "rol.peer."),
+		Addresses: dto.Addresses,
+	})
+}
+
+func validateHostNetworkConfigNamespaceDto(dto dtos.HostNetworkNamespaceDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, linkNamePrefixValidation("rol.ns.")))
+	if err != nil {
+		return convertOzzoErrorToValidationError(err)
+	}
+	return ValidateHostNetworkNamespaceCreateDto(dtos.HostNetworkNamespaceCreateDto{
+		Name:                        strings.TrimPrefix(dto.Name, "rol.ns."),
+		HostNetworkNamespaceBaseDto: dto.HostNetworkNamespaceBaseDto,
+	})
+}
+
+func validateHostNetworkConfigTrafficRuleDto(dto dtos.HostNetworkTrafficRuleDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Chain, []validation.Rule{
+			validation.By(trafficRuleRolChainValidation),
+		}...))
+	if err != nil {
+		return convertOzzoErrorToValidationError(err)
+	}
+	return ValidateHostNetworkTrafficRuleCreateDto(dtos.HostNetworkTrafficRuleCreateDto{
+		HostNetworkTrafficRuleBaseDto: dto.HostNetworkTrafficRuleBaseDto,
+	})
+}
+
+//ValidateHostNetworkConfigDto validates host network config dto, errors of the config items are returned with
+//their paths like Vlans[0].Parent
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkConfigDto(dto dtos.HostNetworkConfigDto) error {
+	var err error
+	addErrors := func(field string, itemErr error) {
+		if itemErr == nil {
+			return
+		}
+		if err == nil {
+			err = errors.Validation.New(errors.ValidationErrorMessage)
+		}
+		for key, message := range errors.GetErrorContext(itemErr) {
+			err = errors.AddErrorContext(err, field+"."+key, message)
+		}
+	}
+	for i, vlan := range dto.Vlans {
+		addErrors(fmt.Sprintf("Vlans[%d]", i), validateHostNetworkConfigVlanDto(vlan))
+	}
+	for i, bridge := range dto.Bridges {
+		addErrors(fmt.Sprintf("Bridges[%d]", i), convertOzzoErrorToValidationError(validation.ValidateStruct(&bridge,
+			validation.Field(&bridge.Name, linkNamePrefixValidation("rol.br.")))))
+		addErrors(fmt.Sprintf("Bridges[%d]", i), validateHostNetworkConfigBridgeDto(bridge))
+	}
+	for i, bond := range dto.Bonds {
+		addErrors(fmt.Sprintf("Bonds[%d]", i), convertOzzoErrorToValidationError(validation.ValidateStruct(&bond,
+			validation.Field(&bond.Name, linkNamePrefixValidation("rol.bond.")))))
+		addErrors(fmt.Sprintf("Bonds[%d]", i), validateHostNetworkConfigBondDto(bond))
+	}
+	for i, veth := range dto.Veths {
+		addErrors(fmt.Sprintf("Veths[%d]", i), validateHostNetworkConfigVethDto(veth))
+	}
+	for i, namespace := range dto.Namespaces {
+		addErrors(fmt.Sprintf("Namespaces[%d]", i), validateHostNetworkConfigNamespaceDto(namespace))
+	}
+	for i, route := range dto.Routes {
+		addErrors(fmt.Sprintf("Routes[%d]", i), validateHostNetworkRouteBaseDto(route.HostNetworkRouteBaseDto))
+	}
+	tables := map[string][]dtos.HostNetworkTrafficRuleDto{
+		"Filter":   dto.TrafficRules.Filter,
+		"NAT":      dto.TrafficRules.NAT,
+		"Mangle":   dto.TrafficRules.Mangle,
+		"Raw":      dto.TrafficRules.Raw,
+		"Security": dto.TrafficRules.Security,
+	}
+	for table, rules := range tables {
+		for i, rule := range rules {
+			//bridges NAT rules are generated from the bridges settings, so they are ignored
+			if strings.HasPrefix(rule.Comment, trafficRuleNatCommentPrefix) {
+				continue
+			}
+			addErrors(fmt.Sprintf("TrafficRules.%s[%d]", table, i), validateHostNetworkConfigTrafficRuleDto(rule))
+		}
+	}
+	return err
+}
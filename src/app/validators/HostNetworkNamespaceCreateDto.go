@@ -0,0 +1,31 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/app/errors"
+	"rol/dtos"
+	"strings"
+)
+
+//ValidateHostNetworkNamespaceCreateDto validates host network namespace create dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkNamespaceCreateDto(dto dtos.HostNetworkNamespaceCreateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, []validation.Rule{
+			validation.Required,
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+			validation.By(func(value interface{}) error {
+				s, _ := value.(string)
+				if strings.Contains(s, "/") {
+					return errors.Validation.New("field cannot contain slashes")
+				}
+				return nil
+			}),
+		}...),
+		validation.Field(&dto.Links, []validation.Rule{
+			validation.By(namespaceLinksValidation),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
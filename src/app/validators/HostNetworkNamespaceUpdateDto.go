@@ -0,0 +1,43 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/app/errors"
+	"rol/dtos"
+)
+
+//namespaceLinksValidation checks names and addresses of the namespace links and that every link is set once
+func namespaceLinksValidation(value interface{}) error {
+	links, _ := value.([]dtos.HostNetworkNamespaceLinkDto)
+	names := map[string]bool{}
+	for _, link := range links {
+		err := validation.Validate(link.Name,
+			validation.Required,
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+		)
+		if err != nil {
+			return errors.Validation.Newf("wrong link name %s: %s", link.Name, err.Error())
+		}
+		if names[link.Name] {
+			return errors.Validation.Newf("link %s is set more than once", link.Name)
+		}
+		names[link.Name] = true
+		err = sliceOfCidrStringsValidation(link.Addresses)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//ValidateHostNetworkNamespaceUpdateDto validates host network namespace update dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkNamespaceUpdateDto(dto dtos.HostNetworkNamespaceUpdateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Links, []validation.Rule{
+			validation.By(namespaceLinksValidation),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
@@ -0,0 +1,73 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"net"
+	"rol/app/errors"
+	"rol/dtos"
+)
+
+func routeDestinationValidation(value interface{}) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	_, _, err := net.ParseCIDR(s)
+	if err != nil {
+		return errors.Validation.New("wrong destination network, expect 10.0.0.0/8 or fd00::/8")
+	}
+	return nil
+}
+
+func routeGatewayValidation(value interface{}, destination string) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	gateway := net.ParseIP(s)
+	if gateway == nil {
+		return errors.Validation.New("wrong gateway address")
+	}
+	destinationIP, _, err := net.ParseCIDR(destination)
+	if err == nil && (destinationIP.To4() == nil) != (gateway.To4() == nil) {
+		return errors.Validation.New("gateway address family differs from the destination family")
+	}
+	return nil
+}
+
+func validateHostNetworkRouteBaseDto(dto dtos.HostNetworkRouteBaseDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Destination, []validation.Rule{
+			validation.Required,
+			validation.By(routeDestinationValidation),
+		}...),
+		validation.Field(&dto.Gateway, []validation.Rule{
+			validation.By(func(value interface{}) error {
+				return routeGatewayValidation(value, dto.Destination)
+			}),
+		}...),
+		validation.Field(&dto.Interface, []validation.Rule{
+			validation.By(containsSpacesValidation),
+			validation.By(func(value interface{}) error {
+				s, _ := value.(string)
+				if s == "" && dto.Gateway == "" {
+					return errors.Validation.New("interface is required for the route without gateway")
+				}
+				return nil
+			}),
+		}...),
+		validation.Field(&dto.Metric, []validation.Rule{
+			validation.Min(0),
+		}...),
+		validation.Field(&dto.Table, []validation.Rule{
+			validation.Min(0),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
+
+//ValidateHostNetworkRouteCreateDto validates host network route create dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkRouteCreateDto(dto dtos.HostNetworkRouteCreateDto) error {
+	return validateHostNetworkRouteBaseDto(dto.HostNetworkRouteBaseDto)
+}
@@ -0,0 +1,10 @@
+package validators
+
+import "rol/dtos"
+
+//ValidateHostNetworkRouteDeleteDto validates host network route delete dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkRouteDeleteDto(dto dtos.HostNetworkRouteDeleteDto) error {
+	return validateHostNetworkRouteBaseDto(dto.HostNetworkRouteBaseDto)
+}
@@ -0,0 +1,139 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"regexp"
+	"rol/app/errors"
+	"rol/dtos"
+	"strconv"
+	"strings"
+)
+
+//regexpTrafficRulePorts single port, ports range or comma separated list of ports and ranges
+const regexpTrafficRulePorts = `^\d{1,5}(:\d{1,5})?(,\d{1,5}(:\d{1,5})?)*$`
+const regexpTrafficRulePortsDesc = "wrong ports format, expect 67, 1000:2000 or 67,69"
+
+//trafficRulePortsRangeValidation checks that ports are not greater than 65535 and ranges start is not greater than end
+func trafficRulePortsRangeValidation(value interface{}) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(item, ":")
+		if !isRange {
+			to = from
+		}
+		fromValue, fromErr := strconv.ParseUint(from, 10, 16)
+		toValue, toErr := strconv.ParseUint(to, 10, 16)
+		if fromErr != nil || toErr != nil {
+			return errors.Validation.Newf("port %s is out of range 0-65535", item)
+		}
+		if fromValue > toValue {
+			return errors.Validation.Newf("ports range %s start is greater than end", item)
+		}
+	}
+	return nil
+}
+
+func trafficRuleStateValidation(value interface{}) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	for _, state := range strings.Split(s, ",") {
+		switch state {
+		case "INVALID", "NEW", "RELATED", "ESTABLISHED", "UNTRACKED", "SNAT", "DNAT":
+		default:
+			return errors.Validation.Newf("unknown conntrack state %s", state)
+		}
+	}
+	return nil
+}
+
+//trafficRuleNatCommentPrefix comment prefix of the rules that are generated from the bridges NAT settings
+const trafficRuleNatCommentPrefix = "rol.nat:"
+
+//trafficRuleCommentValidation checks that comment is listed by iptables as is, so the rule can be found by it
+func trafficRuleCommentValidation(value interface{}) error {
+	s, _ := value.(string)
+	if strings.HasPrefix(s, trafficRuleNatCommentPrefix) {
+		return errors.Validation.Newf("comment prefix %s is reserved for the bridges NAT rules", trafficRuleNatCommentPrefix)
+	}
+	for _, char := range s {
+		if char == '"' || char == '\\' || char < ' ' || char > '~' {
+			return errors.Validation.New("comment can contain only printable ASCII characters except quotes and backslashes")
+		}
+	}
+	return nil
+}
+
+//ValidateHostNetworkTrafficRuleCreateDto validates host network traffic rule create dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkTrafficRuleCreateDto(dto dtos.HostNetworkTrafficRuleCreateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Chain, []validation.Rule{
+			validation.Required,
+			validation.By(containsSpacesValidation),
+		}...),
+		validation.Field(&dto.Action, []validation.Rule{
+			validation.Required,
+			validation.By(containsSpacesValidation),
+		}...),
+		validation.Field(&dto.Protocol, []validation.Rule{
+			validation.In("tcp", "udp", "icmp"),
+		}...),
+		validation.Field(&dto.SourcePorts, []validation.Rule{
+			validation.Match(regexp.MustCompile(regexpTrafficRulePorts)).Error(regexpTrafficRulePortsDesc),
+			validation.By(trafficRulePortsRangeValidation),
+			validation.By(func(value interface{}) error {
+				return trafficRulePortsProtocolValidation(value, dto.Protocol)
+			}),
+		}...),
+		validation.Field(&dto.DestinationPorts, []validation.Rule{
+			validation.Match(regexp.MustCompile(regexpTrafficRulePorts)).Error(regexpTrafficRulePortsDesc),
+			validation.By(trafficRulePortsRangeValidation),
+			validation.By(func(value interface{}) error {
+				return trafficRulePortsProtocolValidation(value, dto.Protocol)
+			}),
+		}...),
+		validation.Field(&dto.InInterface, []validation.Rule{
+			validation.By(containsSpacesValidation),
+		}...),
+		validation.Field(&dto.OutInterface, []validation.Rule{
+			validation.By(containsSpacesValidation),
+		}...),
+		validation.Field(&dto.State, []validation.Rule{
+			validation.By(trafficRuleStateValidation),
+		}...),
+		validation.Field(&dto.Comment, []validation.Rule{
+			validation.Length(0, 256),
+			validation.By(trafficRuleCommentValidation),
+		}...),
+		validation.Field(&dto.To, []validation.Rule{
+			validation.By(containsSpacesValidation),
+			validation.By(func(value interface{}) error {
+				s, _ := value.(string)
+				if s != "" && dto.Action != "DNAT" && dto.Action != "SNAT" {
+					return errors.Validation.New("target address can be set only for DNAT or SNAT action")
+				}
+				if s == "" && (dto.Action == "DNAT" || dto.Action == "SNAT") {
+					return errors.Validation.New("target address is required for DNAT or SNAT action")
+				}
+				return nil
+			}),
+		}...),
+		validation.Field(&dto.Position, []validation.Rule{
+			validation.Min(0),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
+
+func trafficRulePortsProtocolValidation(value interface{}, protocol string) error {
+	s, _ := value.(string)
+	if s != "" && protocol != "tcp" && protocol != "udp" {
+		return errors.Validation.New("ports can be set only for tcp or udp protocol")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/dtos"
+)
+
+//ValidateHostNetworkVethCreateDto validates host network veth create dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkVethCreateDto(dto dtos.HostNetworkVethCreateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, []validation.Rule{
+			validation.Required,
+			validation.By(trimValidation),
+			validation.By(containsSpacesValidation),
+			//linux interface name is limited by 15 characters including the rol.veth. and rol.peer. prefixes
+			validation.Length(1, 6),
+		}...),
+		validation.Field(&dto.Addresses, []validation.Rule{
+			validation.By(sliceOfCidrStringsValidation),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
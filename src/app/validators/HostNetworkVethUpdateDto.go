@@ -0,0 +1,17 @@
+package validators
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"rol/dtos"
+)
+
+//ValidateHostNetworkVethUpdateDto validates host network veth update dto
+//	Return
+//	error - if an error occurs, otherwise nil
+func ValidateHostNetworkVethUpdateDto(dto dtos.HostNetworkVethUpdateDto) error {
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Addresses, []validation.Rule{
+			validation.By(sliceOfCidrStringsValidation),
+		}...))
+	return convertOzzoErrorToValidationError(err)
+}
@@ -23,6 +23,29 @@ type DbConfig struct {
 	SQLite `yaml:"sqlite"`
 }
 
+//EthernetSwitchSessionConfig structure describing ethernet switch management sessions configuration
+type EthernetSwitchSessionConfig struct {
+	//PoolSize max count of simultaneously opened management sessions to one switch
+	PoolSize int `yaml:"poolSize"`
+	//IdleTimeout time in seconds after which an unused session is closed
+	IdleTimeout int `yaml:"idleTimeout"`
+	//KeepaliveInterval time in seconds between keepalive commands for idle sessions
+	KeepaliveInterval int `yaml:"keepaliveInterval"`
+	//CommandTimeout default deadline in seconds for switch operation if the context has no deadline
+	CommandTimeout int `yaml:"commandTimeout"`
+}
+
+//CredentialsConfig structure describing encryption of the credentials that are stored in the database
+type CredentialsConfig struct {
+	//Key current encryption key, ROL_CREDENTIALS_KEY environment variable overrides it
+	Key string `yaml:"key"`
+	//PreviousKeys keys that were used before the key rotation, they are used only for decryption.
+	//ROL_CREDENTIALS_PREVIOUS_KEYS environment variable with comma separated keys overrides it
+	PreviousKeys []string `yaml:"previousKeys"`
+	//AllowPlaintext allows to start without the key, then the credentials are stored in plaintext
+	AllowPlaintext bool `yaml:"allowPlaintext"`
+}
+
 //AppConfig application config structure
 type AppConfig struct {
 	HTTPServer struct {
@@ -37,4 +60,28 @@ type AppConfig struct {
 		Level          string `yaml:"level"`
 		LogsToDatabase bool   `yaml:"logsToDatabase"`
 	} `yaml:"logger"`
+	EthernetSwitch struct {
+		Session EthernetSwitchSessionConfig `yaml:"session"`
+		//DriftCheckInterval time in seconds between comparisons of the switches configuration with the stored one
+		DriftCheckInterval int `yaml:"driftCheckInterval"`
+		//PortStatusCacheTTL time in seconds during which the read port status is reused
+		PortStatusCacheTTL int `yaml:"portStatusCacheTTL"`
+		//FirmwareUpgradeTimeout time in seconds for the firmware image download, installation and switch reboot
+		FirmwareUpgradeTimeout int `yaml:"firmwareUpgradeTimeout"`
+		//TelnetPort TCP port of the switches CLI, 23 if not set
+		TelnetPort int `yaml:"telnetPort"`
+	} `yaml:"ethernetSwitch"`
+	Credentials CredentialsConfig `yaml:"credentials"`
+	HostNetwork struct {
+		//TrafficRuleBackend netfilter rules management backend: iptables or nftables, iptables if not set
+		TrafficRuleBackend string `yaml:"trafficRuleBackend"`
+		//ConfirmTimeout time in seconds after the last host network change when the changes are reset
+		//if they are not confirmed, 0 to keep unconfirmed changes
+		ConfirmTimeout int `yaml:"confirmTimeout"`
+		//HistorySize count of the kept saved configuration revisions, 10 if not set
+		HistorySize int `yaml:"historySize"`
+		//HistoryMaxAge time in days after which saved configuration revisions are deleted,
+		//0 to delete them only when the history size is exceeded. The latest revision is never deleted
+		HistoryMaxAge int `yaml:"historyMaxAge"`
+	} `yaml:"hostNetwork"`
 }
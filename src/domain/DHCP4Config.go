@@ -5,6 +5,8 @@ type DHCP4Config struct {
 	//EntityUUID - nested base entity where ID type is uuid.UUID
 	EntityUUID
 	Interface string `gorm:"type:varchar(64);index"`
+	//Namespace network namespace where the server is running, empty for the host network namespace
+	Namespace string `gorm:"type:varchar(64)"`
 	Gateway   string `gorm:"type:varchar(15)"`
 	NTP       string `gorm:"type:varchar(15)"`
 	//ServerID server id DHCP option
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+//lagPortNamePrefix prefix of the LAG names in the switch configuration, LAG is named like "Po1"
+const lagPortNamePrefix = "Po"
+
+//LAGPortName get name of the LAG with given group ID in the switch configuration
+func LAGPortName(groupID int) string {
+	return lagPortNamePrefix + strconv.Itoa(groupID)
+}
+
+//LAGGroupID get group ID of the LAG by its name in the switch configuration
+//
+//Params:
+//	portName - port name
+//Return:
+//	int - LAG group ID
+//	bool - true if the port is LAG
+func LAGGroupID(portName string) (int, bool) {
+	if !strings.HasPrefix(portName, lagPortNamePrefix) {
+		return 0, false
+	}
+	groupID, err := strconv.Atoi(strings.TrimPrefix(portName, lagPortNamePrefix))
+	if err != nil {
+		return 0, false
+	}
+	return groupID, true
+}
+
+//EthernetSwitchPortConfig configuration of the ethernet switch port
+type EthernetSwitchPortConfig struct {
+	//Name - port name
+	Name string
+	//PVID - port PVID, 0 if unknown
+	PVID int
+	//POEEnabled - POE status on a port
+	POEEnabled bool
+	//POEType - port POE type, can be empty if unknown
+	POEType string
+	//TaggedVLANs - VLAN IDs that are tagged on the port
+	TaggedVLANs []int
+	//UntaggedVLANs - VLAN IDs that are untagged on the port
+	UntaggedVLANs []int
+	//Shutdown - true if the port is administratively disabled
+	Shutdown bool
+	//Description - port description
+	Description string
+	//Speed - port speed: "auto", "10M", "100M", "1000M", empty if unknown
+	Speed string
+	//Duplex - port duplex: "auto", "full", "half", empty if unknown
+	Duplex string
+}
+
+//EthernetSwitchConfig configuration of the ethernet switch, can contain only part of the switch ports.
+//LAGs are configured as ports named by LAGPortName.
+type EthernetSwitchConfig struct {
+	//VLANs - VLAN IDs existing on the switch
+	VLANs []int
+	//Ports - ports configuration
+	Ports []EthernetSwitchPortConfig
+}
+
+//EthernetSwitchPortChanges changes of the ethernet switch port configuration
+type EthernetSwitchPortChanges struct {
+	//Name - port name
+	Name string
+	//RemoveVLANs - VLAN IDs to remove from the port
+	RemoveVLANs []int
+	//AddTaggedVLANs - VLAN IDs to add on the port as tagged
+	AddTaggedVLANs []int
+	//AddUntaggedVLANs - VLAN IDs to add on the port as untagged
+	AddUntaggedVLANs []int
+	//PVID - new port PVID, nil if not changed
+	PVID *int
+	//POEEnabled - new POE status, nil if not changed
+	POEEnabled *bool
+	//POEType - POE type to use when POE is enabled
+	POEType string
+	//Shutdown - new administrative state, nil if not changed
+	Shutdown *bool
+	//Description - new port description, nil if not changed
+	Description *string
+	//Speed - new port speed, nil if not changed
+	Speed *string
+	//Duplex - new port duplex, nil if not changed
+	Duplex *string
+}
+
+//IsEmpty checks that port has no changes
+func (p EthernetSwitchPortChanges) IsEmpty() bool {
+	return len(p.RemoveVLANs) == 0 && len(p.AddTaggedVLANs) == 0 && len(p.AddUntaggedVLANs) == 0 &&
+		p.PVID == nil && p.POEEnabled == nil && p.Shutdown == nil && p.Description == nil && p.Speed == nil && p.Duplex == nil
+}
+
+//EthernetSwitchChangeset full set of changes that should be applied to the ethernet switch.
+//Changes are applied in the order: create VLANs, ports changes, delete VLANs.
+type EthernetSwitchChangeset struct {
+	//CreateVLANs - VLAN IDs to create
+	CreateVLANs []int
+	//DeleteVLANs - VLAN IDs to delete
+	DeleteVLANs []int
+	//Ports - ports changes
+	Ports []EthernetSwitchPortChanges
+}
+
+//IsEmpty checks that changeset has no changes
+func (c EthernetSwitchChangeset) IsEmpty() bool {
+	return len(c.CreateVLANs) == 0 && len(c.DeleteVLANs) == 0 && len(c.Ports) == 0
+}
+
+//GetPortNames get names of the ports affected by the changeset
+func (c EthernetSwitchChangeset) GetPortNames() []string {
+	names := []string{}
+	for _, port := range c.Ports {
+		names = append(names, port.Name)
+	}
+	return names
+}
+
+//GetPort get port configuration by name
+//
+//Params:
+//	name - port name
+//Return:
+//	EthernetSwitchPortConfig - port configuration
+//	bool - true if port is found
+func (c EthernetSwitchConfig) GetPort(name string) (EthernetSwitchPortConfig, bool) {
+	for _, port := range c.Ports {
+		if port.Name == name {
+			return port, true
+		}
+	}
+	return EthernetSwitchPortConfig{}, false
+}
+
+//ChangesetTo computes changes that should be applied to this configuration to get the desired one.
+//Ports that are missing in the desired configuration are not changed. Ports that are missing in this
+//configuration are considered unknown, so their PVID, POE status and administrative state will be set explicitly.
+//Empty speed or duplex in the desired configuration means that they are not managed.
+//
+//Params:
+//	desired - desired switch configuration
+//Return:
+//	EthernetSwitchChangeset - changes to apply
+func (c EthernetSwitchConfig) ChangesetTo(desired EthernetSwitchConfig) EthernetSwitchChangeset {
+	changeset := EthernetSwitchChangeset{
+		CreateVLANs: []int{},
+		DeleteVLANs: []int{},
+		Ports:       []EthernetSwitchPortChanges{},
+	}
+	changeset.DeleteVLANs, changeset.CreateVLANs = intSliceDiff(c.VLANs, desired.VLANs)
+	for _, desiredPort := range desired.Ports {
+		currentPort, known := c.GetPort(desiredPort.Name)
+		changes := EthernetSwitchPortChanges{
+			Name:    desiredPort.Name,
+			POEType: desiredPort.POEType,
+		}
+		currentVLANs := append(append([]int{}, currentPort.TaggedVLANs...), currentPort.UntaggedVLANs...)
+		desiredVLANs := append(append([]int{}, desiredPort.TaggedVLANs...), desiredPort.UntaggedVLANs...)
+		changes.RemoveVLANs, _ = intSliceDiff(currentVLANs, desiredVLANs)
+		_, changes.AddTaggedVLANs = intSliceDiff(currentPort.TaggedVLANs, desiredPort.TaggedVLANs)
+		_, changes.AddUntaggedVLANs = intSliceDiff(currentPort.UntaggedVLANs, desiredPort.UntaggedVLANs)
+		if desiredPort.PVID != 0 && (!known || currentPort.PVID != desiredPort.PVID) {
+			pvid := desiredPort.PVID
+			changes.PVID = &pvid
+		}
+		poeTypeChanged := desiredPort.POEEnabled && currentPort.POEType != "" && desiredPort.POEType != "" &&
+			currentPort.POEType != desiredPort.POEType
+		if !known || currentPort.POEEnabled != desiredPort.POEEnabled || poeTypeChanged {
+			poeEnabled := desiredPort.POEEnabled
+			changes.POEEnabled = &poeEnabled
+		}
+		if !known || currentPort.Shutdown != desiredPort.Shutdown {
+			shutdown := desiredPort.Shutdown
+			changes.Shutdown = &shutdown
+		}
+		if (!known && desiredPort.Description != "") || (known && currentPort.Description != desiredPort.Description) {
+			description := desiredPort.Description
+			changes.Description = &description
+		}
+		if desiredPort.Speed != "" && (!known || currentPort.Speed != desiredPort.Speed) {
+			speed := desiredPort.Speed
+			changes.Speed = &speed
+		}
+		if desiredPort.Duplex != "" && (!known || currentPort.Duplex != desiredPort.Duplex) {
+			duplex := desiredPort.Duplex
+			changes.Duplex = &duplex
+		}
+		if !changes.IsEmpty() {
+			changeset.Ports = append(changeset.Ports, changes)
+		}
+	}
+	return changeset
+}
+
+//intSliceDiff returns elements that are deleted from the original slice and added to the modified one
+func intSliceDiff(original, modified []int) ([]int, []int) {
+	contains := func(slice []int, value int) bool {
+		for _, elem := range slice {
+			if elem == value {
+				return true
+			}
+		}
+		return false
+	}
+	deleted := []int{}
+	for _, elem := range original {
+		if !contains(modified, elem) && !contains(deleted, elem) {
+			deleted = append(deleted, elem)
+		}
+	}
+	added := []int{}
+	for _, elem := range modified {
+		if !contains(original, elem) && !contains(added, elem) {
+			added = append(added, elem)
+		}
+	}
+	return deleted, added
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "github.com/google/uuid"
+
+//EthernetSwitchConfigBackup version of the ethernet switch running configuration
+type EthernetSwitchConfigBackup struct {
+	//EntityUUID - nested base entity where ID type is uuid.UUID, CreatedAt is the backup time
+	EntityUUID
+	//EthernetSwitchID - id of the switch
+	EthernetSwitchID uuid.UUID `gorm:"index;size:36"`
+	//Hash - SHA-256 hash of the configuration in hex
+	Hash string `gorm:"size:64"`
+	//Config - running configuration text
+	Config string `gorm:"type:text"`
+}
@@ -0,0 +1,9 @@
+package domain
+
+//EthernetSwitchFirmwareInfo firmware information of the ethernet switch
+type EthernetSwitchFirmwareInfo struct {
+	//FirmwareVersion - installed firmware version
+	FirmwareVersion string
+	//HardwareVersion - hardware revision
+	HardwareVersion string
+}
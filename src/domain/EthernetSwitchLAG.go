@@ -0,0 +1,22 @@
+package domain
+
+import "github.com/google/uuid"
+
+//EthernetSwitchLAG ethernet switch link aggregation group entity
+type EthernetSwitchLAG struct {
+	//EntityUUID - nested base entity where ID type is uuid.UUID
+	EntityUUID
+	//EthernetSwitchID - id of the switch
+	EthernetSwitchID uuid.UUID `gorm:"index;size:36"`
+	//GroupID - number of the port channel on the switch
+	GroupID int
+	//Mode - LACP mode: "active", "passive" or "static" for the group without LACP
+	Mode string
+	//MemberPorts - IDs of the member ports separated by semicolon
+	MemberPorts string `gorm:"type:text"`
+}
+
+//GetPortName get name of the LAG that is used in the switch configuration
+func (l EthernetSwitchLAG) GetPortName() string {
+	return LAGPortName(l.GroupID)
+}
@@ -0,0 +1,29 @@
+package domain
+
+//EthernetSwitchLLDPNeighbor neighbor device discovered by LLDP on the switch port
+type EthernetSwitchLLDPNeighbor struct {
+	//PortName - name of the local switch port
+	PortName string
+	//ChassisID - neighbor chassis ID, usually MAC address
+	ChassisID string
+	//PortID - neighbor port ID, can be MAC address or interface name
+	PortID string
+	//SystemName - neighbor system name
+	SystemName string
+	//PortDescription - neighbor port description
+	PortDescription string
+	//ManagementAddress - neighbor management address
+	ManagementAddress string
+}
+
+//EthernetSwitchMACEntry entry of the switch MAC address table
+type EthernetSwitchMACEntry struct {
+	//MAC - MAC address in the format like 00:00:00:00:00:00
+	MAC string
+	//VLANID - VLAN where the address is learned
+	VLANID int
+	//PortName - name of the switch port where the address is learned
+	PortName string
+	//Type - entry type, for example "dynamic" or "static"
+	Type string
+}
@@ -17,4 +17,12 @@ type EthernetSwitchPort struct {
 	POEEnabled bool
 	//PVID - port PVID
 	PVID int `gorm:"column:pvid"`
+	//Shutdown - true if the port is administratively disabled
+	Shutdown bool
+	//Description - port description on the switch
+	Description string
+	//Speed - port speed: "auto", "10M", "100M", "1000M", empty if not managed
+	Speed string
+	//Duplex - port duplex: "auto", "full", "half", empty if not managed
+	Duplex string
 }
@@ -0,0 +1,23 @@
+package domain
+
+//FabricVLAN VLAN that spans several ethernet switches and the host
+type FabricVLAN struct {
+	//EntityUUID - nested base entity where ID type is uuid.UUID
+	EntityUUID
+	//Name - VLAN name
+	Name string
+	//VlanID - VLAN ID on the switches and on the host
+	VlanID int `gorm:"index"`
+	//HostParent - name of the host parent interface, empty if the VLAN is not created on the host
+	HostParent string
+	//HostAddresses - addresses of the host VLAN interface separated by semicolon
+	HostAddresses string `gorm:"type:text"`
+	//HostInterface - name of the created host VLAN interface
+	HostInterface string
+	//HostVLANCreated - the host VLAN interface was created for the fabric VLAN, so it is deleted with the fabric VLAN
+	HostVLANCreated bool
+	//HostStatus - status of the VLAN on the host
+	HostStatus string
+	//HostError - reason of the failed host VLAN configuration
+	HostError string `gorm:"type:text"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "github.com/google/uuid"
+
+//FabricVLANMember ethernet switch port or LAG that is a member of the fabric VLAN
+type FabricVLANMember struct {
+	//EntityUUID - nested base entity where ID type is uuid.UUID
+	EntityUUID
+	//FabricVlanID - id of the fabric VLAN
+	FabricVlanID uuid.UUID `gorm:"type:varchar(36);index"`
+	//EthernetSwitchID - id of the switch
+	EthernetSwitchID uuid.UUID `gorm:"type:varchar(36);index"`
+	//PortID - id of the switch port or LAG
+	PortID uuid.UUID `gorm:"type:varchar(36)"`
+	//Tagged - port is a trunk port of the VLAN, otherwise it is an access port
+	Tagged bool
+	//SwitchVLANCreated - the switch VLAN was created for the fabric VLAN, so it is deleted with the fabric VLAN
+	SwitchVLANCreated bool
+	//Status - status of the VLAN on the port
+	Status string
+	//Error - reason of the failed port configuration
+	Error string `gorm:"type:text"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+//HostNetworkBond is a struct for network bonding interface
+type HostNetworkBond struct {
+	HostNetworkLink
+	//Mode bonding mode: "active-backup", "802.3ad" or "balance-alb"
+	Mode string
+	//Miimon MII link monitoring frequency in milliseconds, 0 disables the monitoring
+	Miimon int
+	//Slaves slice of slaves interfaces names
+	Slaves []string
+}
+
+//GetSlaves get bond slaves
+func (h HostNetworkBond) GetSlaves() []string {
+	return h.Slaves
+}
@@ -5,6 +5,10 @@ type HostNetworkBridge struct {
 	HostNetworkLink
 	//Slaves slice of slaves interfaces names
 	Slaves []string
+	//Nat bridge networks have an access to the upstream interface networks through masquerade
+	Nat bool
+	//UpstreamInterface name of the interface through which NAT traffic of the bridge is sent
+	UpstreamInterface string
 }
 
 //GetSlaves get bridge slaves
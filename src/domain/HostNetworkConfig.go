@@ -9,6 +9,14 @@ type HostNetworkConfig struct {
 	Vlans []HostNetworkVlan
 	//Bridges slice of HostNetworkBridge
 	Bridges []HostNetworkBridge
+	//Bonds slice of HostNetworkBond
+	Bonds []HostNetworkBond
+	//Veths slice of HostNetworkVeth, ends of the pairs that are not moved into namespaces
+	Veths []HostNetworkVeth
+	//Namespaces slice of HostNetworkNamespace
+	Namespaces []HostNetworkNamespace
+	//Routes static routes that are managed by RoL
+	Routes []HostNetworkRoute
 	//TrafficRules netfilter traffic rules struct
 	TrafficRules TrafficRules
 }
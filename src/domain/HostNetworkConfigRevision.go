@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//HostNetworkConfigRevision saved version of the host network configuration
+type HostNetworkConfigRevision struct {
+	//ID revision ID
+	ID uuid.UUID
+	//CreatedAt time when the configuration was saved
+	CreatedAt time.Time
+	//Author who confirmed and saved the configuration
+	Author string
+	//RequestID ID of the API request that saved the configuration, empty if it was saved by RoL itself
+	RequestID uuid.UUID
+	//Config saved configuration
+	Config HostNetworkConfig
+}
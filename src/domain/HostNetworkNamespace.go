@@ -0,0 +1,9 @@
+package domain
+
+//HostNetworkNamespace is a struct for network namespace that isolates its links from the host
+type HostNetworkNamespace struct {
+	//Name network namespace name
+	Name string
+	//Links links that are moved into the namespace with their addresses in the namespace
+	Links []HostNetworkLink
+}
@@ -0,0 +1,100 @@
+package domain
+
+import "net"
+
+//HostNetworkPlan changes that converge the host network to the desired configuration.
+//Routes are deleted first, then replaced links are deleted, then links and namespaces are created,
+//then links are moved between namespaces, then addresses, slaves and routes are changed,
+//then links and namespaces are deleted and traffic rules are changed
+type HostNetworkPlan struct {
+	//DeleteRoutes routes to delete, they are deleted before the addresses their gateways depend on
+	DeleteRoutes []HostNetworkRoute
+	//ReplaceLinks links which attributes can't be changed on the host, they are deleted and created again by CreateLinks
+	ReplaceLinks []HostNetworkLinkChange
+	//CreateLinks links to create, they are set up after creation
+	CreateLinks []HostNetworkLinkChange
+	//CreateNamespaces names of the network namespaces to create
+	CreateNamespaces []string
+	//NamespaceLinks links to move into namespaces or back to the host, links are moved back before the moving into
+	NamespaceLinks []HostNetworkNamespaceLinkChange
+	//Addresses addresses to add or delete
+	Addresses []HostNetworkAddressChange
+	//Slaves slaves to add to masters or remove from them
+	Slaves []HostNetworkSlaveChange
+	//CreateRoutes routes to create, they are created after the addresses of their interfaces
+	CreateRoutes []HostNetworkRoute
+	//DeleteLinks links to delete
+	DeleteLinks []HostNetworkLinkChange
+	//DeleteNamespaces names of the network namespaces to delete, their links are moved back to the host before
+	DeleteNamespaces []string
+	//TrafficRules traffic rules to create or delete, rules are deleted before the creation
+	TrafficRules []HostNetworkTrafficRuleChange
+}
+
+//IsEmpty checks that the plan has no changes
+//
+//Return:
+//	bool - true if plan has no changes, otherwise false
+func (h HostNetworkPlan) IsEmpty() bool {
+	return len(h.DeleteRoutes) == 0 && len(h.ReplaceLinks) == 0 && len(h.CreateLinks) == 0 &&
+		len(h.CreateNamespaces) == 0 && len(h.NamespaceLinks) == 0 && len(h.Addresses) == 0 && len(h.Slaves) == 0 &&
+		len(h.CreateRoutes) == 0 && len(h.DeleteLinks) == 0 && len(h.DeleteNamespaces) == 0 && len(h.TrafficRules) == 0
+}
+
+//HostNetworkLinkChange link creation or deletion
+type HostNetworkLinkChange struct {
+	//Name full link name
+	Name string
+	//Type link type: vlan, bridge, bond or veth
+	Type string
+	//Parent vlan parent interface name
+	Parent string
+	//VlanID vlan id
+	VlanID int
+	//Mode bonding mode
+	Mode string
+	//Miimon bond MII link monitoring frequency in milliseconds
+	Miimon int
+}
+
+//HostNetworkAddressChange link address addition or deletion
+type HostNetworkAddressChange struct {
+	//Action "add" or "delete"
+	Action string
+	//LinkName link name
+	LinkName string
+	//Namespace network namespace of the link, empty for the host links
+	Namespace string
+	//Address ip address with mask
+	Address net.IPNet
+}
+
+//HostNetworkNamespaceLinkChange moving of the link into the network namespace or back to the host
+type HostNetworkNamespaceLinkChange struct {
+	//Action "add" to move the link into the namespace or "delete" to move it back to the host
+	Action string
+	//LinkName link name
+	LinkName string
+	//Namespace network namespace name
+	Namespace string
+}
+
+//HostNetworkSlaveChange link master setting or removing
+type HostNetworkSlaveChange struct {
+	//Action "add" to set the master or "delete" to remove the slave from the master
+	Action string
+	//Slave slave link name
+	Slave string
+	//Master master link name
+	Master string
+}
+
+//HostNetworkTrafficRuleChange traffic rule creation or deletion
+type HostNetworkTrafficRuleChange struct {
+	//Action "add" or "delete"
+	Action string
+	//Table netfilter table
+	Table string
+	//Rule traffic rule, rule is created at its position
+	Rule HostNetworkTrafficRule
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "net"
+
+//HostNetworkRoute is a struct for static IPv4 or IPv6 route that is managed by RoL
+type HostNetworkRoute struct {
+	//Destination destination network, 0.0.0.0/0 or ::/0 for the default route
+	Destination net.IPNet
+	//Gateway next hop address of the same family as the destination, nil for the routes without gateway
+	Gateway net.IP
+	//Interface name of the outgoing interface, empty to resolve it by the gateway
+	Interface string
+	//Metric route priority, 0 for the kernel default
+	Metric int
+	//Table routing table id, 0 for the main table
+	Table int
+}
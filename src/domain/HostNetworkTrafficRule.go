@@ -11,4 +11,22 @@ type HostNetworkTrafficRule struct {
 	Source string
 	//Destination packets destination
 	Destination string
+	//Protocol packets protocol: tcp, udp or icmp, empty for any protocol
+	Protocol string
+	//SourcePorts packets source ports, single port "67", range "1000:2000" or list "67,69", requires tcp or udp protocol
+	SourcePorts string
+	//DestinationPorts packets destination ports in the same format as SourcePorts
+	DestinationPorts string
+	//InInterface name of the interface via which a packet was received
+	InInterface string
+	//OutInterface name of the interface via which a packet is going to be sent
+	OutInterface string
+	//State comma separated conntrack states like NEW, RELATED, ESTABLISHED
+	State string
+	//Comment rule comment
+	Comment string
+	//To DNAT or SNAT target address like 192.168.1.10 or 192.168.1.10:80
+	To string
+	//Position 1-based rule position in the chain, 0 to append the rule to the end of the chain
+	Position int
 }
@@ -0,0 +1,8 @@
+package domain
+
+//HostNetworkVeth is a struct for the end of the virtual ethernet pair
+type HostNetworkVeth struct {
+	HostNetworkLink
+	//Peer name of the other end of the pair, it can be in a network namespace
+	Peer string
+}
@@ -8,6 +8,8 @@ type TFTPConfig struct {
 	Address string
 	//Port TFTP server port
 	Port string
+	//Namespace network namespace where the server is running, empty for the host network namespace
+	Namespace string
 	//Enabled TFTP server startup status
 	Enabled bool
 }
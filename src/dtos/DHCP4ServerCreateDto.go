@@ -10,6 +10,8 @@ type DHCP4ServerCreateDto struct {
 	ServerID string
 	//Interface name
 	Interface string
+	//Namespace network namespace name where the server is running, empty for the host network namespace
+	Namespace string
 	//Gateway in ipv4 format
 	Gateway string
 	//DNS servers, separated by ";"
@@ -13,6 +13,8 @@ type DHCP4ServerDto struct {
 	ServerID string
 	//Interface name
 	Interface string
+	//Namespace network namespace name where the server is running, empty for the host network namespace
+	Namespace string
 	//Gateway in ipv4 format
 	Gateway string
 	//DNS servers, separated by ";"
@@ -2,6 +2,8 @@ package dtos
 
 //DHCP4ServerUpdateDto DTO for updating DHCP v4 server
 type DHCP4ServerUpdateDto struct {
+	//Namespace network namespace name where the server is running, empty for the host network namespace
+	Namespace string
 	//DNS servers, separated by ";"
 	DNS string
 	//NTP IP address or dns name of NTP server
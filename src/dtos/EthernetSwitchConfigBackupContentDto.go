@@ -0,0 +1,8 @@
+package dtos
+
+//EthernetSwitchConfigBackupContentDto ethernet switch configuration backup with configuration text
+type EthernetSwitchConfigBackupContentDto struct {
+	EthernetSwitchConfigBackupDto
+	//Config running configuration text
+	Config string
+}
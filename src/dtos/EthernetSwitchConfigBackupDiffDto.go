@@ -0,0 +1,15 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchConfigBackupDiffDto difference between two ethernet switch configuration backups
+type EthernetSwitchConfigBackupDiffDto struct {
+	//FromID ID of the original backup
+	FromID uuid.UUID
+	//ToID ID of the changed backup
+	ToID uuid.UUID
+	//Equal true if configurations are the same
+	Equal bool
+	//Diff unified diff of the configurations
+	Diff string
+}
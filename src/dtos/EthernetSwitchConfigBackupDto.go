@@ -0,0 +1,14 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchConfigBackupDto ethernet switch configuration backup response dto, CreatedAt is the backup time
+type EthernetSwitchConfigBackupDto struct {
+	BaseDto[uuid.UUID]
+	//EthernetSwitchID ethernet switch ID
+	EthernetSwitchID uuid.UUID
+	//Hash SHA-256 hash of the configuration in hex
+	Hash string
+	//Size configuration size in bytes
+	Size int
+}
@@ -0,0 +1,19 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchConfigDiffDto difference between the switch configuration and stored entity
+type EthernetSwitchConfigDiffDto struct {
+	//EntityType - type of the entity: "port" or "vlan"
+	EntityType string
+	//EntityID - ID of the stored entity, empty if entity is not stored
+	EntityID uuid.UUID
+	//Name - port name or VLAN ID
+	Name string
+	//Field - different field name
+	Field string
+	//SwitchValue - value on the switch
+	SwitchValue string
+	//StoredValue - value of the stored entity
+	StoredValue string
+}
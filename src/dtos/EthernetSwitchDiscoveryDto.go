@@ -0,0 +1,11 @@
+package dtos
+
+//EthernetSwitchDiscoveryDto result of the ethernet switch configuration import
+type EthernetSwitchDiscoveryDto struct {
+	//ImportedPorts - ports created from the switch configuration
+	ImportedPorts []EthernetSwitchPortDto
+	//ImportedVLANs - VLANs created from the switch configuration
+	ImportedVLANs []EthernetSwitchVLANDto
+	//Conflicts - differences between the switch and already existing entities, existing entities are not changed
+	Conflicts []EthernetSwitchConfigDiffDto
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+//EthernetSwitchDriftDto result of the comparison of the switch configuration with the stored one
+type EthernetSwitchDriftDto struct {
+	//CheckedAt - time of the comparison
+	CheckedAt time.Time
+	//InSync - true if the switch configuration matches the stored one
+	InSync bool
+	//Error - error that occurred while reading the switch configuration, empty if there is no error
+	Error string
+	//Differences - differences between the switch configuration and the stored entities
+	Differences []EthernetSwitchConfigDiffDto
+}
@@ -0,0 +1,9 @@
+package dtos
+
+//EthernetSwitchFirmwareDto ethernet switch firmware information dto
+type EthernetSwitchFirmwareDto struct {
+	//FirmwareVersion installed firmware version
+	FirmwareVersion string
+	//HardwareVersion hardware revision
+	HardwareVersion string
+}
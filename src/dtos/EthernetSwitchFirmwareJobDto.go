@@ -0,0 +1,30 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//EthernetSwitchFirmwareJobDto ethernet switch firmware upgrade job dto
+type EthernetSwitchFirmwareJobDto struct {
+	//ID job ID
+	ID uuid.UUID
+	//EthernetSwitchID ethernet switch ID
+	EthernetSwitchID uuid.UUID
+	//Stage current job stage: "staging", "transferring", "rebooting", "verifying", "completed" or "failed"
+	Stage string
+	//Progress job progress in percents
+	Progress int
+	//RebootDetected true if the switch was unreachable or its firmware version changed after the upgrade started
+	RebootDetected bool
+	//PreviousVersion firmware version before the upgrade
+	PreviousVersion string
+	//CurrentVersion firmware version after the upgrade
+	CurrentVersion string
+	//Error job error, empty if there is no error
+	Error string
+	//StartedAt job start time
+	StartedAt time.Time
+	//FinishedAt job finish time, zero if the job is running
+	FinishedAt time.Time
+}
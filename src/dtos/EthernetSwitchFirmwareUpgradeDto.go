@@ -0,0 +1,13 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchFirmwareUpgradeDto ethernet switch firmware upgrade request dto
+type EthernetSwitchFirmwareUpgradeDto struct {
+	//TFTPServerID ID of the RoL TFTP server used to stage the image
+	TFTPServerID uuid.UUID
+	//ImagePath path to the firmware image file on the RoL host
+	ImagePath string
+	//TFTPAddress IP address of the TFTP server reachable from the switch, TFTP server address is used if empty
+	TFTPAddress string
+}
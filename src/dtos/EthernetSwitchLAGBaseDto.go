@@ -0,0 +1,11 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchLAGBaseDto base dto for ethernet switch link aggregation group
+type EthernetSwitchLAGBaseDto struct {
+	//Mode LACP mode: "active", "passive" or "static" for the group without LACP
+	Mode string
+	//MemberPorts slice of member ports IDs
+	MemberPorts []uuid.UUID
+}
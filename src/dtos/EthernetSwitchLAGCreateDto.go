@@ -0,0 +1,8 @@
+package dtos
+
+//EthernetSwitchLAGCreateDto ethernet switch link aggregation group create dto
+type EthernetSwitchLAGCreateDto struct {
+	EthernetSwitchLAGBaseDto
+	//GroupID number of the port channel on the switch
+	GroupID int
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchLAGDto ethernet switch link aggregation group response dto
+type EthernetSwitchLAGDto struct {
+	BaseDto[uuid.UUID]
+	EthernetSwitchLAGBaseDto
+	//GroupID number of the port channel on the switch
+	GroupID int
+	//Name LAG name in the switch configuration
+	Name string
+	//EthernetSwitchID ethernet switch ID
+	EthernetSwitchID uuid.UUID
+}
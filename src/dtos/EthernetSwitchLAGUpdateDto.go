@@ -0,0 +1,6 @@
+package dtos
+
+//EthernetSwitchLAGUpdateDto ethernet switch link aggregation group update dto
+type EthernetSwitchLAGUpdateDto struct {
+	EthernetSwitchLAGBaseDto
+}
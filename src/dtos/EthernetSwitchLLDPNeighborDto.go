@@ -0,0 +1,15 @@
+package dtos
+
+//EthernetSwitchLLDPNeighborDto neighbor device discovered by LLDP on the switch port
+type EthernetSwitchLLDPNeighborDto struct {
+	//ChassisID - neighbor chassis ID, usually MAC address
+	ChassisID string
+	//PortID - neighbor port ID, can be MAC address or interface name
+	PortID string
+	//SystemName - neighbor system name
+	SystemName string
+	//PortDescription - neighbor port description
+	PortDescription string
+	//ManagementAddress - neighbor management address
+	ManagementAddress string
+}
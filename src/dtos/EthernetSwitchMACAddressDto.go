@@ -0,0 +1,13 @@
+package dtos
+
+//EthernetSwitchMACAddressDto MAC address learned on the switch port
+type EthernetSwitchMACAddressDto struct {
+	//MAC - MAC address in the format like 00:00:00:00:00:00
+	MAC string
+	//VLANID - VLAN where the address is learned
+	VLANID int
+	//Type - entry type, for example "dynamic" or "static"
+	Type string
+	//Leases - DHCP v4 leases of this MAC address
+	Leases []DHCP4LeaseDto
+}
@@ -0,0 +1,15 @@
+package dtos
+
+//EthernetSwitchPortBulkOperationDto dto of the operation that is applied to the selected ports
+type EthernetSwitchPortBulkOperationDto struct {
+	//Selector ports selector
+	Selector EthernetSwitchPortBulkSelectorDto
+	//Operation can be: "poe-on", "poe-off", "poe-cycle", "set-pvid", "add-vlan", "remove-vlan"
+	Operation string
+	//PVID new ports PVID for "set-pvid" operation
+	PVID int
+	//VLANID 802.1Q VLAN ID for "add-vlan" and "remove-vlan" operations, VLAN must exist on the switch
+	VLANID int
+	//Tagged add ports to the VLAN as tagged for "add-vlan" operation
+	Tagged bool
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchPortBulkResultDto result of the bulk operation for one port
+type EthernetSwitchPortBulkResultDto struct {
+	//EthernetSwitchID ethernet switch ID
+	EthernetSwitchID uuid.UUID
+	//PortID port ID
+	PortID uuid.UUID
+	//PortName port name
+	PortName string
+	//Status can be: "changed", "unchanged", "skipped", "failed"
+	Status string
+	//Error reason of the skipped or failed operation
+	Error string
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchPortBulkSelectorDto selector of the ports for the bulk operation,
+//port is selected if it matches all specified conditions
+type EthernetSwitchPortBulkSelectorDto struct {
+	//SwitchIDs IDs of the switches, all switches if empty. Ignored when the switch is set in the request path
+	SwitchIDs []uuid.UUID
+	//PortIDs IDs of the ports
+	PortIDs []uuid.UUID
+	//NamePattern shell pattern of the port name, for example "gi1/0/1*"
+	NamePattern string
+	//MemberOfVLAN 802.1Q ID of the VLAN that contains the port as tagged or untagged
+	MemberOfVLAN int
+}
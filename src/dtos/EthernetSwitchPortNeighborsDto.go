@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchPortNeighborsDto devices connected to the switch port
+type EthernetSwitchPortNeighborsDto struct {
+	//PortID - ethernet switch port ID
+	PortID uuid.UUID
+	//PortName - ethernet switch port name
+	PortName string
+	//LLDPNeighbors - neighbors discovered by LLDP on the port
+	LLDPNeighbors []EthernetSwitchLLDPNeighborDto
+	//MACAddresses - MAC addresses learned on the port
+	MACAddresses []EthernetSwitchMACAddressDto
+	//Suggestions - devices that are probably connected to the port
+	Suggestions []EthernetSwitchPortSuggestionDto
+}
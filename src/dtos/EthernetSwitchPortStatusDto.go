@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+//EthernetSwitchPortStatusDto actual state of the ethernet switch port
+type EthernetSwitchPortStatusDto struct {
+	//ReadAt - time when the status was read from the switch
+	ReadAt time.Time
+	//AdminUp - true if the port is enabled by configuration
+	AdminUp bool
+	//OperUp - true if the port link is up
+	OperUp bool
+	//Speed - negotiated speed, for example "1000M", empty if link is down
+	Speed string
+	//Duplex - negotiated duplex, for example "Full", empty if link is down
+	Duplex string
+	//RxBytes - received bytes counter
+	RxBytes uint64
+	//TxBytes - transmitted bytes counter
+	TxBytes uint64
+	//RxErrors - receive errors counter
+	RxErrors uint64
+	//TxErrors - transmit errors counter
+	TxErrors uint64
+	//POEPower - power drawn by the powered device in watts
+	POEPower float64
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//EthernetSwitchPortSuggestionDto suggestion that the device is connected to the switch port
+type EthernetSwitchPortSuggestionDto struct {
+	//MAC - device MAC address
+	MAC string
+	//IP - device IP address from the DHCP v4 lease
+	IP string
+	//LeaseID - ID of the DHCP v4 lease of the device
+	LeaseID uuid.UUID
+	//Confidence - "high" if the device is the only one seen on the port, otherwise "low"
+	Confidence string
+	//Reason - why the device is suggested
+	Reason string
+}
@@ -0,0 +1,15 @@
+package dtos
+
+//FabricVLANCreateDto fabric VLAN create dto
+type FabricVLANCreateDto struct {
+	//Name VLAN name
+	Name string
+	//VlanID VLAN ID on the switches and on the host
+	VlanID int
+	//Members trunk and access ports of the switches
+	Members []FabricVLANMemberBaseDto
+	//HostParent name of the host parent interface, empty if the VLAN is not needed on the host
+	HostParent string
+	//HostAddresses addresses of the host VLAN interface
+	HostAddresses []string
+}
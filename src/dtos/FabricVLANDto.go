@@ -0,0 +1,26 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//FabricVLANDto fabric VLAN response dto
+type FabricVLANDto struct {
+	BaseDto[uuid.UUID]
+	//Name VLAN name
+	Name string
+	//VlanID VLAN ID on the switches and on the host
+	VlanID int
+	//Status can be: "pending", "configured", "partial", "failed"
+	Status string
+	//Members trunk and access ports of the switches
+	Members []FabricVLANMemberDto
+	//HostParent name of the host parent interface
+	HostParent string
+	//HostAddresses addresses of the host VLAN interface
+	HostAddresses []string
+	//HostInterface name of the host VLAN interface
+	HostInterface string
+	//HostStatus can be: "", "pending", "configured", "failed"
+	HostStatus string
+	//HostError reason of the failed host VLAN configuration
+	HostError string
+}
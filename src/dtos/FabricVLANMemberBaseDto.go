@@ -0,0 +1,13 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//FabricVLANMemberBaseDto base dto for fabric VLAN member
+type FabricVLANMemberBaseDto struct {
+	//EthernetSwitchID ethernet switch ID
+	EthernetSwitchID uuid.UUID
+	//PortID ethernet switch port or LAG ID
+	PortID uuid.UUID
+	//Tagged port is a trunk port of the VLAN, otherwise it is an access port
+	Tagged bool
+}
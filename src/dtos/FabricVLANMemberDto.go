@@ -0,0 +1,13 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//FabricVLANMemberDto fabric VLAN member response dto
+type FabricVLANMemberDto struct {
+	BaseDto[uuid.UUID]
+	FabricVLANMemberBaseDto
+	//Status can be: "pending", "configured", "failed"
+	Status string
+	//Error reason of the failed port configuration
+	Error string
+}
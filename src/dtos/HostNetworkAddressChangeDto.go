@@ -0,0 +1,13 @@
+package dtos
+
+//HostNetworkAddressChangeDto link address addition or deletion dto
+type HostNetworkAddressChangeDto struct {
+	//Action "add" or "delete"
+	Action string
+	//LinkName link name
+	LinkName string
+	//Namespace network namespace of the link, empty for the host links
+	Namespace string
+	//Address address in CIDR notation
+	Address string
+}
@@ -0,0 +1,9 @@
+package dtos
+
+//HostNetworkBondBaseDto base dto for host network bond
+type HostNetworkBondBaseDto struct {
+	//Addresses list
+	Addresses []string
+	//Slaves slice of slaves interfaces names
+	Slaves []string
+}
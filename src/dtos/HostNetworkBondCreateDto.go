@@ -0,0 +1,12 @@
+package dtos
+
+//HostNetworkBondCreateDto host network bond create dto
+type HostNetworkBondCreateDto struct {
+	//Name interface name, bond will be named rol.bond.{Name}
+	Name string
+	//Mode bonding mode: "active-backup", "802.3ad" or "balance-alb"
+	Mode string
+	//Miimon MII link monitoring frequency in milliseconds, 0 disables the monitoring
+	Miimon int
+	HostNetworkBondBaseDto
+}
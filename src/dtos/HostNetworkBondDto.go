@@ -0,0 +1,12 @@
+package dtos
+
+//HostNetworkBondDto host network bond response dto
+type HostNetworkBondDto struct {
+	//Name interface full name
+	Name string
+	//Mode bonding mode
+	Mode string
+	//Miimon MII link monitoring frequency in milliseconds
+	Miimon int
+	HostNetworkBondBaseDto `yaml:",inline"`
+}
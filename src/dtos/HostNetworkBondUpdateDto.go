@@ -0,0 +1,6 @@
+package dtos
+
+//HostNetworkBondUpdateDto host network bond update dto
+type HostNetworkBondUpdateDto struct {
+	HostNetworkBondBaseDto
+}
@@ -6,4 +6,8 @@ type HostNetworkBridgeBaseDto struct {
 	Addresses []string
 	//Slaves slice of slaves interfaces names
 	Slaves []string
+	//Nat give the bridge networks an internet access through masquerade on the upstream interface
+	Nat bool
+	//UpstreamInterface name of the interface through which NAT traffic of the bridge is sent, required for NAT
+	UpstreamInterface string
 }
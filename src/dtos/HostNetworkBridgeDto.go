@@ -4,5 +4,5 @@ package dtos
 type HostNetworkBridgeDto struct {
 	//Name interface full name
 	Name string
-	HostNetworkBridgeBaseDto
+	HostNetworkBridgeBaseDto `yaml:",inline"`
 }
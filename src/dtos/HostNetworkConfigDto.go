@@ -0,0 +1,20 @@
+package dtos
+
+//HostNetworkConfigDto desired host network configuration dto, only RoL links and rules of RoL chains are managed
+type HostNetworkConfigDto struct {
+	//Vlans RoL vlans, vlan name is rol.{Parent}.{VlanID}
+	Vlans []HostNetworkVlanDto
+	//Bridges RoL bridges, bridge name is rol.br.{name}
+	Bridges []HostNetworkBridgeDto
+	//Bonds RoL bonds, bond name is rol.bond.{name}
+	Bonds []HostNetworkBondDto
+	//Veths RoL veth pairs ends that are left on the host, ends names are rol.veth.{name} and rol.peer.{name}
+	Veths []HostNetworkVethDto
+	//Namespaces RoL network namespaces with the links that are moved into them, namespace name is rol.ns.{name}
+	Namespaces []HostNetworkNamespaceDto
+	//Routes static routes that are managed by RoL, routes of other protocols are not listed
+	Routes []HostNetworkRouteDto
+	//TrafficRules traffic rules of the RoL chains separated by tables, rules with rol.nat:{bridge} comments
+	//are generated from the bridges NAT settings, so they are ignored
+	TrafficRules HostNetworkTrafficRulesDto
+}
@@ -0,0 +1,8 @@
+package dtos
+
+//HostNetworkConfigRevisionContentDto saved host network configuration revision with the configuration
+type HostNetworkConfigRevisionContentDto struct {
+	HostNetworkConfigRevisionDto
+	//Config saved configuration, it can be applied with /host/network/apply
+	Config HostNetworkConfigDto
+}
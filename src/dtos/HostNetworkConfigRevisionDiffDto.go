@@ -0,0 +1,15 @@
+package dtos
+
+import "github.com/google/uuid"
+
+//HostNetworkConfigRevisionDiffDto difference between two saved host network configuration revisions
+type HostNetworkConfigRevisionDiffDto struct {
+	//FromID ID of the original revision
+	FromID uuid.UUID
+	//ToID ID of the changed revision
+	ToID uuid.UUID
+	//Equal true if configurations are the same
+	Equal bool
+	//Diff unified diff of the configurations in yaml
+	Diff string
+}
@@ -0,0 +1,18 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//HostNetworkConfigRevisionDto saved host network configuration revision dto
+type HostNetworkConfigRevisionDto struct {
+	//ID revision ID
+	ID uuid.UUID
+	//CreatedAt time when the configuration was saved
+	CreatedAt time.Time
+	//Author who confirmed and saved the configuration
+	Author string
+	//RequestID ID of the API request that saved the configuration, empty if it was saved by RoL itself
+	RequestID uuid.UUID
+}
@@ -0,0 +1,17 @@
+package dtos
+
+//HostNetworkLinkChangeDto link creation or deletion dto
+type HostNetworkLinkChangeDto struct {
+	//Name full link name
+	Name string
+	//Type link type: vlan, bridge, bond or veth
+	Type string
+	//Parent vlan parent interface name
+	Parent string
+	//VlanID vlan id
+	VlanID int
+	//Mode bonding mode
+	Mode string
+	//Miimon bond MII link monitoring frequency in milliseconds
+	Miimon int
+}
@@ -0,0 +1,7 @@
+package dtos
+
+//HostNetworkNamespaceBaseDto base dto for host network namespace
+type HostNetworkNamespaceBaseDto struct {
+	//Links host links that are moved into the namespace
+	Links []HostNetworkNamespaceLinkDto
+}
@@ -0,0 +1,8 @@
+package dtos
+
+//HostNetworkNamespaceCreateDto host network namespace create dto
+type HostNetworkNamespaceCreateDto struct {
+	//Name namespace name, namespace full name will be rol.ns.{Name}
+	Name string
+	HostNetworkNamespaceBaseDto
+}
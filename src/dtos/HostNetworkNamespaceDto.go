@@ -0,0 +1,8 @@
+package dtos
+
+//HostNetworkNamespaceDto host network namespace response dto
+type HostNetworkNamespaceDto struct {
+	//Name namespace full name
+	Name string
+	HostNetworkNamespaceBaseDto `yaml:",inline"`
+}
@@ -0,0 +1,11 @@
+package dtos
+
+//HostNetworkNamespaceLinkChangeDto moving of the link into the network namespace or back to the host dto
+type HostNetworkNamespaceLinkChangeDto struct {
+	//Action "add" to move the link into the namespace or "delete" to move it back to the host
+	Action string
+	//LinkName link name
+	LinkName string
+	//Namespace network namespace name
+	Namespace string
+}
@@ -0,0 +1,9 @@
+package dtos
+
+//HostNetworkNamespaceLinkDto dto of the link that is moved into the network namespace
+type HostNetworkNamespaceLinkDto struct {
+	//Name link name
+	Name string
+	//Addresses list of the link in the namespace
+	Addresses []string
+}
@@ -0,0 +1,6 @@
+package dtos
+
+//HostNetworkNamespaceUpdateDto host network namespace update dto
+type HostNetworkNamespaceUpdateDto struct {
+	HostNetworkNamespaceBaseDto
+}
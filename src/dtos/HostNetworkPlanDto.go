@@ -0,0 +1,28 @@
+package dtos
+
+//HostNetworkPlanDto changes that converge the host network to the desired configuration,
+//changes are applied in the order of the fields
+type HostNetworkPlanDto struct {
+	//DeleteRoutes routes to delete
+	DeleteRoutes []HostNetworkRouteDto
+	//ReplaceLinks links which attributes can't be changed on the host, they are deleted and created again
+	ReplaceLinks []HostNetworkLinkChangeDto
+	//CreateLinks links to create, they are set up after creation
+	CreateLinks []HostNetworkLinkChangeDto
+	//CreateNamespaces names of the network namespaces to create
+	CreateNamespaces []string
+	//NamespaceLinks links to move into namespaces or back to the host
+	NamespaceLinks []HostNetworkNamespaceLinkChangeDto
+	//Addresses addresses to add or delete
+	Addresses []HostNetworkAddressChangeDto
+	//Slaves slaves to add to masters or remove from them
+	Slaves []HostNetworkSlaveChangeDto
+	//CreateRoutes routes to create
+	CreateRoutes []HostNetworkRouteDto
+	//DeleteLinks links to delete
+	DeleteLinks []HostNetworkLinkChangeDto
+	//DeleteNamespaces names of the network namespaces to delete
+	DeleteNamespaces []string
+	//TrafficRules traffic rules to create or delete
+	TrafficRules []HostNetworkTrafficRuleChangeDto
+}
@@ -0,0 +1,16 @@
+// Package dtos stores all data transfer objects
+package dtos
+
+//HostNetworkRouteBaseDto base dto for host network static route
+type HostNetworkRouteBaseDto struct {
+	//Destination destination network in CIDR notation, 0.0.0.0/0 or ::/0 for the default route
+	Destination string
+	//Gateway next hop address of the same family as the destination, empty for the routes without gateway
+	Gateway string
+	//Interface name of the outgoing interface, empty to resolve it by the gateway
+	Interface string
+	//Metric route priority, 0 for the kernel default
+	Metric int
+	//Table routing table id, 0 for the main table
+	Table int
+}
@@ -0,0 +1,7 @@
+// Package dtos stores all data transfer objects
+package dtos
+
+//HostNetworkRouteCreateDto create dto for host network static route
+type HostNetworkRouteCreateDto struct {
+	HostNetworkRouteBaseDto
+}
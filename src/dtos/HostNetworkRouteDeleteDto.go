@@ -0,0 +1,7 @@
+// Package dtos stores all data transfer objects
+package dtos
+
+//HostNetworkRouteDeleteDto delete dto for host network static route
+type HostNetworkRouteDeleteDto struct {
+	HostNetworkRouteBaseDto
+}
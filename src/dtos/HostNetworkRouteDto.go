@@ -0,0 +1,7 @@
+// Package dtos stores all data transfer objects
+package dtos
+
+//HostNetworkRouteDto dto for host network static route entity
+type HostNetworkRouteDto struct {
+	HostNetworkRouteBaseDto `yaml:",inline"`
+}
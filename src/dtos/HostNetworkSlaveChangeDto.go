@@ -0,0 +1,11 @@
+package dtos
+
+//HostNetworkSlaveChangeDto link master setting or removing dto
+type HostNetworkSlaveChangeDto struct {
+	//Action "add" to set the master or "delete" to remove the slave from the master
+	Action string
+	//Slave slave link name
+	Slave string
+	//Master master link name
+	Master string
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+//HostNetworkStatusDto host network changes status dto
+type HostNetworkStatusDto struct {
+	//HasUnsavedChanges applied changes are not confirmed and saved yet
+	HasUnsavedChanges bool
+	//ConfirmDeadline time when unconfirmed changes will be reset to the saved configuration,
+	//nil if there is no pending reset
+	ConfirmDeadline *time.Time
+	//ResetError error of the last failed automatic reset of unconfirmed changes, the reset is retried
+	//at the ConfirmDeadline, empty if the reset didn't fail
+	ResetError string
+}
@@ -0,0 +1,11 @@
+package dtos
+
+//HostNetworkTrafficRuleChangeDto traffic rule creation or deletion dto
+type HostNetworkTrafficRuleChangeDto struct {
+	//Action "add" or "delete"
+	Action string
+	//Table netfilter table
+	Table string
+	//Rule traffic rule, rule is created at its position
+	Rule HostNetworkTrafficRuleDto
+}
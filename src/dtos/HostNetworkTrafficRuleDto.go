@@ -3,5 +3,5 @@ package dtos
 
 //HostNetworkTrafficRuleDto dto for host network traffic rule entity
 type HostNetworkTrafficRuleDto struct {
-	HostNetworkTrafficRuleBaseDto
+	HostNetworkTrafficRuleBaseDto `yaml:",inline"`
 }
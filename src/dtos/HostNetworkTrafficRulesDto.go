@@ -0,0 +1,15 @@
+package dtos
+
+//HostNetworkTrafficRulesDto traffic rules separated by tables, rules chains are RoL chains like ROL-INPUT
+type HostNetworkTrafficRulesDto struct {
+	//Filter 'filter' table rules
+	Filter []HostNetworkTrafficRuleDto
+	//NAT 'nat' table rules
+	NAT []HostNetworkTrafficRuleDto
+	//Mangle 'mangle' table rules
+	Mangle []HostNetworkTrafficRuleDto
+	//Raw 'raw' table rules
+	Raw []HostNetworkTrafficRuleDto
+	//Security 'security' table rules
+	Security []HostNetworkTrafficRuleDto
+}
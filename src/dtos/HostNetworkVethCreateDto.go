@@ -0,0 +1,9 @@
+package dtos
+
+//HostNetworkVethCreateDto host network virtual ethernet pair create dto
+type HostNetworkVethCreateDto struct {
+	//Name veth pair name, ends of the pair will be rol.veth.{Name} and rol.peer.{Name}
+	Name string
+	//Addresses list of the rol.veth.{Name} end
+	Addresses []string
+}
@@ -0,0 +1,11 @@
+package dtos
+
+//HostNetworkVethDto host network virtual ethernet pair end response dto
+type HostNetworkVethDto struct {
+	//Name interface full name
+	Name string
+	//Peer name of the other end of the pair
+	Peer string
+	//Addresses list
+	Addresses []string
+}
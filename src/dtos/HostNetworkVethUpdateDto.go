@@ -0,0 +1,7 @@
+package dtos
+
+//HostNetworkVethUpdateDto host network virtual ethernet pair end update dto
+type HostNetworkVethUpdateDto struct {
+	//Addresses list
+	Addresses []string
+}
@@ -7,6 +7,8 @@ type TFTPServerBaseDto struct {
 	Address string
 	//Port TFTP server port
 	Port string
+	//Namespace network namespace name where the server is running, empty for the host network namespace
+	Namespace string
 	//Enabled TFTP server startup status
 	Enabled bool
 }
@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"io"
+	"os"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/domain"
+	"strings"
+)
+
+const (
+	//credentialsKeyEnv environment variable with the current credentials encryption key
+	credentialsKeyEnv = "ROL_CREDENTIALS_KEY"
+	//credentialsPreviousKeysEnv environment variable with comma separated previous credentials encryption keys
+	credentialsPreviousKeysEnv = "ROL_CREDENTIALS_PREVIOUS_KEYS"
+	//aesSecretPrefix prefix of the encrypted secret, full format is "enc:<version>:<key id>:<base64 nonce and ciphertext>"
+	aesSecretPrefix = "enc:"
+	//aesSecretVersion version of the secrets encrypted with the key derived by argon2id
+	aesSecretVersion = "v1"
+	//aesKeySalt salt of the key derivation. The salt is fixed because the configured key is a random
+	//per-install secret, not a user password: it can't be found by a precomputed dictionary, so a random salt
+	//doesn't add protection. The fixed salt only separates the derived key from other uses of the same secret
+	//and keeps the derived key reproducible from the configured key alone, so the key rotation and a database
+	//restore on the other host need nothing but the key.
+	aesKeySalt = "rol.credentials.key"
+)
+
+//AESSecretCipher is implementation of interfaces.ISecretCipher with AES-256-GCM.
+//Each encrypted secret contains ID of the key, so secrets encrypted with the previous keys
+//can be decrypted after the key rotation.
+type AESSecretCipher struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+}
+
+//NewAESSecretCipher constructor for AES secrets cipher. Keys are taken from the environment variables
+//or from the application configuration. If the key isn't set, the cipher is not created unless
+//storing of the secrets in plaintext is explicitly allowed.
+//
+//Params:
+//	config - application configuration
+//Return:
+//	interfaces.ISecretCipher - secrets cipher
+//	error - if an error occurs, otherwise nil
+func NewAESSecretCipher(config *domain.AppConfig) (interfaces.ISecretCipher, error) {
+	currentKey := config.Credentials.Key
+	if envKey := os.Getenv(credentialsKeyEnv); envKey != "" {
+		currentKey = envKey
+	}
+	previousKeys := config.Credentials.PreviousKeys
+	if envKeys := os.Getenv(credentialsPreviousKeysEnv); envKeys != "" {
+		previousKeys = strings.Split(envKeys, ",")
+	}
+	secretCipher := &AESSecretCipher{keys: map[string]cipher.AEAD{}}
+	for _, key := range previousKeys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, err := secretCipher.addKey(key); err != nil {
+			return nil, err
+		}
+	}
+	if currentKey == "" {
+		if !config.Credentials.AllowPlaintext {
+			return nil, errors.Internal.Newf("credentials encryption key is not set, set it in the configuration or "+
+				"in the %s environment variable, or allow plaintext credentials explicitly", credentialsKeyEnv)
+		}
+		return secretCipher, nil
+	}
+	keyID, err := secretCipher.addKey(currentKey)
+	if err != nil {
+		return nil, err
+	}
+	secretCipher.currentKeyID = keyID
+	return secretCipher, nil
+}
+
+//addKey derives AES-256 key from the configured key by argon2id and registers it by the version and the key ID
+func (a *AESSecretCipher) addKey(key string) (string, error) {
+	return a.addDerivedKey(aesSecretVersion, argon2.IDKey([]byte(key), []byte(aesKeySalt), 1, 64*1024, 4, 32))
+}
+
+//addDerivedKey registers AES-256 key by the version and its ID
+func (a *AESSecretCipher) addDerivedKey(version string, derivedKey []byte) (string, error) {
+	keyHash := sha256.Sum256(derivedKey)
+	keyID := hex.EncodeToString(keyHash[:4])
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "failed to create AES cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "failed to create GCM cipher")
+	}
+	a.keys[version+":"+keyID] = aead
+	return keyID, nil
+}
+
+//parseSecret splits encrypted secret to the version, the key ID and the sealed data
+func parseSecret(secret string) (string, string, []byte, bool) {
+	if !strings.HasPrefix(secret, aesSecretPrefix) {
+		return "", "", nil, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(secret, aesSecretPrefix), ":", 3)
+	if len(parts) != 3 {
+		return "", "", nil, false
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", "", nil, false
+	}
+	return parts[0], parts[1], sealed, true
+}
+
+//Encrypt secret with the current key, empty secret and secret without configured key are returned as is
+//
+//Params:
+//	plaintext - secret to encrypt
+//Return:
+//	string - encrypted secret that can be stored
+//	error - if an error occurs, otherwise nil
+func (a *AESSecretCipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" || a.currentKeyID == "" {
+		return plaintext, nil
+	}
+	aead := a.keys[aesSecretVersion+":"+a.currentKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Internal.Wrap(err, "failed to generate nonce")
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return fmt.Sprintf("%s%s:%s:%s", aesSecretPrefix, aesSecretVersion, a.currentKeyID,
+		base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+//Decrypt secret encrypted with the current or one of the previous keys, not encrypted secret is returned as is
+//
+//Params:
+//	secret - stored secret
+//Return:
+//	string - decrypted secret
+//	error - if an error occurs, otherwise nil
+func (a *AESSecretCipher) Decrypt(secret string) (string, error) {
+	version, keyID, sealed, encrypted := parseSecret(secret)
+	if !encrypted {
+		return secret, nil
+	}
+	aead, found := a.keys[version+":"+keyID]
+	if !found {
+		return "", errors.Internal.Newf("credentials encryption key %s of the version %s is not configured", keyID, version)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.Internal.New("encrypted secret is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "failed to decrypt secret")
+	}
+	return string(plaintext), nil
+}
+
+//NeedsReEncryption checks that the stored secret is not encrypted or encrypted with one of the previous keys
+//
+//Params:
+//	secret - stored secret
+//Return:
+//	bool - true if the secret must be encrypted again with the current key
+func (a *AESSecretCipher) NeedsReEncryption(secret string) bool {
+	if secret == "" || a.currentKeyID == "" {
+		return false
+	}
+	version, keyID, _, encrypted := parseSecret(secret)
+	return !encrypted || version != aesSecretVersion || keyID != a.currentKeyID
+}
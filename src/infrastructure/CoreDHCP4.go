@@ -26,6 +26,7 @@ func initializeCoreDHCPPlugins(leasesRepo interfaces.IGenericRepository[uuid.UUI
 		NewRangeRepositoryPlugin(leasesRepo),
 		&pluginRouter.Plugin,
 		&pluginServerid.Plugin,
+		NewBroadcastReplyPlugin(),
 	}
 	for _, plugin := range pluginsSlice {
 		if err := plugins.RegisterPlugin(plugin); err != nil {
@@ -36,9 +37,10 @@ func initializeCoreDHCPPlugins(leasesRepo interfaces.IGenericRepository[uuid.UUI
 }
 
 type coreDHCP4Server struct {
-	config *config.Config
-	server *server.Servers
-	state  domain.DHCPServerState
+	config    *config.Config
+	server    *server.Servers
+	state     domain.DHCPServerState
+	namespace string
 }
 
 //NewCoreDHCP4Server constructor for core DHCP v4 server
@@ -67,6 +69,7 @@ func (s *coreDHCP4Server) ReloadConfiguration(dhcp4config domain.DHCP4Config) er
 	if len(startEndIPs) < 2 {
 		return errors.Internal.Newf("incorrect ip range: %s", dhcp4config.Range)
 	}
+	s.namespace = dhcp4config.Namespace
 	s.config = &config.Config{
 		Server6: nil,
 		Server4: &config.ServerConfig{
@@ -104,12 +107,20 @@ func (s *coreDHCP4Server) ReloadConfiguration(dhcp4config domain.DHCP4Config) er
 			},
 		},
 	}
+	if dhcp4config.Namespace != "" {
+		s.config.Server4.Plugins = append(s.config.Server4.Plugins, config.PluginConfig{Name: "broadcast_reply"})
+	}
 	return nil
 }
 
 //Start DHCP v4 server
 func (s *coreDHCP4Server) Start() error {
-	coredhcp, err := server.Start(s.config)
+	var coredhcp *server.Servers
+	err := runInNetworkNamespace(s.namespace, func() error {
+		var err error
+		coredhcp, err = server.Start(s.config)
+		return err
+	})
 	if err != nil {
 		s.state = domain.DHCPStateError
 		return errors.Internal.Wrap(err, "failed to start dhcp v4 server")
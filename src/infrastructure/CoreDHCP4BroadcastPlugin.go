@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"github.com/coredhcp/coredhcp/handler"
+	"github.com/coredhcp/coredhcp/plugins"
+	"github.com/insomniacslk/dhcp/dhcpv4"
+)
+
+//NewBroadcastReplyPlugin constructor for plugin that forces broadcast replies to the clients without address.
+//Layer 2 unicast replies are sent through the raw socket of the interface that is found in the host network namespace,
+//so they can't be used by the server that is running in other network namespace
+func NewBroadcastReplyPlugin() *plugins.Plugin {
+	return &plugins.Plugin{
+		Name:   "broadcast_reply",
+		Setup4: setupBroadcastReply,
+	}
+}
+
+func setupBroadcastReply(args ...string) (handler.Handler4, error) {
+	return broadcastReplyHandler4, nil
+}
+
+func broadcastReplyHandler4(req, resp *dhcpv4.DHCPv4) (*dhcpv4.DHCPv4, bool) {
+	req.SetBroadcast()
+	return resp, false
+}
@@ -2,33 +2,55 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"go.uber.org/fx"
 	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/domain"
+	"sync"
 )
 
 //EthernetSwitchManagerProvider struct for switch manager getter
 type EthernetSwitchManagerProvider struct {
-	switchRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch]
-	managers   map[uuid.UUID]interfaces.IEthernetSwitchManager
+	switchRepo    interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch]
+	sessionConfig domain.EthernetSwitchSessionConfig
+	telnetPort    int
+	managers      map[uuid.UUID]interfaces.IEthernetSwitchManager
+	mutex         sync.Mutex
 }
 
 //NewEthernetSwitchManagerProvider constructor for EthernetSwitchManagerProvider
-func NewEthernetSwitchManagerProvider(switchRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch]) interfaces.IEthernetSwitchManagerProvider {
+//
+//Params:
+//	switchRepo - ethernet switch repository
+//	config - application configuration
+//Return:
+//	interfaces.IEthernetSwitchManagerProvider - switch managers provider
+func NewEthernetSwitchManagerProvider(switchRepo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch], config *domain.AppConfig) interfaces.IEthernetSwitchManagerProvider {
+	telnetPort := config.EthernetSwitch.TelnetPort
+	if telnetPort <= 0 {
+		telnetPort = 23
+	}
 	return &EthernetSwitchManagerProvider{
-		managers:   make(map[uuid.UUID]interfaces.IEthernetSwitchManager),
-		switchRepo: switchRepo,
+		managers:      make(map[uuid.UUID]interfaces.IEthernetSwitchManager),
+		switchRepo:    switchRepo,
+		sessionConfig: config.EthernetSwitch.Session,
+		telnetPort:    telnetPort,
 	}
 }
 
-//Get ethernet switch manager
+//Get ethernet switch manager, one manager with its own sessions pool is created for each switch
 //
 //Params:
-//	ethernetSwitch - switch entity
+//	ctx - context
+//	switchID - ethernet switch ID
 //Return:
 //	interfaces.IEthernetSwitchManager - switch manager interface
+//	error - if an error occurs, otherwise nil
 func (e *EthernetSwitchManagerProvider) Get(ctx context.Context, switchID uuid.UUID) (interfaces.IEthernetSwitchManager, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
 	if e.managers[switchID] == nil {
 		ethSwitch, err := e.switchRepo.GetByID(ctx, switchID)
 		if err != nil {
@@ -36,10 +58,48 @@ func (e *EthernetSwitchManagerProvider) Get(ctx context.Context, switchID uuid.U
 		}
 		switch ethSwitch.SwitchModel {
 		case "tl-sg2210mp":
-			e.managers[switchID] = NewTPLinkEthernetSwitchManager(ethSwitch.Address+":23", ethSwitch.Username, ethSwitch.Password)
+			e.managers[switchID] = NewTPLinkEthernetSwitchManager(fmt.Sprintf("%s:%d", ethSwitch.Address, e.telnetPort), ethSwitch.Username, ethSwitch.Password, e.sessionConfig)
 			return e.managers[switchID], nil
 		}
 		return nil, nil
 	}
 	return e.managers[switchID], nil
 }
+
+//Invalidate closes the switch manager, so the new one will be created with the actual switch
+//address and credentials on the next Get
+//
+//Params:
+//	switchID - ethernet switch ID
+func (e *EthernetSwitchManagerProvider) Invalidate(switchID uuid.UUID) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if manager, found := e.managers[switchID]; found {
+		manager.Close()
+		delete(e.managers, switchID)
+	}
+}
+
+//Close closes all switch managers
+func (e *EthernetSwitchManagerProvider) Close() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for switchID, manager := range e.managers {
+		manager.Close()
+		delete(e.managers, switchID)
+	}
+}
+
+//RegisterEthernetSwitchManagerProviderHooks closes all switch managers when the application stops
+//
+//Params:
+//	lifecycle - application lifecycle
+//	provider - ethernet switch managers provider
+func RegisterEthernetSwitchManagerProviderHooks(lifecycle fx.Lifecycle, provider interfaces.IEthernetSwitchManagerProvider) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			provider.Close()
+			return nil
+		},
+	})
+}
@@ -96,6 +96,10 @@ func NewGormEntityDb(cfg *domain.AppConfig) (*gorm.DB, error) {
 		&domain.EthernetSwitch{},
 		&domain.EthernetSwitchPort{},
 		&domain.EthernetSwitchVLAN{},
+		&domain.EthernetSwitchLAG{},
+		&domain.EthernetSwitchConfigBackup{},
+		&domain.FabricVLAN{},
+		&domain.FabricVLANMember{},
 		&domain.DHCP4Config{},
 		&domain.DHCP4Lease{},
 	)
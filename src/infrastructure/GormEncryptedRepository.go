@@ -0,0 +1,205 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"rol/app/errors"
+	"rol/app/interfaces"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//reEncryptPageSize count of entities that are read at once during the secrets re-encryption
+const reEncryptPageSize = 100
+
+//GormEncryptedRepository is implementation of interfaces.IGenericRepository that stores
+//the specified string fields of the entity encrypted. Entities are returned with decrypted fields,
+//so the encryption is transparent for the services.
+type GormEncryptedRepository[EntityIDType comparable, EntityType interfaces.IEntityModel[EntityIDType]] struct {
+	*GormGenericRepository[EntityIDType, EntityType]
+	cipher interfaces.ISecretCipher
+	//secretFields - names of the encrypted entity fields
+	secretFields []string
+}
+
+//NewGormEncryptedRepository GORM repository with encrypted fields constructor
+//
+//Params
+//	db - gorm database
+//	cipher - secrets cipher
+//	log - logrus logger
+//	secretFields - names of the entity string fields that will be encrypted
+//Return
+//	*GormEncryptedRepository[EntityIDType, EntityType] - repository for instantiated entity
+func NewGormEncryptedRepository[EntityIDType comparable, EntityType interfaces.IEntityModel[EntityIDType]](db *gorm.DB,
+	cipher interfaces.ISecretCipher, log *logrus.Logger, secretFields ...string) *GormEncryptedRepository[EntityIDType, EntityType] {
+	entityType := reflect.TypeOf(*new(EntityType))
+	for _, name := range secretFields {
+		field, found := entityType.FieldByName(name)
+		if !found || field.Type.Kind() != reflect.String {
+			panic(fmt.Sprintf("entity %s has no string field %s", entityType.Name(), name))
+		}
+	}
+	return &GormEncryptedRepository[EntityIDType, EntityType]{
+		GormGenericRepository: NewGormGenericRepository[EntityIDType, EntityType](db, log),
+		cipher:                cipher,
+		secretFields:          secretFields,
+	}
+}
+
+//convertSecrets applies the conversion to all secret fields of the entity
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) convertSecrets(entity *EntityType, convert func(string) (string, error)) error {
+	value := reflect.ValueOf(entity).Elem()
+	for _, name := range g.secretFields {
+		field := value.FieldByName(name)
+		converted, err := convert(field.String())
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to convert %s field", name)
+		}
+		field.SetString(converted)
+	}
+	return nil
+}
+
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) decrypt(entity EntityType, err error) (EntityType, error) {
+	if err != nil {
+		return entity, err
+	}
+	err = g.convertSecrets(&entity, g.cipher.Decrypt)
+	if err != nil {
+		return *new(EntityType), err
+	}
+	return entity, nil
+}
+
+//GetList of elements with filtering and pagination
+//
+//Params
+//	ctx - context is used only for logging
+//	orderBy - order by string parameter
+//	orderDirection - ascending or descending order
+//	page - page number
+//	size - page size
+//	queryBuilder - query builder for filtering
+//Return
+//	[]EntityType - array of entities with decrypted secrets
+//	error - if an error occurs, otherwise nil
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) GetList(ctx context.Context, orderBy string, orderDirection string, page int, size int, queryBuilder interfaces.IQueryBuilder) ([]EntityType, error) {
+	entities, err := g.GormGenericRepository.GetList(ctx, orderBy, orderDirection, page, size, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entities {
+		err = g.convertSecrets(&entities[i], g.cipher.Decrypt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
+
+//GetByID gets entity by ID from repository
+//
+//Params
+//	ctx - context
+//	id - entity id
+//Return
+//	EntityType - entity with decrypted secrets
+//	error - if an error occurs, otherwise nil
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) GetByID(ctx context.Context, id EntityIDType) (EntityType, error) {
+	return g.decrypt(g.GormGenericRepository.GetByID(ctx, id))
+}
+
+//GetByIDExtended Get entity by ID and query from repository
+//
+//Params
+//	ctx - context
+//	id - entity id
+//	queryBuilder - extended query conditions
+//Return
+//	EntityType - entity with decrypted secrets
+//	error - if an error occurs, otherwise nil
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) GetByIDExtended(ctx context.Context, id EntityIDType, queryBuilder interfaces.IQueryBuilder) (EntityType, error) {
+	return g.decrypt(g.GormGenericRepository.GetByIDExtended(ctx, id, queryBuilder))
+}
+
+//Update encrypt secrets and save the changes to the existing entity in the repository
+//
+//Params
+//	ctx - context
+//	entity - updated entity to save
+//Return
+//	EntityType - updated entity with decrypted secrets
+//	error - if an error occurs, otherwise nil
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) Update(ctx context.Context, entity EntityType) (EntityType, error) {
+	err := g.convertSecrets(&entity, g.cipher.Encrypt)
+	if err != nil {
+		return *new(EntityType), err
+	}
+	return g.decrypt(g.GormGenericRepository.Update(ctx, entity))
+}
+
+//Insert encrypt secrets and insert entity to the repository
+//
+//Params
+//	ctx - context
+//	entity - entity to save
+//Return
+//	EntityType - created entity with decrypted secrets
+//	error - if an error occurs, otherwise nil
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) Insert(ctx context.Context, entity EntityType) (EntityType, error) {
+	err := g.convertSecrets(&entity, g.cipher.Encrypt)
+	if err != nil {
+		return *new(EntityType), err
+	}
+	return g.decrypt(g.GormGenericRepository.Insert(ctx, entity))
+}
+
+//ReEncryptSecrets encrypts with the current key all stored secrets that are not encrypted
+//or encrypted with one of the previous keys. Only the secret columns are updated.
+//
+//Params
+//	ctx - context
+//Return
+//	int - count of updated entities
+//	error - if an error occurs, otherwise nil
+func (g *GormEncryptedRepository[EntityIDType, EntityType]) ReEncryptSecrets(ctx context.Context) (int, error) {
+	updated := 0
+	for page := 1; ; page++ {
+		entities, err := g.GormGenericRepository.GetList(ctx, "CreatedAt", "asc", page, reEncryptPageSize, nil)
+		if err != nil {
+			return updated, errors.Internal.Wrap(err, "failed to get entities")
+		}
+		for _, entity := range entities {
+			columns := map[string]interface{}{}
+			value := reflect.ValueOf(entity)
+			for _, name := range g.secretFields {
+				secret := value.FieldByName(name).String()
+				if !g.cipher.NeedsReEncryption(secret) {
+					continue
+				}
+				plaintext, err := g.cipher.Decrypt(secret)
+				if err != nil {
+					return updated, errors.Internal.Wrapf(err, "failed to decrypt %s field of the entity %v", name, entity.GetID())
+				}
+				columns[ToSnakeCase(name)], err = g.cipher.Encrypt(plaintext)
+				if err != nil {
+					return updated, errors.Internal.Wrapf(err, "failed to encrypt %s field of the entity %v", name, entity.GetID())
+				}
+			}
+			if len(columns) == 0 {
+				continue
+			}
+			err = g.Db.Model(new(EntityType)).Where("id = ?", entity.GetID()).UpdateColumns(columns).Error
+			if err != nil {
+				return updated, errors.Internal.Wrap(err, "failed to update encrypted secrets")
+			}
+			updated++
+		}
+		if len(entities) < reEncryptPageSize {
+			return updated, nil
+		}
+	}
+}
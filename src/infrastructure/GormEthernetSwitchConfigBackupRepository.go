@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"rol/app/interfaces"
+	"rol/domain"
+)
+
+//GormEthernetSwitchConfigBackupRepository repository for domain.EthernetSwitchConfigBackup entity
+type GormEthernetSwitchConfigBackupRepository struct {
+	*GormGenericRepository[uuid.UUID, domain.EthernetSwitchConfigBackup]
+}
+
+//NewGormEthernetSwitchConfigBackupRepository constructor for domain.EthernetSwitchConfigBackup GORM generic repository
+//
+//Params
+//	db - gorm database
+//	log - logrus logger
+//Return
+//	generic.IGenericRepository[domain.EthernetSwitchConfigBackup] - new ethernet switch configuration backup repository
+func NewGormEthernetSwitchConfigBackupRepository(db *gorm.DB, log *logrus.Logger) interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchConfigBackup] {
+	genericRepository := NewGormGenericRepository[uuid.UUID, domain.EthernetSwitchConfigBackup](db, log)
+	return GormEthernetSwitchConfigBackupRepository{
+		genericRepository,
+	}
+}
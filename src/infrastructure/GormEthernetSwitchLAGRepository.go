@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"rol/app/interfaces"
+	"rol/domain"
+)
+
+//GormEthernetSwitchLAGRepository repository for domain.EthernetSwitchLAG entity
+type GormEthernetSwitchLAGRepository struct {
+	*GormGenericRepository[uuid.UUID, domain.EthernetSwitchLAG]
+}
+
+//NewGormEthernetSwitchLAGRepository constructor for domain.EthernetSwitchLAG GORM generic repository
+//
+//Params
+//	db - gorm database
+//	log - logrus logger
+//Return
+//	generic.IGenericRepository[domain.EthernetSwitchLAG] - new ethernet switch LAG repository
+func NewGormEthernetSwitchLAGRepository(db *gorm.DB, log *logrus.Logger) interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitchLAG] {
+	genericRepository := NewGormGenericRepository[uuid.UUID, domain.EthernetSwitchLAG](db, log)
+	return GormEthernetSwitchLAGRepository{
+		genericRepository,
+	}
+}
@@ -1,28 +1,55 @@
 package infrastructure
 
 import (
+	"context"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/domain"
 )
 
-//GormEthernetSwitchRepository repository for EthernetSwitch entity
+//GormEthernetSwitchRepository repository for EthernetSwitch entity, switch password is stored encrypted
 type GormEthernetSwitchRepository struct {
-	*GormGenericRepository[uuid.UUID, domain.EthernetSwitch]
+	*GormEncryptedRepository[uuid.UUID, domain.EthernetSwitch]
 }
 
 //NewGormEthernetSwitchRepository constructor for domain.EthernetSwitch GORM generic repository
 //
 //Params
 //	db - gorm database
+//	cipher - secrets cipher for the switch password
 //	log - logrus logger
 //Return
 //	generic.IGenericRepository[domain.EthernetSwitch] - new ethernet switch repository
-func NewGormEthernetSwitchRepository(db *gorm.DB, log *logrus.Logger) interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch] {
-	genericRepository := NewGormGenericRepository[uuid.UUID, domain.EthernetSwitch](db, log)
+func NewGormEthernetSwitchRepository(db *gorm.DB, cipher interfaces.ISecretCipher, log *logrus.Logger) interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch] {
+	encryptedRepository := NewGormEncryptedRepository[uuid.UUID, domain.EthernetSwitch](db, cipher, log, "Password")
 	return GormEthernetSwitchRepository{
-		genericRepository,
+		encryptedRepository,
 	}
 }
+
+//EthernetSwitchRepositoryInit re-encrypts stored switch passwords with the current key, it's needed
+//after the key rotation and for the passwords that were stored before the encryption was enabled
+//
+//Params
+//	repo - ethernet switch repository
+//	log - logrus logger
+//Return
+//	error - if an error occurs, otherwise nil
+func EthernetSwitchRepositoryInit(repo interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch], log *logrus.Logger) error {
+	reEncryptor, ok := repo.(interfaces.ISecretsReEncryptor)
+	if !ok {
+		return nil
+	}
+	updated, err := reEncryptor.ReEncryptSecrets(context.Background())
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to re-encrypt ethernet switch passwords")
+	}
+	if updated > 0 {
+		log.Info(fmt.Sprintf("ethernet switch passwords of %d switches are re-encrypted with the current key", updated))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"rol/app/interfaces"
+	"rol/domain"
+)
+
+//GormFabricVLANMemberRepository repository for domain.FabricVLANMember entity
+type GormFabricVLANMemberRepository struct {
+	*GormGenericRepository[uuid.UUID, domain.FabricVLANMember]
+}
+
+//NewGormFabricVLANMemberRepository constructor for domain.FabricVLANMember GORM generic repository
+//
+//Params
+//	db - gorm database
+//	log - logrus logger
+//Return
+//	generic.IGenericRepository[domain.FabricVLANMember] - new fabric VLAN member repository
+func NewGormFabricVLANMemberRepository(db *gorm.DB, log *logrus.Logger) interfaces.IGenericRepository[uuid.UUID, domain.FabricVLANMember] {
+	genericRepository := NewGormGenericRepository[uuid.UUID, domain.FabricVLANMember](db, log)
+	return GormFabricVLANMemberRepository{
+		genericRepository,
+	}
+}
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"rol/app/interfaces"
+	"rol/domain"
+)
+
+//GormFabricVLANRepository repository for domain.FabricVLAN entity
+type GormFabricVLANRepository struct {
+	*GormGenericRepository[uuid.UUID, domain.FabricVLAN]
+}
+
+//NewGormFabricVLANRepository constructor for domain.FabricVLAN GORM generic repository
+//
+//Params
+//	db - gorm database
+//	log - logrus logger
+//Return
+//	generic.IGenericRepository[domain.FabricVLAN] - new fabric VLAN repository
+func NewGormFabricVLANRepository(db *gorm.DB, log *logrus.Logger) interfaces.IGenericRepository[uuid.UUID, domain.FabricVLAN] {
+	genericRepository := NewGormGenericRepository[uuid.UUID, domain.FabricVLAN](db, log)
+	return GormFabricVLANRepository{
+		genericRepository,
+	}
+}
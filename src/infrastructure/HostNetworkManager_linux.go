@@ -3,24 +3,41 @@ package infrastructure
 
 import (
 	"fmt"
-	"github.com/coreos/go-iptables/iptables"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
 	"net"
+	"os"
 	"rol/app/errors"
 	"rol/app/interfaces"
-	"rol/app/mappers"
 	"rol/app/utils"
 	"rol/domain"
 	"strings"
+	"sync"
+	"time"
 )
 
 //HostNetworkManager is a struct for network manager
 type HostNetworkManager struct {
 	configStorage     interfaces.IHostNetworkConfigStorage
-	iptables          *iptables.IPTables
+	trafficBackend    interfaces.ITrafficRuleBackend
 	hasUnsavedChanges bool
+	logger            *logrus.Logger
+	//mutex serializes the host network changes with the unsaved changes flag, the confirm timer and the changes reset
+	mutex *sync.Mutex
+	//confirmTimeout time after the last change when unconfirmed changes are reset, 0 if changes are not reset
+	confirmTimeout  time.Duration
+	confirmTimer    *time.Timer
+	confirmDeadline time.Time
+	//confirmResetError error of the last failed automatic reset of unconfirmed changes, nil if it didn't fail
+	confirmResetError error
 }
 
+//confirmResetRetryInterval max time after the failed automatic reset of unconfirmed changes when the reset is retried
+const confirmResetRetryInterval = 30 * time.Second
+
 var netfilterTables = []string{
 	"filter",
 	"nat",
@@ -29,22 +46,69 @@ var netfilterTables = []string{
 	"security",
 }
 
+//trafficChainPrefix prefix of the netfilter chains managed by RoL
+const trafficChainPrefix = "ROL-"
+
+//netfilterBuiltinChains builtin chains of the netfilter tables, RoL chains are jumped from them
+var netfilterBuiltinChains = map[string][]string{
+	"filter":   {"INPUT", "FORWARD", "OUTPUT"},
+	"nat":      {"PREROUTING", "INPUT", "OUTPUT", "POSTROUTING"},
+	"mangle":   {"PREROUTING", "INPUT", "FORWARD", "OUTPUT", "POSTROUTING"},
+	"raw":      {"PREROUTING", "OUTPUT"},
+	"security": {"INPUT", "FORWARD", "OUTPUT"},
+}
+
+//initialConfigAuthor author of the configuration that is saved when there is no saved configuration
+const initialConfigAuthor = "RoL"
+
+//bridgeNatCommentPrefix comment prefix of the bridge NAT rules, the bridge name follows it
+const bridgeNatCommentPrefix = "rol.nat:"
+
+//vethPrefix name prefix of the RoL veth, the peer of the rol.veth.{name} is rol.peer.{name}
+const vethPrefix = "rol.veth."
+
+//vethPeerPrefix name prefix of the RoL veth peer
+const vethPeerPrefix = "rol.peer."
+
+//namespacePrefix name prefix of the RoL network namespaces
+const namespacePrefix = "rol.ns."
+
+//rolRouteProtocol route protocol id that marks the static routes managed by RoL, ids up to 4 are used by the kernel
+const rolRouteProtocol = 199
+
+//ipv4ForwardingPath sysctl of the IPv4 forwarding between interfaces
+const ipv4ForwardingPath = "/proc/sys/net/ipv4/ip_forward"
+
+//netfilterTargets actions that are not jumps to the user chains
+var netfilterTargets = []string{
+	"ACCEPT", "DROP", "RETURN", "REJECT", "MASQUERADE", "DNAT", "SNAT",
+	"LOG", "MARK", "CONNMARK", "TCPMSS", "NOTRACK", "QUEUE",
+}
+
 //NewHostNetworkManager constructor for HostNetworkManager
-func NewHostNetworkManager(configStorage interfaces.IHostNetworkConfigStorage) (interfaces.IHostNetworkManager, error) {
-	ipTables, err := iptables.New()
-	if err != nil {
-		return nil, errors.Internal.Wrap(err, "error getting iptables instance")
-	}
+func NewHostNetworkManager(configStorage interfaces.IHostNetworkConfigStorage, trafficBackend interfaces.ITrafficRuleBackend,
+	config *domain.AppConfig, log *logrus.Logger) (interfaces.IHostNetworkManager, error) {
+	confirmTimeout := time.Duration(config.HostNetwork.ConfirmTimeout) * time.Second
+	return NewHostNetworkManagerWithConfirmTimeout(configStorage, trafficBackend, confirmTimeout, log)
+}
+
+//NewHostNetworkManagerWithConfirmTimeout constructor for HostNetworkManager with the confirm timeout
+//that is not rounded to seconds
+func NewHostNetworkManagerWithConfirmTimeout(configStorage interfaces.IHostNetworkConfigStorage, trafficBackend interfaces.ITrafficRuleBackend,
+	confirmTimeout time.Duration, log *logrus.Logger) (interfaces.IHostNetworkManager, error) {
 	hostNetworkManager := &HostNetworkManager{
 		configStorage:     configStorage,
-		iptables:          ipTables,
+		trafficBackend:    trafficBackend,
+		logger:            log,
+		mutex:             &sync.Mutex{},
+		confirmTimeout:    confirmTimeout,
 		hasUnsavedChanges: true,
 		// we set this flag for calling reset changes function at start, for apply configuration from storage
 	}
 	//if it's a first time, we need to save config based on current configuration
-	_, err = configStorage.GetConfig()
+	_, err := configStorage.GetConfig()
 	if err != nil && !errors.As(err, errors.Internal) {
-		err = hostNetworkManager.SaveConfiguration()
+		err = hostNetworkManager.SaveConfiguration(initialConfigAuthor, uuid.Nil)
 		if err != nil {
 			return nil, errors.Internal.Wrap(err, "failed to save initial host network configuration to storage")
 		}
@@ -59,15 +123,26 @@ func NewHostNetworkManager(configStorage interfaces.IHostNetworkConfigStorage) (
 	return hostNetworkManager, nil
 }
 
+//isManagedAddr checks that the link address is managed by RoL, IPv6 link-local and autoconfigured addresses are
+//set by the kernel, so they are not listed
+func (h *HostNetworkManager) isManagedAddr(addr netlink.Addr) bool {
+	if addr.IP.To4() != nil {
+		return true
+	}
+	return !addr.IP.IsLinkLocalUnicast() && addr.Flags&unix.IFA_F_PERMANENT != 0
+}
+
 func (h *HostNetworkManager) parseLinkAddr(link netlink.Link) ([]net.IPNet, error) {
-	addrList, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	addrList, err := netlink.AddrList(link, netlink.FAMILY_ALL)
 	if err != nil {
 		return nil, errors.Internal.Wrap(err, "get addresses error")
 	}
 
 	var out []net.IPNet
 	for _, addr := range addrList {
-		out = append(out, *addr.IPNet)
+		if h.isManagedAddr(addr) {
+			out = append(out, *addr.IPNet)
+		}
 	}
 	return out, nil
 }
@@ -136,15 +211,55 @@ func (h *HostNetworkManager) mapLink(link netlink.Link) (interfaces.IHostNetwork
 		if err != nil {
 			return nil, errors.Internal.Wrap(err, "get slaves failed")
 		}
+		upstreamInterface, err := h.getBridgeNatUpstream(link.Attrs().Name)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "get bridge NAT failed")
+		}
 		bridge := domain.HostNetworkBridge{
 			HostNetworkLink: domain.HostNetworkLink{
 				Name:      link.Attrs().Name,
 				Type:      link.Type(),
 				Addresses: addresses,
 			},
-			Slaves: slaves,
+			Slaves:            slaves,
+			Nat:               upstreamInterface != "",
+			UpstreamInterface: upstreamInterface,
 		}
 		return bridge, nil
+	} else if link.Type() == "bond" {
+		addresses, err := h.parseLinkAddr(link)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "error parsing link addresses")
+		}
+		slaves, err := h.getSlaves(link)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "get slaves failed")
+		}
+		bond := domain.HostNetworkBond{
+			HostNetworkLink: domain.HostNetworkLink{
+				Name:      link.Attrs().Name,
+				Type:      link.Type(),
+				Addresses: addresses,
+			},
+			Mode:   link.(*netlink.Bond).Mode.String(),
+			Miimon: link.(*netlink.Bond).Miimon,
+			Slaves: slaves,
+		}
+		return bond, nil
+	} else if link.Type() == "veth" {
+		addresses, err := h.parseLinkAddr(link)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "error parsing link addresses")
+		}
+		veth := domain.HostNetworkVeth{
+			HostNetworkLink: domain.HostNetworkLink{
+				Name:      link.Attrs().Name,
+				Type:      link.Type(),
+				Addresses: addresses,
+			},
+			Peer: h.vethPeerName(link.Attrs().Name),
+		}
+		return veth, nil
 	}
 	return domain.HostNetworkLink{Name: link.Attrs().Name, Type: "none", Addresses: []net.IPNet{}}, nil
 }
@@ -205,6 +320,13 @@ func (h *HostNetworkManager) GetByName(name string) (interfaces.IHostNetworkLink
 //	string - new vlan name that will be rol.{master}.{vlanID}
 //	error - if an error occurs, otherwise nil
 func (h *HostNetworkManager) CreateVlan(master string, vlanID int) (string, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createVlan(master, vlanID)
+}
+
+//createVlan see CreateVlan, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createVlan(master string, vlanID int) (string, error) {
 	parent, err := netlink.LinkByName(master)
 	if err != nil {
 		return "", errors.Internal.Wrap(err, "getting device link by name failed")
@@ -224,7 +346,7 @@ func (h *HostNetworkManager) CreateVlan(master string, vlanID int) (string, erro
 		return "", errors.Internal.Wrap(err, "failed to add vlan link")
 	}
 
-	h.hasUnsavedChanges = true
+	h.setUnsavedChanges()
 
 	return vlanName, nil
 }
@@ -237,6 +359,13 @@ func (h *HostNetworkManager) CreateVlan(master string, vlanID int) (string, erro
 //	string - new bridge name that will be rol.br.{name}
 //	error - if an error occurs, otherwise nil
 func (h *HostNetworkManager) CreateBridge(name string) (string, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createBridge(name)
+}
+
+//createBridge see CreateBridge, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createBridge(name string) (string, error) {
 	la := netlink.NewLinkAttrs()
 	bridgeName := fmt.Sprintf("rol.br.%s", name)
 	la.Name = bridgeName
@@ -245,11 +374,46 @@ func (h *HostNetworkManager) CreateBridge(name string) (string, error) {
 	if err != nil {
 		return "", errors.Internal.Wrap(err, "failed to add bridge link")
 	}
-	h.hasUnsavedChanges = true
+	h.setUnsavedChanges()
 	return bridgeName, nil
 }
 
-//SetLinkMaster set master for link
+//CreateBond creates bonding interface on host, slaves are added by SetLinkMaster
+//
+//Params:
+//	name - new bond name
+//	mode - bonding mode: "active-backup", "802.3ad" or "balance-alb"
+//	miimon - MII link monitoring frequency in milliseconds
+//Return:
+//	string - new bond name that will be rol.bond.{name}
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateBond(name, mode string, miimon int) (string, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createBond(name, mode, miimon)
+}
+
+//createBond see CreateBond, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createBond(name, mode string, miimon int) (string, error) {
+	bondMode := netlink.StringToBondMode(mode)
+	if bondMode == netlink.BOND_MODE_UNKNOWN {
+		return "", errors.Internal.Newf("unknown bonding mode %s", mode)
+	}
+	la := netlink.NewLinkAttrs()
+	bondName := fmt.Sprintf("rol.bond.%s", name)
+	la.Name = bondName
+	bond := netlink.NewLinkBond(la)
+	bond.Mode = bondMode
+	bond.Miimon = miimon
+	err := netlink.LinkAdd(bond)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "failed to add bond link")
+	}
+	h.setUnsavedChanges()
+	return bondName, nil
+}
+
+//SetLinkMaster set master for link, the slave of the bond is set down while it is being enslaved
 //
 //Params:
 //	slaveName - name of link that will be slave
@@ -257,6 +421,13 @@ func (h *HostNetworkManager) CreateBridge(name string) (string, error) {
 //Return:
 //	error - if an error occurs, otherwise nil
 func (h *HostNetworkManager) SetLinkMaster(slaveName, masterName string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.setLinkMaster(slaveName, masterName)
+}
+
+//setLinkMaster see SetLinkMaster, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) setLinkMaster(slaveName, masterName string) error {
 	slave, err := netlink.LinkByName(slaveName)
 	if err != nil {
 		return errors.Internal.Wrap(err, "getting slave by name failed")
@@ -265,11 +436,24 @@ func (h *HostNetworkManager) SetLinkMaster(slaveName, masterName string) error {
 	if err != nil {
 		return errors.Internal.Wrap(err, "getting master by name failed")
 	}
+	if master.Type() == "bond" {
+		//kernel refuses to enslave the link that is up to the bond
+		err = netlink.LinkSetDown(slave)
+		if err != nil {
+			return errors.Internal.Wrap(err, "link set down failed")
+		}
+	}
 	err = netlink.LinkSetMaster(slave, master)
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed set link master")
 	}
-	h.hasUnsavedChanges = true
+	if master.Type() == "bond" {
+		err = netlink.LinkSetUp(slave)
+		if err != nil {
+			return errors.Internal.Wrap(err, "link set up failed")
+		}
+	}
+	h.setUnsavedChanges()
 	return nil
 }
 
@@ -280,6 +464,13 @@ func (h *HostNetworkManager) SetLinkMaster(slaveName, masterName string) error {
 //Return:
 //	error - if an error occurs, otherwise nil
 func (h *HostNetworkManager) UnsetLinkMaster(linkName string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.unsetLinkMaster(linkName)
+}
+
+//unsetLinkMaster see UnsetLinkMaster, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) unsetLinkMaster(linkName string) error {
 	link, err := netlink.LinkByName(linkName)
 	if err != nil {
 		return errors.Internal.Wrap(err, "getting link by name failed")
@@ -288,264 +479,964 @@ func (h *HostNetworkManager) UnsetLinkMaster(linkName string) error {
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to set no master for link")
 	}
-	h.hasUnsavedChanges = true
+	h.setUnsavedChanges()
 	return nil
 }
 
-//SetLinkUp enables the link
-//
-//Params:
-//	linkName - name of the link
-//Return:
-//	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) SetLinkUp(linkName string) error {
-	link, err := netlink.LinkByName(linkName)
-	if err != nil {
-		return errors.Internal.Wrap(err, "getting link by name failed")
+//isRolVeth checks that the veth end is created by RoL
+func (h *HostNetworkManager) isRolVeth(name string) bool {
+	return strings.HasPrefix(name, vethPrefix) || strings.HasPrefix(name, vethPeerPrefix)
+}
+
+//vethPairName gets the name that RoL veth pair is created with, empty string for the other links
+func (h *HostNetworkManager) vethPairName(name string) string {
+	if strings.HasPrefix(name, vethPrefix) {
+		return strings.TrimPrefix(name, vethPrefix)
 	}
-	err = netlink.LinkSetUp(link)
-	if err != nil {
-		return errors.Internal.Wrap(err, "link set up failed")
+	if strings.HasPrefix(name, vethPeerPrefix) {
+		return strings.TrimPrefix(name, vethPeerPrefix)
 	}
-	return nil
+	return ""
 }
 
-//DeleteLinkByName deletes interface on host by its name
-//
-//Params:
-//	name - interface name
-//Return
-//	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) DeleteLinkByName(name string) error {
-	link, err := netlink.LinkByName(name)
-	if err != nil {
-		return errors.Internal.Wrap(err, "getting link by name failed")
+//vethPeerName gets the name of the other end of the RoL veth pair, empty string for the other links
+func (h *HostNetworkManager) vethPeerName(name string) string {
+	if strings.HasPrefix(name, vethPrefix) {
+		return vethPeerPrefix + strings.TrimPrefix(name, vethPrefix)
 	}
-	err = netlink.LinkDel(link)
-	if err != nil {
-		return errors.Internal.Wrap(err, "deleting link failed")
+	if strings.HasPrefix(name, vethPeerPrefix) {
+		return vethPrefix + strings.TrimPrefix(name, vethPeerPrefix)
 	}
-	h.hasUnsavedChanges = true
-	return nil
+	return ""
 }
 
-//AddrAdd Add new ip address for network interface
+//CreateVeth creates virtual ethernet pair on host, both ends are created on the host
 //
 //Params:
-//	linkName - name of the interface
-//	addr - ip address with mask net.IPNet
+//	name - new veth pair name
 //Return:
+//	string - new veth name that will be rol.veth.{name}, its peer name will be rol.peer.{name}
 //	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) AddrAdd(linkName string, addr net.IPNet) error {
-	link, err := netlink.LinkByName(linkName)
+func (h *HostNetworkManager) CreateVeth(name string) (string, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createVeth(name)
+}
+
+//createVeth see CreateVeth, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createVeth(name string) (string, error) {
+	la := netlink.NewLinkAttrs()
+	vethName := vethPrefix + name
+	la.Name = vethName
+	veth := &netlink.Veth{LinkAttrs: la, PeerName: vethPeerPrefix + name}
+	err := netlink.LinkAdd(veth)
 	if err != nil {
-		return errors.Internal.Wrap(err, "getting link by name failed")
+		return "", errors.Internal.Wrap(err, "failed to add veth link")
 	}
-	cidr := addr.String()
-	linkAddr, err := netlink.ParseAddr(cidr)
+	h.setUnsavedChanges()
+	return vethName, nil
+}
+
+//namespaceHandle gets netlink handle of the named network namespace, handle must be deleted after use
+func (h *HostNetworkManager) namespaceHandle(namespace string) (*netlink.Handle, error) {
+	ns, err := netns.GetFromName(namespace)
 	if err != nil {
-		return errors.Internal.Wrap(err, "parse cidr address failed")
+		return nil, errors.NotFound.Wrapf(err, "network namespace %s is not found", namespace)
 	}
-	err = netlink.AddrAdd(link, linkAddr)
+	defer ns.Close()
+	handle, err := netlink.NewHandleAt(ns)
 	if err != nil {
-		return errors.Internal.Wrap(err, "error adding address to link")
+		return nil, errors.Internal.Wrapf(err, "failed to get netlink handle of network namespace %s", namespace)
 	}
-	h.hasUnsavedChanges = true
-	return nil
+	return handle, nil
 }
 
-//AddrDelete Delete ip address from network interface
-//
-//Params:
-//	linkName - name of the interface
-//	addr - ip address with mask net.IPNet
-//Return:
-//	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) AddrDelete(linkName string, addr net.IPNet) error {
-	link, err := netlink.LinkByName(linkName)
+//getNamespaceLinks gets links of the network namespace without loopback
+func (h *HostNetworkManager) getNamespaceLinks(namespace string) ([]domain.HostNetworkLink, error) {
+	handle, err := h.namespaceHandle(namespace)
 	if err != nil {
-		return errors.Internal.Wrap(err, "getting link by name failed")
+		return nil, err
 	}
-	cidr := addr.String()
-	linkAddr, err := netlink.ParseAddr(cidr)
+	defer handle.Delete()
+	links, err := handle.LinkList()
 	if err != nil {
-		return errors.Internal.Wrap(err, "parse cidr address failed")
+		return nil, errors.Internal.Wrap(err, "error getting a list of namespace link devices")
 	}
-	err = netlink.AddrDel(link, linkAddr)
-	if err != nil {
-		return errors.Internal.Wrap(err, "error adding address to link")
+	out := []domain.HostNetworkLink{}
+	for _, link := range links {
+		if link.Attrs().Name == "lo" {
+			continue
+		}
+		addrList, err := handle.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "get namespace link addresses error")
+		}
+		addresses := []net.IPNet{}
+		for _, addr := range addrList {
+			if h.isManagedAddr(addr) {
+				addresses = append(addresses, *addr.IPNet)
+			}
+		}
+		out = append(out, domain.HostNetworkLink{
+			Name:      link.Attrs().Name,
+			Type:      link.Type(),
+			Addresses: addresses,
+		})
 	}
-	h.hasUnsavedChanges = true
-	return nil
+	return out, nil
 }
 
-func (h *HostNetworkManager) parseRule(rule domain.HostNetworkTrafficRule) []string {
-	var rulespec []string
-	if rule.Source != "" {
-		rulespec = append(rulespec, "-s")
-		rulespec = append(rulespec, rule.Source)
-	}
-	if rule.Destination != "" {
-		rulespec = append(rulespec, "-d")
-		rulespec = append(rulespec, rule.Destination)
+//GetNamespaces gets list of RoL network namespaces with their links
+//
+//Return:
+//	[]domain.HostNetworkNamespace - list of namespaces
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetNamespaces() ([]domain.HostNetworkNamespace, error) {
+	out := []domain.HostNetworkNamespace{}
+	names, err := getNetworkNamespaceNames()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get network namespaces names")
 	}
-	if rule.Action != "" {
-		rulespec = append(rulespec, "-j")
-		rulespec = append(rulespec, rule.Action)
+	for _, name := range names {
+		if !strings.HasPrefix(name, namespacePrefix) {
+			continue
+		}
+		links, err := h.getNamespaceLinks(name)
+		if err != nil {
+			return nil, errors.Internal.Wrapf(err, "failed to get links of network namespace %s", name)
+		}
+		out = append(out, domain.HostNetworkNamespace{Name: name, Links: links})
 	}
-	return rulespec
+	return out, nil
 }
 
-//CreateTrafficRule Create netfilter traffic rule for specified table
+//CreateNamespace creates network namespace on host, loopback of the namespace is set up
 //
 //Params:
-//	table - table to create a rule
-//	rule - rule entity
+//	name - new namespace name
 //Return:
-//	domain.HostNetworkTrafficRule - new traffic rule
+//	string - new namespace name that will be rol.ns.{name}
 //	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) CreateTrafficRule(table string, rule domain.HostNetworkTrafficRule) (domain.HostNetworkTrafficRule, error) {
-	rulespec := h.parseRule(rule)
-	err := h.iptables.AppendUnique(table, rule.Chain, rulespec...)
+func (h *HostNetworkManager) CreateNamespace(name string) (string, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createNamespace(name)
+}
+
+//createNamespace see CreateNamespace, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createNamespace(name string) (string, error) {
+	namespace := namespacePrefix + name
+	err := createNetworkNamespace(namespace)
 	if err != nil {
-		return domain.HostNetworkTrafficRule{}, errors.Internal.Wrap(err, "failed to create traffic rule")
+		return "", err
 	}
-	h.hasUnsavedChanges = true
-	return rule, nil
+	h.setUnsavedChanges()
+	handle, err := h.namespaceHandle(namespace)
+	if err != nil {
+		return "", err
+	}
+	defer handle.Delete()
+	lo, err := handle.LinkByName("lo")
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "getting namespace loopback failed")
+	}
+	err = handle.LinkSetUp(lo)
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "namespace loopback set up failed")
+	}
+	return namespace, nil
 }
 
-//DeleteTrafficRule Delete netfilter traffic rule in specified table
+//DeleteNamespace deletes network namespace on host, its links are moved back to the host before,
+//so the veth pairs are not deleted with the namespace
 //
 //Params:
-//	table - table to delete a rule
-//	rule - rule entity
+//	name - namespace name
 //Return:
 //	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) DeleteTrafficRule(table string, rule domain.HostNetworkTrafficRule) error {
-	rulespec := h.parseRule(rule)
-	exist, err := h.iptables.Exists(table, rule.Chain, rulespec...)
+func (h *HostNetworkManager) DeleteNamespace(name string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.deleteNamespace(name)
+}
+
+//deleteNamespace see DeleteNamespace, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) deleteNamespace(name string) error {
+	links, err := h.getNamespaceLinks(name)
 	if err != nil {
-		return errors.Internal.Wrap(err, "failed to check existence of traffic rule")
+		return err
 	}
-	if exist {
-		err = h.iptables.Delete(table, rule.Chain, rulespec...)
+	for _, link := range links {
+		err = h.unsetLinkNamespace(link.Name, name)
 		if err != nil {
-			return errors.Internal.Wrap(err, "failed to delete traffic rule")
+			return err
 		}
-		h.hasUnsavedChanges = true
-		return nil
 	}
-	return errors.NotFound.New("traffic rule not found")
+	err = netns.DeleteNamed(name)
+	if err != nil {
+		return errors.Internal.Wrapf(err, "failed to delete network namespace %s", name)
+	}
+	h.setUnsavedChanges()
+	return nil
 }
 
-//GetChainRules Get selected netfilter chain rules at specified table
+//SetLinkNamespace moves the host link into the network namespace and sets it up there,
+//link addresses are lost after the moving
 //
 //Params:
-//	table - table to get a rules
-//	chain - chain where we get the rules
+//	linkName - name of the link
+//	namespace - name of the namespace
 //Return:
-//	[]domain.HostNetworkTrafficRule - slice of rules
 //	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) GetChainRules(table string, chain string) ([]domain.HostNetworkTrafficRule, error) {
-	var rules []domain.HostNetworkTrafficRule
+func (h *HostNetworkManager) SetLinkNamespace(linkName, namespace string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.setLinkNamespace(linkName, namespace)
+}
 
-	list, err := h.iptables.Stats(table, chain)
+//setLinkNamespace see SetLinkNamespace, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) setLinkNamespace(linkName, namespace string) error {
+	link, err := netlink.LinkByName(linkName)
 	if err != nil {
-		return nil, errors.Internal.Wrap(err, "failed to get list of traffic rules")
+		return errors.Internal.Wrap(err, "getting link by name failed")
 	}
-	for _, l := range list {
-		stat, err := h.iptables.ParseStat(l)
-		if err != nil {
-			return nil, errors.Internal.Wrap(err, "failed to parse traffic rule to stat struct")
-		}
-		rule := &domain.HostNetworkTrafficRule{Chain: chain}
-		mappers.MapStatToTrafficRule(stat, rule)
-		rules = append(rules, *rule)
+	ns, err := netns.GetFromName(namespace)
+	if err != nil {
+		return errors.NotFound.Wrapf(err, "network namespace %s is not found", namespace)
 	}
-	return rules, err
-}
-
-func (h *HostNetworkManager) trimExclamationMarkInStat(slice []string) (out []string) {
-	for _, element := range slice {
-		out = append(out, strings.Trim(element, "!"))
+	defer ns.Close()
+	err = netlink.LinkSetNsFd(link, int(ns))
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to move link into network namespace")
+	}
+	h.setUnsavedChanges()
+	handle, err := h.namespaceHandle(namespace)
+	if err != nil {
+		return err
+	}
+	defer handle.Delete()
+	nsLink, err := handle.LinkByName(linkName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting namespace link by name failed")
+	}
+	err = handle.LinkSetUp(nsLink)
+	if err != nil {
+		return errors.Internal.Wrap(err, "namespace link set up failed")
 	}
-	return
+	return nil
 }
 
-//GetTableRules Get specified netfilter table rules
+//UnsetLinkNamespace moves the link from the network namespace back to the host and sets it up,
+//link addresses are lost after the moving
 //
 //Params:
-//	table - table to get a rules
+//	linkName - name of the link
+//	namespace - name of the namespace
 //Return:
-//	[]domain.HostNetworkTrafficRule - slice of rules
 //	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) GetTableRules(table string) ([]domain.HostNetworkTrafficRule, error) {
-	var rules []domain.HostNetworkTrafficRule
+func (h *HostNetworkManager) UnsetLinkNamespace(linkName, namespace string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.unsetLinkNamespace(linkName, namespace)
+}
 
-	chains, err := h.iptables.ListChains(table)
+//unsetLinkNamespace see UnsetLinkNamespace, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) unsetLinkNamespace(linkName, namespace string) error {
+	handle, err := h.namespaceHandle(namespace)
 	if err != nil {
-		return nil, errors.Internal.Wrap(err, "failed to get list table chains")
+		return err
 	}
-	for _, chain := range chains {
-		list, err := h.iptables.Stats(table, chain)
-		if err != nil {
-			return nil, errors.Internal.Wrap(err, "failed to get list of traffic rules")
-		}
-		for _, l := range list {
-			stat, err := h.iptables.ParseStat(h.trimExclamationMarkInStat(l))
-			if err != nil {
-				return nil, errors.Internal.Wrap(err, "failed to parse traffic rule to stat struct")
-			}
-			rule := &domain.HostNetworkTrafficRule{Chain: chain}
-			mappers.MapStatToTrafficRule(stat, rule)
-			rules = append(rules, *rule)
-		}
+	defer handle.Delete()
+	nsLink, err := handle.LinkByName(linkName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting namespace link by name failed")
+	}
+	hostNs, err := netns.GetFromPid(os.Getpid())
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to get host network namespace")
+	}
+	defer hostNs.Close()
+	err = handle.LinkSetNsFd(nsLink, int(hostNs))
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to move link back to the host")
 	}
-	return rules, nil
+	h.setUnsavedChanges()
+	return h.setLinkUp(linkName)
 }
 
-//SaveConfiguration save current host network configuration to the configuration storage
-//Save previous config file to .back file
-//
-//Return:
-//	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) SaveConfiguration() error {
-	config := domain.HostNetworkConfig{}
-	networkInterfaces, err := h.GetList()
+//namespaceAddrChange adds or deletes address of the network namespace link
+func (h *HostNetworkManager) namespaceAddrChange(namespace, linkName string, addr net.IPNet, add bool) error {
+	handle, err := h.namespaceHandle(namespace)
 	if err != nil {
-		return errors.Internal.Wrap(err, "failed to get list of host network interfaces")
+		return err
 	}
-	for _, inter := range networkInterfaces {
-		if inter.GetType() == "vlan" {
-			config.Vlans = append(config.Vlans, inter.(domain.HostNetworkVlan))
-		} else if inter.GetType() == "device" {
-			config.Devices = append(config.Devices, inter.(domain.HostNetworkDevice))
-		} else if inter.GetType() == "bridge" {
-			config.Bridges = append(config.Bridges, inter.(domain.HostNetworkBridge))
-		}
+	defer handle.Delete()
+	link, err := handle.LinkByName(linkName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting namespace link by name failed")
 	}
-	for _, table := range netfilterTables {
-		rules, err := h.GetTableRules(table)
-		if err != nil {
-			return errors.Internal.Wrap(err, "failed to get table rules")
-		}
-
-		h.setTrafficRulesConfigField(table, rules, &config)
+	linkAddr, err := netlink.ParseAddr(addr.String())
+	if err != nil {
+		return errors.Internal.Wrap(err, "parse cidr address failed")
+	}
+	if add {
+		err = handle.AddrAdd(link, linkAddr)
+	} else {
+		err = handle.AddrDel(link, linkAddr)
 	}
-	err = h.configStorage.SaveConfig(config)
 	if err != nil {
-		return errors.Internal.Wrap(err, "failed to save host network config to storage")
+		return errors.Internal.Wrap(err, "error changing address of namespace link")
 	}
-	h.hasUnsavedChanges = false
+	h.setUnsavedChanges()
 	return nil
 }
 
-func (h *HostNetworkManager) bridgeExistOnHost(links []interfaces.IHostNetworkLink, bridgeName string) bool {
-	for _, inter := range links {
-		if inter.GetType() == "bridge" && inter.GetName() == bridgeName {
-			return true
-		}
-	}
+//NamespaceAddrAdd Add new ip address for network interface in the network namespace
+//
+//Params:
+//	namespace - name of the namespace
+//	linkName - name of the interface
+//	addr - ip address with mask net.IPNet
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) NamespaceAddrAdd(namespace, linkName string, addr net.IPNet) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.namespaceAddrChange(namespace, linkName, addr, true)
+}
+
+//NamespaceAddrDelete Delete ip address from network interface in the network namespace
+//
+//Params:
+//	namespace - name of the namespace
+//	linkName - name of the interface
+//	addr - ip address with mask net.IPNet
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) NamespaceAddrDelete(namespace, linkName string, addr net.IPNet) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.namespaceAddrChange(namespace, linkName, addr, false)
+}
+
+//bridgeNatRules gets NAT rules of the bridge by netfilter tables
+func (h *HostNetworkManager) bridgeNatRules(bridgeName, upstreamInterface string, addresses []net.IPNet) map[string][]domain.HostNetworkTrafficRule {
+	comment := bridgeNatCommentPrefix + bridgeName
+	rules := map[string][]domain.HostNetworkTrafficRule{
+		"filter": {{
+			Chain:        h.trafficChainName("FORWARD"),
+			Action:       "ACCEPT",
+			InInterface:  bridgeName,
+			OutInterface: upstreamInterface,
+			Comment:      comment,
+		}, {
+			Chain:        h.trafficChainName("FORWARD"),
+			Action:       "ACCEPT",
+			InInterface:  upstreamInterface,
+			OutInterface: bridgeName,
+			State:        "RELATED,ESTABLISHED",
+			Comment:      comment,
+		}},
+	}
+	for _, address := range addresses {
+		if address.IP.To4() == nil {
+			continue
+		}
+		network := net.IPNet{IP: address.IP.Mask(address.Mask), Mask: address.Mask}
+		rules["nat"] = append(rules["nat"], domain.HostNetworkTrafficRule{
+			Chain:        h.trafficChainName("POSTROUTING"),
+			Action:       "MASQUERADE",
+			Source:       network.String(),
+			OutInterface: upstreamInterface,
+			Comment:      comment,
+		})
+	}
+	return rules
+}
+
+//getBridgeNatHostRules gets NAT rules of the bridge that exist on the host in specified table
+func (h *HostNetworkManager) getBridgeNatHostRules(table, bridgeName string) ([]domain.HostNetworkTrafficRule, error) {
+	rules, err := h.GetTableRules(table)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get table rules")
+	}
+	var out []domain.HostNetworkTrafficRule
+	for _, rule := range rules {
+		if rule.Comment == bridgeNatCommentPrefix+bridgeName {
+			out = append(out, rule)
+		}
+	}
+	return out, nil
+}
+
+//getBridgeNatUpstream gets upstream interface of the bridge NAT, empty string if NAT is not set
+func (h *HostNetworkManager) getBridgeNatUpstream(bridgeName string) (string, error) {
+	rules, err := h.getBridgeNatHostRules("filter", bridgeName)
+	if err != nil {
+		return "", err
+	}
+	for _, rule := range rules {
+		if rule.InInterface == bridgeName {
+			return rule.OutInterface, nil
+		}
+	}
+	return "", nil
+}
+
+//syncBridgeNatRules deletes NAT rules of the bridge that are not in rules and creates missing ones
+func (h *HostNetworkManager) syncBridgeNatRules(bridgeName string, rules map[string][]domain.HostNetworkTrafficRule) error {
+	for _, table := range []string{"filter", "nat"} {
+		hostRules, err := h.getBridgeNatHostRules(table, bridgeName)
+		if err != nil {
+			return err
+		}
+		keys := h.trafficRulesKeys(rules[table])
+		hostKeys := h.trafficRulesKeys(hostRules)
+		for i, rule := range hostRules {
+			if !utils.SliceContainsElement(keys, hostKeys[i]) {
+				err = h.deleteTrafficRule(table, rule)
+				if err != nil {
+					return errors.Internal.Wrap(err, "failed to delete bridge NAT rule")
+				}
+			}
+		}
+		for i, rule := range rules[table] {
+			if !utils.SliceContainsElement(hostKeys, keys[i]) {
+				_, err = h.createTrafficRule(table, rule)
+				if err != nil {
+					return errors.Internal.Wrap(err, "failed to create bridge NAT rule")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (h *HostNetworkManager) enableIPv4Forwarding() error {
+	err := os.WriteFile(ipv4ForwardingPath, []byte("1"), 0644)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to enable IPv4 forwarding")
+	}
+	return nil
+}
+
+//SetBridgeNat gives the bridge networks an access to the upstream interface networks,
+//creates masquerade and forward rules in the RoL chains and enables IPv4 forwarding.
+//IPv4 forwarding is not disabled when NAT is unset, because other tools can rely on it
+//
+//Params:
+//	bridgeName - name of the bridge
+//	upstreamInterface - name of the interface through which NAT traffic is sent
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) SetBridgeNat(bridgeName, upstreamInterface string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	bridge, err := netlink.LinkByName(bridgeName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting bridge by name failed")
+	}
+	if bridge.Type() != "bridge" {
+		return errors.Internal.Newf("%s is not a bridge", bridgeName)
+	}
+	_, err = netlink.LinkByName(upstreamInterface)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting upstream interface by name failed")
+	}
+	addresses, err := h.parseLinkAddr(bridge)
+	if err != nil {
+		return errors.Internal.Wrap(err, "error parsing bridge addresses")
+	}
+	err = h.syncBridgeNatRules(bridgeName, h.bridgeNatRules(bridgeName, upstreamInterface, addresses))
+	if err != nil {
+		return err
+	}
+	return h.enableIPv4Forwarding()
+}
+
+//UnsetBridgeNat removes NAT rules of the bridge
+//
+//Params:
+//	bridgeName - name of the bridge
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) UnsetBridgeNat(bridgeName string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.syncBridgeNatRules(bridgeName, map[string][]domain.HostNetworkTrafficRule{})
+}
+
+//SetLinkUp enables the link
+//
+//Params:
+//	linkName - name of the link
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) SetLinkUp(linkName string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.setLinkUp(linkName)
+}
+
+//setLinkUp see SetLinkUp, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) setLinkUp(linkName string) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting link by name failed")
+	}
+	err = netlink.LinkSetUp(link)
+	if err != nil {
+		return errors.Internal.Wrap(err, "link set up failed")
+	}
+	return nil
+}
+
+//DeleteLinkByName deletes interface on host by its name
+//
+//Params:
+//	name - interface name
+//Return
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) DeleteLinkByName(name string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.deleteLinkByName(name)
+}
+
+//deleteLinkByName see DeleteLinkByName, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) deleteLinkByName(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting link by name failed")
+	}
+	err = netlink.LinkDel(link)
+	if err != nil {
+		return errors.Internal.Wrap(err, "deleting link failed")
+	}
+	h.setUnsavedChanges()
+	return nil
+}
+
+//AddrAdd Add new ip address for network interface
+//
+//Params:
+//	linkName - name of the interface
+//	addr - ip address with mask net.IPNet
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) AddrAdd(linkName string, addr net.IPNet) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.addrAdd(linkName, addr)
+}
+
+//addrAdd see AddrAdd, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) addrAdd(linkName string, addr net.IPNet) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting link by name failed")
+	}
+	cidr := addr.String()
+	linkAddr, err := netlink.ParseAddr(cidr)
+	if err != nil {
+		return errors.Internal.Wrap(err, "parse cidr address failed")
+	}
+	err = netlink.AddrAdd(link, linkAddr)
+	if err != nil {
+		return errors.Internal.Wrap(err, "error adding address to link")
+	}
+	h.setUnsavedChanges()
+	return nil
+}
+
+//AddrDelete Delete ip address from network interface
+//
+//Params:
+//	linkName - name of the interface
+//	addr - ip address with mask net.IPNet
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) AddrDelete(linkName string, addr net.IPNet) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.addrDelete(linkName, addr)
+}
+
+//addrDelete see AddrDelete, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) addrDelete(linkName string, addr net.IPNet) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return errors.Internal.Wrap(err, "getting link by name failed")
+	}
+	cidr := addr.String()
+	linkAddr, err := netlink.ParseAddr(cidr)
+	if err != nil {
+		return errors.Internal.Wrap(err, "parse cidr address failed")
+	}
+	err = netlink.AddrDel(link, linkAddr)
+	if err != nil {
+		return errors.Internal.Wrap(err, "error adding address to link")
+	}
+	h.setUnsavedChanges()
+	return nil
+}
+
+//defaultRouteDestination gets the default route destination of the address family
+func (h *HostNetworkManager) defaultRouteDestination(family int) net.IPNet {
+	if family == netlink.FAMILY_V6 {
+		return net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 8*net.IPv6len)}
+	}
+	return net.IPNet{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, 8*net.IPv4len)}
+}
+
+//GetRoutes gets static IPv4 and IPv6 routes of all routing tables that are managed by RoL
+//
+//Return:
+//	[]domain.HostNetworkRoute - slice of routes
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetRoutes() ([]domain.HostNetworkRoute, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "error getting a list of link devices")
+	}
+	linkNames := map[int]string{}
+	for _, link := range links {
+		linkNames[link.Attrs().Index] = link.Attrs().Name
+	}
+	filter := &netlink.Route{Protocol: rolRouteProtocol, Table: unix.RT_TABLE_UNSPEC}
+	out := []domain.HostNetworkRoute{}
+	//families are listed separately, because the default route has no destination to get its family
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		routes, err := netlink.RouteListFiltered(family, filter, netlink.RT_FILTER_PROTOCOL|netlink.RT_FILTER_TABLE)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "error getting a list of routes")
+		}
+		for _, route := range routes {
+			hostRoute := domain.HostNetworkRoute{
+				Destination: h.defaultRouteDestination(family),
+				Gateway:     route.Gw,
+				Interface:   linkNames[route.LinkIndex],
+				Metric:      route.Priority,
+				Table:       route.Table,
+			}
+			if route.Dst != nil {
+				hostRoute.Destination = *route.Dst
+			}
+			if route.Table == unix.RT_TABLE_MAIN {
+				hostRoute.Table = 0
+			}
+			out = append(out, hostRoute)
+		}
+	}
+	return out, nil
+}
+
+//netlinkRoute converts the route to the netlink route that is marked by the RoL route protocol
+func (h *HostNetworkManager) netlinkRoute(route domain.HostNetworkRoute) (*netlink.Route, error) {
+	destination := route.Destination
+	out := &netlink.Route{
+		Dst:      &destination,
+		Gw:       route.Gateway,
+		Protocol: rolRouteProtocol,
+		Priority: route.Metric,
+		Table:    route.Table,
+	}
+	if route.Interface != "" {
+		link, err := netlink.LinkByName(route.Interface)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "getting link by name failed")
+		}
+		out.LinkIndex = link.Attrs().Index
+	}
+	return out, nil
+}
+
+//CreateRoute creates static route that is managed by RoL
+//
+//Params:
+//	route - route entity
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateRoute(route domain.HostNetworkRoute) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createRoute(route)
+}
+
+//createRoute see CreateRoute, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createRoute(route domain.HostNetworkRoute) error {
+	netlinkRoute, err := h.netlinkRoute(route)
+	if err != nil {
+		return err
+	}
+	if route.Gateway == nil {
+		netlinkRoute.Scope = netlink.SCOPE_LINK
+	}
+	err = netlink.RouteAdd(netlinkRoute)
+	if err != nil {
+		return errors.Internal.Wrap(err, "error adding route")
+	}
+	h.setUnsavedChanges()
+	return nil
+}
+
+//DeleteRoute deletes static route that is managed by RoL
+//
+//Params:
+//	route - route entity
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) DeleteRoute(route domain.HostNetworkRoute) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.deleteRoute(route)
+}
+
+//deleteRoute see DeleteRoute, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) deleteRoute(route domain.HostNetworkRoute) error {
+	netlinkRoute, err := h.netlinkRoute(route)
+	if err != nil {
+		return err
+	}
+	//the scope of the route is not known, so any scope is matched
+	netlinkRoute.Scope = netlink.SCOPE_NOWHERE
+	err = netlink.RouteDel(netlinkRoute)
+	if err == unix.ESRCH {
+		return errors.NotFound.Wrap(err, "route is not found")
+	}
+	if err != nil {
+		return errors.Internal.Wrap(err, "error deleting route")
+	}
+	h.setUnsavedChanges()
+	return nil
+}
+
+//trafficChainName gets the RoL chain name for the chain name
+func (h *HostNetworkManager) trafficChainName(chain string) string {
+	if strings.HasPrefix(chain, trafficChainPrefix) {
+		return chain
+	}
+	return trafficChainPrefix + chain
+}
+
+//isJumpAction checks that rule action is a jump to the user chain
+func (h *HostNetworkManager) isJumpAction(action string) bool {
+	return !utils.SliceContainsElement(netfilterTargets, action)
+}
+
+//trafficRuleInRolChains moves the rule and the chain it jumps to into RoL chains
+func (h *HostNetworkManager) trafficRuleInRolChains(rule domain.HostNetworkTrafficRule) domain.HostNetworkTrafficRule {
+	rule.Chain = h.trafficChainName(rule.Chain)
+	if h.isJumpAction(rule.Action) {
+		rule.Action = h.trafficChainName(rule.Action)
+	}
+	return rule
+}
+
+//ensureTrafficChain creates RoL chain and jump to it from the builtin chain with the same name
+func (h *HostNetworkManager) ensureTrafficChain(table, chain string) error {
+	err := h.trafficBackend.CreateChain(table, chain)
+	if err != nil {
+		return errors.Internal.Wrapf(err, "failed to create chain %s", chain)
+	}
+	builtinChain := strings.TrimPrefix(chain, trafficChainPrefix)
+	if !utils.SliceContainsElement(netfilterBuiltinChains[table], builtinChain) {
+		return nil
+	}
+	err = h.trafficBackend.CreateRule(table, domain.HostNetworkTrafficRule{
+		Chain:    builtinChain,
+		Action:   chain,
+		Position: 1,
+	})
+	if err != nil {
+		return errors.Internal.Wrapf(err, "failed to create jump to chain %s", chain)
+	}
+	return nil
+}
+
+//ensureTableTrafficChains creates RoL chains with jumps for all builtin chains of the table
+func (h *HostNetworkManager) ensureTableTrafficChains(table string) error {
+	for _, chain := range netfilterBuiltinChains[table] {
+		err := h.ensureTrafficChain(table, h.trafficChainName(chain))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//CreateTrafficRule Create netfilter traffic rule for specified table.
+//Rule is created in the RoL chain ROL-{chain}, which is jumped from the builtin chain
+//
+//Params:
+//	table - table to create a rule
+//	rule - rule entity, rule is inserted at the rule position or appended if position is 0
+//Return:
+//	domain.HostNetworkTrafficRule - new traffic rule
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateTrafficRule(table string, rule domain.HostNetworkTrafficRule) (domain.HostNetworkTrafficRule, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.createTrafficRule(table, rule)
+}
+
+//createTrafficRule see CreateTrafficRule, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) createTrafficRule(table string, rule domain.HostNetworkTrafficRule) (domain.HostNetworkTrafficRule, error) {
+	rule = h.trafficRuleInRolChains(rule)
+	err := h.ensureTrafficChain(table, rule.Chain)
+	if err != nil {
+		return domain.HostNetworkTrafficRule{}, err
+	}
+	if h.isJumpAction(rule.Action) {
+		err = h.ensureTrafficChain(table, rule.Action)
+		if err != nil {
+			return domain.HostNetworkTrafficRule{}, err
+		}
+	}
+	err = h.trafficBackend.CreateRule(table, rule)
+	if err != nil {
+		return domain.HostNetworkTrafficRule{}, errors.Internal.Wrap(err, "failed to create traffic rule")
+	}
+	h.setUnsavedChanges()
+	return rule, nil
+}
+
+//DeleteTrafficRule Delete netfilter traffic rule in specified table from the RoL chain
+//
+//Params:
+//	table - table to delete a rule
+//	rule - rule entity, rule position is ignored
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) DeleteTrafficRule(table string, rule domain.HostNetworkTrafficRule) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.deleteTrafficRule(table, rule)
+}
+
+//deleteTrafficRule see DeleteTrafficRule, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) deleteTrafficRule(table string, rule domain.HostNetworkTrafficRule) error {
+	err := h.trafficBackend.DeleteRule(table, h.trafficRuleInRolChains(rule))
+	if err != nil {
+		if errors.As(err, errors.NotFound) {
+			return err
+		}
+		return errors.Internal.Wrap(err, "failed to delete traffic rule")
+	}
+	h.setUnsavedChanges()
+	return nil
+}
+
+//GetChainRules Get selected netfilter RoL chain rules at specified table
+//
+//Params:
+//	table - table to get a rules
+//	chain - chain where we get the rules, INPUT and ROL-INPUT are the same RoL chain
+//Return:
+//	[]domain.HostNetworkTrafficRule - slice of rules
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetChainRules(table string, chain string) ([]domain.HostNetworkTrafficRule, error) {
+	return h.trafficBackend.GetChainRules(table, h.trafficChainName(chain))
+}
+
+//GetTableRules Get specified netfilter table rules of the RoL chains
+//
+//Params:
+//	table - table to get a rules
+//Return:
+//	[]domain.HostNetworkTrafficRule - slice of rules
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetTableRules(table string) ([]domain.HostNetworkTrafficRule, error) {
+	rules, err := h.trafficBackend.GetTableRules(table)
+	if err != nil {
+		return nil, err
+	}
+	return h.rolTrafficRules(rules), nil
+}
+
+//rolTrafficRules filters rules of the RoL chains, rules of the other chains are managed by other tools
+func (h *HostNetworkManager) rolTrafficRules(rules []domain.HostNetworkTrafficRule) []domain.HostNetworkTrafficRule {
+	var out []domain.HostNetworkTrafficRule
+	for _, rule := range rules {
+		if strings.HasPrefix(rule.Chain, trafficChainPrefix) {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
+
+//GetConfiguration Gets current host network configuration
+//
+//Return:
+//	domain.HostNetworkConfig - current host network configuration
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetConfiguration() (domain.HostNetworkConfig, error) {
+	config := domain.HostNetworkConfig{}
+	networkInterfaces, err := h.GetList()
+	if err != nil {
+		return config, errors.Internal.Wrap(err, "failed to get list of host network interfaces")
+	}
+	for _, inter := range networkInterfaces {
+		if inter.GetType() == "vlan" {
+			config.Vlans = append(config.Vlans, inter.(domain.HostNetworkVlan))
+		} else if inter.GetType() == "device" {
+			config.Devices = append(config.Devices, inter.(domain.HostNetworkDevice))
+		} else if inter.GetType() == "bridge" {
+			config.Bridges = append(config.Bridges, inter.(domain.HostNetworkBridge))
+		} else if inter.GetType() == "bond" {
+			config.Bonds = append(config.Bonds, inter.(domain.HostNetworkBond))
+		} else if inter.GetType() == "veth" {
+			config.Veths = append(config.Veths, inter.(domain.HostNetworkVeth))
+		}
+	}
+	config.Namespaces, err = h.GetNamespaces()
+	if err != nil {
+		return config, errors.Internal.Wrap(err, "failed to get list of host network namespaces")
+	}
+	config.Routes, err = h.GetRoutes()
+	if err != nil {
+		return config, errors.Internal.Wrap(err, "failed to get list of host routes")
+	}
+	for _, table := range netfilterTables {
+		rules, err := h.GetTableRules(table)
+		if err != nil {
+			return config, errors.Internal.Wrap(err, "failed to get table rules")
+		}
+
+		h.setTrafficRulesConfigField(table, rules, &config)
+	}
+	return config, nil
+}
+
+//SaveConfiguration save current host network configuration to the configuration storage as a new revision
+//
+//Params:
+//	author - who saved the configuration
+//	requestID - ID of the API request that saved the configuration, uuid.Nil if there is no request
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) SaveConfiguration(author string, requestID uuid.UUID) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	config, err := h.GetConfiguration()
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to get current host network configuration")
+	}
+	err = h.configStorage.SaveConfig(config, author, requestID)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to save host network config to storage")
+	}
+	h.hasUnsavedChanges = false
+	h.stopConfirmTimer()
+	return nil
+}
+
+func (h *HostNetworkManager) bridgeExistOnHost(links []interfaces.IHostNetworkLink, bridgeName string) bool {
+	for _, inter := range links {
+		if inter.GetType() == "bridge" && inter.GetName() == bridgeName {
+			return true
+		}
+	}
 	return false
 }
 
@@ -558,6 +1449,24 @@ func (h *HostNetworkManager) bridgeExistInConfig(config domain.HostNetworkConfig
 	return false
 }
 
+func (h *HostNetworkManager) bondExistOnHost(links []interfaces.IHostNetworkLink, bondName string) bool {
+	for _, inter := range links {
+		if inter.GetType() == "bond" && inter.GetName() == bondName {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *HostNetworkManager) bondExistInConfig(config domain.HostNetworkConfig, bondName string) bool {
+	for _, bond := range config.Bonds {
+		if bond.GetName() == bondName {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *HostNetworkManager) vlanExistOnHost(links []interfaces.IHostNetworkLink, vlanName string) bool {
 	for _, inter := range links {
 		if inter.GetType() == "vlan" && inter.GetName() == vlanName {
@@ -587,243 +1496,928 @@ func (h *HostNetworkManager) addressExistOnHostLink(links []interfaces.IHostNetw
 			}
 		}
 	}
-	return false
+	return false
+}
+
+func (h *HostNetworkManager) addressExistInLinkConfig(config domain.HostNetworkConfig, linkName string, address net.IPNet) bool {
+	for _, inter := range config.Vlans {
+		if inter.GetName() == linkName {
+			addresses := inter.GetAddresses()
+			for _, addr := range addresses {
+				if addr.String() == address.String() {
+					return true
+				}
+			}
+		}
+	}
+	for _, inter := range config.Devices {
+		if inter.GetName() == linkName {
+			addresses := inter.GetAddresses()
+			for _, addr := range addresses {
+				if addr.String() == address.String() {
+					return true
+				}
+			}
+		}
+	}
+	for _, inter := range config.Bridges {
+		if inter.GetName() == linkName {
+			addresses := inter.GetAddresses()
+			for _, addr := range addresses {
+				if addr.String() == address.String() {
+					return true
+				}
+			}
+		}
+	}
+	for _, inter := range config.Bonds {
+		if inter.GetName() == linkName {
+			addresses := inter.GetAddresses()
+			for _, addr := range addresses {
+				if addr.String() == address.String() {
+					return true
+				}
+			}
+		}
+	}
+	for _, inter := range config.Veths {
+		if inter.GetName() == linkName {
+			addresses := inter.GetAddresses()
+			for _, addr := range addresses {
+				if addr.String() == address.String() {
+					return true
+				}
+			}
+		}
+	}
+	//TODO: If we add new type of the interfaces, we must not forget to add it here.
+	return false
+}
+
+func (h *HostNetworkManager) setTrafficRulesConfigField(table string, rule []domain.HostNetworkTrafficRule, config *domain.HostNetworkConfig) {
+	switch table {
+	case "filter":
+		config.TrafficRules.Filter = rule
+	case "nat":
+		config.TrafficRules.NAT = rule
+	case "mangle":
+		config.TrafficRules.Mangle = rule
+	case "raw":
+		config.TrafficRules.Raw = rule
+	case "security":
+		config.TrafficRules.Security = rule
+	}
+}
+
+func (h *HostNetworkManager) getTrafficRulesConfigField(table string, config domain.HostNetworkConfig) []domain.HostNetworkTrafficRule {
+	switch table {
+	case "filter":
+		return config.TrafficRules.Filter
+	case "nat":
+		return config.TrafficRules.NAT
+	case "mangle":
+		return config.TrafficRules.Mangle
+	case "raw":
+		return config.TrafficRules.Raw
+	case "security":
+		return config.TrafficRules.Security
+	default:
+		return nil
+	}
+}
+
+//trafficRuleKey gets the rule without position to compare rules specs
+func (h *HostNetworkManager) trafficRuleKey(rule domain.HostNetworkTrafficRule) domain.HostNetworkTrafficRule {
+	rule.Position = 0
+	//configurations saved from iptables statistics have any address as 0.0.0.0/0
+	if rule.Source == "0.0.0.0/0" {
+		rule.Source = ""
+	}
+	if rule.Destination == "0.0.0.0/0" {
+		rule.Destination = ""
+	}
+	return rule
+}
+
+func (h *HostNetworkManager) trafficRulesKeys(rules []domain.HostNetworkTrafficRule) []domain.HostNetworkTrafficRule {
+	var keys []domain.HostNetworkTrafficRule
+	for _, rule := range rules {
+		keys = append(keys, h.trafficRuleKey(rule))
+	}
+	return keys
+}
+
+//configLinks gets RoL links from the config, links of the other tools are not managed
+func (h *HostNetworkManager) configLinks(config domain.HostNetworkConfig) []interfaces.IHostNetworkLink {
+	var links []interfaces.IHostNetworkLink
+	for _, vlan := range config.Vlans {
+		if strings.Contains(vlan.Name, "rol.") {
+			links = append(links, vlan)
+		}
+	}
+	for _, bond := range config.Bonds {
+		if strings.Contains(bond.Name, "rol.bond.") {
+			links = append(links, bond)
+		}
+	}
+	for _, bridge := range config.Bridges {
+		if strings.Contains(bridge.Name, "rol.br.") {
+			links = append(links, bridge)
+		}
+	}
+	for _, veth := range config.Veths {
+		if h.isRolVeth(veth.Name) {
+			links = append(links, veth)
+		}
+	}
+	return links
+}
+
+//isRolLink checks that the host link is created by RoL
+func (h *HostNetworkManager) isRolLink(link interfaces.IHostNetworkLink) bool {
+	switch link.GetType() {
+	case "vlan":
+		return strings.Contains(link.GetName(), "rol.")
+	case "bond":
+		return strings.Contains(link.GetName(), "rol.bond.")
+	case "bridge":
+		return strings.Contains(link.GetName(), "rol.br.")
+	case "veth":
+		return h.isRolVeth(link.GetName())
+	}
+	return false
+}
+
+func (h *HostNetworkManager) linkExistInConfig(config domain.HostNetworkConfig, link interfaces.IHostNetworkLink) bool {
+	switch link.GetType() {
+	case "vlan":
+		return h.vlanExistInConfig(config, link.GetName())
+	case "bond":
+		return h.bondExistInConfig(config, link.GetName())
+	case "bridge":
+		return h.bridgeExistInConfig(config, link.GetName())
+	case "veth":
+		return h.vethPairExistInConfig(config, link.GetName())
+	}
+	return false
+}
+
+func (h *HostNetworkManager) linkExistOnHost(hostLinks []interfaces.IHostNetworkLink, link interfaces.IHostNetworkLink) bool {
+	switch link.GetType() {
+	case "vlan":
+		return h.vlanExistOnHost(hostLinks, link.GetName())
+	case "bond":
+		return h.bondExistOnHost(hostLinks, link.GetName())
+	case "bridge":
+		return h.bridgeExistOnHost(hostLinks, link.GetName())
+	case "veth":
+		for _, inter := range hostLinks {
+			if inter.GetType() == "veth" && h.vethPairName(inter.GetName()) == h.vethPairName(link.GetName()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//vethPairExistInConfig checks that any end of the RoL veth pair is in the config on the host or in a namespace
+func (h *HostNetworkManager) vethPairExistInConfig(config domain.HostNetworkConfig, vethName string) bool {
+	pairName := h.vethPairName(vethName)
+	for _, veth := range config.Veths {
+		if h.isRolVeth(veth.Name) && h.vethPairName(veth.Name) == pairName {
+			return true
+		}
+	}
+	for _, namespace := range config.Namespaces {
+		for _, link := range namespace.Links {
+			if h.isRolVeth(link.Name) && h.vethPairName(link.Name) == pairName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//linkNamespaceInConfig gets the namespace of the link in the config, empty string if the link is on the host
+func (h *HostNetworkManager) linkNamespaceInConfig(config domain.HostNetworkConfig, linkName string) string {
+	for _, namespace := range config.Namespaces {
+		for _, link := range namespace.Links {
+			if link.Name == linkName {
+				return namespace.Name
+			}
+		}
+	}
+	return ""
+}
+
+//vethPairChangePlanned checks that the change of the veth pair is already in the changes
+func (h *HostNetworkManager) vethPairChangePlanned(changes []domain.HostNetworkLinkChange, vethName string) bool {
+	for _, change := range changes {
+		if change.Type == "veth" && h.vethPairName(change.Name) == h.vethPairName(vethName) {
+			return true
+		}
+	}
+	return false
+}
+
+//planVethCreation plans creation of the RoL veth pair if none of its ends exists on the host or in the namespaces
+func (h *HostNetworkManager) planVethCreation(vethName string, hostLinks []interfaces.IHostNetworkLink,
+	hostNamespaces []domain.HostNetworkNamespace, plan *domain.HostNetworkPlan) {
+	if h.linkExistOnHost(hostLinks, domain.HostNetworkVeth{HostNetworkLink: domain.HostNetworkLink{Name: vethName, Type: "veth"}}) ||
+		h.vethPairChangePlanned(plan.CreateLinks, vethName) {
+		return
+	}
+	for _, namespace := range hostNamespaces {
+		for _, link := range namespace.Links {
+			if h.isRolVeth(link.Name) && h.vethPairName(link.Name) == h.vethPairName(vethName) {
+				return
+			}
+		}
+	}
+	plan.CreateLinks = append(plan.CreateLinks, domain.HostNetworkLinkChange{
+		Name: vethPrefix + h.vethPairName(vethName),
+		Type: "veth",
+	})
+}
+
+//linkSlaves gets slaves of the bond or bridge, nil for the other links
+func (h *HostNetworkManager) linkSlaves(link interfaces.IHostNetworkLink) []string {
+	switch l := link.(type) {
+	case domain.HostNetworkBond:
+		return l.GetSlaves()
+	case domain.HostNetworkBridge:
+		return l.GetSlaves()
+	}
+	return nil
+}
+
+//planReplacedLinks plans deletion of the RoL bonds which mode or MII monitoring frequency differs from the config,
+//they can't be changed while the bond exists. Host links are returned without the replaced bonds, so the bonds are
+//planned for the creation with their addresses and slaves like the missing links
+func (h *HostNetworkManager) planReplacedLinks(config domain.HostNetworkConfig, hostLinks []interfaces.IHostNetworkLink,
+	plan *domain.HostNetworkPlan) []interfaces.IHostNetworkLink {
+	var replaced []string
+	for _, inter := range hostLinks {
+		hostBond, ok := inter.(domain.HostNetworkBond)
+		if !ok || !h.isRolLink(inter) {
+			continue
+		}
+		for _, bond := range config.Bonds {
+			if bond.Name == hostBond.Name && (bond.Mode != hostBond.Mode || bond.Miimon != hostBond.Miimon) {
+				plan.ReplaceLinks = append(plan.ReplaceLinks, domain.HostNetworkLinkChange{
+					Name:   hostBond.Name,
+					Type:   hostBond.Type,
+					Mode:   hostBond.Mode,
+					Miimon: hostBond.Miimon,
+				})
+				replaced = append(replaced, hostBond.Name)
+			}
+		}
+	}
+	if len(replaced) == 0 {
+		return hostLinks
+	}
+	var out []interfaces.IHostNetworkLink
+	for _, inter := range hostLinks {
+		if utils.SliceContainsElement(replaced, inter.GetName()) {
+			continue
+		}
+		//the deleted bond is removed from its master
+		if bridge, ok := inter.(domain.HostNetworkBridge); ok {
+			var slaves []string
+			for _, slave := range bridge.Slaves {
+				if !utils.SliceContainsElement(replaced, slave) {
+					slaves = append(slaves, slave)
+				}
+			}
+			bridge.Slaves = slaves
+			inter = bridge
+		}
+		out = append(out, inter)
+	}
+	return out
+}
+
+//planRemovedLinks plans deletion of the RoL links, addresses and slaves that are not in the config
+func (h *HostNetworkManager) planRemovedLinks(config domain.HostNetworkConfig, hostLinks []interfaces.IHostNetworkLink, plan *domain.HostNetworkPlan) {
+	configLinks := h.configLinks(config)
+	for _, inter := range hostLinks {
+		if !h.isRolLink(inter) {
+			continue
+		}
+		if !h.linkExistInConfig(config, inter) {
+			//deletion of the one veth end deletes the pair
+			if inter.GetType() != "veth" || !h.vethPairChangePlanned(plan.DeleteLinks, inter.GetName()) {
+				plan.DeleteLinks = append(plan.DeleteLinks, domain.HostNetworkLinkChange{
+					Name: inter.GetName(),
+					Type: inter.GetType(),
+				})
+			}
+			continue
+		}
+		//addresses of the link are lost when it's moved into the namespace
+		if h.linkNamespaceInConfig(config, inter.GetName()) != "" {
+			continue
+		}
+		for _, address := range inter.GetAddresses() {
+			if !h.addressExistInLinkConfig(config, inter.GetName(), address) {
+				plan.Addresses = append(plan.Addresses, domain.HostNetworkAddressChange{
+					Action:   "delete",
+					LinkName: inter.GetName(),
+					Address:  address,
+				})
+			}
+		}
+		for _, configLink := range configLinks {
+			if configLink.GetName() != inter.GetName() {
+				continue
+			}
+			for _, slave := range h.linkSlaves(inter) {
+				if !utils.SliceContainsElement(h.linkSlaves(configLink), slave) {
+					plan.Slaves = append(plan.Slaves, domain.HostNetworkSlaveChange{
+						Action: "delete",
+						Slave:  slave,
+						Master: inter.GetName(),
+					})
+				}
+			}
+		}
+	}
 }
 
-func (h *HostNetworkManager) addressExistInLinkConfig(config domain.HostNetworkConfig, linkName string, address net.IPNet) bool {
-	for _, inter := range config.Vlans {
-		if inter.GetName() == linkName {
-			addresses := inter.GetAddresses()
-			for _, addr := range addresses {
-				if addr.String() == address.String() {
-					return true
+//planConfigLinks plans creation of the RoL links from the config and addition of their addresses and slaves
+func (h *HostNetworkManager) planConfigLinks(config domain.HostNetworkConfig, hostLinks []interfaces.IHostNetworkLink,
+	hostNamespaces []domain.HostNetworkNamespace, plan *domain.HostNetworkPlan) {
+	for _, link := range h.configLinks(config) {
+		currSlaves := []string{}
+		if link.GetType() == "veth" {
+			h.planVethCreation(link.GetName(), hostLinks, hostNamespaces, plan)
+		} else if h.linkExistOnHost(hostLinks, link) {
+			for _, inter := range hostLinks {
+				if inter.GetName() == link.GetName() {
+					currSlaves = h.linkSlaves(inter)
 				}
 			}
+		} else {
+			change := domain.HostNetworkLinkChange{
+				Name: link.GetName(),
+				Type: link.GetType(),
+			}
+			switch l := link.(type) {
+			case domain.HostNetworkVlan:
+				change.Parent = l.Parent
+				change.VlanID = l.VlanID
+			case domain.HostNetworkBond:
+				change.Mode = l.Mode
+				change.Miimon = l.Miimon
+			}
+			plan.CreateLinks = append(plan.CreateLinks, change)
 		}
-	}
-	for _, inter := range config.Devices {
-		if inter.GetName() == linkName {
-			addresses := inter.GetAddresses()
-			for _, addr := range addresses {
-				if addr.String() == address.String() {
-					return true
-				}
+		for _, address := range link.GetAddresses() {
+			if !h.addressExistOnHostLink(hostLinks, link.GetName(), address) {
+				plan.Addresses = append(plan.Addresses, domain.HostNetworkAddressChange{
+					Action:   "add",
+					LinkName: link.GetName(),
+					Address:  address,
+				})
 			}
 		}
-	}
-	for _, inter := range config.Bridges {
-		if inter.GetName() == linkName {
-			addresses := inter.GetAddresses()
-			for _, addr := range addresses {
-				if addr.String() == address.String() {
-					return true
-				}
+		for _, slave := range h.linkSlaves(link) {
+			if !utils.SliceContainsElement(currSlaves, slave) {
+				plan.Slaves = append(plan.Slaves, domain.HostNetworkSlaveChange{
+					Action: "add",
+					Slave:  slave,
+					Master: link.GetName(),
+				})
 			}
 		}
 	}
-	//TODO: If we add new type of the interfaces, we must not forget to add it here.
-	return false
 }
 
-func (h *HostNetworkManager) loadVlanConfiguration(config domain.HostNetworkConfig) error {
-	hostLinks, err := h.GetList()
-	if err != nil {
-		return errors.Internal.Wrap(err, "failed to get list of host network interfaces")
-	}
-	// Add all settings from config to vlan interfaces
-	for _, vlan := range config.Vlans {
-		//Skip all vlans that not configured by our system
-		if !strings.Contains(vlan.Name, "rol.") {
+//namespaceLinkInConfig gets the link of the namespace in the config
+func (h *HostNetworkManager) namespaceLinkInConfig(config domain.HostNetworkConfig, namespace, linkName string) (domain.HostNetworkLink, bool) {
+	for _, configNamespace := range config.Namespaces {
+		if configNamespace.Name != namespace {
 			continue
 		}
-		vlanExist := h.vlanExistOnHost(hostLinks, vlan.Name)
-		if !vlanExist {
-			vlanName, err := h.CreateVlan(vlan.Parent, vlan.VlanID)
-			if err != nil {
-				return errors.Internal.Wrap(err, "error when creating a vlan")
+		for _, link := range configNamespace.Links {
+			if link.Name == linkName {
+				return link, true
 			}
-			for _, addr := range vlan.Addresses {
-				err = h.AddrAdd(vlanName, addr)
-				if err != nil {
-					return errors.Internal.Wrap(err, "failed set address to vlan")
+		}
+	}
+	return domain.HostNetworkLink{}, false
+}
+
+//planNamespaces plans creation and deletion of the RoL namespaces, moving of the links between the host and
+//the namespaces and changes of the namespaces links addresses. Links are moved back to the host before
+//the namespace deletion, so veth pairs are not deleted with the namespace
+func (h *HostNetworkManager) planNamespaces(config domain.HostNetworkConfig, hostLinks []interfaces.IHostNetworkLink,
+	hostNamespaces []domain.HostNetworkNamespace, plan *domain.HostNetworkPlan) {
+	linkNamespaces := map[string]string{}
+	for _, namespace := range hostNamespaces {
+		namespaceInConfig := false
+		for _, configNamespace := range config.Namespaces {
+			if configNamespace.Name == namespace.Name {
+				namespaceInConfig = true
+			}
+		}
+		for _, link := range namespace.Links {
+			configLink, inConfig := h.namespaceLinkInConfig(config, namespace.Name, link.Name)
+			if !inConfig {
+				plan.NamespaceLinks = append(plan.NamespaceLinks, domain.HostNetworkNamespaceLinkChange{
+					Action:    "delete",
+					LinkName:  link.Name,
+					Namespace: namespace.Name,
+				})
+				if h.isRolVeth(link.Name) && !h.vethPairExistInConfig(config, link.Name) &&
+					!h.vethPairChangePlanned(plan.DeleteLinks, link.Name) {
+					plan.DeleteLinks = append(plan.DeleteLinks, domain.HostNetworkLinkChange{
+						Name: link.Name,
+						Type: link.Type,
+					})
 				}
+				continue
 			}
-		} else {
-			for _, addr := range vlan.Addresses {
-				h.addressExistOnHostLink(hostLinks, vlan.GetName(), addr)
-				err = h.AddrAdd(vlan.GetName(), addr)
-				if err != nil {
-					return errors.Internal.Wrap(err, "failed set address to vlan")
+			linkNamespaces[link.Name] = namespace.Name
+			for _, address := range link.Addresses {
+				if !h.addressExist(configLink.Addresses, address) {
+					plan.Addresses = append(plan.Addresses, domain.HostNetworkAddressChange{
+						Action:    "delete",
+						LinkName:  link.Name,
+						Namespace: namespace.Name,
+						Address:   address,
+					})
 				}
 			}
 		}
-	}
-	// Remove all configurations that not exist in config
-	networkInterfaces, err := h.GetList()
-	if err != nil {
-		return errors.Internal.Wrap(err, "failed to get list of host network interfaces")
-	}
-	for _, inter := range networkInterfaces {
-		if !strings.Contains(inter.GetName(), "rol.") && inter.GetType() != "vlan" {
-			continue
+		if !namespaceInConfig {
+			plan.DeleteNamespaces = append(plan.DeleteNamespaces, namespace.Name)
 		}
-		if !h.vlanExistInConfig(config, inter.GetName()) {
-			err := h.DeleteLinkByName(inter.GetName())
-			if err != nil {
-				return errors.Internal.Wrap(err, "delete link by name error")
+	}
+	for _, configNamespace := range config.Namespaces {
+		namespaceExist := false
+		for _, namespace := range hostNamespaces {
+			if namespace.Name == configNamespace.Name {
+				namespaceExist = true
 			}
-		} else if h.vlanExistInConfig(config, inter.GetName()) {
-			addresses := inter.GetAddresses()
-			for _, address := range addresses {
-				if !h.addressExistInLinkConfig(config, inter.GetName(), address) {
-					err = h.AddrDelete(inter.GetName(), address)
-					if err != nil {
-						return errors.Internal.Wrap(err, "failed delete address from vlan")
+		}
+		if !namespaceExist {
+			plan.CreateNamespaces = append(plan.CreateNamespaces, configNamespace.Name)
+		}
+		for _, link := range configNamespace.Links {
+			var currAddresses []net.IPNet
+			if linkNamespaces[link.Name] == configNamespace.Name {
+				for _, namespace := range hostNamespaces {
+					if namespace.Name != configNamespace.Name {
+						continue
 					}
+					for _, nsLink := range namespace.Links {
+						if nsLink.Name == link.Name {
+							currAddresses = nsLink.Addresses
+						}
+					}
+				}
+			} else {
+				if h.isRolVeth(link.Name) {
+					h.planVethCreation(link.Name, hostLinks, hostNamespaces, plan)
+				}
+				plan.NamespaceLinks = append(plan.NamespaceLinks, domain.HostNetworkNamespaceLinkChange{
+					Action:    "add",
+					LinkName:  link.Name,
+					Namespace: configNamespace.Name,
+				})
+			}
+			for _, address := range link.Addresses {
+				if !h.addressExist(currAddresses, address) {
+					plan.Addresses = append(plan.Addresses, domain.HostNetworkAddressChange{
+						Action:    "add",
+						LinkName:  link.Name,
+						Namespace: configNamespace.Name,
+						Address:   address,
+					})
 				}
 			}
 		}
 	}
-	return nil
 }
 
-func (h *HostNetworkManager) loadBridgeConfiguration(config domain.HostNetworkConfig) error {
-	hostLinks, err := h.GetList()
+//addressExist checks that the address is in the addresses
+func (h *HostNetworkManager) addressExist(addresses []net.IPNet, address net.IPNet) bool {
+	for _, addr := range addresses {
+		if addr.String() == address.String() {
+			return true
+		}
+	}
+	return false
+}
+
+//routeMetric gets the route metric as the kernel sets it, IPv6 routes without metric get the metric 1024
+func (h *HostNetworkManager) routeMetric(route domain.HostNetworkRoute) int {
+	if route.Metric == 0 && route.Destination.IP.To4() == nil {
+		return 1024
+	}
+	return route.Metric
+}
+
+//routeTable gets the route table id as the kernel sets it
+func (h *HostNetworkManager) routeTable(route domain.HostNetworkRoute) int {
+	if route.Table == 0 {
+		return unix.RT_TABLE_MAIN
+	}
+	return route.Table
+}
+
+//routeExist checks that the route is in the routes, the route without interface matches the route via any interface
+func (h *HostNetworkManager) routeExist(routes []domain.HostNetworkRoute, route domain.HostNetworkRoute) bool {
+	for _, r := range routes {
+		if r.Destination.String() == route.Destination.String() && r.Gateway.Equal(route.Gateway) &&
+			h.routeMetric(r) == h.routeMetric(route) && h.routeTable(r) == h.routeTable(route) &&
+			(r.Interface == "" || route.Interface == "" || r.Interface == route.Interface) {
+			return true
+		}
+	}
+	return false
+}
+
+//planRoutes plans deletion of the RoL routes that are not in the config and creation of the missing routes
+func (h *HostNetworkManager) planRoutes(config domain.HostNetworkConfig, plan *domain.HostNetworkPlan) error {
+	routes, err := h.GetRoutes()
 	if err != nil {
-		return errors.Internal.Wrap(err, "failed to get list of host network interfaces")
+		return errors.Internal.Wrap(err, "failed to get list of host routes")
+	}
+	var hostRoutes []domain.HostNetworkRoute
+	for _, route := range routes {
+		//routes of the replaced links are deleted with them
+		replaced := false
+		for _, change := range plan.ReplaceLinks {
+			replaced = replaced || change.Name == route.Interface
+		}
+		if !replaced {
+			hostRoutes = append(hostRoutes, route)
+		}
 	}
-	// Add all settings from config to bridge interfaces
-	for _, bridge := range config.Bridges {
-		//Skip all bridges that not configured by our system
-		if !strings.Contains(bridge.Name, "rol.br.") {
-			continue
+	for _, route := range hostRoutes {
+		if !h.routeExist(config.Routes, route) {
+			plan.DeleteRoutes = append(plan.DeleteRoutes, route)
 		}
-		bridgeExist := h.bridgeExistOnHost(hostLinks, bridge.Name)
-		if !bridgeExist {
-			bridgeName, err := h.CreateBridge(bridge.Name[7:])
-			if err != nil {
-				return errors.Internal.Wrap(err, "error when creating a bridge")
-			}
-			for _, addr := range bridge.Addresses {
-				err = h.AddrAdd(bridgeName, addr)
-				if err != nil {
-					return errors.Internal.Wrap(err, "failed set address to bridge")
-				}
-			}
-		} else {
-			for _, addr := range bridge.Addresses {
-				h.addressExistOnHostLink(hostLinks, bridge.GetName(), addr)
-				err = h.AddrAdd(bridge.GetName(), addr)
-				if err != nil {
-					return errors.Internal.Wrap(err, "failed set address to bridge")
-				}
-			}
+	}
+	for _, route := range config.Routes {
+		if !h.routeExist(hostRoutes, route) {
+			plan.CreateRoutes = append(plan.CreateRoutes, route)
 		}
 	}
-	// Remove all configurations that not exist in config
-	for _, inter := range hostLinks {
-		if !strings.Contains(inter.GetName(), "rol.br.") && inter.GetType() != "bridge" {
-			continue
+	return nil
+}
+
+//configTrafficRules gets RoL chains rules of the config table, NAT rules are generated from the bridges NAT settings
+func (h *HostNetworkManager) configTrafficRules(table string, config domain.HostNetworkConfig) []domain.HostNetworkTrafficRule {
+	var rules []domain.HostNetworkTrafficRule
+	//configurations saved before RoL chains were introduced have rules of all chains, they are not restored
+	for _, rule := range h.rolTrafficRules(h.getTrafficRulesConfigField(table, config)) {
+		if !strings.HasPrefix(rule.Comment, bridgeNatCommentPrefix) {
+			rules = append(rules, rule)
 		}
-		if !h.bridgeExistInConfig(config, inter.GetName()) {
-			err := h.DeleteLinkByName(inter.GetName())
-			if err != nil {
-				return errors.Internal.Wrap(err, "delete link by name error")
+	}
+	for _, bridge := range config.Bridges {
+		if bridge.Nat && strings.Contains(bridge.Name, "rol.br.") {
+			rules = append(rules, h.bridgeNatRules(bridge.Name, bridge.UpstreamInterface, bridge.Addresses)[table]...)
+		}
+	}
+	return rules
+}
+
+//planTrafficRules plans deletion of the extra RoL chains rules and creation of the missing rules at their positions
+func (h *HostNetworkManager) planTrafficRules(config domain.HostNetworkConfig, plan *domain.HostNetworkPlan) error {
+	for _, table := range netfilterTables {
+		rules, err := h.GetTableRules(table)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to get table rules")
+		}
+		configRules := h.configTrafficRules(table, config)
+		configKeys := h.trafficRulesKeys(configRules)
+		hostKeys := h.trafficRulesKeys(rules)
+		//extra rules are deleted first, so missing rules are inserted at their saved positions
+		for i, rule := range rules {
+			if !utils.SliceContainsElement(configKeys, hostKeys[i]) {
+				plan.TrafficRules = append(plan.TrafficRules, domain.HostNetworkTrafficRuleChange{
+					Action: "delete",
+					Table:  table,
+					Rule:   rule,
+				})
 			}
-		} else if h.bridgeExistInConfig(config, inter.GetName()) {
-			addresses := inter.GetAddresses()
-			for _, address := range addresses {
-				if !h.addressExistInLinkConfig(config, inter.GetName(), address) {
-					err = h.AddrDelete(inter.GetName(), address)
-					if err != nil {
-						return errors.Internal.Wrap(err, "failed delete address from vlan")
-					}
-				}
+		}
+		for i, rule := range configRules {
+			if !utils.SliceContainsElement(hostKeys, configKeys[i]) {
+				createRule := configKeys[i]
+				createRule.Position = rule.Position
+				plan.TrafficRules = append(plan.TrafficRules, domain.HostNetworkTrafficRuleChange{
+					Action: "add",
+					Table:  table,
+					Rule:   createRule,
+				})
 			}
 		}
 	}
 	return nil
 }
 
-func (h *HostNetworkManager) setTrafficRulesConfigField(table string, rule []domain.HostNetworkTrafficRule, config *domain.HostNetworkConfig) {
-	switch table {
-	case "filter":
-		config.TrafficRules.Filter = rule
-	case "nat":
-		config.TrafficRules.NAT = rule
-	case "mangle":
-		config.TrafficRules.Mangle = rule
-	case "raw":
-		config.TrafficRules.Raw = rule
-	case "security":
-		config.TrafficRules.Security = rule
+//planConfiguration compares the config with the host network, slaves and addresses are removed before the addition,
+//so the link can be moved to another master and the address can be moved to another link
+func (h *HostNetworkManager) planConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error) {
+	plan := domain.HostNetworkPlan{}
+	hostLinks, err := h.GetList()
+	if err != nil {
+		return plan, errors.Internal.Wrap(err, "failed to get list of host network interfaces")
+	}
+	hostNamespaces, err := h.GetNamespaces()
+	if err != nil {
+		return plan, errors.Internal.Wrap(err, "failed to get list of host network namespaces")
 	}
+	hostLinks = h.planReplacedLinks(config, hostLinks, &plan)
+	h.planRemovedLinks(config, hostLinks, &plan)
+	h.planConfigLinks(config, hostLinks, hostNamespaces, &plan)
+	h.planNamespaces(config, hostLinks, hostNamespaces, &plan)
+	err = h.planRoutes(config, &plan)
+	if err != nil {
+		return plan, err
+	}
+	err = h.planTrafficRules(config, &plan)
+	if err != nil {
+		return plan, err
+	}
+	return plan, nil
 }
 
-func (h *HostNetworkManager) getTrafficRulesConfigField(table string, config domain.HostNetworkConfig) []domain.HostNetworkTrafficRule {
-	switch table {
-	case "filter":
-		return config.TrafficRules.Filter
-	case "nat":
-		return config.TrafficRules.NAT
-	case "mangle":
-		return config.TrafficRules.Mangle
-	case "raw":
-		return config.TrafficRules.Raw
-	case "security":
-		return config.TrafficRules.Security
+func (h *HostNetworkManager) applyLinkCreation(change domain.HostNetworkLinkChange) error {
+	var err error
+	switch change.Type {
+	case "vlan":
+		_, err = h.createVlan(change.Parent, change.VlanID)
+	case "bridge":
+		_, err = h.createBridge(strings.TrimPrefix(change.Name, "rol.br."))
+	case "bond":
+		_, err = h.createBond(strings.TrimPrefix(change.Name, "rol.bond."), change.Mode, change.Miimon)
+	case "veth":
+		_, err = h.createVeth(h.vethPairName(change.Name))
+		if err == nil {
+			err = h.setLinkUp(h.vethPeerName(change.Name))
+		}
 	default:
-		return nil
+		return errors.Internal.Newf("unknown link type %s", change.Type)
+	}
+	if err != nil {
+		return errors.Internal.Wrapf(err, "error when creating a %s", change.Type)
 	}
+	err = h.setLinkUp(change.Name)
+	if err != nil {
+		return errors.Internal.Wrapf(err, "set %s up failed", change.Type)
+	}
+	return nil
 }
 
-func (h *HostNetworkManager) loadTrafficConfiguration(config domain.HostNetworkConfig) error {
-	for _, table := range netfilterTables {
-		rules, err := h.GetTableRules(table)
+//applyPlan applies the plan changes in the order of the plan fields
+func (h *HostNetworkManager) applyPlan(plan domain.HostNetworkPlan) error {
+	for _, route := range plan.DeleteRoutes {
+		err := h.deleteRoute(route)
 		if err != nil {
-			return errors.Internal.Wrap(err, "failed to get table rules")
+			return errors.Internal.Wrapf(err, "failed to delete route to %s", route.Destination.String())
 		}
-
-		configField := h.getTrafficRulesConfigField(table, config)
-		if configField == nil {
-			return errors.Internal.New("failed to get config field")
+	}
+	for _, change := range plan.ReplaceLinks {
+		err := h.deleteLinkByName(change.Name)
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to delete replaced %s %s", change.Type, change.Name)
 		}
-
-		for _, rule := range configField {
-			if !utils.SliceContainsElement(rules, rule) {
-				_, err = h.CreateTrafficRule(table, rule)
-				if err != nil {
-					return errors.Internal.New("error when creating traffic rule")
-				}
-			}
+	}
+	for _, change := range plan.CreateLinks {
+		err := h.applyLinkCreation(change)
+		if err != nil {
+			return err
 		}
-		for _, rule := range rules {
-			if !utils.SliceContainsElement(configField, rule) {
-				err = h.DeleteTrafficRule(table, rule)
-				if err != nil {
-					return errors.Internal.New("error when deleting traffic rule")
-				}
-			}
+	}
+	for _, namespace := range plan.CreateNamespaces {
+		_, err := h.createNamespace(strings.TrimPrefix(namespace, namespacePrefix))
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to create namespace %s", namespace)
+		}
+	}
+	for _, change := range plan.NamespaceLinks {
+		var err error
+		if change.Action == "add" {
+			err = h.setLinkNamespace(change.LinkName, change.Namespace)
+		} else {
+			err = h.unsetLinkNamespace(change.LinkName, change.Namespace)
+		}
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to %s link %s of namespace %s", change.Action, change.LinkName, change.Namespace)
+		}
+	}
+	for _, change := range plan.Addresses {
+		var err error
+		switch {
+		case change.Namespace != "" && change.Action == "add":
+			err = h.namespaceAddrChange(change.Namespace, change.LinkName, change.Address, true)
+		case change.Namespace != "":
+			err = h.namespaceAddrChange(change.Namespace, change.LinkName, change.Address, false)
+		case change.Action == "add":
+			err = h.addrAdd(change.LinkName, change.Address)
+		default:
+			err = h.addrDelete(change.LinkName, change.Address)
+		}
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to %s address %s", change.Action, change.Address.String())
+		}
+	}
+	for _, change := range plan.Slaves {
+		var err error
+		if change.Action == "add" {
+			err = h.setLinkMaster(change.Slave, change.Master)
+		} else {
+			err = h.unsetLinkMaster(change.Slave)
+		}
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to %s slave %s", change.Action, change.Slave)
+		}
+	}
+	for _, route := range plan.CreateRoutes {
+		err := h.createRoute(route)
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to create route to %s", route.Destination.String())
+		}
+	}
+	for _, change := range plan.DeleteLinks {
+		err := h.deleteLinkByName(change.Name)
+		if err != nil {
+			return errors.Internal.Wrap(err, "delete link by name error")
+		}
+	}
+	for _, namespace := range plan.DeleteNamespaces {
+		err := h.deleteNamespace(namespace)
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to delete namespace %s", namespace)
+		}
+	}
+	for _, change := range plan.TrafficRules {
+		var err error
+		if change.Action == "add" {
+			_, err = h.createTrafficRule(change.Table, change.Rule)
+		} else {
+			err = h.deleteTrafficRule(change.Table, change.Rule)
+		}
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to %s traffic rule", change.Action)
 		}
 	}
 	return nil
 }
 
-func (h *HostNetworkManager) loadConfiguration(config domain.HostNetworkConfig) error {
-	err := h.loadVlanConfiguration(config)
-	if err != nil {
-		return errors.Internal.Wrap(err, "error loading vlan configuration")
+//loadConfiguration converges the host network to the config
+//
+//Return:
+//	domain.HostNetworkPlan - applied changes
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) loadConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error) {
+	//RoL chains and jumps to them are restored before the rules
+	for _, table := range netfilterTables {
+		err := h.ensureTableTrafficChains(table)
+		if err != nil {
+			return domain.HostNetworkPlan{}, errors.Internal.Wrap(err, "failed to create RoL chains")
+		}
 	}
-	err = h.loadBridgeConfiguration(config)
+	plan, err := h.planConfiguration(config)
 	if err != nil {
-		return errors.Internal.Wrap(err, "error loading bridge configuration")
+		return plan, errors.Internal.Wrap(err, "failed to plan host network configuration")
 	}
-	err = h.loadTrafficConfiguration(config)
+	err = h.applyPlan(plan)
 	if err != nil {
-		return errors.Internal.Wrap(err, "error loading host network traffic configuration")
+		return plan, errors.Internal.Wrap(err, "failed to apply host network configuration")
+	}
+	for _, bridge := range config.Bridges {
+		if bridge.Nat {
+			err = h.enableIPv4Forwarding()
+			if err != nil {
+				return plan, err
+			}
+			break
+		}
 	}
 	h.hasUnsavedChanges = false
-	return nil
+	return plan, nil
+}
+
+//PlanConfiguration Compares the config with the host network without changing it
+//
+//Params:
+//	config - desired host network configuration
+//Return:
+//	domain.HostNetworkPlan - changes that converge the host network to the config
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) PlanConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.planConfiguration(config)
+}
+
+//ApplyConfiguration Converges the host network to the config with the same changes as PlanConfiguration returns,
+//applied changes are unsaved until they are confirmed
+//
+//Params:
+//	config - desired host network configuration
+//Return:
+//	domain.HostNetworkPlan - applied changes
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) ApplyConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	hadUnsavedChanges := h.hasUnsavedChanges
+	plan, err := h.loadConfiguration(config)
+	if err != nil {
+		h.setUnsavedChanges()
+		return plan, err
+	}
+	if !plan.IsEmpty() {
+		h.setUnsavedChanges()
+	} else {
+		h.hasUnsavedChanges = hadUnsavedChanges
+	}
+	return plan, nil
+}
+
+//setUnsavedChanges sets the unsaved changes flag and restarts the confirm timer, the manager mutex must be locked
+func (h *HostNetworkManager) setUnsavedChanges() {
+	h.hasUnsavedChanges = true
+	if h.confirmTimeout == 0 {
+		return
+	}
+	h.startConfirmTimer(h.confirmTimeout)
+}
+
+//startConfirmTimer restarts the confirm timer with the new deadline after the delay, the manager mutex must be locked
+func (h *HostNetworkManager) startConfirmTimer(delay time.Duration) {
+	if h.confirmTimer != nil {
+		h.confirmTimer.Stop()
+	}
+	deadline := time.Now().Add(delay)
+	h.confirmDeadline = deadline
+	h.confirmTimer = time.AfterFunc(delay, func() {
+		h.confirmTimeoutExpired(deadline)
+	})
+}
+
+//stopConfirmTimer stops the confirm timer and forgets the failed reset, the manager mutex must be locked
+func (h *HostNetworkManager) stopConfirmTimer() {
+	if h.confirmTimer != nil {
+		h.confirmTimer.Stop()
+		h.confirmTimer = nil
+	}
+	h.confirmDeadline = time.Time{}
+	h.confirmResetError = nil
+}
+
+//confirmTimeoutExpired resets unconfirmed changes, deadline is checked because the timer can fire while it is stopped.
+//If the reset fails, the error is kept for the status and the reset is retried with the new deadline
+func (h *HostNetworkManager) confirmTimeoutExpired(deadline time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if !h.confirmDeadline.Equal(deadline) {
+		return
+	}
+	entry := h.logger.WithFields(logrus.Fields{
+		"source": "HostNetworkManager",
+	})
+	entry.Warn(fmt.Sprintf("host network changes are not confirmed until %s, resetting them", deadline.Format(time.RFC3339)))
+	err := h.resetChanges()
+	if err != nil {
+		retryDelay := h.confirmTimeout
+		if retryDelay > confirmResetRetryInterval {
+			retryDelay = confirmResetRetryInterval
+		}
+		h.startConfirmTimer(retryDelay)
+		h.confirmResetError = err
+		entry.Error(fmt.Sprintf("failed to reset unconfirmed host network changes, retry at %s: %s",
+			h.confirmDeadline.Format(time.RFC3339), err.Error()))
+	}
+}
+
+//GetConfirmDeadline Gets the time when unconfirmed changes will be reset
+//
+//Return:
+//	time.Time - deadline of the changes confirmation, zero time if there is no pending reset
+func (h *HostNetworkManager) GetConfirmDeadline() time.Time {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.confirmDeadline
+}
+
+//GetConfirmResetError Gets the error of the last failed automatic reset of unconfirmed changes
+//
+//Return:
+//	error - error of the failed reset, nil if the reset didn't fail or the changes are saved or reset since then
+func (h *HostNetworkManager) GetConfirmResetError() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.confirmResetError
 }
 
 //ResetChanges Reset all applied changes to state from saved configuration
@@ -831,16 +2425,24 @@ func (h *HostNetworkManager) loadConfiguration(config domain.HostNetworkConfig)
 //Return:
 //	error - if an error occurs, otherwise nil
 func (h *HostNetworkManager) ResetChanges() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.resetChanges()
+}
+
+//resetChanges see ResetChanges, the manager mutex must be locked by the caller
+func (h *HostNetworkManager) resetChanges() error {
 	if h.hasUnsavedChanges == true {
 		config, err := h.configStorage.GetConfig()
 		if err != nil {
 			return errors.Internal.Wrap(err, "error while getting network configuration from storage")
 		}
-		err = h.loadConfiguration(config)
+		_, err = h.loadConfiguration(config)
 		if err != nil {
 			return errors.Internal.Wrap(err, "load backup configuration failed")
 		}
 		h.hasUnsavedChanges = false
+		h.stopConfirmTimer()
 	}
 	return nil
 }
@@ -850,15 +2452,17 @@ func (h *HostNetworkManager) ResetChanges() error {
 //Return:
 //	error - if an error occurs, otherwise nil
 func (h *HostNetworkManager) RestoreFromBackup() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
 	backConfig, err := h.configStorage.GetBackupConfig()
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to restore host network configuration from backup")
 	}
-	err = h.loadConfiguration(backConfig)
+	_, err = h.loadConfiguration(backConfig)
 	if err != nil {
 		return errors.Internal.Wrap(err, "load backup configuration failed")
 	}
-	h.hasUnsavedChanges = true
+	h.setUnsavedChanges()
 	return nil
 }
 
@@ -867,5 +2471,7 @@ func (h *HostNetworkManager) RestoreFromBackup() error {
 //Return:
 //	bool - if unsaved changes exist - true, otherwise false
 func (h *HostNetworkManager) HasUnsavedChanges() bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
 	return h.hasUnsavedChanges
 }
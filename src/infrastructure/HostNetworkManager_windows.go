@@ -1,8 +1,12 @@
 package infrastructure
 
 import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"net"
 	"rol/app/interfaces"
+	"rol/domain"
+	"time"
 )
 
 //HostNetworkManager is a struct for network manager
@@ -11,7 +15,18 @@ type HostNetworkManager struct {
 }
 
 //NewHostNetworkManager constructor for HostNetworkManager
-func NewHostNetworkManager(configStorage interfaces.IHostNetworkConfigStorage) (interfaces.IHostNetworkManager, error) {
+func NewHostNetworkManager(configStorage interfaces.IHostNetworkConfigStorage, _ interfaces.ITrafficRuleBackend,
+	_ *domain.AppConfig, _ *logrus.Logger) (interfaces.IHostNetworkManager, error) {
+	hostNetworkManager := &HostNetworkManager{
+		configStorage: configStorage,
+	}
+	return hostNetworkManager, nil
+}
+
+//NewHostNetworkManagerWithConfirmTimeout constructor for HostNetworkManager with the confirm timeout
+//that is not rounded to seconds
+func NewHostNetworkManagerWithConfirmTimeout(configStorage interfaces.IHostNetworkConfigStorage, _ interfaces.ITrafficRuleBackend,
+	_ time.Duration, _ *logrus.Logger) (interfaces.IHostNetworkManager, error) {
 	hostNetworkManager := &HostNetworkManager{
 		configStorage: configStorage,
 	}
@@ -61,6 +76,127 @@ func (h *HostNetworkManager) CreateBridge(_ string) (string, error) {
 	panic("not implemented")
 }
 
+//CreateBond creates bonding interface on host, slaves are added by SetLinkMaster
+//
+//Params:
+//	name - new bond name
+//	mode - bonding mode: "active-backup", "802.3ad" or "balance-alb"
+//	miimon - MII link monitoring frequency in milliseconds
+//Return:
+//	string - new bond name that will be rol.bond.{name}
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateBond(_, _ string, _ int) (string, error) {
+	panic("not implemented")
+}
+
+//CreateVeth creates virtual ethernet pair on host, both ends are created on the host
+//
+//Params:
+//	name - new veth pair name
+//Return:
+//	string - new veth name that will be rol.veth.{name}, its peer name will be rol.peer.{name}
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateVeth(_ string) (string, error) {
+	panic("not implemented")
+}
+
+//GetNamespaces gets list of RoL network namespaces with their links
+//
+//Return:
+//	[]domain.HostNetworkNamespace - list of namespaces
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetNamespaces() ([]domain.HostNetworkNamespace, error) {
+	panic("not implemented")
+}
+
+//CreateNamespace creates network namespace on host
+//
+//Params:
+//	name - new namespace name
+//Return:
+//	string - new namespace name that will be rol.ns.{name}
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateNamespace(_ string) (string, error) {
+	panic("not implemented")
+}
+
+//DeleteNamespace deletes network namespace on host, its links are moved back to the host before
+//
+//Params:
+//	name - namespace name
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) DeleteNamespace(_ string) error {
+	panic("not implemented")
+}
+
+//SetLinkNamespace moves the host link into the network namespace and sets it up there
+//
+//Params:
+//	linkName - name of the link
+//	namespace - name of the namespace
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) SetLinkNamespace(_, _ string) error {
+	panic("not implemented")
+}
+
+//UnsetLinkNamespace moves the link from the network namespace back to the host and sets it up
+//
+//Params:
+//	linkName - name of the link
+//	namespace - name of the namespace
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) UnsetLinkNamespace(_, _ string) error {
+	panic("not implemented")
+}
+
+//NamespaceAddrAdd Add new ip address for network interface in the network namespace
+//
+//Params:
+//	namespace - name of the namespace
+//	linkName - name of the interface
+//	addr - ip address with mask net.IPNet
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) NamespaceAddrAdd(_, _ string, _ net.IPNet) error {
+	panic("not implemented")
+}
+
+//NamespaceAddrDelete Delete ip address from network interface in the network namespace
+//
+//Params:
+//	namespace - name of the namespace
+//	linkName - name of the interface
+//	addr - ip address with mask net.IPNet
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) NamespaceAddrDelete(_, _ string, _ net.IPNet) error {
+	panic("not implemented")
+}
+
+//SetBridgeNat gives the bridge networks an access to the upstream interface networks
+//
+//Params:
+//	bridgeName - name of the bridge
+//	upstreamInterface - name of the interface through which NAT traffic is sent
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) SetBridgeNat(_, _ string) error {
+	panic("not implemented")
+}
+
+//UnsetBridgeNat removes NAT rules of the bridge
+//
+//Params:
+//	bridgeName - name of the bridge
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) UnsetBridgeNat(_ string) error {
+	panic("not implemented")
+}
+
 //SetLinkUp enables the link
 //
 //Params:
@@ -131,12 +267,52 @@ func (h *HostNetworkManager) AddrDelete(_ string, _ net.IPNet) error {
 	panic("not implemented")
 }
 
-//SaveConfiguration save current host network configuration to the configuration storage
-//Save previous config file to .back file
+//GetRoutes gets static IPv4 and IPv6 routes of all routing tables that are managed by RoL
 //
 //Return:
+//	[]domain.HostNetworkRoute - slice of routes
 //	error - if an error occurs, otherwise nil
-func (h *HostNetworkManager) SaveConfiguration() error {
+func (h *HostNetworkManager) GetRoutes() ([]domain.HostNetworkRoute, error) {
+	panic("not implemented")
+}
+
+//CreateRoute creates static route that is managed by RoL
+//
+//Params:
+//	route - route entity
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) CreateRoute(_ domain.HostNetworkRoute) error {
+	panic("not implemented")
+}
+
+//DeleteRoute deletes static route that is managed by RoL
+//
+//Params:
+//	route - route entity
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) DeleteRoute(_ domain.HostNetworkRoute) error {
+	panic("not implemented")
+}
+
+//GetConfiguration Gets current host network configuration
+//
+//Return:
+//	domain.HostNetworkConfig - current host network configuration
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) GetConfiguration() (domain.HostNetworkConfig, error) {
+	panic("not implemented")
+}
+
+//SaveConfiguration save current host network configuration to the configuration storage as a new revision
+//
+//Params:
+//	author - who saved the configuration
+//	requestID - ID of the API request that saved the configuration, uuid.Nil if there is no request
+//Return:
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) SaveConfiguration(_ string, _ uuid.UUID) error {
 	panic("not implemented")
 }
 
@@ -163,3 +339,41 @@ func (h *HostNetworkManager) ResetChanges() error {
 func (h *HostNetworkManager) HasUnsavedChanges() bool {
 	panic("not implemented")
 }
+
+//GetConfirmDeadline Gets the time when unconfirmed changes will be reset
+//
+//Return:
+//	time.Time - deadline of the changes confirmation, zero time if there is no pending reset
+func (h *HostNetworkManager) GetConfirmDeadline() time.Time {
+	panic("not implemented")
+}
+
+//GetConfirmResetError Gets the error of the last failed automatic reset of unconfirmed changes
+//
+//Return:
+//	error - error of the failed reset, nil if the reset didn't fail or the changes are saved or reset since then
+func (h *HostNetworkManager) GetConfirmResetError() error {
+	panic("not implemented")
+}
+
+//PlanConfiguration Compares the config with the host network without changing it
+//
+//Params:
+//	config - desired host network configuration
+//Return:
+//	domain.HostNetworkPlan - changes that converge the host network to the config
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) PlanConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error) {
+	panic("not implemented")
+}
+
+//ApplyConfiguration Converges the host network to the config
+//
+//Params:
+//	config - desired host network configuration
+//Return:
+//	domain.HostNetworkPlan - applied changes
+//	error - if an error occurs, otherwise nil
+func (h *HostNetworkManager) ApplyConfiguration(config domain.HostNetworkConfig) (domain.HostNetworkPlan, error) {
+	panic("not implemented")
+}
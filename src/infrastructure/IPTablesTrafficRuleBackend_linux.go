@@ -0,0 +1,292 @@
+package infrastructure
+
+import (
+	"github.com/coreos/go-iptables/iptables"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/domain"
+	"strings"
+)
+
+//IPTablesTrafficRuleBackend traffic rules backend that manages rules with iptables utility
+type IPTablesTrafficRuleBackend struct {
+	iptables *iptables.IPTables
+}
+
+//NewIPTablesTrafficRuleBackend constructor for IPTablesTrafficRuleBackend
+//
+//Return:
+//	interfaces.ITrafficRuleBackend - iptables traffic rules backend
+//	error - if an error occurs, otherwise nil
+func NewIPTablesTrafficRuleBackend() (interfaces.ITrafficRuleBackend, error) {
+	ipTables, err := iptables.New()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "error getting iptables instance")
+	}
+	return &IPTablesTrafficRuleBackend{
+		iptables: ipTables,
+	}, nil
+}
+
+func (i *IPTablesTrafficRuleBackend) appendRuleOption(rulespec []string, option, value string) []string {
+	if value == "" {
+		return rulespec
+	}
+	if strings.HasPrefix(value, "!") {
+		return append(rulespec, "!", option, strings.TrimPrefix(value, "!"))
+	}
+	return append(rulespec, option, value)
+}
+
+func (i *IPTablesTrafficRuleBackend) parseRule(rule domain.HostNetworkTrafficRule) []string {
+	var rulespec []string
+	rulespec = i.appendRuleOption(rulespec, "-s", rule.Source)
+	rulespec = i.appendRuleOption(rulespec, "-d", rule.Destination)
+	rulespec = i.appendRuleOption(rulespec, "-i", rule.InInterface)
+	rulespec = i.appendRuleOption(rulespec, "-o", rule.OutInterface)
+	rulespec = i.appendRuleOption(rulespec, "-p", rule.Protocol)
+	//lists of ports are supported only by the multiport match
+	if strings.Contains(rule.SourcePorts+rule.DestinationPorts, ",") {
+		rulespec = append(rulespec, "-m", "multiport")
+		rulespec = i.appendRuleOption(rulespec, "--sports", rule.SourcePorts)
+		rulespec = i.appendRuleOption(rulespec, "--dports", rule.DestinationPorts)
+	} else {
+		rulespec = i.appendRuleOption(rulespec, "--sport", rule.SourcePorts)
+		rulespec = i.appendRuleOption(rulespec, "--dport", rule.DestinationPorts)
+	}
+	if rule.State != "" {
+		rulespec = append(rulespec, "-m", "conntrack", "--ctstate", rule.State)
+	}
+	if rule.Comment != "" {
+		rulespec = append(rulespec, "-m", "comment", "--comment", rule.Comment)
+	}
+	rulespec = i.appendRuleOption(rulespec, "-j", rule.Action)
+	switch rule.Action {
+	case "DNAT":
+		rulespec = i.appendRuleOption(rulespec, "--to-destination", rule.To)
+	case "SNAT":
+		rulespec = i.appendRuleOption(rulespec, "--to-source", rule.To)
+	}
+	return rulespec
+}
+
+//splitListedRule splits rule listed by iptables -S to the arguments, quoted arguments are unquoted
+func (i *IPTablesTrafficRuleBackend) splitListedRule(line string) []string {
+	var args []string
+	var current strings.Builder
+	quoted := false
+	escaped := false
+	hasArg := false
+	for _, char := range line {
+		switch {
+		case escaped:
+			current.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+			hasArg = true
+		case char == ' ' && !quoted:
+			if hasArg || current.Len() > 0 {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteRune(char)
+		}
+	}
+	if hasArg || current.Len() > 0 {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+//parseListedRule parses rule listed by iptables -S like '-A INPUT -p udp -m udp --dport 67 -j ACCEPT'
+func (i *IPTablesTrafficRuleBackend) parseListedRule(line string, position int) (domain.HostNetworkTrafficRule, error) {
+	args := i.splitListedRule(line)
+	if len(args) < 2 || args[0] != "-A" {
+		return domain.HostNetworkTrafficRule{}, errors.Internal.Newf("unexpected traffic rule format: %s", line)
+	}
+	rule := domain.HostNetworkTrafficRule{Chain: args[1], Position: position}
+	negation := ""
+	for i := 2; i < len(args); i++ {
+		option := args[i]
+		if option == "!" {
+			negation = "!"
+			continue
+		}
+		if i+1 >= len(args) {
+			return domain.HostNetworkTrafficRule{}, errors.Internal.Newf("traffic rule option %s has no value: %s", option, line)
+		}
+		i++
+		value := negation + args[i]
+		negation = ""
+		switch option {
+		case "-s":
+			rule.Source = value
+		case "-d":
+			rule.Destination = value
+		case "-i":
+			rule.InInterface = value
+		case "-o":
+			rule.OutInterface = value
+		case "-p":
+			rule.Protocol = value
+		case "--sport", "--sports":
+			rule.SourcePorts = value
+		case "--dport", "--dports":
+			rule.DestinationPorts = value
+		case "--ctstate", "--state":
+			rule.State = value
+		case "--comment":
+			rule.Comment = value
+		case "-j":
+			rule.Action = value
+		case "--to-destination", "--to-source":
+			rule.To = value
+		}
+		//other options and match modules names like '-m udp' are implied by the rule fields
+	}
+	return rule, nil
+}
+
+//CreateChain Create iptables user chain in specified table if it does not exist
+//
+//Params:
+//	table - table to create a chain
+//	chain - chain name
+//Return:
+//	error - if an error occurs, otherwise nil
+func (i *IPTablesTrafficRuleBackend) CreateChain(table string, chain string) error {
+	exist, err := i.iptables.ChainExists(table, chain)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to check existence of chain")
+	}
+	if exist {
+		return nil
+	}
+	err = i.iptables.NewChain(table, chain)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to create chain")
+	}
+	return nil
+}
+
+//CreateRule Create iptables traffic rule in specified table if the same rule does not exist
+//
+//Params:
+//	table - table to create a rule
+//	rule - rule entity, rule is inserted at the rule position or appended if position is 0
+//Return:
+//	error - if an error occurs, otherwise nil
+func (i *IPTablesTrafficRuleBackend) CreateRule(table string, rule domain.HostNetworkTrafficRule) error {
+	rulespec := i.parseRule(rule)
+	if rule.Position == 0 {
+		err := i.iptables.AppendUnique(table, rule.Chain, rulespec...)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to create traffic rule")
+		}
+		return nil
+	}
+	exist, err := i.iptables.Exists(table, rule.Chain, rulespec...)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to check existence of traffic rule")
+	}
+	if exist {
+		return nil
+	}
+	list, err := i.iptables.List(table, rule.Chain)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to get list of traffic rules")
+	}
+	//first listed line is the chain policy or declaration
+	if rule.Position > len(list) {
+		err = i.iptables.Append(table, rule.Chain, rulespec...)
+	} else {
+		err = i.iptables.Insert(table, rule.Chain, rule.Position, rulespec...)
+	}
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to create traffic rule")
+	}
+	return nil
+}
+
+//DeleteRule Delete iptables traffic rule in specified table
+//
+//Params:
+//	table - table to delete a rule
+//	rule - rule entity, rule position is ignored
+//Return:
+//	error - if an error occurs, otherwise nil
+func (i *IPTablesTrafficRuleBackend) DeleteRule(table string, rule domain.HostNetworkTrafficRule) error {
+	rulespec := i.parseRule(rule)
+	exist, err := i.iptables.Exists(table, rule.Chain, rulespec...)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to check existence of traffic rule")
+	}
+	if exist {
+		err = i.iptables.Delete(table, rule.Chain, rulespec...)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to delete traffic rule")
+		}
+		return nil
+	}
+	return errors.NotFound.New("traffic rule not found")
+}
+
+//GetChainRules Get selected iptables chain rules at specified table
+//
+//Params:
+//	table - table to get a rules
+//	chain - chain where we get the rules
+//Return:
+//	[]domain.HostNetworkTrafficRule - slice of rules
+//	error - if an error occurs, otherwise nil
+func (i *IPTablesTrafficRuleBackend) GetChainRules(table string, chain string) ([]domain.HostNetworkTrafficRule, error) {
+	var rules []domain.HostNetworkTrafficRule
+
+	list, err := i.iptables.List(table, chain)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get list of traffic rules")
+	}
+	position := 0
+	for _, l := range list {
+		//skip chain policy and declaration
+		if !strings.HasPrefix(l, "-A ") {
+			continue
+		}
+		position++
+		rule, err := i.parseListedRule(l, position)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to parse traffic rule")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+//GetTableRules Get specified iptables table rules
+//
+//Params:
+//	table - table to get a rules
+//Return:
+//	[]domain.HostNetworkTrafficRule - slice of rules
+//	error - if an error occurs, otherwise nil
+func (i *IPTablesTrafficRuleBackend) GetTableRules(table string) ([]domain.HostNetworkTrafficRule, error) {
+	var rules []domain.HostNetworkTrafficRule
+
+	chains, err := i.iptables.ListChains(table)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get list table chains")
+	}
+	for _, chain := range chains {
+		chainRules, err := i.GetChainRules(table, chain)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, chainRules...)
+	}
+	return rules, nil
+}
@@ -0,0 +1,981 @@
+package infrastructure
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"golang.org/x/sys/unix"
+	"math"
+	"math/bits"
+	"net"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/domain"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//nftablesTableName name of the nftables table with all RoL rules
+const nftablesTableName = "rol"
+
+//nftablesCommentType rule user data type of the comment, the same as nft utility uses
+const nftablesCommentType = 0
+
+//nftablesICMPPortUnreachable ICMP code of the reject action, the same as iptables REJECT uses by default
+const nftablesICMPPortUnreachable = 3
+
+//nftablesBaseChain hook parameters of the netfilter builtin chain
+type nftablesBaseChain struct {
+	hook      nftables.ChainHook
+	priority  nftables.ChainPriority
+	chainType nftables.ChainType
+}
+
+//nftablesBaseChains builtin chains of the netfilter tables with the same hooks and priorities as iptables uses
+var nftablesBaseChains = map[string]map[string]nftablesBaseChain{
+	"filter": {
+		"INPUT":   {nftables.ChainHookInput, nftables.ChainPriorityFilter, nftables.ChainTypeFilter},
+		"FORWARD": {nftables.ChainHookForward, nftables.ChainPriorityFilter, nftables.ChainTypeFilter},
+		"OUTPUT":  {nftables.ChainHookOutput, nftables.ChainPriorityFilter, nftables.ChainTypeFilter},
+	},
+	"nat": {
+		"PREROUTING":  {nftables.ChainHookPrerouting, nftables.ChainPriorityNATDest, nftables.ChainTypeNAT},
+		"INPUT":       {nftables.ChainHookInput, nftables.ChainPriorityNATSource, nftables.ChainTypeNAT},
+		"OUTPUT":      {nftables.ChainHookOutput, nftables.ChainPriorityNATDest, nftables.ChainTypeNAT},
+		"POSTROUTING": {nftables.ChainHookPostrouting, nftables.ChainPriorityNATSource, nftables.ChainTypeNAT},
+	},
+	"mangle": {
+		"PREROUTING":  {nftables.ChainHookPrerouting, nftables.ChainPriorityMangle, nftables.ChainTypeFilter},
+		"INPUT":       {nftables.ChainHookInput, nftables.ChainPriorityMangle, nftables.ChainTypeFilter},
+		"FORWARD":     {nftables.ChainHookForward, nftables.ChainPriorityMangle, nftables.ChainTypeFilter},
+		"OUTPUT":      {nftables.ChainHookOutput, nftables.ChainPriorityMangle, nftables.ChainTypeRoute},
+		"POSTROUTING": {nftables.ChainHookPostrouting, nftables.ChainPriorityMangle, nftables.ChainTypeFilter},
+	},
+	"raw": {
+		"PREROUTING": {nftables.ChainHookPrerouting, nftables.ChainPriorityRaw, nftables.ChainTypeFilter},
+		"OUTPUT":     {nftables.ChainHookOutput, nftables.ChainPriorityRaw, nftables.ChainTypeFilter},
+	},
+	"security": {
+		"INPUT":   {nftables.ChainHookInput, nftables.ChainPrioritySecurity, nftables.ChainTypeFilter},
+		"FORWARD": {nftables.ChainHookForward, nftables.ChainPrioritySecurity, nftables.ChainTypeFilter},
+		"OUTPUT":  {nftables.ChainHookOutput, nftables.ChainPrioritySecurity, nftables.ChainTypeFilter},
+	},
+}
+
+//nftablesCtStates conntrack states in the order iptables lists them
+var nftablesCtStates = []struct {
+	name string
+	bit  uint32
+}{
+	{"INVALID", expr.CtStateBitINVALID},
+	{"NEW", expr.CtStateBitNEW},
+	{"RELATED", expr.CtStateBitRELATED},
+	{"ESTABLISHED", expr.CtStateBitESTABLISHED},
+	{"UNTRACKED", expr.CtStateBitUNTRACKED},
+}
+
+var nftablesProtocols = map[string]byte{
+	"icmp": unix.IPPROTO_ICMP,
+	"tcp":  unix.IPPROTO_TCP,
+	"udp":  unix.IPPROTO_UDP,
+}
+
+//nftablesPortRange inclusive range of the ports, single port has the same bounds
+type nftablesPortRange struct {
+	from uint16
+	to   uint16
+}
+
+//NFTablesTrafficRuleBackend traffic rules backend that manages rules in the own 'rol' nftables table through netlink.
+//Netfilter tables are mapped to the 'rol' table chains with {table}_{chain} names, for example filter_INPUT,
+//builtin chains are created with the same hooks and priorities as iptables uses.
+type NFTablesTrafficRuleBackend struct {
+	//mutex prevents mixing of netlink batches of the parallel operations
+	mutex *sync.Mutex
+	conn  *nftables.Conn
+	table *nftables.Table
+}
+
+//NewNFTablesTrafficRuleBackend constructor for NFTablesTrafficRuleBackend, creates 'rol' table if it does not exist
+//
+//Return:
+//	interfaces.ITrafficRuleBackend - nftables traffic rules backend
+//	error - if an error occurs, otherwise nil
+func NewNFTablesTrafficRuleBackend() (interfaces.ITrafficRuleBackend, error) {
+	conn, err := nftables.New()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "error getting nftables connection")
+	}
+	table := conn.AddTable(&nftables.Table{
+		Name:   nftablesTableName,
+		Family: nftables.TableFamilyIPv4,
+	})
+	err = conn.Flush()
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to create nftables table")
+	}
+	return &NFTablesTrafficRuleBackend{
+		mutex: &sync.Mutex{},
+		conn:  conn,
+		table: table,
+	}, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) chainName(table, chain string) string {
+	return table + "_" + chain
+}
+
+func (n *NFTablesTrafficRuleBackend) getChains() ([]*nftables.Chain, error) {
+	chains, err := n.conn.ListChainsOfTableFamily(nftables.TableFamilyIPv4)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to get list of nftables chains")
+	}
+	var out []*nftables.Chain
+	for _, chain := range chains {
+		if chain.Table.Name == nftablesTableName {
+			out = append(out, chain)
+		}
+	}
+	return out, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) getChain(table, chain string) (*nftables.Chain, error) {
+	chains, err := n.getChains()
+	if err != nil {
+		return nil, err
+	}
+	name := n.chainName(table, chain)
+	for _, nftChain := range chains {
+		if nftChain.Name == name {
+			return nftChain, nil
+		}
+	}
+	return nil, nil
+}
+
+//getOrCreateChain gets the chain or creates it, builtin chains are created as base chains with accept policy
+func (n *NFTablesTrafficRuleBackend) getOrCreateChain(table, chain string) (*nftables.Chain, error) {
+	nftChain, err := n.getChain(table, chain)
+	if err != nil || nftChain != nil {
+		return nftChain, err
+	}
+	nftChain = &nftables.Chain{
+		Name:  n.chainName(table, chain),
+		Table: n.table,
+	}
+	if baseChain, ok := nftablesBaseChains[table][chain]; ok {
+		policy := nftables.ChainPolicyAccept
+		nftChain.Hooknum = baseChain.hook
+		nftChain.Priority = baseChain.priority
+		nftChain.Type = baseChain.chainType
+		nftChain.Policy = &policy
+	}
+	n.conn.AddChain(nftChain)
+	err = n.conn.Flush()
+	if err != nil {
+		return nil, errors.Internal.Wrapf(err, "failed to create nftables chain %s", nftChain.Name)
+	}
+	return nftChain, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) normalizeAddress(address string) (string, error) {
+	negation := ""
+	if strings.HasPrefix(address, "!") {
+		negation = "!"
+		address = strings.TrimPrefix(address, "!")
+	}
+	if !strings.Contains(address, "/") {
+		address += "/32"
+	}
+	_, network, err := net.ParseCIDR(address)
+	if err != nil || network.IP.To4() == nil {
+		return "", errors.Internal.Newf("wrong IPv4 address %s", address)
+	}
+	return negation + network.String(), nil
+}
+
+func (n *NFTablesTrafficRuleBackend) normalizePorts(ports string) string {
+	if !strings.Contains(ports, ",") {
+		return ports
+	}
+	negation := ""
+	if strings.HasPrefix(ports, "!") {
+		negation = "!"
+		ports = strings.TrimPrefix(ports, "!")
+	}
+	list := strings.Split(ports, ",")
+	//ports ranges are kept in the interval set, that merges overlapping and adjacent ranges
+	if strings.Contains(ports, ":") {
+		ranges, err := n.parsePortRanges(list)
+		if err != nil {
+			return negation + ports
+		}
+		return negation + n.formatPortRanges(ranges)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		first, _ := strconv.Atoi(list[i])
+		second, _ := strconv.Atoi(list[j])
+		return first < second
+	})
+	return negation + strings.Join(list, ",")
+}
+
+func (n *NFTablesTrafficRuleBackend) normalizeState(state string) string {
+	if state == "" {
+		return ""
+	}
+	names := strings.Split(state, ",")
+	order := func(name string) int {
+		for i, ctState := range nftablesCtStates {
+			if ctState.name == name {
+				return i
+			}
+		}
+		//unknown states are kept at the end to be reported by stateExprs
+		return len(nftablesCtStates)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return order(names[i]) < order(names[j])
+	})
+	return strings.Join(names, ",")
+}
+
+//normalizeRule converts rule fields to the form in which they are read back from nftables
+func (n *NFTablesTrafficRuleBackend) normalizeRule(rule domain.HostNetworkTrafficRule) (domain.HostNetworkTrafficRule, error) {
+	var err error
+	if rule.Source != "" {
+		rule.Source, err = n.normalizeAddress(rule.Source)
+		if err != nil {
+			return rule, err
+		}
+	}
+	if rule.Destination != "" {
+		rule.Destination, err = n.normalizeAddress(rule.Destination)
+		if err != nil {
+			return rule, err
+		}
+	}
+	rule.SourcePorts = n.normalizePorts(rule.SourcePorts)
+	rule.DestinationPorts = n.normalizePorts(rule.DestinationPorts)
+	rule.State = n.normalizeState(rule.State)
+	rule.Position = 0
+	return rule, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) cutNegation(value string) (string, expr.CmpOp) {
+	if strings.HasPrefix(value, "!") {
+		return strings.TrimPrefix(value, "!"), expr.CmpOpNeq
+	}
+	return value, expr.CmpOpEq
+}
+
+func (n *NFTablesTrafficRuleBackend) addressExprs(address string, offset uint32) []expr.Any {
+	address, op := n.cutNegation(address)
+	_, network, _ := net.ParseCIDR(address)
+	exprs := []expr.Any{
+		&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: offset, Len: 4},
+	}
+	if ones, _ := network.Mask.Size(); ones != 32 {
+		exprs = append(exprs, &expr.Bitwise{
+			SourceRegister: 1,
+			DestRegister:   1,
+			Len:            4,
+			Mask:           network.Mask,
+			Xor:            []byte{0, 0, 0, 0},
+		})
+	}
+	return append(exprs, &expr.Cmp{Op: op, Register: 1, Data: network.IP.To4()})
+}
+
+func (n *NFTablesTrafficRuleBackend) interfaceExprs(name string, key expr.MetaKey) []expr.Any {
+	name, op := n.cutNegation(name)
+	//iptables wildcard 'eth+' matches all interfaces with the prefix
+	data := []byte(strings.TrimSuffix(name, "+"))
+	if !strings.HasSuffix(name, "+") {
+		data = make([]byte, unix.IFNAMSIZ)
+		copy(data, name)
+	}
+	return []expr.Any{
+		&expr.Meta{Key: key, Register: 1},
+		&expr.Cmp{Op: op, Register: 1, Data: data},
+	}
+}
+
+func (n *NFTablesTrafficRuleBackend) parsePort(port string) ([]byte, error) {
+	value, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, errors.Internal.Newf("wrong port %s", port)
+	}
+	return binaryutil.BigEndian.PutUint16(uint16(value)), nil
+}
+
+//parsePortRanges parses the list of the ports and ports ranges, sorts it and merges overlapping and adjacent ranges
+func (n *NFTablesTrafficRuleBackend) parsePortRanges(list []string) ([]nftablesPortRange, error) {
+	ranges := []nftablesPortRange{}
+	for _, item := range list {
+		from, to, isRange := strings.Cut(item, ":")
+		if !isRange {
+			to = from
+		}
+		fromValue, err := strconv.ParseUint(from, 10, 16)
+		if err != nil {
+			return nil, errors.Internal.Newf("wrong port %s", item)
+		}
+		toValue, err := strconv.ParseUint(to, 10, 16)
+		if err != nil || toValue < fromValue {
+			return nil, errors.Internal.Newf("wrong ports range %s", item)
+		}
+		ranges = append(ranges, nftablesPortRange{from: uint16(fromValue), to: uint16(toValue)})
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].from < ranges[j].from
+	})
+	merged := []nftablesPortRange{}
+	for _, portRange := range ranges {
+		last := len(merged) - 1
+		if last >= 0 && uint32(portRange.from) <= uint32(merged[last].to)+1 {
+			if portRange.to > merged[last].to {
+				merged[last].to = portRange.to
+			}
+			continue
+		}
+		merged = append(merged, portRange)
+	}
+	return merged, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) formatPortRanges(ranges []nftablesPortRange) string {
+	var out []string
+	for _, portRange := range ranges {
+		if portRange.from == portRange.to {
+			out = append(out, strconv.Itoa(int(portRange.from)))
+			continue
+		}
+		out = append(out, fmt.Sprintf("%d:%d", portRange.from, portRange.to))
+	}
+	return strings.Join(out, ",")
+}
+
+//portsSetElements gets elements of the ports set. Interval set keeps the start of each range and the next port
+//after the range end, the same as nft utility does.
+func (n *NFTablesTrafficRuleBackend) portsSetElements(list []string, interval bool) ([]nftables.SetElement, error) {
+	var elements []nftables.SetElement
+	if !interval {
+		for _, port := range list {
+			key, err := n.parsePort(port)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, nftables.SetElement{Key: key})
+		}
+		return elements, nil
+	}
+	ranges, err := n.parsePortRanges(list)
+	if err != nil {
+		return nil, err
+	}
+	if ranges[0].from > 0 {
+		elements = append(elements, nftables.SetElement{Key: binaryutil.BigEndian.PutUint16(0), IntervalEnd: true})
+	}
+	for _, portRange := range ranges {
+		elements = append(elements, nftables.SetElement{Key: binaryutil.BigEndian.PutUint16(portRange.from)})
+		if portRange.to < math.MaxUint16 {
+			elements = append(elements, nftables.SetElement{
+				Key:         binaryutil.BigEndian.PutUint16(portRange.to + 1),
+				IntervalEnd: true,
+			})
+		}
+	}
+	return elements, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) portsExprs(ports string, offset uint32) ([]expr.Any, error) {
+	ports, op := n.cutNegation(ports)
+	exprs := []expr.Any{
+		&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseTransportHeader, Offset: offset, Len: 2},
+	}
+	if strings.Contains(ports, ",") {
+		set := &nftables.Set{
+			Table:     n.table,
+			Anonymous: true,
+			Constant:  true,
+			Interval:  strings.Contains(ports, ":"),
+			KeyType:   nftables.TypeInetService,
+		}
+		elements, err := n.portsSetElements(strings.Split(ports, ","), set.Interval)
+		if err != nil {
+			return nil, err
+		}
+		err = n.conn.AddSet(set, elements)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to add nftables ports set")
+		}
+		return append(exprs, &expr.Lookup{
+			SourceRegister: 1,
+			SetID:          set.ID,
+			SetName:        set.Name,
+			Invert:         op == expr.CmpOpNeq,
+		}), nil
+	}
+	if strings.Contains(ports, ":") {
+		bounds := strings.SplitN(ports, ":", 2)
+		from, err := n.parsePort(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		to, err := n.parsePort(bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		return append(exprs, &expr.Range{Op: op, Register: 1, FromData: from, ToData: to}), nil
+	}
+	port, err := n.parsePort(ports)
+	if err != nil {
+		return nil, err
+	}
+	return append(exprs, &expr.Cmp{Op: op, Register: 1, Data: port}), nil
+}
+
+func (n *NFTablesTrafficRuleBackend) stateExprs(state string) ([]expr.Any, error) {
+	var mask uint32
+	for _, name := range strings.Split(state, ",") {
+		found := false
+		for _, ctState := range nftablesCtStates {
+			if ctState.name == name {
+				mask |= ctState.bit
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.Internal.Newf("conntrack state %s is not supported by nftables backend", name)
+		}
+	}
+	return []expr.Any{
+		&expr.Ct{Register: 1, Key: expr.CtKeySTATE},
+		&expr.Bitwise{
+			SourceRegister: 1,
+			DestRegister:   1,
+			Len:            4,
+			Mask:           binaryutil.NativeEndian.PutUint32(mask),
+			Xor:            binaryutil.NativeEndian.PutUint32(0),
+		},
+		&expr.Cmp{Op: expr.CmpOpNeq, Register: 1, Data: binaryutil.NativeEndian.PutUint32(0)},
+	}, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) natExprs(natType expr.NATType, to string) ([]expr.Any, error) {
+	address, port, hasPort := strings.Cut(to, ":")
+	ip := net.ParseIP(address).To4()
+	if ip == nil {
+		return nil, errors.Internal.Newf("wrong NAT target address %s", to)
+	}
+	nat := &expr.NAT{Type: natType, Family: unix.NFPROTO_IPV4, RegAddrMin: 1}
+	exprs := []expr.Any{&expr.Immediate{Register: 1, Data: ip}}
+	if hasPort {
+		portData, err := n.parsePort(port)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, &expr.Immediate{Register: 2, Data: portData})
+		nat.RegProtoMin = 2
+	}
+	return append(exprs, nat), nil
+}
+
+func (n *NFTablesTrafficRuleBackend) actionExprs(table string, rule domain.HostNetworkTrafficRule) ([]expr.Any, error) {
+	switch rule.Action {
+	case "ACCEPT":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictAccept}}, nil
+	case "DROP":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictDrop}}, nil
+	case "RETURN":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictReturn}}, nil
+	case "REJECT":
+		return []expr.Any{&expr.Reject{Type: unix.NFT_REJECT_ICMP_UNREACH, Code: nftablesICMPPortUnreachable}}, nil
+	case "MASQUERADE":
+		return []expr.Any{&expr.Masq{}}, nil
+	case "DNAT":
+		return n.natExprs(expr.NATTypeDestNAT, rule.To)
+	case "SNAT":
+		return n.natExprs(expr.NATTypeSourceNAT, rule.To)
+	case "LOG", "MARK", "CONNMARK", "TCPMSS", "NOTRACK", "QUEUE":
+		return nil, errors.Internal.Newf("action %s is not supported by nftables backend", rule.Action)
+	}
+	//other actions are user chains
+	_, err := n.getOrCreateChain(table, rule.Action)
+	if err != nil {
+		return nil, err
+	}
+	return []expr.Any{&expr.Verdict{Kind: expr.VerdictJump, Chain: n.chainName(table, rule.Action)}}, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) ruleExprs(table string, rule domain.HostNetworkTrafficRule) ([]expr.Any, error) {
+	//action and state are checked before the ports sets are added to the netlink batch
+	actionExprs, err := n.actionExprs(table, rule)
+	if err != nil {
+		return nil, err
+	}
+	var stateExprs []expr.Any
+	if rule.State != "" {
+		stateExprs, err = n.stateExprs(rule.State)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var exprs []expr.Any
+	if rule.InInterface != "" {
+		exprs = append(exprs, n.interfaceExprs(rule.InInterface, expr.MetaKeyIIFNAME)...)
+	}
+	if rule.OutInterface != "" {
+		exprs = append(exprs, n.interfaceExprs(rule.OutInterface, expr.MetaKeyOIFNAME)...)
+	}
+	if rule.Source != "" {
+		exprs = append(exprs, n.addressExprs(rule.Source, 12)...)
+	}
+	if rule.Destination != "" {
+		exprs = append(exprs, n.addressExprs(rule.Destination, 16)...)
+	}
+	if rule.Protocol != "" {
+		protocol, op := n.cutNegation(rule.Protocol)
+		number, ok := nftablesProtocols[protocol]
+		if !ok {
+			return nil, errors.Internal.Newf("protocol %s is not supported by nftables backend", protocol)
+		}
+		exprs = append(exprs,
+			&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+			&expr.Cmp{Op: op, Register: 1, Data: []byte{number}})
+	}
+	if rule.SourcePorts != "" {
+		portsExprs, err := n.portsExprs(rule.SourcePorts, 0)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, portsExprs...)
+	}
+	if rule.DestinationPorts != "" {
+		portsExprs, err := n.portsExprs(rule.DestinationPorts, 2)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, portsExprs...)
+	}
+	exprs = append(exprs, stateExprs...)
+	return append(exprs, actionExprs...), nil
+}
+
+func (n *NFTablesTrafficRuleBackend) commentUserData(comment string) []byte {
+	if comment == "" {
+		return nil
+	}
+	return append([]byte{nftablesCommentType, byte(len(comment) + 1)}, append([]byte(comment), 0)...)
+}
+
+func (n *NFTablesTrafficRuleBackend) parseComment(userData []byte) string {
+	for len(userData) >= 2 {
+		dataType, length := userData[0], int(userData[1])
+		if len(userData) < 2+length {
+			break
+		}
+		if dataType == nftablesCommentType {
+			return strings.TrimRight(string(userData[2:2+length]), "\x00")
+		}
+		userData = userData[2+length:]
+	}
+	return ""
+}
+
+func (n *NFTablesTrafficRuleBackend) negation(negative bool) string {
+	if negative {
+		return "!"
+	}
+	return ""
+}
+
+func (n *NFTablesTrafficRuleBackend) parsePortsSet(lookup *expr.Lookup) (string, error) {
+	set, err := n.conn.GetSetByName(n.table, lookup.SetName)
+	if err != nil {
+		return "", errors.Internal.Wrapf(err, "failed to get nftables set %s", lookup.SetName)
+	}
+	elements, err := n.conn.GetSetElements(set)
+	if err != nil {
+		return "", errors.Internal.Wrapf(err, "failed to get nftables set %s elements", lookup.SetName)
+	}
+	if set.Interval {
+		return n.negation(lookup.Invert) + n.formatPortRanges(n.parseIntervalElements(elements)), nil
+	}
+	var ports []int
+	for _, element := range elements {
+		if len(element.Key) == 2 {
+			ports = append(ports, int(binary.BigEndian.Uint16(element.Key)))
+		}
+	}
+	sort.Ints(ports)
+	var out []string
+	for _, port := range ports {
+		out = append(out, strconv.Itoa(port))
+	}
+	return n.negation(lookup.Invert) + strings.Join(out, ","), nil
+}
+
+//parseIntervalElements converts elements of the interval set that were created by portsSetElements back to the ranges
+func (n *NFTablesTrafficRuleBackend) parseIntervalElements(setElements []nftables.SetElement) []nftablesPortRange {
+	var elements []nftables.SetElement
+	for _, element := range setElements {
+		if len(element.Key) == 2 {
+			elements = append(elements, element)
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		return binary.BigEndian.Uint16(elements[i].Key) < binary.BigEndian.Uint16(elements[j].Key)
+	})
+	ranges := []nftablesPortRange{}
+	for i, element := range elements {
+		if element.IntervalEnd {
+			continue
+		}
+		portRange := nftablesPortRange{from: binary.BigEndian.Uint16(element.Key), to: math.MaxUint16}
+		if i+1 < len(elements) && elements[i+1].IntervalEnd {
+			portRange.to = binary.BigEndian.Uint16(elements[i+1].Key) - 1
+		}
+		ranges = append(ranges, portRange)
+	}
+	return ranges
+}
+
+//parseRule converts nftables rule expressions that were created by ruleExprs back to the traffic rule
+func (n *NFTablesTrafficRuleBackend) parseRule(table, chain string, nftRule *nftables.Rule, position int) (domain.HostNetworkTrafficRule, error) {
+	rule := domain.HostNetworkTrafficRule{
+		Chain:    chain,
+		Position: position,
+		Comment:  n.parseComment(nftRule.UserData),
+	}
+	field := ""
+	var mask []byte
+	registers := map[uint32][]byte{}
+	for _, e := range nftRule.Exprs {
+		switch e := e.(type) {
+		case *expr.Payload:
+			mask = nil
+			switch {
+			case e.Base == expr.PayloadBaseNetworkHeader && e.Offset == 12:
+				field = "source"
+			case e.Base == expr.PayloadBaseNetworkHeader && e.Offset == 16:
+				field = "destination"
+			case e.Base == expr.PayloadBaseTransportHeader && e.Offset == 0:
+				field = "sourcePorts"
+			case e.Base == expr.PayloadBaseTransportHeader && e.Offset == 2:
+				field = "destinationPorts"
+			default:
+				field = ""
+			}
+		case *expr.Meta:
+			mask = nil
+			switch e.Key {
+			case expr.MetaKeyIIFNAME:
+				field = "inInterface"
+			case expr.MetaKeyOIFNAME:
+				field = "outInterface"
+			case expr.MetaKeyL4PROTO:
+				field = "protocol"
+			default:
+				field = ""
+			}
+		case *expr.Ct:
+			mask = nil
+			field = ""
+			if e.Key == expr.CtKeySTATE {
+				field = "state"
+			}
+		case *expr.Bitwise:
+			mask = e.Mask
+		case *expr.Cmp:
+			n.setRuleField(&rule, field, e, mask)
+		case *expr.Range:
+			value := fmt.Sprintf("%s%d:%d", n.negation(e.Op == expr.CmpOpNeq),
+				binary.BigEndian.Uint16(e.FromData), binary.BigEndian.Uint16(e.ToData))
+			if field == "sourcePorts" {
+				rule.SourcePorts = value
+			} else if field == "destinationPorts" {
+				rule.DestinationPorts = value
+			}
+		case *expr.Lookup:
+			value, err := n.parsePortsSet(e)
+			if err != nil {
+				return rule, err
+			}
+			if field == "sourcePorts" {
+				rule.SourcePorts = value
+			} else if field == "destinationPorts" {
+				rule.DestinationPorts = value
+			}
+		case *expr.Immediate:
+			registers[e.Register] = e.Data
+		case *expr.NAT:
+			rule.Action = "SNAT"
+			if e.Type == expr.NATTypeDestNAT {
+				rule.Action = "DNAT"
+			}
+			rule.To = net.IP(registers[e.RegAddrMin]).String()
+			if port, ok := registers[e.RegProtoMin]; ok && e.RegProtoMin != 0 && len(port) == 2 {
+				rule.To += ":" + strconv.Itoa(int(binary.BigEndian.Uint16(port)))
+			}
+		case *expr.Verdict:
+			switch e.Kind {
+			case expr.VerdictAccept:
+				rule.Action = "ACCEPT"
+			case expr.VerdictDrop:
+				rule.Action = "DROP"
+			case expr.VerdictReturn:
+				rule.Action = "RETURN"
+			case expr.VerdictJump, expr.VerdictGoto:
+				rule.Action = strings.TrimPrefix(e.Chain, table+"_")
+			}
+		}
+	}
+	//masq and reject expressions are not decoded by the nftables library,
+	//they are the only actions without decoded expression and masquerade is allowed only in nat chains
+	if rule.Action == "" {
+		rule.Action = "REJECT"
+		if table == "nat" {
+			rule.Action = "MASQUERADE"
+		}
+	}
+	return rule, nil
+}
+
+func (n *NFTablesTrafficRuleBackend) setRuleField(rule *domain.HostNetworkTrafficRule, field string, cmp *expr.Cmp, mask []byte) {
+	negation := n.negation(cmp.Op == expr.CmpOpNeq)
+	switch field {
+	case "source", "destination":
+		ones := 32
+		if mask != nil {
+			ones = 0
+			for _, b := range mask {
+				ones += bits.OnesCount8(b)
+			}
+		}
+		value := fmt.Sprintf("%s%s/%d", negation, net.IP(cmp.Data).String(), ones)
+		if field == "source" {
+			rule.Source = value
+		} else {
+			rule.Destination = value
+		}
+	case "inInterface", "outInterface":
+		name := string(cmp.Data)
+		if strings.Contains(name, "\x00") {
+			name = strings.TrimRight(name, "\x00")
+		} else {
+			name += "+"
+		}
+		if field == "inInterface" {
+			rule.InInterface = negation + name
+		} else {
+			rule.OutInterface = negation + name
+		}
+	case "protocol":
+		for name, number := range nftablesProtocols {
+			if len(cmp.Data) == 1 && cmp.Data[0] == number {
+				rule.Protocol = negation + name
+			}
+		}
+	case "sourcePorts":
+		rule.SourcePorts = negation + strconv.Itoa(int(binary.BigEndian.Uint16(cmp.Data)))
+	case "destinationPorts":
+		rule.DestinationPorts = negation + strconv.Itoa(int(binary.BigEndian.Uint16(cmp.Data)))
+	case "state":
+		if len(mask) != 4 {
+			return
+		}
+		var states []string
+		stateMask := binaryutil.NativeEndian.Uint32(mask)
+		for _, ctState := range nftablesCtStates {
+			if stateMask&ctState.bit != 0 {
+				states = append(states, ctState.name)
+			}
+		}
+		rule.State = strings.Join(states, ",")
+	}
+}
+
+func (n *NFTablesTrafficRuleBackend) getNFTRules(table, chain string, nftChain *nftables.Chain) ([]*nftables.Rule, []domain.HostNetworkTrafficRule, error) {
+	nftRules, err := n.conn.GetRules(n.table, nftChain)
+	if err != nil {
+		return nil, nil, errors.Internal.Wrap(err, "failed to get list of nftables rules")
+	}
+	var rules []domain.HostNetworkTrafficRule
+	for i, nftRule := range nftRules {
+		rule, err := n.parseRule(table, chain, nftRule, i+1)
+		if err != nil {
+			return nil, nil, errors.Internal.Wrap(err, "failed to parse nftables rule")
+		}
+		rules = append(rules, rule)
+	}
+	return nftRules, rules, nil
+}
+
+//findRule finds rule index in the chain by comparing rules fields without position
+func (n *NFTablesTrafficRuleBackend) findRule(rules []domain.HostNetworkTrafficRule, rule domain.HostNetworkTrafficRule) int {
+	for i, chainRule := range rules {
+		chainRule.Position = 0
+		if chainRule == rule {
+			return i
+		}
+	}
+	return -1
+}
+
+//CreateChain Create chain of the netfilter table in 'rol' nftables table if it does not exist
+//
+//Params:
+//	table - netfilter table to create a chain
+//	chain - chain name
+//Return:
+//	error - if an error occurs, otherwise nil
+func (n *NFTablesTrafficRuleBackend) CreateChain(table string, chain string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	_, err := n.getOrCreateChain(table, chain)
+	return err
+}
+
+//CreateRule Create traffic rule in 'rol' nftables table if the same rule does not exist
+//
+//Params:
+//	table - netfilter table to create a rule
+//	rule - rule entity, rule is inserted at the rule position or appended if position is 0
+//Return:
+//	error - if an error occurs, otherwise nil
+func (n *NFTablesTrafficRuleBackend) CreateRule(table string, rule domain.HostNetworkTrafficRule) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	position := rule.Position
+	rule, err := n.normalizeRule(rule)
+	if err != nil {
+		return err
+	}
+	nftChain, err := n.getOrCreateChain(table, rule.Chain)
+	if err != nil {
+		return err
+	}
+	nftRules, rules, err := n.getNFTRules(table, rule.Chain, nftChain)
+	if err != nil {
+		return err
+	}
+	if n.findRule(rules, rule) >= 0 {
+		return nil
+	}
+	exprs, err := n.ruleExprs(table, rule)
+	if err != nil {
+		return err
+	}
+	nftRule := &nftables.Rule{
+		Table:    n.table,
+		Chain:    nftChain,
+		Exprs:    exprs,
+		UserData: n.commentUserData(rule.Comment),
+	}
+	if position > 0 && position <= len(nftRules) {
+		//rule is inserted before the rule that is at the position now
+		nftRule.Position = nftRules[position-1].Handle
+		n.conn.InsertRule(nftRule)
+	} else {
+		n.conn.AddRule(nftRule)
+	}
+	err = n.conn.Flush()
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to create nftables rule")
+	}
+	return nil
+}
+
+//DeleteRule Delete traffic rule in 'rol' nftables table
+//
+//Params:
+//	table - netfilter table to delete a rule
+//	rule - rule entity, rule position is ignored
+//Return:
+//	error - if an error occurs, otherwise nil
+func (n *NFTablesTrafficRuleBackend) DeleteRule(table string, rule domain.HostNetworkTrafficRule) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	rule, err := n.normalizeRule(rule)
+	if err != nil {
+		return err
+	}
+	nftChain, err := n.getChain(table, rule.Chain)
+	if err != nil {
+		return err
+	}
+	if nftChain == nil {
+		return errors.NotFound.New("traffic rule not found")
+	}
+	nftRules, rules, err := n.getNFTRules(table, rule.Chain, nftChain)
+	if err != nil {
+		return err
+	}
+	index := n.findRule(rules, rule)
+	if index < 0 {
+		return errors.NotFound.New("traffic rule not found")
+	}
+	err = n.conn.DelRule(nftRules[index])
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to delete nftables rule")
+	}
+	err = n.conn.Flush()
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to delete nftables rule")
+	}
+	return nil
+}
+
+//GetChainRules Get selected chain rules of the netfilter table from 'rol' nftables table
+//
+//Params:
+//	table - netfilter table to get a rules
+//	chain - chain where we get the rules
+//Return:
+//	[]domain.HostNetworkTrafficRule - slice of rules
+//	error - if an error occurs, otherwise nil
+func (n *NFTablesTrafficRuleBackend) GetChainRules(table string, chain string) ([]domain.HostNetworkTrafficRule, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	nftChain, err := n.getChain(table, chain)
+	if err != nil || nftChain == nil {
+		return nil, err
+	}
+	_, rules, err := n.getNFTRules(table, chain, nftChain)
+	return rules, err
+}
+
+//GetTableRules Get all rules of the netfilter table from 'rol' nftables table
+//
+//Params:
+//	table - netfilter table to get a rules
+//Return:
+//	[]domain.HostNetworkTrafficRule - slice of rules
+//	error - if an error occurs, otherwise nil
+func (n *NFTablesTrafficRuleBackend) GetTableRules(table string) ([]domain.HostNetworkTrafficRule, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	chains, err := n.getChains()
+	if err != nil {
+		return nil, err
+	}
+	var rules []domain.HostNetworkTrafficRule
+	for _, nftChain := range chains {
+		if !strings.HasPrefix(nftChain.Name, table+"_") {
+			continue
+		}
+		chain := strings.TrimPrefix(nftChain.Name, table+"_")
+		_, chainRules, err := n.getNFTRules(table, chain, nftChain)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, chainRules...)
+	}
+	return rules, nil
+}
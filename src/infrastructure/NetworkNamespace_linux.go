@@ -0,0 +1,101 @@
+// Package infrastructure stores all implementations of app interfaces
+package infrastructure
+
+import (
+	"github.com/vishvananda/netns"
+	"net"
+	"os"
+	"rol/app/errors"
+	"runtime"
+)
+
+//networkNamespacesPath directory where named network namespaces are mounted
+const networkNamespacesPath = "/var/run/netns"
+
+//withOriginNetworkNamespace runs the function in the locked thread and returns the thread to the origin
+//network namespace after it, so the function can switch the thread network namespace
+func withOriginNetworkNamespace(fn func() error) error {
+	runtime.LockOSThread()
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return errors.Internal.Wrap(err, "failed to get current network namespace")
+	}
+	defer origin.Close()
+	fnErr := fn()
+	err = netns.Set(origin)
+	if err != nil {
+		//the thread is left locked, so it's terminated with the goroutine instead of being reused in other namespace
+		return errors.Internal.Wrap(err, "failed to return to the origin network namespace")
+	}
+	runtime.UnlockOSThread()
+	return fnErr
+}
+
+//runInNetworkNamespace runs the function in the named network namespace,
+//sockets that are opened by the function stay in the namespace. Function runs in the host network namespace if name is empty
+func runInNetworkNamespace(name string, fn func() error) error {
+	if name == "" {
+		return fn()
+	}
+	return withOriginNetworkNamespace(func() error {
+		ns, err := netns.GetFromName(name)
+		if err != nil {
+			return errors.NotFound.Wrapf(err, "network namespace %s is not found", name)
+		}
+		defer ns.Close()
+		err = netns.Set(ns)
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to switch to network namespace %s", name)
+		}
+		return fn()
+	})
+}
+
+//createNetworkNamespace creates the named network namespace
+func createNetworkNamespace(name string) error {
+	return withOriginNetworkNamespace(func() error {
+		ns, err := netns.NewNamed(name)
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to create network namespace %s", name)
+		}
+		return ns.Close()
+	})
+}
+
+//getNetworkNamespaceNames gets names of the named network namespaces
+func getNetworkNamespaceNames() ([]string, error) {
+	entries, err := os.ReadDir(networkNamespacesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, errors.Internal.Wrap(err, "failed to read network namespaces directory")
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+//listenUDPInNetworkNamespace opens UDP socket in the network namespace, host network namespace is used if name is empty
+func listenUDPInNetworkNamespace(name, address string) (*net.UDPConn, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, errors.Internal.Wrapf(err, "failed to resolve address %s", address)
+	}
+	var conn *net.UDPConn
+	listen := func() error {
+		conn, err = net.ListenUDP("udp", udpAddr)
+		if err != nil {
+			return errors.Internal.Wrapf(err, "failed to listen %s", address)
+		}
+		return nil
+	}
+	err = runInNetworkNamespace(name, listen)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
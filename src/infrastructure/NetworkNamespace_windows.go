@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"net"
+	"rol/app/errors"
+)
+
+//listenUDPInNetworkNamespace opens UDP socket, network namespaces are not implemented for windows
+func listenUDPInNetworkNamespace(name, address string) (*net.UDPConn, error) {
+	if name != "" {
+		return nil, errors.Internal.New("network namespaces are not supported on windows")
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, errors.Internal.Wrapf(err, "failed to resolve address %s", address)
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, errors.Internal.Wrapf(err, "failed to listen %s", address)
+	}
+	return conn, nil
+}
+
+//runInNetworkNamespace runs the function, network namespaces are not implemented for windows
+func runInNetworkNamespace(name string, fn func() error) error {
+	if name != "" {
+		return errors.Internal.New("network namespaces are not supported on windows")
+	}
+	return fn()
+}
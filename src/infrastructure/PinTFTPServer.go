@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/pin/tftp/v3"
 	"io"
+	"net"
 	"os"
 	"rol/app/errors"
 	"rol/app/interfaces"
@@ -14,6 +15,7 @@ import (
 //PinTFTPServer TFTP server implementation for ITFTPServer interface
 type PinTFTPServer struct {
 	runtime *tftp.Server
+	conn    net.PacketConn
 	config  domain.TFTPConfig
 	paths   *[]domain.TFTPPathRatio
 	state   domain.TFTPServerState
@@ -69,6 +71,25 @@ func (s *PinTFTPServer) ReloadPaths(paths []domain.TFTPPathRatio) error {
 
 //Start TFTP server
 func (s *PinTFTPServer) Start() error {
+	if s.config.Namespace != "" {
+		//the listening socket is opened in the namespace, transfers use the same socket
+		//because new sockets of the transfers would be opened in the host network namespace
+		conn, err := listenUDPInNetworkNamespace(s.config.Namespace, fmt.Sprintf("%s:%s", s.config.Address, s.config.Port))
+		if err != nil {
+			s.state = domain.TFTPStateError
+			return err
+		}
+		s.conn = conn
+		s.runtime.EnableSinglePort()
+		go func() {
+			s.state = domain.TFTPStateLaunched
+			err := s.runtime.Serve(conn)
+			if err != nil {
+				s.state = domain.TFTPStateError
+			}
+		}()
+		return nil
+	}
 	go func() {
 		s.state = domain.TFTPStateLaunched
 		err := s.runtime.ListenAndServe(fmt.Sprintf("%s:%s", s.config.Address, s.config.Port))
@@ -84,6 +105,11 @@ func (s *PinTFTPServer) Stop() {
 	if s.runtime != nil {
 		s.runtime.Shutdown()
 	}
+	//server doesn't close the socket in the single port mode
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
 	s.state = domain.TFTPStateStopped
 }
 
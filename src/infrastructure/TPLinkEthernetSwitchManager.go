@@ -1,10 +1,13 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"rol/app/errors"
 	"rol/app/interfaces"
+	"rol/app/utils"
+	"rol/domain"
 	"strconv"
 	"strings"
 )
@@ -24,81 +27,54 @@ const (
 	ErrorExecuteTelnet = "error executing telnet commands"
 )
 
+const tpLinkPagerPrompt = "Press any key to continue"
+
+var tpLinkPortLineRegexp = regexp.MustCompile("[A-Z][a-z]\\d/\\d/\\d")
+
 //TPLinkEthernetSwitchManager is a struct for tp link ethernet switch management
 type TPLinkEthernetSwitchManager struct {
-	telnetConn *TelnetConnection
-	address    string
-	login      string
-	password   string
+	address  string
+	login    string
+	password string
+	pool     *TelnetSessionPool
 }
 
 //NewTPLinkEthernetSwitchManager constructor for TPLinkEthernetSwitchManager
-func NewTPLinkEthernetSwitchManager(address, login, password string) interfaces.IEthernetSwitchManager {
-	tNet := NewTelnetConnection()
-	return &TPLinkEthernetSwitchManager{
-		telnetConn: tNet,
-		address:    address,
-		login:      login,
+//
+//Params:
+//	address - switch telnet address with port
+//	login - switch login
+//	password - switch password
+//	sessionConfig - switch sessions pool configuration
+//Return:
+//	interfaces.IEthernetSwitchManager - new switch manager
+func NewTPLinkEthernetSwitchManager(address, login, password string, sessionConfig domain.EthernetSwitchSessionConfig) interfaces.IEthernetSwitchManager {
+	manager := &TPLinkEthernetSwitchManager{
+		address: address,
+		login:   login,
 		//  pragma: allowlist nextline secret
 		password: password,
 	}
+	manager.pool = NewTelnetSessionPool(sessionConfig, manager.openSession, manager.pingSession)
+	return manager
 }
 
 //GetVLANs gets all VLANs on switch
 //
+//Params:
+//	ctx - context with deadline for the switch operation
 //Return:
 //	[]int - slice of VLANs
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) GetVLANs() ([]int, error) {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return []int{}, errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return []int{}, errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	err = t.telnetConn.Send("enable")
-	if err != nil {
-		return []int{}, errors.Internal.Wrap(err, ErrorEnablingTelnet)
-	}
-	err = t.telnetConn.Send("show vlan")
-	if err != nil {
-		return []int{}, errors.Internal.Wrap(err, "showing vlan error")
-	}
-	_, err = t.telnetConn.Read("-----------\r\n")
-	if err != nil {
-		return []int{}, errors.Internal.Wrap(err, ErrorReadingTelnet)
-	}
+func (t *TPLinkEthernetSwitchManager) GetVLANs(ctx context.Context) ([]int, error) {
 	out := []int{}
-	for {
-		msg, err := t.telnetConn.Read("\r")
-		if err != nil {
-			return []int{}, errors.Internal.Wrap(err, ErrorReadingTelnet)
-		}
-		fields := strings.Fields(msg)
-		if len(fields) == 0 {
-			break
-		}
-		if fields[0] == "System-VLAN" {
-			id := 1
-			out = append(out, id)
-			continue
-		}
-		match, err := regexp.MatchString("[A-Z][a-z]\\d/\\d/\\d", fields[0])
-		if err != nil {
-			return nil, errors.Internal.Wrap(err, "regexp match string error")
-		}
-		if match {
-			continue
-		}
-		if len(fields) > 1 {
-			id, err := strconv.Atoi(fields[0])
-			if err != nil {
-				return nil, errors.Internal.Wrap(err, "error convert string to int")
-			}
-			out = append(out, id)
-		}
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		var err error
+		out, err = t.readVLANs(session)
+		return err
+	})
+	if err != nil {
+		return []int{}, err
 	}
 	return out, nil
 }
@@ -106,380 +82,1294 @@ func (t *TPLinkEthernetSwitchManager) GetVLANs() ([]int, error) {
 //GetVLANsOnPort gets all ethernet switch VLANs on given port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - port name
 //Return:
 //	int - untagged VLAN ID
 //	[]int - slice of tagged VLANs IDs
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) GetVLANsOnPort(portName string) (int, []int, error) {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return 0, []int{}, errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return 0, []int{}, errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	portNumber := portName[2:]
-	err = t.telnetConn.Send("enable")
-	if err != nil {
-		return 0, []int{}, errors.Internal.Wrap(err, ErrorEnablingTelnet)
-	}
-	err = t.telnetConn.Send("show interface switchport gigabitEthernet " + portNumber)
-	if err != nil {
-		return 0, []int{}, errors.Internal.Wrap(err, ErrorShowInterface)
-	}
-
-	msg, err := t.telnetConn.Read("-----------\r\n")
-	if err != nil {
-		return 0, []int{}, errors.Internal.Wrap(err, ErrorReadingTelnet)
-	}
-
-	msg, err = t.telnetConn.Read("\r\n\n\r")
+func (t *TPLinkEthernetSwitchManager) GetVLANsOnPort(ctx context.Context, portName string) (int, []int, error) {
+	portConfig := domain.EthernetSwitchPortConfig{}
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		var err error
+		portConfig, err = t.readPortVLANs(session, portName)
+		return err
+	})
 	if err != nil {
-		return 0, []int{}, errors.Internal.Wrap(err, ErrorReadingTelnet)
+		return 0, []int{}, err
 	}
 	untaggedVLAN := 0
-	taggedVLANs := []int{}
-	str := msg[:len(msg)-4]
-	vlansInfo := strings.Split(str, "\r\n")
-	for _, vlan := range vlansInfo {
-		fields := strings.Fields(vlan)
-		id, err := strconv.Atoi(fields[0])
-		if err != nil {
-			return 0, nil, errors.Internal.Wrap(err, "convert string to int failed")
-		}
-		if fields[2] == "Untagged" {
-			untaggedVLAN = id
-		} else {
-			taggedVLANs = append(taggedVLANs, id)
-		}
+	if len(portConfig.UntaggedVLANs) > 0 {
+		untaggedVLAN = portConfig.UntaggedVLANs[len(portConfig.UntaggedVLANs)-1]
 	}
-	return untaggedVLAN, taggedVLANs, nil
+	return untaggedVLAN, portConfig.TaggedVLANs, nil
 }
 
 //AddTaggedVLANOnPort add tagged VLAN on given port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - port name
 //	vlanID - vlan ID
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) AddTaggedVLANOnPort(portName string, vlanID int) error {
-	return t.addVLANOnPort(portName, "tagged", vlanID)
+func (t *TPLinkEthernetSwitchManager) AddTaggedVLANOnPort(ctx context.Context, portName string, vlanID int) error {
+	return t.addVLANOnPort(ctx, portName, "tagged", vlanID)
 }
 
 //RemoveVLANFromPort remove tagged VLAN from given port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - name of port
 //	vlanID	- vlan ID
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) RemoveVLANFromPort(portName string, vlanID int) error {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	portNumber := portName[2:]
-	exec := fmt.Sprintf("enable;config;interface gigabitEthernet %s;no switchport general allowed vlan %d;exit;exit;exit", portNumber, vlanID)
-	err = t.executeTelnetCommands(exec)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
-	}
-
-	return nil
+func (t *TPLinkEthernetSwitchManager) RemoveVLANFromPort(ctx context.Context, portName string, vlanID int) error {
+	return t.configure(ctx,
+		"interface "+tpLinkInterface(portName),
+		fmt.Sprintf("no switchport general allowed vlan %d", vlanID))
 }
 
 //AddUntaggedVLANOnPort sets tagged VLAN on given port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - port name
 //	vlanID - vlan ID
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) AddUntaggedVLANOnPort(portName string, vlanID int) error {
-	return t.addVLANOnPort(portName, "untagged", vlanID)
+func (t *TPLinkEthernetSwitchManager) AddUntaggedVLANOnPort(ctx context.Context, portName string, vlanID int) error {
+	return t.addVLANOnPort(ctx, portName, "untagged", vlanID)
 }
 
 //SetPortPVID set PVID on given port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - port on which to set up the PVID
 //	vlanID - PVID
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) SetPortPVID(portName string, vlanID int) error {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	portNumber := portName[2:]
-	exec := fmt.Sprintf("enable;config;interface gigabitEthernet %s;switchport pvid %d;end;exit;exit", portNumber, vlanID)
-	err = t.executeTelnetCommands(exec)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
-	}
-	return nil
+func (t *TPLinkEthernetSwitchManager) SetPortPVID(ctx context.Context, portName string, vlanID int) error {
+	return t.configure(ctx,
+		"interface "+tpLinkInterface(portName),
+		fmt.Sprintf("switchport pvid %d", vlanID))
 }
 
 //DeleteVLAN delete VLAN by id
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	vlanID - vlan ID
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) DeleteVLAN(vlanID int) error {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	exec := fmt.Sprintf("enable;config;no vlan %d;exit;exit;exit", vlanID)
-	err = t.executeTelnetCommands(exec)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
-	}
-	return nil
+func (t *TPLinkEthernetSwitchManager) DeleteVLAN(ctx context.Context, vlanID int) error {
+	return t.configure(ctx, fmt.Sprintf("no vlan %d", vlanID))
 }
 
 //CreateVLAN create vlan on switch
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	vlanID	- vlan ID
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) CreateVLAN(vlanID int) error {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	exec := fmt.Sprintf("enable;config;vlan %d;exit;exit", vlanID)
-	err = t.executeTelnetCommands(exec)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
-	}
-	return nil
+func (t *TPLinkEthernetSwitchManager) CreateVLAN(ctx context.Context, vlanID int) error {
+	return t.configure(ctx, fmt.Sprintf("vlan %d", vlanID))
 }
 
 //GetPOEPortStatus gets poe status on give port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - port name
 //Return:
 //	string - poe port status "enable" or "disable"
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) GetPOEPortStatus(portName string) (string, error) {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return "", errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return "", errors.Internal.Wrap(err, ErrorLoginIn)
-	}
-	portNumber := portName[2:]
-	err = t.telnetConn.Send("enable")
-	if err != nil {
-		return "", errors.Internal.Wrap(err, ErrorEnablingTelnet)
-	}
-	err = t.telnetConn.Send("show power inline configuration interface gigabitEthernet " + portNumber)
-	if err != nil {
-		return "", errors.Internal.Wrap(err, ErrorShowInterface)
-	}
-	_, err = t.telnetConn.Read("-----------\r\n")
-	if err != nil {
-		return "", errors.Internal.Wrap(err, ErrorReadingTelnet)
-	}
-	msg, err := t.telnetConn.Read("\r\n\n\r")
+func (t *TPLinkEthernetSwitchManager) GetPOEPortStatus(ctx context.Context, portName string) (string, error) {
+	status := ""
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		var err error
+		status, err = t.readPOEStatus(session, portName)
+		return err
+	})
 	if err != nil {
-		return "", errors.Internal.Wrap(err, ErrorReadingTelnet)
+		return "", err
 	}
-	portConfig := strings.Fields(msg)
-	return portConfig[1], nil
+	return status, nil
 }
 
 //EnablePOEPort enable poe on given port
 //
 //Params:
+//	ctx - context with deadline for the switch operation
 //	portName - port name
 //	poeType - poe type: "poe", "poe+" etc
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) EnablePOEPort(portName, poeType string) error {
-	err := t.telnetConn.Connect(t.address)
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
-	}
-	err = t.logIn()
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
-	}
+func (t *TPLinkEthernetSwitchManager) EnablePOEPort(ctx context.Context, portName, poeType string) error {
 	consumption := ""
 	switch poeType {
 	case "passive24":
-		return errors.Internal.Wrap(err, "this switch does not support passive24 poe")
+		return errors.Internal.New("this switch does not support passive24 poe")
 	default:
 		consumption = "auto"
 	}
+	return t.configure(ctx,
+		"interface "+tpLinkInterface(portName),
+		"power inline consumption "+consumption,
+		"power inline supply enable")
+}
 
-	portNumber := portName[2:]
-	exec := fmt.Sprintf("enable;config;interface gigabitEthernet %s;power inline consumption %s;power inline supply enable;exit;exit;exit;exit", portNumber, consumption)
-	err = t.executeTelnetCommands(exec)
+//DisablePOEPort disable poe on given port
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	portName - port name
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) DisablePOEPort(ctx context.Context, portName string) error {
+	return t.configure(ctx,
+		"interface "+tpLinkInterface(portName),
+		"power inline supply disable")
+}
+
+//SetPortShutdown sets administrative state of the port
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	portName - port name
+//	shutdown - true to disable the port, false to enable it
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) SetPortShutdown(ctx context.Context, portName string, shutdown bool) error {
+	return t.configure(ctx,
+		"interface "+tpLinkInterface(portName),
+		tpLinkShutdownCommand(shutdown))
+}
+
+//SetPortDescription sets port description
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	portName - port name
+//	description - port description, empty to remove it
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) SetPortDescription(ctx context.Context, portName, description string) error {
+	return t.configure(ctx,
+		"interface "+tpLinkInterface(portName),
+		tpLinkDescriptionCommand(description))
+}
+
+//SetPortSpeed sets port speed and duplex
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	portName - port name
+//	speed - port speed: "auto", "10M", "100M", "1000M", empty to keep the current one
+//	duplex - port duplex: "auto", "full", "half", empty to keep the current one
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) SetPortSpeed(ctx context.Context, portName, speed, duplex string) error {
+	commands := []string{"interface " + tpLinkInterface(portName)}
+	if speed != "" {
+		commands = append(commands, tpLinkSpeedCommand(speed))
+	}
+	if duplex != "" {
+		commands = append(commands, "duplex "+duplex)
+	}
+	if len(commands) == 1 {
+		return nil
+	}
+	return t.configure(ctx, commands...)
+}
+
+//CreateLAG creates link aggregation group with given member ports
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	groupID - port channel number
+//	mode - LACP mode: "active", "passive" or "static"
+//	portNames - member ports names
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) CreateLAG(ctx context.Context, groupID int, mode string, portNames []string) error {
+	return t.AddLAGMembers(ctx, groupID, mode, portNames)
+}
+
+//AddLAGMembers adds ports to the link aggregation group
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	groupID - port channel number
+//	mode - LACP mode: "active", "passive" or "static"
+//	portNames - ports names
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) AddLAGMembers(ctx context.Context, groupID int, mode string, portNames []string) error {
+	if mode == "static" {
+		mode = "on"
+	}
+	commands := []string{}
+	for _, portName := range portNames {
+		commands = append(commands,
+			"interface "+tpLinkInterface(portName),
+			fmt.Sprintf("channel-group %d mode %s", groupID, mode),
+			"exit")
+	}
+	return t.configure(ctx, commands...)
+}
+
+//RemoveLAGMembers removes ports from their link aggregation group
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	portNames - ports names
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) RemoveLAGMembers(ctx context.Context, portNames []string) error {
+	commands := []string{}
+	for _, portName := range portNames {
+		commands = append(commands, "interface "+tpLinkInterface(portName), "no channel-group", "exit")
+	}
+	return t.configure(ctx, commands...)
+}
+
+//DeleteLAG deletes link aggregation group, the port channel is removed by the switch with the last member
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	groupID - port channel number
+//	portNames - member ports names
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) DeleteLAG(ctx context.Context, groupID int, portNames []string) error {
+	return t.RemoveLAGMembers(ctx, portNames)
+}
+
+//SaveConfig Save current settings on switch
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) SaveConfig(ctx context.Context) error {
+	return t.pool.Do(ctx, func(session *TelnetSession) error {
+		_, err := t.execCommand(session, "copy running-config startup-config")
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		}
+		return nil
+	})
+}
+
+//GetRunningConfig gets full running configuration of the switch as text
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//Return:
+//	string - running configuration
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) GetRunningConfig(ctx context.Context) (string, error) {
+	config := ""
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		msg, err := t.execCommand(session, "show running-config")
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		}
+		config = tpLinkRunningConfig(msg, "show running-config")
+		return nil
+	})
+	return config, err
+}
+
+//RestoreRunningConfig applies configuration previously got by GetRunningConfig to the switch.
+//The changeset from the current running configuration to the given one is applied, so VLANs,
+//LAG members and port settings that were changed after the backup are reverted. Other global
+//commands are not restored. The configuration is not saved to the startup configuration.
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	config - running configuration
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) RestoreRunningConfig(ctx context.Context, config string) error {
+	desired, desiredGroups, err := tpLinkParseRunningConfig(config)
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		return err
 	}
-	return nil
+	return t.pool.Do(ctx, func(session *TelnetSession) error {
+		msg, err := t.execCommand(session, "show running-config")
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		}
+		current, currentGroups, err := tpLinkParseRunningConfig(tpLinkRunningConfig(msg, "show running-config"))
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to parse current switch configuration")
+		}
+		commands := tpLinkChannelGroupCommands(current, currentGroups, desiredGroups)
+		changeset := current.ChangesetTo(desired)
+		ports := []domain.EthernetSwitchPortChanges{}
+		for _, port := range changeset.Ports {
+			//LAG doesn't support POE, speed and duplex settings
+			if _, isLAG := domain.LAGGroupID(port.Name); isLAG {
+				port.POEEnabled, port.Speed, port.Duplex = nil, nil, nil
+			}
+			if !port.IsEmpty() {
+				ports = append(ports, port)
+			}
+		}
+		changeset.Ports = ports
+		changesetCommands, err := t.changesetCommands(changeset)
+		if err != nil {
+			return err
+		}
+		commands = append(commands, changesetCommands...)
+		if len(commands) == 0 {
+			return nil
+		}
+		return t.configureInSession(session, commands...)
+	})
 }
 
-//DisablePOEPort disable poe on given port
+//GetFirmwareInfo gets firmware version and hardware revision of the switch
 //
 //Params:
-//	portName - port name
+//	ctx - context with deadline for the switch operation
+//Return:
+//	domain.EthernetSwitchFirmwareInfo - switch firmware information
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) GetFirmwareInfo(ctx context.Context) (domain.EthernetSwitchFirmwareInfo, error) {
+	info := domain.EthernetSwitchFirmwareInfo{}
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		msg, err := t.execCommand(session, "show system-info")
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		}
+		for _, line := range tpLinkLines(msg) {
+			separator := strings.Index(line, " - ")
+			if separator < 0 {
+				continue
+			}
+			value := strings.TrimSpace(line[separator+3:])
+			switch strings.TrimSpace(line[:separator]) {
+			case "Firmware Version":
+				info.FirmwareVersion = value
+			case "Hardware Version":
+				info.HardwareVersion = value
+			}
+		}
+		if info.FirmwareVersion == "" {
+			return errors.Internal.New("firmware version is not found in the system information")
+		}
+		return nil
+	})
+	return info, err
+}
+
+//UpgradeFirmware makes the switch to download the firmware image from the TFTP server, install it
+//and reboot. The method returns when the switch starts rebooting.
+//
+//Params:
+//	ctx - context with deadline for the image download and installation
+//	tftpAddress - IP address of the TFTP server
+//	fileName - image file name on the TFTP server
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) UpgradeFirmware(ctx context.Context, tftpAddress, fileName string) error {
+	isQuestion := func(out string) bool {
+		return strings.Contains(tpLinkLastLine(out), "(Y/N)")
+	}
+	return t.pool.Do(ctx, func(session *TelnetSession) error {
+		command := fmt.Sprintf("firmware upgrade ip-address %s filename %s", tftpAddress, fileName)
+		msg, err := session.ExecConfirmed(command, isQuestion, "y")
+		//the switch closes the connection when it starts rebooting after the confirmation
+		if err != nil && (strings.Contains(msg, "(Y/N)") || strings.Contains(strings.ToLower(msg), "reboot")) {
+			return nil
+		}
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		}
+		for _, line := range tpLinkLines(msg) {
+			if strings.HasPrefix(line, "Error") || strings.HasPrefix(line, "%") {
+				return errors.Internal.Newf("firmware upgrade failed: %s", line)
+			}
+		}
+		if !strings.Contains(strings.ToLower(msg), "reboot") {
+			return errors.Internal.New("switch didn't start rebooting after the firmware upgrade")
+		}
+		return nil
+	})
+}
+
+//GetConfig gets configuration of the switch: VLANs and all physical ports with their PVID, VLANs, POE status,
+//administrative state, description, speed and duplex
+//
+//Params:
+//	ctx - context with deadline for the switch operation
 //Return:
+//	domain.EthernetSwitchConfig - switch configuration
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) DisablePOEPort(portName string) error {
-	err := t.telnetConn.Connect(t.address)
+func (t *TPLinkEthernetSwitchManager) GetConfig(ctx context.Context) (domain.EthernetSwitchConfig, error) {
+	config := domain.EthernetSwitchConfig{}
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		vlans, err := t.readVLANs(session)
+		if err != nil {
+			return err
+		}
+		portNames, err := t.readPortNames(session)
+		if err != nil {
+			return err
+		}
+		settings, err := t.readPortSettings(session, "")
+		if err != nil {
+			return err
+		}
+		config = domain.EthernetSwitchConfig{VLANs: vlans, Ports: []domain.EthernetSwitchPortConfig{}}
+		for _, portName := range portNames {
+			portConfig, err := t.readPortVLANs(session, portName)
+			if err != nil {
+				return err
+			}
+			applyTPLinkPortSettings(&portConfig, settings[portName])
+			portConfig.POEType = "none"
+			poeStatus, err := t.readPOEStatus(session, portName)
+			if err != nil && session.broken {
+				return err
+			}
+			//ports without POE support return an error on POE configuration request
+			if err == nil {
+				portConfig.POEType = "poe+"
+				portConfig.POEEnabled = poeStatus == "enable"
+			}
+			config.Ports = append(config.Ports, portConfig)
+		}
+		return nil
+	})
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
+		return domain.EthernetSwitchConfig{}, errors.Internal.Wrap(err, "failed to read switch configuration")
 	}
-	err = t.logIn()
+	return config, nil
+}
+
+//GetLLDPNeighbors gets neighbors discovered by LLDP on all switch ports
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//Return:
+//	[]domain.EthernetSwitchLLDPNeighbor - LLDP neighbors
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) GetLLDPNeighbors(ctx context.Context) ([]domain.EthernetSwitchLLDPNeighbor, error) {
+	msg := ""
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		var err error
+		msg, err = t.execCommand(session, "show lldp neighbor-information")
+		return err
+	})
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
+		return nil, errors.Internal.Wrap(err, "failed to read lldp neighbors")
+	}
+	neighbors := []domain.EthernetSwitchLLDPNeighbor{}
+	portName := ""
+	var neighbor *domain.EthernetSwitchLLDPNeighbor
+	for _, line := range tpLinkLines(msg) {
+		if strings.HasPrefix(line, "LLDP Neighbor Information of port") {
+			portName = tpLinkPortLineRegexp.FindString(line)
+			continue
+		}
+		if strings.HasPrefix(line, "Neighbor index") {
+			if neighbor != nil {
+				neighbors = append(neighbors, *neighbor)
+			}
+			neighbor = &domain.EthernetSwitchLLDPNeighbor{PortName: portName}
+			continue
+		}
+		separator := strings.Index(line, ":")
+		if neighbor == nil || separator < 0 {
+			continue
+		}
+		value := strings.TrimSpace(line[separator+1:])
+		switch strings.TrimSpace(line[:separator]) {
+		case "Chassis ID":
+			neighbor.ChassisID = value
+		case "Port ID":
+			neighbor.PortID = value
+		case "System name":
+			neighbor.SystemName = value
+		case "Port description":
+			neighbor.PortDescription = value
+		case "Management address":
+			neighbor.ManagementAddress = value
+		}
 	}
-	portNumber := portName[2:]
-	exec := fmt.Sprintf("enable;config;interface gigabitEthernet %s;power inline supply disable;exit;exit;exit;exit", portNumber)
-	err = t.executeTelnetCommands(exec)
+	if neighbor != nil {
+		neighbors = append(neighbors, *neighbor)
+	}
+	return neighbors, nil
+}
+
+//GetMACTable gets MAC address table of the switch
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//Return:
+//	[]domain.EthernetSwitchMACEntry - MAC address table entries
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) GetMACTable(ctx context.Context) ([]domain.EthernetSwitchMACEntry, error) {
+	msg := ""
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		var err error
+		msg, err = t.execCommand(session, "show mac address-table")
+		return err
+	})
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		return nil, errors.Internal.Wrap(err, "failed to read mac address table")
 	}
-	return nil
+	entries := []domain.EthernetSwitchMACEntry{}
+	for _, row := range tpLinkTableRows(msg) {
+		fields := strings.Fields(row)
+		if len(fields) < 4 || !tpLinkPortLineRegexp.MatchString(fields[2]) {
+			continue
+		}
+		vlanID, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "convert string to int failed")
+		}
+		entries = append(entries, domain.EthernetSwitchMACEntry{
+			MAC:      strings.ToLower(strings.ReplaceAll(fields[0], "-", ":")),
+			VLANID:   vlanID,
+			PortName: fields[2],
+			Type:     fields[3],
+		})
+	}
+	return entries, nil
 }
 
-//SaveConfig Save current settings on switch
+//GetPortStatus gets actual state of the port: link status, speed, counters and POE power
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	portName - port name
+//Return:
+//	domain.EthernetSwitchPortStatus - port status
+//	error - if an error occurs, otherwise nil
+func (t *TPLinkEthernetSwitchManager) GetPortStatus(ctx context.Context, portName string) (domain.EthernetSwitchPortStatus, error) {
+	status := domain.EthernetSwitchPortStatus{Name: portName}
+	err := t.pool.Do(ctx, func(session *TelnetSession) error {
+		portNumber := tpLinkPortNumber(portName)
+		msg, err := t.execCommand(session, "show interface configuration gigabitEthernet "+portNumber)
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorShowInterface)
+		}
+		rows := tpLinkTableRows(msg)
+		if len(rows) == 0 {
+			return errors.Internal.New("port configuration is not found")
+		}
+		fields := strings.Fields(rows[0])
+		status.AdminUp = len(fields) > 1 && fields[1] == "Enable"
+		msg, err = t.execCommand(session, "show interface status gigabitEthernet "+portNumber)
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorShowInterface)
+		}
+		rows = tpLinkTableRows(msg)
+		if len(rows) == 0 {
+			return errors.Internal.New("port status is not found")
+		}
+		fields = strings.Fields(rows[0])
+		status.OperUp = len(fields) > 1 && fields[1] == "LinkUp"
+		if status.OperUp && len(fields) > 3 {
+			status.Speed = fields[2]
+			status.Duplex = fields[3]
+		}
+		msg, err = t.execCommand(session, "show interface counters gigabitEthernet "+portNumber)
+		if err != nil {
+			return errors.Internal.Wrap(err, ErrorShowInterface)
+		}
+		tpLinkParseCounters(msg, &status)
+		msg, err = t.execCommand(session, "show power inline information interface gigabitEthernet "+portNumber)
+		if err != nil && session.broken {
+			return err
+		}
+		//ports without POE support return an error on POE information request
+		if err == nil {
+			rows = tpLinkTableRows(msg)
+			if len(rows) > 0 {
+				fields = strings.Fields(rows[0])
+				if len(fields) > 3 {
+					status.POEPower, _ = strconv.ParseFloat(fields[3], 64)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.EthernetSwitchPortStatus{}, errors.Internal.Wrap(err, "failed to read port status")
+	}
+	return status, nil
+}
+
+//Begin starts the configuration transaction
 //
+//Params:
+//	ctx - context with deadline for waiting of the free switch session
 //Return:
+//	interfaces.IEthernetSwitchTransaction - started transaction
 //	error - if an error occurs, otherwise nil
-func (t *TPLinkEthernetSwitchManager) SaveConfig() error {
-	err := t.telnetConn.Connect(t.address)
+func (t *TPLinkEthernetSwitchManager) Begin(ctx context.Context) (interfaces.IEthernetSwitchTransaction, error) {
+	session, err := t.pool.Acquire(ctx)
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
+		return nil, errors.Internal.Wrap(err, "failed to acquire switch session")
 	}
-	err = t.logIn()
+	return newTPLinkEthernetSwitchTransaction(t, session), nil
+}
+
+//Close closes all switch sessions and stops the sessions keepalive, the manager can't be used after closing
+func (t *TPLinkEthernetSwitchManager) Close() {
+	t.pool.Close()
+}
+
+//openSession opens new telnet connection, logs in and enters privileged mode
+func (t *TPLinkEthernetSwitchManager) openSession(ctx context.Context) (*TelnetSession, error) {
+	conn := NewTelnetConnection()
+	err := conn.Connect(ctx, t.address)
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
+		return nil, errors.Internal.Wrap(err, ErrorCreatingConnection)
 	}
-	exec := fmt.Sprintf("enable;copy running-config startup-config;exit;exit")
-	err = t.executeTelnetCommands(exec)
+	//login reading can't be interrupted by the context, so we close the connection when the context is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+	hostname, err := t.logIn(conn)
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		_ = conn.Close()
+		return nil, errors.Internal.Wrap(err, ErrorLoginIn)
 	}
-	return nil
+	isPrompt := func(out string) bool {
+		line := tpLinkLastLine(out)
+		return strings.HasPrefix(line, hostname) && (strings.HasSuffix(line, "#") || strings.HasSuffix(line, ">"))
+	}
+	isPager := func(out string) bool {
+		return strings.Contains(tpLinkLastLine(out), tpLinkPagerPrompt)
+	}
+	session := NewTelnetSession(conn, isPrompt, isPager)
+	_, err = session.Exec("enable")
+	if err != nil {
+		session.Close()
+		return nil, errors.Internal.Wrap(err, ErrorEnablingTelnet)
+	}
+	return session, nil
+}
 
+//pingSession checks that the session is alive by reading the prompt after an empty command
+func (t *TPLinkEthernetSwitchManager) pingSession(session *TelnetSession) error {
+	_, err := session.Exec("")
+	return err
 }
 
-func (t *TPLinkEthernetSwitchManager) logIn() (err error) {
-	_, err = t.telnetConn.Read("Login")
+//logIn authorizes the connection and returns the switch hostname taken from the CLI prompt
+func (t *TPLinkEthernetSwitchManager) logIn(conn *TelnetConnection) (string, error) {
+	_, err := conn.Read("Login")
 	if err != nil {
-		return errors.Internal.Wrap(err, "error waiting for login string")
+		return "", errors.Internal.Wrap(err, "error waiting for login string")
 	}
-	err = t.telnetConn.Send(t.login)
+	err = conn.Send(t.login)
 	if err != nil {
-		return errors.Internal.Wrap(err, "login send error")
+		return "", errors.Internal.Wrap(err, "login send error")
 	}
-	_, err = t.telnetConn.Read("Password")
+	_, err = conn.Read("Password")
 	if err != nil {
-		return errors.Internal.Wrap(err, "error waiting for password string")
+		return "", errors.Internal.Wrap(err, "error waiting for password string")
 	}
-	err = t.telnetConn.Send(t.password)
+	err = conn.Send(t.password)
 	if err != nil {
-		return errors.Internal.Wrap(err, "password send error")
+		return "", errors.Internal.Wrap(err, "password send error")
 	}
-	return nil
+	out, err := conn.ReadUntil(func(out string) bool {
+		line := tpLinkLastLine(out)
+		return strings.HasSuffix(line, ">") || strings.HasSuffix(line, "#") || strings.Contains(line, "Login")
+	})
+	if err != nil {
+		return "", errors.Internal.Wrap(err, "error waiting for the prompt")
+	}
+	prompt := tpLinkLastLine(out)
+	if strings.Contains(prompt, "Login") {
+		return "", errors.Internal.New("switch rejected login or password")
+	}
+	hostname := prompt[:len(prompt)-1]
+	if hostname == "" {
+		return "", errors.Internal.New("failed to get switch hostname from the prompt")
+	}
+	return hostname, nil
 }
 
-func (t *TPLinkEthernetSwitchManager) addVLANOnPort(portName, vlanType string, vlanID int) error {
-	err := t.telnetConn.Connect(t.address)
+//execCommand executes the command and checks that the switch didn't report an error
+func (t *TPLinkEthernetSwitchManager) execCommand(session *TelnetSession, command string) (string, error) {
+	out, err := session.Exec(command)
 	if err != nil {
-		return errors.Internal.Wrap(err, ErrorCreatingConnection)
+		return "", errors.Internal.Wrap(err, ErrorReadingTelnet)
 	}
-	err = t.logIn()
-	if err != nil {
-		return errors.Internal.Wrap(err, ErrorLoginIn)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Error") || strings.HasPrefix(line, "%") {
+			return "", errors.Internal.Newf("command '%s' failed: %s", command, line)
+		}
 	}
+	return out, nil
+}
+
+//configure executes commands in the global configuration mode within one session
+func (t *TPLinkEthernetSwitchManager) configure(ctx context.Context, commands ...string) error {
+	return t.pool.Do(ctx, func(session *TelnetSession) error {
+		return t.configureInSession(session, commands...)
+	})
+}
 
-	vlanExist, err := t.isVLANExists(vlanID)
+func (t *TPLinkEthernetSwitchManager) configureInSession(session *TelnetSession, commands ...string) error {
+	_, err := t.execCommand(session, "config")
 	if err != nil {
-		return errors.Internal.Wrap(err, "failed check vlan existence")
+		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
 	}
-	if vlanExist {
-		portNumber := portName[2:]
-		exec := fmt.Sprintf("enable;config;interface gigabitEthernet %s;switchport general allowed vlan %d %s;exit;exit;exit;", portNumber, vlanID, vlanType)
-		err = t.executeTelnetCommands(exec)
+	for _, command := range commands {
+		_, err = t.execCommand(session, command)
 		if err != nil {
+			//return to the privileged mode, so the session can be reused
+			_, _ = session.Exec("end")
 			return errors.Internal.Wrap(err, ErrorExecuteTelnet)
 		}
-		return nil
 	}
-	return errors.NotFound.New("vlan not found")
+	_, err = t.execCommand(session, "end")
+	if err != nil {
+		return errors.Internal.Wrap(err, ErrorExecuteTelnet)
+	}
+	return nil
 }
 
-func (t *TPLinkEthernetSwitchManager) isVLANExists(vlanID int) (bool, error) {
-	err := t.telnetConn.Connect(t.address)
+func (t *TPLinkEthernetSwitchManager) readVLANs(session *TelnetSession) ([]int, error) {
+	msg, err := t.execCommand(session, "show vlan")
 	if err != nil {
-		return false, errors.Internal.Wrap(err, ErrorCreatingConnection)
+		return nil, errors.Internal.Wrap(err, "showing vlan error")
+	}
+	out := []int{}
+	for _, row := range tpLinkTableRows(msg) {
+		fields := strings.Fields(row)
+		if fields[0] == "System-VLAN" {
+			out = append(out, 1)
+			continue
+		}
+		if tpLinkPortLineRegexp.MatchString(fields[0]) {
+			continue
+		}
+		if len(fields) > 1 {
+			id, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, errors.Internal.Wrap(err, "error convert string to int")
+			}
+			out = append(out, id)
+		}
 	}
-	err = t.logIn()
+	return out, nil
+}
+
+//readPortNames reads names of all physical ports
+func (t *TPLinkEthernetSwitchManager) readPortNames(session *TelnetSession) ([]string, error) {
+	msg, err := t.execCommand(session, "show interface status")
 	if err != nil {
-		return false, errors.Internal.Wrap(err, ErrorLoginIn)
+		return nil, errors.Internal.Wrap(err, ErrorShowInterface)
+	}
+	names := []string{}
+	for _, row := range tpLinkTableRows(msg) {
+		fields := strings.Fields(row)
+		if tpLinkPortLineRegexp.MatchString(fields[0]) {
+			names = append(names, fields[0])
+		}
+	}
+	return names, nil
+}
+
+//readPortVLANs reads PVID and VLANs of the port
+func (t *TPLinkEthernetSwitchManager) readPortVLANs(session *TelnetSession, portName string) (domain.EthernetSwitchPortConfig, error) {
+	portConfig := domain.EthernetSwitchPortConfig{
+		Name:          portName,
+		TaggedVLANs:   []int{},
+		UntaggedVLANs: []int{},
 	}
-	err = t.telnetConn.Send("enable")
+	msg, err := t.execCommand(session, "show interface switchport "+tpLinkInterface(portName))
 	if err != nil {
-		return false, errors.Internal.Wrap(err, ErrorEnablingTelnet)
+		return portConfig, errors.Internal.Wrap(err, ErrorShowInterface)
+	}
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "PVID:") {
+			portConfig.PVID, err = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "PVID:")))
+			if err != nil {
+				return portConfig, errors.Internal.Wrap(err, "failed to parse port PVID")
+			}
+			break
+		}
 	}
-	exec := fmt.Sprintf("config;show vlan id %d", vlanID)
-	err = t.executeTelnetCommands(exec)
+	for _, row := range tpLinkTableRows(msg) {
+		fields := strings.Fields(row)
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return portConfig, errors.Internal.Wrap(err, "convert string to int failed")
+		}
+		if fields[len(fields)-1] == "Untagged" {
+			portConfig.UntaggedVLANs = append(portConfig.UntaggedVLANs, id)
+		} else {
+			portConfig.TaggedVLANs = append(portConfig.TaggedVLANs, id)
+		}
+	}
+	return portConfig, nil
+}
+
+//readPOEStatus reads POE status of the port, "enable" or "disable"
+func (t *TPLinkEthernetSwitchManager) readPOEStatus(session *TelnetSession, portName string) (string, error) {
+	msg, err := t.execCommand(session, "show power inline configuration interface gigabitEthernet "+tpLinkPortNumber(portName))
 	if err != nil {
-		return false, errors.Internal.Wrap(err, ErrorExecuteTelnet)
+		return "", errors.Internal.Wrap(err, ErrorShowInterface)
 	}
-	_, err = t.telnetConn.Read("---\r\n")
+	rows := tpLinkTableRows(msg)
+	if len(rows) == 0 {
+		return "", errors.Internal.New("poe configuration of the port is not found")
+	}
+	portConfig := strings.Fields(rows[0])
+	if len(portConfig) < 2 {
+		return "", errors.Internal.New("failed to parse poe configuration of the port")
+	}
+	return portConfig[1], nil
+}
+
+//readPortConfig reads PVID, VLANs, POE status and settings of the port, only PVID and VLANs are read for LAG
+func (t *TPLinkEthernetSwitchManager) readPortConfig(session *TelnetSession, portName string) (domain.EthernetSwitchPortConfig, error) {
+	portConfig, err := t.readPortVLANs(session, portName)
 	if err != nil {
-		return false, errors.Internal.Wrap(err, ErrorReadingTelnet)
+		return portConfig, err
+	}
+	if _, isLAG := domain.LAGGroupID(portName); isLAG {
+		return portConfig, nil
 	}
-	msg, err := t.telnetConn.Read("\r")
+	poeStatus, err := t.readPOEStatus(session, portName)
+	if err != nil && session.broken {
+		return portConfig, err
+	}
+	//ports without POE support return an error on POE configuration request
+	if err == nil {
+		portConfig.POEEnabled = poeStatus == "enable"
+	}
+	settings, err := t.readPortSettings(session, portName)
+	if err != nil {
+		return portConfig, err
+	}
+	applyTPLinkPortSettings(&portConfig, settings[portName])
+	return portConfig, nil
+}
+
+//readPortSettings reads administrative state, speed, duplex and description of the port,
+//settings of all ports are read if the port name is empty
+func (t *TPLinkEthernetSwitchManager) readPortSettings(session *TelnetSession, portName string) (map[string]domain.EthernetSwitchPortConfig, error) {
+	command := "show interface configuration"
+	if portName != "" {
+		command += " gigabitEthernet " + tpLinkPortNumber(portName)
+	}
+	msg, err := t.execCommand(session, command)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, ErrorShowInterface)
+	}
+	settings := map[string]domain.EthernetSwitchPortConfig{}
+	//columns: Port, State, Speed, Duplex, FlowCtrl, Description
+	for _, row := range tpLinkTableRows(msg) {
+		fields := strings.Fields(row)
+		if len(fields) < 4 || !tpLinkPortLineRegexp.MatchString(fields[0]) {
+			continue
+		}
+		portSettings := domain.EthernetSwitchPortConfig{
+			Shutdown: fields[1] == "Disable",
+			Speed:    fields[2],
+			Duplex:   strings.ToLower(fields[3]),
+		}
+		if strings.EqualFold(portSettings.Speed, "auto") {
+			portSettings.Speed = "auto"
+		}
+		if len(fields) > 5 {
+			portSettings.Description = strings.Join(fields[5:], " ")
+		}
+		settings[fields[0]] = portSettings
+	}
+	return settings, nil
+}
+
+//changesetCommands converts changeset to the configuration mode commands
+func (t *TPLinkEthernetSwitchManager) changesetCommands(changeset domain.EthernetSwitchChangeset) ([]string, error) {
+	commands := []string{}
+	for _, vlanID := range changeset.CreateVLANs {
+		commands = append(commands, fmt.Sprintf("vlan %d", vlanID), "exit")
+	}
+	for _, port := range changeset.Ports {
+		commands = append(commands, "interface "+tpLinkInterface(port.Name))
+		for _, vlanID := range port.RemoveVLANs {
+			commands = append(commands, fmt.Sprintf("no switchport general allowed vlan %d", vlanID))
+		}
+		for _, vlanID := range port.AddTaggedVLANs {
+			commands = append(commands, fmt.Sprintf("switchport general allowed vlan %d tagged", vlanID))
+		}
+		for _, vlanID := range port.AddUntaggedVLANs {
+			commands = append(commands, fmt.Sprintf("switchport general allowed vlan %d untagged", vlanID))
+		}
+		if port.PVID != nil {
+			commands = append(commands, fmt.Sprintf("switchport pvid %d", *port.PVID))
+		}
+		if port.Shutdown != nil {
+			commands = append(commands, tpLinkShutdownCommand(*port.Shutdown))
+		}
+		if port.Description != nil {
+			commands = append(commands, tpLinkDescriptionCommand(*port.Description))
+		}
+		if port.Speed != nil {
+			commands = append(commands, tpLinkSpeedCommand(*port.Speed))
+		}
+		if port.Duplex != nil {
+			commands = append(commands, "duplex "+*port.Duplex)
+		}
+		if port.POEEnabled != nil {
+			if *port.POEEnabled {
+				if port.POEType == "passive24" {
+					return nil, errors.Internal.New("this switch does not support passive24 poe")
+				}
+				commands = append(commands, "power inline consumption auto", "power inline supply enable")
+			} else {
+				commands = append(commands, "power inline supply disable")
+			}
+		}
+		commands = append(commands, "exit")
+	}
+	for _, vlanID := range changeset.DeleteVLANs {
+		commands = append(commands, fmt.Sprintf("no vlan %d", vlanID))
+	}
+	return commands, nil
+}
+
+func (t *TPLinkEthernetSwitchManager) addVLANOnPort(ctx context.Context, portName, vlanType string, vlanID int) error {
+	return t.pool.Do(ctx, func(session *TelnetSession) error {
+		vlanExist, err := t.isVLANExists(session, vlanID)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed check vlan existence")
+		}
+		if !vlanExist {
+			return errors.NotFound.New("vlan not found")
+		}
+		return t.configureInSession(session,
+			"interface "+tpLinkInterface(portName),
+			fmt.Sprintf("switchport general allowed vlan %d %s", vlanID, vlanType))
+	})
+}
+
+func (t *TPLinkEthernetSwitchManager) isVLANExists(session *TelnetSession, vlanID int) (bool, error) {
+	msg, err := session.Exec(fmt.Sprintf("show vlan id %d", vlanID))
 	if err != nil {
 		return false, errors.Internal.Wrap(err, ErrorReadingTelnet)
 	}
-	vlan := strings.Fields(msg)
-	if len(vlan) > 0 {
-		return true, nil
+	return len(tpLinkTableRows(msg)) > 0, nil
+}
+
+//applyTPLinkPortSettings copies administrative state, speed, duplex and description to the port configuration
+func applyTPLinkPortSettings(portConfig *domain.EthernetSwitchPortConfig, settings domain.EthernetSwitchPortConfig) {
+	portConfig.Shutdown = settings.Shutdown
+	portConfig.Description = settings.Description
+	portConfig.Speed = settings.Speed
+	portConfig.Duplex = settings.Duplex
+}
+
+func tpLinkShutdownCommand(shutdown bool) string {
+	if shutdown {
+		return "shutdown"
+	}
+	return "no shutdown"
+}
+
+func tpLinkDescriptionCommand(description string) string {
+	if description == "" {
+		return "no description"
 	}
-	return false, nil
+	return fmt.Sprintf("description \"%s\"", description)
 }
 
-func (t *TPLinkEthernetSwitchManager) executeTelnetCommands(exec string) error {
-	commands := strings.Split(exec, ";")
-	var err error
-	for _, command := range commands {
-		err = t.telnetConn.Send(command)
+//tpLinkSpeedCommand converts speed like "1000M" to the command "speed 1000"
+func tpLinkSpeedCommand(speed string) string {
+	return "speed " + strings.TrimSuffix(speed, "M")
+}
+
+//tpLinkInterface converts port name to the interface name for commands,
+//like "gigabitEthernet 1/0/1" for port "Gi1/0/1" or "port-channel 1" for LAG "Po1"
+func tpLinkInterface(portName string) string {
+	if groupID, isLAG := domain.LAGGroupID(portName); isLAG {
+		return fmt.Sprintf("port-channel %d", groupID)
+	}
+	return "gigabitEthernet " + tpLinkPortNumber(portName)
+}
+
+//tpLinkPortNumber converts port name like "Gi1/0/1" to the port number "1/0/1"
+func tpLinkPortNumber(portName string) string {
+	if len(portName) < 2 {
+		return portName
+	}
+	return portName[2:]
+}
+
+//tpLinkLastLine returns trimmed last line of the output
+func tpLinkLastLine(out string) string {
+	index := strings.LastIndexAny(out, "\r\n")
+	return strings.TrimSpace(out[index+1:])
+}
+
+//tpLinkTableRows returns not empty rows of the first table in the command output,
+//the table header is separated from rows by the dashed line
+func tpLinkTableRows(out string) []string {
+	rows := []string{}
+	tableStarted := false
+	for _, line := range tpLinkLines(out) {
+		if !tableStarted {
+			tableStarted = strings.HasPrefix(line, "---")
+			continue
+		}
+		if line == "" {
+			if len(rows) > 0 {
+				break
+			}
+			continue
+		}
+		rows = append(rows, line)
+	}
+	//the last line is the CLI prompt
+	if len(rows) > 0 && strings.HasSuffix(rows[len(rows)-1], "#") {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+//tpLinkLines returns trimmed lines of the command output without the pager prompts
+func tpLinkLines(out string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\r", ""))
+		if strings.Contains(line, tpLinkPagerPrompt) {
+			line = strings.TrimSpace(line[strings.Index(line, tpLinkPagerPrompt)+len(tpLinkPagerPrompt):])
+			line = strings.TrimSpace(strings.TrimPrefix(line, "(Q to quit)"))
+			if line == "" {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+//tpLinkRunningConfig extracts configuration text from the command output: the command echo,
+//pager prompts and the CLI prompt are removed, indentation of the lines is kept
+func tpLinkRunningConfig(out, command string) string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(out, "\r", ""), "\n") {
+		if strings.Contains(line, tpLinkPagerPrompt) {
+			line = line[strings.Index(line, tpLinkPagerPrompt)+len(tpLinkPagerPrompt):]
+			line = strings.TrimPrefix(strings.TrimLeft(line, " "), "(Q to quit)")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+		}
+		if len(lines) == 0 && (strings.TrimSpace(line) == "" || strings.Contains(line, command)) {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	//the last line is the CLI prompt like "TL-SG2210MP#"
+	config := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if index := strings.LastIndex(config, "\n"); index >= 0 {
+		prompt := config[index+1:]
+		if prompt != "#" && (strings.HasSuffix(prompt, "#") || strings.HasSuffix(prompt, ">")) {
+			config = strings.TrimRight(config[:index], "\n")
+		}
+	}
+	return config
+}
+
+//tpLinkChannelGroup LAG membership of the port in the running configuration
+type tpLinkChannelGroup struct {
+	groupID int
+	mode    string
+}
+
+//tpLinkParseRunningConfig parses VLANs, ports configuration and LAG membership of the ports from the running
+//configuration. Settings that are missing in the configuration have the switch default values.
+func tpLinkParseRunningConfig(config string) (domain.EthernetSwitchConfig, map[string]tpLinkChannelGroup, error) {
+	parsed := domain.EthernetSwitchConfig{VLANs: []int{1}, Ports: []domain.EthernetSwitchPortConfig{}}
+	groups := map[string]tpLinkChannelGroup{}
+	var port *domain.EthernetSwitchPortConfig
+	for _, line := range strings.Split(config, "\n") {
+		command := strings.TrimSpace(line)
+		if command == "" || command == "end" || strings.HasPrefix(command, "!") {
+			continue
+		}
+		if command == "#" {
+			port = nil
+			continue
+		}
+		fields := strings.Fields(command)
+		var err error
+		switch {
+		case !strings.HasPrefix(line, " ") && len(fields) == 2 && fields[0] == "vlan":
+			var vlanIDs []int
+			vlanIDs, err = tpLinkParseVLANList(fields[1])
+			for _, vlanID := range vlanIDs {
+				if !utils.SliceContainsElement(parsed.VLANs, vlanID) {
+					parsed.VLANs = append(parsed.VLANs, vlanID)
+				}
+			}
+		case !strings.HasPrefix(line, " ") && len(fields) == 3 && fields[0] == "interface":
+			parsed.Ports = append(parsed.Ports, tpLinkDefaultPortConfig(fields[1], fields[2]))
+			port = &parsed.Ports[len(parsed.Ports)-1]
+		case port == nil:
+			continue
+		case len(fields) == 4 && fields[0] == "channel-group" && fields[2] == "mode":
+			group := tpLinkChannelGroup{mode: fields[3]}
+			group.groupID, err = strconv.Atoi(fields[1])
+			groups[port.Name] = group
+		case len(fields) == 5 && strings.HasPrefix(command, "no switchport general allowed vlan "):
+			var vlanIDs []int
+			vlanIDs, err = tpLinkParseVLANList(fields[4])
+			for _, vlanID := range vlanIDs {
+				port.TaggedVLANs = utils.RemoveElementFromSlice(port.TaggedVLANs, vlanID)
+				port.UntaggedVLANs = utils.RemoveElementFromSlice(port.UntaggedVLANs, vlanID)
+			}
+		case len(fields) == 6 && strings.HasPrefix(command, "switchport general allowed vlan "):
+			var vlanIDs []int
+			vlanIDs, err = tpLinkParseVLANList(fields[4])
+			for _, vlanID := range vlanIDs {
+				port.TaggedVLANs = utils.RemoveElementFromSlice(port.TaggedVLANs, vlanID)
+				port.UntaggedVLANs = utils.RemoveElementFromSlice(port.UntaggedVLANs, vlanID)
+			}
+			if fields[5] == "tagged" {
+				port.TaggedVLANs = append(port.TaggedVLANs, vlanIDs...)
+			} else {
+				port.UntaggedVLANs = append(port.UntaggedVLANs, vlanIDs...)
+			}
+		case len(fields) == 3 && fields[0] == "switchport" && fields[1] == "pvid":
+			port.PVID, err = strconv.Atoi(fields[2])
+		case command == "power inline supply disable":
+			port.POEEnabled = false
+		case command == "shutdown":
+			port.Shutdown = true
+		case fields[0] == "description":
+			port.Description = strings.Trim(strings.TrimSpace(strings.TrimPrefix(command, "description")), "\"")
+		case len(fields) == 2 && fields[0] == "speed" && fields[1] != "auto":
+			port.Speed = fields[1] + "M"
+		case len(fields) == 2 && fields[0] == "duplex":
+			port.Duplex = fields[1]
+		}
 		if err != nil {
-			return errors.Internal.Wrap(err, "send command to telnet server failed")
+			return domain.EthernetSwitchConfig{}, nil, errors.Internal.Wrapf(err, "failed to parse configuration line \"%s\"", command)
+		}
+	}
+	if len(parsed.Ports) == 0 {
+		return domain.EthernetSwitchConfig{}, nil, errors.Internal.New("configuration doesn't contain any interface")
+	}
+	return parsed, groups, nil
+}
+
+//tpLinkDefaultPortConfig returns default configuration of the port or LAG by its interface type and number
+func tpLinkDefaultPortConfig(interfaceType, number string) domain.EthernetSwitchPortConfig {
+	portConfig := domain.EthernetSwitchPortConfig{
+		PVID:          1,
+		TaggedVLANs:   []int{},
+		UntaggedVLANs: []int{1},
+	}
+	if interfaceType == "port-channel" {
+		portConfig.Name = "Po" + number
+		return portConfig
+	}
+	portConfig.Name = "Gi" + number
+	portConfig.POEEnabled = true
+	portConfig.Speed = "auto"
+	portConfig.Duplex = "auto"
+	return portConfig
+}
+
+//tpLinkChannelGroupCommands returns commands that change LAG membership of the ports from the current
+//to the desired one, ports leave their groups before other ports join them
+func tpLinkChannelGroupCommands(current domain.EthernetSwitchConfig, currentGroups, desiredGroups map[string]tpLinkChannelGroup) []string {
+	leave := []string{}
+	join := []string{}
+	for _, port := range current.Ports {
+		currentGroup, desiredGroup := currentGroups[port.Name], desiredGroups[port.Name]
+		if currentGroup == desiredGroup {
+			continue
+		}
+		if currentGroup.groupID != 0 {
+			leave = append(leave, "interface "+tpLinkInterface(port.Name), "no channel-group", "exit")
+		}
+		if desiredGroup.groupID != 0 {
+			join = append(join, "interface "+tpLinkInterface(port.Name),
+				fmt.Sprintf("channel-group %d mode %s", desiredGroup.groupID, desiredGroup.mode), "exit")
+		}
+	}
+	return append(leave, join...)
+}
+
+//tpLinkParseVLANList parses VLAN list like "10,20-22" of the configuration commands
+func tpLinkParseVLANList(list string) ([]int, error) {
+	vlanIDs := []int{}
+	for _, item := range strings.Split(list, ",") {
+		bounds := strings.SplitN(item, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+		for vlanID := first; vlanID <= last; vlanID++ {
+			vlanIDs = append(vlanIDs, vlanID)
+		}
+	}
+	return vlanIDs, nil
+}
+
+//tpLinkParseCounters parses bytes and errors counters from the receive and transmit sections of the port counters
+func tpLinkParseCounters(out string, status *domain.EthernetSwitchPortStatus) {
+	var bytes, errorsCounter *uint64
+	for _, line := range tpLinkLines(out) {
+		switch strings.TrimSuffix(line, ":") {
+		case "Rx", "RX":
+			bytes, errorsCounter = &status.RxBytes, &status.RxErrors
+			continue
+		case "Tx", "TX":
+			bytes, errorsCounter = &status.TxBytes, &status.TxErrors
+			continue
+		}
+		separator := strings.LastIndex(line, ":")
+		if bytes == nil || separator < 0 {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimSpace(line[separator+1:]), 10, 64)
+		if err != nil {
+			continue
+		}
+		name := strings.ToLower(line[:separator])
+		switch {
+		case name == "octets" || name == "bytes" || name == "total octets":
+			*bytes = value
+		case strings.Contains(name, "error"):
+			*errorsCounter += value
 		}
 	}
-	return nil
 }
@@ -0,0 +1,177 @@
+package infrastructure
+
+import (
+	"context"
+	"rol/app/errors"
+	"rol/domain"
+	"sync"
+)
+
+//tpLinkEthernetSwitchTransaction configuration transaction of the tp link ethernet switch
+type tpLinkEthernetSwitchTransaction struct {
+	manager *TPLinkEthernetSwitchManager
+	session *TelnetSession
+	//captured - configuration of the changed VLANs and ports before the transaction
+	captured      domain.EthernetSwitchConfig
+	vlansCaptured bool
+	finished      bool
+	mutex         sync.Mutex
+}
+
+func newTPLinkEthernetSwitchTransaction(manager *TPLinkEthernetSwitchManager, session *TelnetSession) *tpLinkEthernetSwitchTransaction {
+	return &tpLinkEthernetSwitchTransaction{
+		manager: manager,
+		session: session,
+		captured: domain.EthernetSwitchConfig{
+			VLANs: []int{},
+			Ports: []domain.EthernetSwitchPortConfig{},
+		},
+	}
+}
+
+//Apply applies changeset to the running configuration of the switch
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//	changeset - changes to apply
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *tpLinkEthernetSwitchTransaction) Apply(ctx context.Context, changeset domain.EthernetSwitchChangeset) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.finished {
+		return errors.Internal.New("transaction is already finished")
+	}
+	if changeset.IsEmpty() {
+		return nil
+	}
+	commands, err := t.manager.changesetCommands(changeset)
+	if err != nil {
+		return err
+	}
+	return t.manager.pool.Run(ctx, t.session, func(session *TelnetSession) error {
+		err := t.capture(session, changeset.GetPortNames())
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to capture switch configuration")
+		}
+		return t.manager.configureInSession(session, commands...)
+	})
+}
+
+//Commit saves the running configuration of the switch and finishes the transaction,
+//the transaction is not finished if saving fails, so it can be rolled back
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *tpLinkEthernetSwitchTransaction) Commit(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.finished {
+		return errors.Internal.New("transaction is already finished")
+	}
+	err := t.manager.pool.Run(ctx, t.session, func(session *TelnetSession) error {
+		_, err := t.manager.execCommand(session, "copy running-config startup-config")
+		if err != nil {
+			return errors.Internal.Wrap(err, "save switch config failed")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	t.finish()
+	return nil
+}
+
+//Rollback reverts changed VLANs and ports to the configuration captured before changes and finishes the transaction
+//
+//Params:
+//	ctx - context with deadline for the switch operation
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *tpLinkEthernetSwitchTransaction) Rollback(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.finished {
+		return errors.Internal.New("transaction is already finished")
+	}
+	defer t.finish()
+	if !t.vlansCaptured {
+		//nothing was changed
+		return nil
+	}
+	if t.session.broken {
+		session, err := t.manager.pool.Reopen(ctx, t.session)
+		t.session = session
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to reopen session for rollback")
+		}
+	}
+	return t.manager.pool.Run(ctx, t.session, func(session *TelnetSession) error {
+		current, err := t.read(session, t.captured.Ports)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to read switch configuration for rollback")
+		}
+		commands, err := t.manager.changesetCommands(current.ChangesetTo(t.captured))
+		if err != nil {
+			return err
+		}
+		if len(commands) == 0 {
+			return nil
+		}
+		err = t.manager.configureInSession(session, commands...)
+		if err != nil {
+			return errors.Internal.Wrap(err, "failed to revert switch configuration")
+		}
+		return nil
+	})
+}
+
+func (t *tpLinkEthernetSwitchTransaction) finish() {
+	t.finished = true
+	t.manager.pool.Release(t.session)
+	t.session = nil
+}
+
+//capture saves the current configuration of the VLANs and given ports, if they are not captured yet
+func (t *tpLinkEthernetSwitchTransaction) capture(session *TelnetSession, portNames []string) error {
+	if !t.vlansCaptured {
+		vlans, err := t.manager.readVLANs(session)
+		if err != nil {
+			return err
+		}
+		t.captured.VLANs = vlans
+		t.vlansCaptured = true
+	}
+	for _, portName := range portNames {
+		if _, captured := t.captured.GetPort(portName); captured {
+			continue
+		}
+		portConfig, err := t.manager.readPortConfig(session, portName)
+		if err != nil {
+			return err
+		}
+		t.captured.Ports = append(t.captured.Ports, portConfig)
+	}
+	return nil
+}
+
+//read reads the current configuration of the VLANs and given ports
+func (t *tpLinkEthernetSwitchTransaction) read(session *TelnetSession, ports []domain.EthernetSwitchPortConfig) (domain.EthernetSwitchConfig, error) {
+	config := domain.EthernetSwitchConfig{Ports: []domain.EthernetSwitchPortConfig{}}
+	vlans, err := t.manager.readVLANs(session)
+	if err != nil {
+		return config, err
+	}
+	config.VLANs = vlans
+	for _, port := range ports {
+		portConfig, err := t.manager.readPortConfig(session, port.Name)
+		if err != nil {
+			return config, err
+		}
+		config.Ports = append(config.Ports, portConfig)
+	}
+	return config, nil
+}
@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"github.com/reiver/go-telnet"
 	"rol/app/errors"
 	"strings"
@@ -19,15 +20,50 @@ func NewTelnetConnection() *TelnetConnection {
 //Connect makes a connection with telnet server
 //
 //Params:
+//	ctx - context, if it is done before the connection is established, the dial is abandoned
 //	address - telnet server address
 //Return:
 //	error - if an error occurs, otherwise nil
-func (t *TelnetConnection) Connect(address string) (err error) {
-	t.bond, err = telnet.DialTo(address)
-	if nil != err {
-		if err != nil {
-			return errors.Internal.Wrap(err, "error connecting to telnet server")
+func (t *TelnetConnection) Connect(ctx context.Context, address string) error {
+	type dialResult struct {
+		conn *telnet.Conn
+		err  error
+	}
+	resultChan := make(chan dialResult, 1)
+	go func() {
+		conn, err := telnet.DialTo(address)
+		resultChan <- dialResult{conn: conn, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		//close the connection if it will be established after the context is done
+		go func() {
+			result := <-resultChan
+			if result.conn != nil {
+				_ = result.conn.Close()
+			}
+		}()
+		return errors.Internal.Wrap(ctx.Err(), "error connecting to telnet server")
+	case result := <-resultChan:
+		if result.err != nil {
+			return errors.Internal.Wrap(result.err, "error connecting to telnet server")
 		}
+		t.bond = result.conn
+	}
+	return nil
+}
+
+//Close closes the connection with telnet server
+//
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t *TelnetConnection) Close() error {
+	if t.bond == nil {
+		return nil
+	}
+	err := t.bond.Close()
+	if err != nil {
+		return errors.Internal.Wrap(err, "error closing telnet connection")
 	}
 	return nil
 }
@@ -40,6 +76,19 @@ func (t *TelnetConnection) Connect(address string) (err error) {
 //	string - telnet output
 //	error - if an error occurs, otherwise nil
 func (t TelnetConnection) Read(expect string) (string, error) {
+	return t.ReadUntil(func(out string) bool {
+		return strings.Contains(out, expect)
+	})
+}
+
+//ReadUntil reads telnet output byte by byte until the stop function returns true
+//
+//Params:
+//	stop - function that receives all read output and returns true when reading should be stopped
+//Return:
+//	string - telnet output
+//	error - if an error occurs, otherwise nil
+func (t TelnetConnection) ReadUntil(stop func(out string) bool) (string, error) {
 	var buffer [1]byte
 	recvData := buffer[:]
 	var (
@@ -47,19 +96,42 @@ func (t TelnetConnection) Read(expect string) (string, error) {
 		err error
 		out string
 	)
+	if t.bond == nil {
+		return "", errors.Internal.New("telnet connection is not established")
+	}
 	for {
 		n, err = t.bond.Read(recvData)
-		if n <= 0 || err != nil || strings.Contains(out, expect) {
-			if err != nil {
-				return "", errors.Internal.Wrap(err, "error reading from telnet server")
-			}
+		if err != nil {
+			return "", errors.Internal.Wrap(err, "error reading from telnet server")
+		}
+		if n <= 0 {
 			break
 		}
 		out += string(recvData)
+		if stop(out) {
+			break
+		}
 	}
 	return out, nil
 }
 
+//SendKey sends single key press to telnet server without line break
+//
+//Params:
+//	key - key to send
+//Return:
+//	error - if an error occurs, otherwise nil
+func (t TelnetConnection) SendKey(key byte) error {
+	if t.bond == nil {
+		return errors.Internal.New("telnet connection is not established")
+	}
+	_, err := t.bond.Write([]byte{key})
+	if err != nil {
+		return errors.Internal.Wrap(err, "error sending key to telnet server")
+	}
+	return nil
+}
+
 //Send sends command to telnet server
 //
 //Params:
@@ -67,6 +139,9 @@ func (t TelnetConnection) Read(expect string) (string, error) {
 //Return:
 //	error - if an error occurs, otherwise nil
 func (t TelnetConnection) Send(command string) error {
+	if t.bond == nil {
+		return errors.Internal.New("telnet connection is not established")
+	}
 	var commandBuffer []byte
 	for _, char := range command {
 		commandBuffer = append(commandBuffer, byte(char))
@@ -0,0 +1,415 @@
+package infrastructure
+
+import (
+	"context"
+	"rol/app/errors"
+	"rol/domain"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSessionPoolSize          = 1
+	defaultSessionIdleTimeout       = 300
+	defaultSessionKeepaliveInterval = 60
+	defaultSessionCommandTimeout    = 30
+)
+
+//TelnetSession authorized telnet session with the device CLI
+type TelnetSession struct {
+	conn     *TelnetConnection
+	isPrompt func(out string) bool
+	isPager  func(out string) bool
+	lastUsed time.Time
+	broken   bool
+}
+
+//NewTelnetSession constructor for TelnetSession
+//
+//Params:
+//	conn - established and authorized telnet connection
+//	isPrompt - returns true if the output ends with the CLI prompt
+//	isPager - returns true if the output ends with the pager prompt, can be nil
+//Return:
+//	*TelnetSession - new telnet session
+func NewTelnetSession(conn *TelnetConnection, isPrompt, isPager func(out string) bool) *TelnetSession {
+	return &TelnetSession{
+		conn:     conn,
+		isPrompt: isPrompt,
+		isPager:  isPager,
+		lastUsed: time.Now(),
+	}
+}
+
+//Exec sends the command and reads its output until the CLI prompt
+//
+//Params:
+//	command - command to execute
+//Return:
+//	string - command output including the prompt
+//	error - if an error occurs, otherwise nil
+func (s *TelnetSession) Exec(command string) (string, error) {
+	err := s.conn.Send(command)
+	if err != nil {
+		s.broken = true
+		return "", errors.Internal.Wrap(err, "failed to send command")
+	}
+	return s.ReadOutput()
+}
+
+//ExecConfirmed sends the command and reads its output until the CLI prompt, all confirmation questions
+//of the command are answered with the same answer
+//
+//Params:
+//	command - command to execute
+//	isQuestion - returns true if the output ends with the confirmation question
+//	answer - answer to send on each question
+//Return:
+//	string - command output including the prompt, on error the output read before the error
+//	error - if an error occurs, otherwise nil
+func (s *TelnetSession) ExecConfirmed(command string, isQuestion func(out string) bool, answer string) (string, error) {
+	err := s.conn.Send(command)
+	if err != nil {
+		s.broken = true
+		return "", errors.Internal.Wrap(err, "failed to send command")
+	}
+	out := ""
+	for {
+		page, err := s.conn.ReadUntil(func(out string) bool {
+			return s.isPrompt(out) || isQuestion(out) || (s.isPager != nil && s.isPager(out))
+		})
+		if err != nil {
+			s.broken = true
+			return out, errors.Internal.Wrap(err, "failed to read command output")
+		}
+		out += page
+		switch {
+		case s.isPrompt(page):
+			return out, nil
+		case isQuestion(page):
+			err = s.conn.Send(answer)
+		default:
+			err = s.conn.SendKey(' ')
+		}
+		if err != nil {
+			s.broken = true
+			return out, errors.Internal.Wrap(err, "failed to answer the command")
+		}
+	}
+}
+
+//ReadOutput reads the output until the CLI prompt, all pages of the output will be read
+//
+//Return:
+//	string - output including the prompt
+//	error - if an error occurs, otherwise nil
+func (s *TelnetSession) ReadOutput() (string, error) {
+	out := ""
+	for {
+		page, err := s.conn.ReadUntil(func(out string) bool {
+			return s.isPrompt(out) || (s.isPager != nil && s.isPager(out))
+		})
+		if err != nil {
+			s.broken = true
+			return "", errors.Internal.Wrap(err, "failed to read command output")
+		}
+		out += page
+		if s.isPrompt(page) {
+			return out, nil
+		}
+		err = s.conn.SendKey(' ')
+		if err != nil {
+			s.broken = true
+			return "", errors.Internal.Wrap(err, "failed to request next output page")
+		}
+	}
+}
+
+//Close closes the session connection
+func (s *TelnetSession) Close() {
+	s.broken = true
+	_ = s.conn.Close()
+}
+
+//TelnetSessionPool pool of authorized telnet sessions to one device.
+//Count of simultaneously used sessions is limited by pool size, all other callers wait in the queue.
+type TelnetSessionPool struct {
+	config  domain.EthernetSwitchSessionConfig
+	open    func(ctx context.Context) (*TelnetSession, error)
+	ping    func(session *TelnetSession) error
+	slots   chan struct{}
+	mutex   sync.Mutex
+	idle    []*TelnetSession
+	stop    chan struct{}
+	stopped sync.Once
+	//closed - sessions that are released after the pool closing are closed too
+	closed bool
+}
+
+//NewTelnetSessionPool constructor for TelnetSessionPool, starts keepalive routine for idle sessions
+//
+//Params:
+//	config - sessions configuration, zero values will be replaced by defaults
+//	open - function that opens and authorizes new session
+//	ping - function that checks that the session is alive
+//Return:
+//	*TelnetSessionPool - new telnet sessions pool
+func NewTelnetSessionPool(config domain.EthernetSwitchSessionConfig, open func(ctx context.Context) (*TelnetSession, error),
+	ping func(session *TelnetSession) error) *TelnetSessionPool {
+	if config.PoolSize <= 0 {
+		config.PoolSize = defaultSessionPoolSize
+	}
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = defaultSessionIdleTimeout
+	}
+	if config.KeepaliveInterval <= 0 {
+		config.KeepaliveInterval = defaultSessionKeepaliveInterval
+	}
+	if config.CommandTimeout <= 0 {
+		config.CommandTimeout = defaultSessionCommandTimeout
+	}
+	pool := &TelnetSessionPool{
+		config: config,
+		open:   open,
+		ping:   ping,
+		slots:  make(chan struct{}, config.PoolSize),
+		idle:   []*TelnetSession{},
+		stop:   make(chan struct{}),
+	}
+	go pool.keepalive()
+	return pool
+}
+
+//Do runs the action with one of the pool sessions. If the context has no deadline,
+//then the default command timeout is used. If reused session is broken, then the action
+//will be retried once with the new session.
+//
+//Params:
+//	ctx - context with deadline for the whole operation
+//	action - action to run
+//Return:
+//	error - if an error occurs, otherwise nil
+func (p *TelnetSessionPool) Do(ctx context.Context, action func(session *TelnetSession) error) error {
+	ctx, cancel := p.withDefaultTimeout(ctx)
+	defer cancel()
+	session, reused, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	err = p.run(ctx, session, action)
+	if err != nil && reused && session.broken && ctx.Err() == nil {
+		session, err = p.Reopen(ctx, session)
+		if err != nil {
+			p.Release(session)
+			return err
+		}
+		err = p.run(ctx, session, action)
+	}
+	p.Release(session)
+	return err
+}
+
+//Acquire takes the session from the pool for exclusive usage, the session must be released by Release
+//
+//Params:
+//	ctx - context with deadline for waiting of the free session
+//Return:
+//	*TelnetSession - acquired session
+//	error - if an error occurs, otherwise nil
+func (p *TelnetSessionPool) Acquire(ctx context.Context) (*TelnetSession, error) {
+	ctx, cancel := p.withDefaultTimeout(ctx)
+	defer cancel()
+	session, reused, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		err = p.run(ctx, session, p.ping)
+		if err != nil {
+			session, err = p.Reopen(ctx, session)
+			if err != nil {
+				p.Release(session)
+				return nil, err
+			}
+		}
+	}
+	return session, nil
+}
+
+//Release returns acquired session to the pool, broken session will be closed
+//
+//Params:
+//	session - acquired session, can be nil if the session was lost
+func (p *TelnetSessionPool) Release(session *TelnetSession) {
+	if session != nil {
+		p.pushIdle(session)
+	}
+	<-p.slots
+}
+
+//Reopen closes acquired session and opens the new one instead of it
+//
+//Params:
+//	ctx - context with deadline for the session opening
+//	session - acquired session
+//Return:
+//	*TelnetSession - new session, nil if an error occurs
+//	error - if an error occurs, otherwise nil
+func (p *TelnetSessionPool) Reopen(ctx context.Context, session *TelnetSession) (*TelnetSession, error) {
+	if session != nil {
+		session.Close()
+	}
+	newSession, err := p.open(ctx)
+	if err != nil {
+		return nil, errors.Internal.Wrap(err, "failed to reopen switch session")
+	}
+	return newSession, nil
+}
+
+//Run runs the action with acquired session. If the context has no deadline, then the default command timeout is used.
+//
+//Params:
+//	ctx - context with deadline for the operation
+//	session - acquired session
+//	action - action to run
+//Return:
+//	error - if an error occurs, otherwise nil
+func (p *TelnetSessionPool) Run(ctx context.Context, session *TelnetSession, action func(session *TelnetSession) error) error {
+	ctx, cancel := p.withDefaultTimeout(ctx)
+	defer cancel()
+	return p.run(ctx, session, action)
+}
+
+//Close closes all idle sessions and stops keepalive routine
+func (p *TelnetSessionPool) Close() {
+	p.stopped.Do(func() {
+		close(p.stop)
+	})
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	for _, session := range p.idle {
+		session.Close()
+	}
+	p.idle = []*TelnetSession{}
+}
+
+func (p *TelnetSessionPool) withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, time.Duration(p.config.CommandTimeout)*time.Second)
+}
+
+//acquire takes free slot and idle or new session
+func (p *TelnetSessionPool) acquire(ctx context.Context) (*TelnetSession, bool, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, false, errors.Internal.Wrap(ctx.Err(), "timed out waiting for free switch session")
+	}
+	session, reused := p.popIdle()
+	if session == nil {
+		var err error
+		session, err = p.open(ctx)
+		if err != nil {
+			<-p.slots
+			return nil, false, errors.Internal.Wrap(err, "failed to open switch session")
+		}
+	}
+	return session, reused, nil
+}
+
+func (p *TelnetSessionPool) run(ctx context.Context, session *TelnetSession, action func(session *TelnetSession) error) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			//interrupt blocked reading, session will be marked as broken after the action returns
+			_ = session.conn.Close()
+		case <-done:
+		}
+	}()
+	err := action(session)
+	if ctx.Err() != nil {
+		session.broken = true
+		return errors.Internal.Wrap(ctx.Err(), "switch operation deadline exceeded")
+	}
+	return err
+}
+
+func (p *TelnetSessionPool) popIdle() (*TelnetSession, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.idle) == 0 {
+		return nil, false
+	}
+	session := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return session, true
+}
+
+func (p *TelnetSessionPool) pushIdle(session *TelnetSession) {
+	if session.broken {
+		session.Close()
+		return
+	}
+	session.lastUsed = time.Now()
+	p.returnIdle(session)
+}
+
+func (p *TelnetSessionPool) keepalive() {
+	ticker := time.NewTicker(time.Duration(p.config.KeepaliveInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.keepaliveIdleSessions()
+		}
+	}
+}
+
+func (p *TelnetSessionPool) keepaliveIdleSessions() {
+	idleTimeout := time.Duration(p.config.IdleTimeout) * time.Second
+	p.mutex.Lock()
+	sessions := p.idle
+	p.idle = []*TelnetSession{}
+	p.mutex.Unlock()
+	for _, session := range sessions {
+		if time.Since(session.lastUsed) > idleTimeout {
+			session.Close()
+			continue
+		}
+		//take the slot to be sure that the pool size is not exceeded while the session is pinged
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			//all slots are busy, the session will be pinged on the next tick
+			p.returnIdle(session)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(p.config.CommandTimeout)*time.Second)
+		_ = p.run(ctx, session, p.ping)
+		cancel()
+		p.returnIdle(session)
+		<-p.slots
+	}
+}
+
+//returnIdle returns the session to the idle sessions without updating last usage time
+func (p *TelnetSessionPool) returnIdle(session *TelnetSession) {
+	if session.broken {
+		session.Close()
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		session.Close()
+		return
+	}
+	p.idle = append(p.idle, session)
+}
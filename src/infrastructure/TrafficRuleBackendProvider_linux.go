@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/domain"
+)
+
+//NewTrafficRuleBackend creates traffic rules backend selected in the application config
+//
+//Params:
+//	config - application config
+//Return:
+//	interfaces.ITrafficRuleBackend - traffic rules backend
+//	error - if an error occurs, otherwise nil
+func NewTrafficRuleBackend(config *domain.AppConfig) (interfaces.ITrafficRuleBackend, error) {
+	switch config.HostNetwork.TrafficRuleBackend {
+	case "", "iptables":
+		return NewIPTablesTrafficRuleBackend()
+	case "nftables":
+		return NewNFTablesTrafficRuleBackend()
+	default:
+		return nil, errors.Internal.Newf("unknown traffic rule backend %s", config.HostNetwork.TrafficRuleBackend)
+	}
+}
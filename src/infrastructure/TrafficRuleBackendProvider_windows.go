@@ -0,0 +1,17 @@
+package infrastructure
+
+import (
+	"rol/app/interfaces"
+	"rol/domain"
+)
+
+//NewTrafficRuleBackend traffic rules backends are not implemented for windows
+//
+//Params:
+//	config - application config
+//Return:
+//	interfaces.ITrafficRuleBackend - nil
+//	error - always nil
+func NewTrafficRuleBackend(_ *domain.AppConfig) (interfaces.ITrafficRuleBackend, error) {
+	return nil, nil
+}
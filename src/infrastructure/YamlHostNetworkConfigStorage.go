@@ -1,41 +1,113 @@
 package infrastructure
 
 import (
+	"github.com/google/uuid"
 	"os"
 	"path/filepath"
 	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/domain"
+	"sort"
+	"strings"
+	"time"
 )
 
-//YamlHostNetworkConfigStorage Yaml implementation of IHostNetworkConfigStorage interface for host config management
+//defaultHostNetworkHistorySize count of the kept configuration revisions if it's not set in the app config
+const defaultHostNetworkHistorySize = 10
+
+//revisionFileTimeFormat revision file names start with the save time, so they are sorted by it
+const revisionFileTimeFormat = "20060102T150405.000000000"
+
+//YamlHostNetworkConfigStorage Yaml implementation of IHostNetworkConfigStorage interface for host config management.
+//Current configuration is stored in the config file, every saved configuration is stored as a revision file
+//in the history directory
 type YamlHostNetworkConfigStorage struct {
 	configFilePath string
+	historyPath    string
+	historySize    int
+	historyMaxAge  time.Duration
 }
 
 //NewYamlHostNetworkConfigStorage constructor for YamlHostNetworkConfigStorage
-func NewYamlHostNetworkConfigStorage(parameters domain.GlobalDIParameters) interfaces.IHostNetworkConfigStorage {
+func NewYamlHostNetworkConfigStorage(parameters domain.GlobalDIParameters, config *domain.AppConfig) interfaces.IHostNetworkConfigStorage {
+	historySize := config.HostNetwork.HistorySize
+	if historySize <= 0 {
+		historySize = defaultHostNetworkHistorySize
+	}
 	return &YamlHostNetworkConfigStorage{
 		configFilePath: filepath.Join(parameters.RootPath, "hostNetworkConfig.yaml"),
+		historyPath:    filepath.Join(parameters.RootPath, "hostNetworkConfigHistory"),
+		historySize:    historySize,
+		historyMaxAge:  time.Duration(config.HostNetwork.HistoryMaxAge) * 24 * time.Hour,
+	}
+}
+
+//revisionFiles gets revision files names from the latest to the oldest
+func (y *YamlHostNetworkConfigStorage) revisionFiles() ([]string, error) {
+	entries, err := os.ReadDir(y.historyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, errors.Internal.Wrap(err, "failed to read host network config history directory")
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".yaml") {
+			files = append(files, entry.Name())
+		}
 	}
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	return files, nil
 }
 
-//SaveConfig Save network configuration to config yaml file
-func (y *YamlHostNetworkConfigStorage) SaveConfig(config domain.HostNetworkConfig) error {
-	// Create backup config file
-	if _, err := os.Stat(y.configFilePath); err == nil {
-		backupFilePath := y.configFilePath + ".back"
-		err = os.Rename(y.configFilePath, backupFilePath)
+//removeExpiredRevisions removes revisions that exceed the history size or are older than the history max age
+func (y *YamlHostNetworkConfigStorage) removeExpiredRevisions() error {
+	files, err := y.revisionFiles()
+	if err != nil {
+		return err
+	}
+	//the latest revision is the current configuration, it's always kept
+	for i := 1; i < len(files); i++ {
+		expired := i >= y.historySize
+		if !expired && y.historyMaxAge > 0 {
+			savedAt, err := time.Parse(revisionFileTimeFormat, strings.Split(files[i], "_")[0])
+			expired = err == nil && time.Since(savedAt) > y.historyMaxAge
+		}
+		if !expired {
+			continue
+		}
+		err = os.Remove(filepath.Join(y.historyPath, files[i]))
 		if err != nil {
-			return errors.Internal.Wrap(err, "error when creating backup host network config file")
+			return errors.Internal.Wrap(err, "failed to remove expired host network config revision")
 		}
 	}
+	return nil
+}
 
-	err := SaveYamlFile(config, y.configFilePath)
+//SaveConfig Save network configuration to config yaml file and to the new revision file
+func (y *YamlHostNetworkConfigStorage) SaveConfig(config domain.HostNetworkConfig, author string, requestID uuid.UUID) error {
+	revision := domain.HostNetworkConfigRevision{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().UTC(),
+		Author:    author,
+		RequestID: requestID,
+		Config:    config,
+	}
+	err := os.MkdirAll(y.historyPath, 0775)
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to create host network config history directory")
+	}
+	revisionFileName := revision.CreatedAt.Format(revisionFileTimeFormat) + "_" + revision.ID.String() + ".yaml"
+	err = SaveYamlFile(revision, filepath.Join(y.historyPath, revisionFileName))
+	if err != nil {
+		return errors.Internal.Wrap(err, "failed to save host network config revision")
+	}
+	err = SaveYamlFile(config, y.configFilePath)
 	if err != nil {
 		return errors.Internal.Wrap(err, "failed to save host network config file")
 	}
-	return nil
+	return y.removeExpiredRevisions()
 }
 
 //GetConfig Gets configuration from config yaml file
@@ -47,11 +119,58 @@ func (y *YamlHostNetworkConfigStorage) GetConfig() (domain.HostNetworkConfig, er
 	return config, nil
 }
 
-//GetBackupConfig Gets config from file config backup
+//GetBackupConfig Gets the previous configuration revision, config file backup of the previous versions is used
+//if there is no previous revision
 func (y *YamlHostNetworkConfigStorage) GetBackupConfig() (domain.HostNetworkConfig, error) {
+	files, err := y.revisionFiles()
+	if err != nil {
+		return domain.HostNetworkConfig{}, err
+	}
+	if len(files) > 1 {
+		revision, err := ReadYamlFile[domain.HostNetworkConfigRevision](filepath.Join(y.historyPath, files[1]))
+		if err != nil {
+			return domain.HostNetworkConfig{}, errors.Internal.Wrap(err, "failed to read host network config revision")
+		}
+		return revision.Config, nil
+	}
 	config, err := ReadYamlFile[domain.HostNetworkConfig](y.configFilePath + ".back")
 	if err != nil {
 		return domain.HostNetworkConfig{}, errors.NotFound.Wrap(err, "backup of host network config is not found")
 	}
 	return config, nil
 }
+
+//GetRevisions Gets saved configuration revisions from the latest to the oldest
+func (y *YamlHostNetworkConfigStorage) GetRevisions() ([]domain.HostNetworkConfigRevision, error) {
+	files, err := y.revisionFiles()
+	if err != nil {
+		return nil, err
+	}
+	revisions := []domain.HostNetworkConfigRevision{}
+	for _, file := range files {
+		revision, err := ReadYamlFile[domain.HostNetworkConfigRevision](filepath.Join(y.historyPath, file))
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "failed to read host network config revision")
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+//GetRevision Gets saved configuration revision by ID
+func (y *YamlHostNetworkConfigStorage) GetRevision(id uuid.UUID) (domain.HostNetworkConfigRevision, error) {
+	files, err := y.revisionFiles()
+	if err != nil {
+		return domain.HostNetworkConfigRevision{}, err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_"+id.String()+".yaml") {
+			revision, err := ReadYamlFile[domain.HostNetworkConfigRevision](filepath.Join(y.historyPath, file))
+			if err != nil {
+				return domain.HostNetworkConfigRevision{}, errors.Internal.Wrap(err, "failed to read host network config revision")
+			}
+			return revision, nil
+		}
+	}
+	return domain.HostNetworkConfigRevision{}, errors.NotFound.New("host network config revision is not found")
+}
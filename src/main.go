@@ -46,6 +46,7 @@ func main() {
 			infrastructure.NewYmlConfig,
 			infrastructure.NewGormEntityDb,
 			infrastructure.NewGormLogDb,
+			infrastructure.NewAESSecretCipher,
 			infrastructure.NewGormEthernetSwitchRepository,
 			infrastructure.NewGormHTTPLogRepository,
 			infrastructure.NewGormAppLogRepository,
@@ -56,8 +57,13 @@ func main() {
 			infrastructure.NewGormEthernetSwitchPortRepository,
 			infrastructure.NewDeviceTemplateStorage,
 			infrastructure.NewYamlHostNetworkConfigStorage,
+			infrastructure.NewTrafficRuleBackend,
 			infrastructure.NewHostNetworkManager,
 			infrastructure.NewGormEthernetSwitchVLANRepository,
+			infrastructure.NewGormEthernetSwitchLAGRepository,
+			infrastructure.NewGormEthernetSwitchConfigBackupRepository,
+			infrastructure.NewGormFabricVLANRepository,
+			infrastructure.NewGormFabricVLANMemberRepository,
 			infrastructure.NewEthernetSwitchManagerProvider,
 			infrastructure.NewGormDHCP4LeaseRepository,
 			infrastructure.NewGormDHCP4ConfigRepository,
@@ -70,6 +76,8 @@ func main() {
 			services.NewHostNetworkService,
 			services.NewDHCP4ServerService,
 			services.NewTFTPServerService,
+			services.NewEthernetSwitchFirmwareService,
+			services.NewFabricVLANService,
 			// WEB API -> GIN Server
 			webapi.NewGinHTTPServer,
 			// WEB API -> GIN Controllers
@@ -80,16 +88,28 @@ func main() {
 			controllers.NewDeviceTemplateController,
 			controllers.NewHostNetworkVlanController,
 			controllers.NewHostNetworkBridgeController,
+			controllers.NewHostNetworkBondController,
+			controllers.NewHostNetworkVethController,
+			controllers.NewHostNetworkNamespaceController,
+			controllers.NewHostNetworkRouteController,
 			controllers.NewHostNetworkController,
 			controllers.NewEthernetSwitchVLANGinController,
+			controllers.NewEthernetSwitchLAGGinController,
+			controllers.NewEthernetSwitchConfigBackupGinController,
+			controllers.NewEthernetSwitchFirmwareGinController,
+			controllers.NewFabricVLANGinController,
 			controllers.NewDHCP4ServerGinController,
 			controllers.NewTFTPServerGinController,
 		),
 		fx.Invoke(
 			//Register logrus hooks
 			infrastructure.RegisterLogHooks,
+			//Repositories initialization
+			infrastructure.EthernetSwitchRepositoryInit,
+			infrastructure.RegisterEthernetSwitchManagerProviderHooks,
 			//Services initialization
 			services.EthernetSwitchServiceInit,
+			services.RegisterEthernetSwitchServiceHooks,
 			services.DHCP4ServerServiceInit,
 			services.TFTPServerServiceInit,
 			//GIN Controllers registration
@@ -100,8 +120,16 @@ func main() {
 			controllers.RegisterDeviceTemplateController,
 			controllers.RegisterHostNetworkVlanController,
 			controllers.RegisterHostNetworkBridgeController,
+			controllers.RegisterHostNetworkBondController,
+			controllers.RegisterHostNetworkVethController,
+			controllers.RegisterHostNetworkNamespaceController,
+			controllers.RegisterHostNetworkRouteController,
 			controllers.RegisterHostNetworkController,
 			controllers.RegisterEthernetSwitchVLANGinController,
+			controllers.RegisterEthernetSwitchLAGGinController,
+			controllers.RegisterEthernetSwitchConfigBackupGinController,
+			controllers.RegisterEthernetSwitchFirmwareGinController,
+			controllers.RegisterFabricVLANGinController,
 			controllers.RegisterDHCP4ServerGinController,
 			controllers.RegisterTFTPServerGinController,
 			//Start GIN http server
@@ -0,0 +1,379 @@
+package tests
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	customErrors "rol/app/errors"
+	"rol/app/interfaces"
+	"rol/app/services"
+	"rol/domain"
+	"rol/dtos"
+	"rol/infrastructure"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	firmwareTestUpgradeTimeout = 2 * time.Second
+	firmwareTestPollInterval   = 50 * time.Millisecond
+	firmwareTestPollTimeout    = 200 * time.Millisecond
+	firmwareTestRebootDuration = 300 * time.Millisecond
+	firmwareTestNewVersion     = "2.0.1 Build 20230301 Rel.60233"
+)
+
+type tEthernetSwitchFirmwareService struct {
+	tftpService  *services.TFTPServerService
+	switchRepo   interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch]
+	logger       *logrus.Logger
+	dbPath       string
+	imagePath    string
+	tftpServerID uuid.UUID
+	//ethSwitch - switch for the tests that don't upgrade the firmware
+	ethSwitch *tFirmwareTestSwitch
+}
+
+//tFirmwareTestSwitch switch with its own simulator and firmware service,
+//so the simulator state and the upgrade jobs are not shared between the tests
+type tFirmwareTestSwitch struct {
+	service   *services.EthernetSwitchFirmwareService
+	managers  interfaces.IEthernetSwitchManagerProvider
+	simulator *TPLinkSwitchSimulator
+	switchID  uuid.UUID
+}
+
+var firmwareTester *tEthernetSwitchFirmwareService
+
+func Test_EthernetSwitchFirmwareService_Prepare(t *testing.T) {
+	firmwareTester = &tEthernetSwitchFirmwareService{
+		dbPath: "ethernetSwitchFirmwareService_test.db",
+		logger: logrus.New(),
+	}
+	if _, err := os.Stat(firmwareTester.dbPath); err == nil {
+		err = os.Remove(firmwareTester.dbPath)
+		if err != nil {
+			t.Errorf("remove db failed:  %q", err)
+		}
+	}
+	db, err := gorm.Open(sqlite.Open(firmwareTester.dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("creating db failed: %v", err)
+	}
+	err = db.AutoMigrate(
+		new(domain.EthernetSwitch),
+		new(domain.TFTPConfig),
+		new(domain.TFTPPathRatio),
+	)
+	if err != nil {
+		t.Errorf("migration failed: %v", err)
+	}
+	config := &domain.AppConfig{}
+	config.Credentials.Key = "test_credentials_key"
+	cipher, err := infrastructure.NewAESSecretCipher(config)
+	if err != nil {
+		t.Fatalf("create secrets cipher failed: %v", err)
+	}
+	firmwareTester.switchRepo = infrastructure.NewGormEthernetSwitchRepository(db, cipher, firmwareTester.logger)
+	factory, _ := infrastructure.NewPinTFTPServerFactory()
+	firmwareTester.tftpService = services.NewTFTPServerService(
+		infrastructure.NewGormGenericRepository[uuid.UUID, domain.TFTPConfig](db, firmwareTester.logger),
+		infrastructure.NewGormGenericRepository[uuid.UUID, domain.TFTPPathRatio](db, firmwareTester.logger),
+		factory, nil, firmwareTester.logger)
+	firmwareTester.ethSwitch = newFirmwareTestSwitch(t)
+	server, err := firmwareTester.tftpService.CreateServer(context.Background(), dtos.TFTPServerCreateDto{
+		TFTPServerBaseDto: dtos.TFTPServerBaseDto{Address: "127.0.0.1", Port: "6970", Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("create TFTP server failed: %v", err)
+	}
+	firmwareTester.tftpServerID = server.ID
+	waitForTFTPServerLaunch(t, server.ID)
+	image, err := os.CreateTemp("", "rol-firmware-*.bin")
+	if err != nil {
+		t.Fatalf("create firmware image failed: %v", err)
+	}
+	_ = image.Close()
+	firmwareTester.imagePath = image.Name()
+}
+
+func Test_EthernetSwitchFirmwareService_GetFirmware(t *testing.T) {
+	ctx := context.Background()
+	ethSwitch := firmwareTester.ethSwitch
+	firmware, err := ethSwitch.service.GetFirmware(ctx, ethSwitch.switchID)
+	if err != nil {
+		t.Fatalf("get firmware failed: %v", err)
+	}
+	if firmware.FirmwareVersion != SimulatorFirmwareVersion || firmware.HardwareVersion != "TL-SG2210MP 1.0" {
+		t.Errorf("unexpected firmware: %+v", firmware)
+	}
+	_, err = ethSwitch.service.GetFirmware(ctx, uuid.New())
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("firmware of the nonexistent switch is returned: %v", err)
+	}
+	_, err = ethSwitch.service.GetUpgradeJob(ctx, ethSwitch.switchID)
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("upgrade job is returned before the upgrade: %v", err)
+	}
+	ethSwitch.simulator.FailCommands("show system-info")
+	defer ethSwitch.simulator.ClearFailures()
+	_, err = ethSwitch.service.GetFirmware(ctx, ethSwitch.switchID)
+	if err == nil {
+		t.Errorf("get firmware doesn't fail when the switch fails")
+	}
+}
+
+func Test_EthernetSwitchFirmwareService_StartUpgradeValidation(t *testing.T) {
+	ctx := context.Background()
+	disabled, err := firmwareTester.tftpService.CreateServer(ctx, dtos.TFTPServerCreateDto{
+		TFTPServerBaseDto: dtos.TFTPServerBaseDto{Address: "127.0.0.1", Port: "6971", Enabled: false},
+	})
+	if err != nil {
+		t.Fatalf("create TFTP server failed: %v", err)
+	}
+	defer func() {
+		_ = firmwareTester.tftpService.DeleteServer(ctx, disabled.ID)
+	}()
+	allAddresses, err := firmwareTester.tftpService.CreateServer(ctx, dtos.TFTPServerCreateDto{
+		TFTPServerBaseDto: dtos.TFTPServerBaseDto{Address: "0.0.0.0", Port: "6972", Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("create TFTP server failed: %v", err)
+	}
+	defer func() {
+		_ = firmwareTester.tftpService.DeleteServer(ctx, allAddresses.ID)
+	}()
+	waitForTFTPServerLaunch(t, allAddresses.ID)
+	cases := map[string]dtos.EthernetSwitchFirmwareUpgradeDto{
+		"empty dto":           {},
+		"bad TFTP address":    {TFTPServerID: firmwareTester.tftpServerID, ImagePath: firmwareTester.imagePath, TFTPAddress: "address"},
+		"missing image":       {TFTPServerID: firmwareTester.tftpServerID, ImagePath: firmwareTester.imagePath + ".missing"},
+		"missing server":      {TFTPServerID: uuid.New(), ImagePath: firmwareTester.imagePath},
+		"stopped server":      {TFTPServerID: disabled.ID, ImagePath: firmwareTester.imagePath},
+		"all addresses":       {TFTPServerID: allAddresses.ID, ImagePath: firmwareTester.imagePath},
+		"unspecified address": {TFTPServerID: firmwareTester.tftpServerID, ImagePath: firmwareTester.imagePath, TFTPAddress: "0.0.0.0"},
+	}
+	ethSwitch := firmwareTester.ethSwitch
+	for name, upgradeDto := range cases {
+		_, err = ethSwitch.service.StartUpgrade(ctx, ethSwitch.switchID, upgradeDto)
+		if !customErrors.As(err, customErrors.Validation) {
+			t.Errorf("%s: upgrade is started: %v", name, err)
+		}
+	}
+	_, err = ethSwitch.service.StartUpgrade(ctx, uuid.New(), dtos.EthernetSwitchFirmwareUpgradeDto{
+		TFTPServerID: firmwareTester.tftpServerID,
+		ImagePath:    firmwareTester.imagePath,
+	})
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("upgrade of the nonexistent switch is started: %v", err)
+	}
+}
+
+func Test_EthernetSwitchFirmwareService_Upgrade(t *testing.T) {
+	ctx := context.Background()
+	ethSwitch := newFirmwareTestSwitch(t)
+	defer ethSwitch.close()
+	ethSwitch.simulator.SetUpgrade(firmwareTestNewVersion, firmwareTestRebootDuration)
+	upgradeDto := dtos.EthernetSwitchFirmwareUpgradeDto{
+		TFTPServerID: firmwareTester.tftpServerID,
+		ImagePath:    firmwareTester.imagePath,
+	}
+	job, err := ethSwitch.service.StartUpgrade(ctx, ethSwitch.switchID, upgradeDto)
+	if err != nil {
+		t.Fatalf("start upgrade failed: %v", err)
+	}
+	if job.Stage != "staging" || !job.FinishedAt.IsZero() {
+		t.Errorf("unexpected started job: %+v", job)
+	}
+	_, err = ethSwitch.service.StartUpgrade(ctx, ethSwitch.switchID, upgradeDto)
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Errorf("second upgrade is started while the first one is running: %v", err)
+	}
+	job = waitForFirmwareJob(t, ethSwitch)
+	if job.Stage != "completed" || job.Progress != 100 || !job.RebootDetected || job.Error != "" {
+		t.Errorf("upgrade is not completed: %+v", job)
+	}
+	if job.PreviousVersion != SimulatorFirmwareVersion || job.CurrentVersion != firmwareTestNewVersion {
+		t.Errorf("unexpected job versions: %+v", job)
+	}
+	paths, err := firmwareTester.tftpService.GetPathsList(ctx, firmwareTester.tftpServerID, "", "", 1, 10)
+	if err != nil || paths.Pagination.TotalCount != 0 {
+		t.Errorf("staged firmware image is not removed from the TFTP server: %+v, %v", paths, err)
+	}
+}
+
+func Test_EthernetSwitchFirmwareService_UpgradeWithoutDowntime(t *testing.T) {
+	ethSwitch := newFirmwareTestSwitch(t)
+	defer ethSwitch.close()
+	//the switch reboots faster than the poll interval, so only the changed version shows the reboot
+	ethSwitch.simulator.SetUpgrade(firmwareTestNewVersion, 0)
+	_, err := ethSwitch.service.StartUpgrade(context.Background(), ethSwitch.switchID, dtos.EthernetSwitchFirmwareUpgradeDto{
+		TFTPServerID: firmwareTester.tftpServerID,
+		ImagePath:    firmwareTester.imagePath,
+	})
+	if err != nil {
+		t.Fatalf("start upgrade failed: %v", err)
+	}
+	job := waitForFirmwareJob(t, ethSwitch)
+	if job.Stage != "completed" || !job.RebootDetected || job.CurrentVersion != firmwareTestNewVersion {
+		t.Errorf("upgrade is not completed: %+v", job)
+	}
+}
+
+func Test_EthernetSwitchFirmwareService_UpgradeFailures(t *testing.T) {
+	upgradeDto := dtos.EthernetSwitchFirmwareUpgradeDto{
+		TFTPServerID: firmwareTester.tftpServerID,
+		ImagePath:    firmwareTester.imagePath,
+	}
+	cases := []struct {
+		name    string
+		prepare func(simulator *TPLinkSwitchSimulator)
+		error   string
+	}{
+		{"upgrade command fails", func(simulator *TPLinkSwitchSimulator) {
+			simulator.FailCommands("firmware upgrade")
+		}, "failed to upgrade switch firmware"},
+		{"version isn't changed", func(simulator *TPLinkSwitchSimulator) {
+			simulator.SetUpgrade(SimulatorFirmwareVersion, firmwareTestRebootDuration)
+		}, "firmware version didn't change"},
+		{"switch doesn't reboot", func(simulator *TPLinkSwitchSimulator) {
+			simulator.SetUpgrade("", 0)
+		}, "switch reboot is not detected"},
+	}
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ethSwitch := newFirmwareTestSwitch(t)
+			defer ethSwitch.close()
+			testCase.prepare(ethSwitch.simulator)
+			_, err := ethSwitch.service.StartUpgrade(context.Background(), ethSwitch.switchID, upgradeDto)
+			if err != nil {
+				t.Fatalf("start upgrade failed: %v", err)
+			}
+			job := waitForFirmwareJob(t, ethSwitch)
+			if job.Stage != "failed" || !strings.Contains(job.Error, testCase.error) {
+				t.Errorf("unexpected job result: %+v", job)
+			}
+		})
+	}
+}
+
+func Test_EthernetSwitchFirmwareService_UpgradeTransientError(t *testing.T) {
+	ctx := context.Background()
+	ethSwitch := newFirmwareTestSwitch(t)
+	defer ethSwitch.close()
+	ethSwitch.simulator.SetUpgrade("", 0)
+	_, err := ethSwitch.service.StartUpgrade(ctx, ethSwitch.switchID, dtos.EthernetSwitchFirmwareUpgradeDto{
+		TFTPServerID: firmwareTester.tftpServerID,
+		ImagePath:    firmwareTester.imagePath,
+	})
+	if err != nil {
+		t.Fatalf("start upgrade failed: %v", err)
+	}
+	rebooting := waitForCondition(firmwareTestUpgradeTimeout, func() bool {
+		job, err := ethSwitch.service.GetUpgradeJob(ctx, ethSwitch.switchID)
+		return err == nil && job.Stage == "rebooting"
+	})
+	if !rebooting {
+		t.Fatalf("upgrade doesn't wait for the reboot")
+	}
+	//the switch answers with the error to fewer polls than needed to consider it rebooting
+	ethSwitch.simulator.FailCommands("show system-info")
+	time.Sleep(firmwareTestPollInterval * 3 / 2)
+	ethSwitch.simulator.ClearFailures()
+	job := waitForFirmwareJob(t, ethSwitch)
+	if job.Stage != "failed" || job.RebootDetected || !strings.Contains(job.Error, "switch reboot is not detected") {
+		t.Errorf("transient switch error is taken for the reboot: %+v", job)
+	}
+}
+
+func Test_EthernetSwitchFirmwareService_CloseAll(t *testing.T) {
+	err := firmwareTester.tftpService.DeleteServer(context.Background(), firmwareTester.tftpServerID)
+	if err != nil {
+		t.Errorf("delete TFTP server failed: %v", err)
+	}
+	firmwareTester.ethSwitch.close()
+	err = os.Remove(firmwareTester.imagePath)
+	if err != nil {
+		t.Errorf("remove firmware image failed: %v", err)
+	}
+	err = os.Remove(firmwareTester.dbPath)
+	if err != nil {
+		t.Errorf("remove db failed: %v", err)
+	}
+}
+
+//newFirmwareTestSwitch starts the switch simulator and creates the switch with the firmware service for it
+func newFirmwareTestSwitch(t *testing.T) *tFirmwareTestSwitch {
+	simulator, err := NewTPLinkSwitchSimulator(tpLinkSimulatorPortsCount, tpLinkSimulatorPOEPortsCount)
+	if err != nil {
+		t.Fatalf("start switch simulator failed: %v", err)
+	}
+	config := &domain.AppConfig{}
+	config.EthernetSwitch.TelnetPort = simulator.Port()
+	managers := infrastructure.NewEthernetSwitchManagerProvider(firmwareTester.switchRepo, config)
+	service, err := services.NewEthernetSwitchFirmwareServiceWithTimeouts(firmwareTester.switchRepo, managers,
+		firmwareTester.tftpService, firmwareTestUpgradeTimeout, firmwareTestPollInterval, firmwareTestPollTimeout,
+		firmwareTester.logger)
+	if err != nil {
+		t.Fatalf("create firmware service failed: %v", err)
+	}
+	ethSwitch := domain.EthernetSwitch{
+		Name:        "TPLinkSwitch",
+		Serial:      "tplink_firmware_serial_" + uuid.NewString(),
+		SwitchModel: "tl-sg2210mp",
+		Address:     "127.0.0.1",
+		Username:    SimulatorLogin,
+		Password:    SimulatorPassword,
+	}
+	ethSwitch.ID = uuid.New()
+	ethSwitch, err = firmwareTester.switchRepo.Insert(context.Background(), ethSwitch)
+	if err != nil {
+		t.Fatalf("insert switch failed: %v", err)
+	}
+	return &tFirmwareTestSwitch{
+		service:   service,
+		managers:  managers,
+		simulator: simulator,
+		switchID:  ethSwitch.ID,
+	}
+}
+
+func (s *tFirmwareTestSwitch) close() {
+	s.managers.Close()
+	s.simulator.Close()
+}
+
+// waitForTFTPServerLaunch waits until the TFTP server is started in the background
+func waitForTFTPServerLaunch(t *testing.T, serverID uuid.UUID) {
+	for i := 0; i < 20; i++ {
+		server, err := firmwareTester.tftpService.GetServerByID(context.Background(), serverID)
+		if err != nil {
+			t.Fatalf("get TFTP server failed: %v", err)
+		}
+		if server.State == domain.TFTPStateLaunched.String() {
+			return
+		}
+		time.Sleep(firmwareTestPollInterval)
+	}
+	t.Fatalf("TFTP server isn't launched")
+}
+
+// waitForFirmwareJob waits until the last firmware upgrade job of the switch is finished
+func waitForFirmwareJob(t *testing.T, ethSwitch *tFirmwareTestSwitch) dtos.EthernetSwitchFirmwareJobDto {
+	deadline := time.Now().Add(2 * firmwareTestUpgradeTimeout)
+	for time.Now().Before(deadline) {
+		job, err := ethSwitch.service.GetUpgradeJob(context.Background(), ethSwitch.switchID)
+		if err != nil {
+			t.Fatalf("get upgrade job failed: %v", err)
+		}
+		if !job.FinishedAt.IsZero() {
+			return job
+		}
+		time.Sleep(firmwareTestPollInterval)
+	}
+	t.Fatalf("upgrade job isn't finished in time")
+	return dtos.EthernetSwitchFirmwareJobDto{}
+}
@@ -0,0 +1,581 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	"reflect"
+	customErrors "rol/app/errors"
+	"rol/app/services"
+	"rol/domain"
+	"rol/dtos"
+	"rol/infrastructure"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tEthernetSwitchServiceTPLink struct {
+	service   *services.EthernetSwitchService
+	simulator *TPLinkSwitchSimulator
+	db        *gorm.DB
+	dbPath    string
+	switchID  uuid.UUID
+	//portIDs - IDs of the discovered ports by names
+	portIDs map[string]uuid.UUID
+	vlanID  uuid.UUID
+}
+
+var tpLinkServiceTester *tEthernetSwitchServiceTPLink
+
+func Test_EthernetSwitchServiceTPLink_Prepare(t *testing.T) {
+	tpLinkServiceTester = &tEthernetSwitchServiceTPLink{
+		dbPath:  "ethernetSwitchServiceTPLink_test.db",
+		portIDs: map[string]uuid.UUID{},
+	}
+	if _, err := os.Stat(tpLinkServiceTester.dbPath); err == nil {
+		err = os.Remove(tpLinkServiceTester.dbPath)
+		if err != nil {
+			t.Errorf("remove db failed:  %q", err)
+		}
+	}
+	var err error
+	tpLinkServiceTester.simulator, err = NewTPLinkSwitchSimulator(tpLinkSimulatorPortsCount, tpLinkSimulatorPOEPortsCount)
+	if err != nil {
+		t.Fatalf("start switch simulator failed: %v", err)
+	}
+	tpLinkServiceTester.db, err = gorm.Open(sqlite.Open(tpLinkServiceTester.dbPath), &gorm.Config{})
+	if err != nil {
+		t.Errorf("creating db failed: %v", err)
+	}
+	err = tpLinkServiceTester.db.AutoMigrate(
+		new(domain.EthernetSwitch),
+		new(domain.EthernetSwitchPort),
+		new(domain.EthernetSwitchVLAN),
+		new(domain.EthernetSwitchLAG),
+		new(domain.EthernetSwitchConfigBackup),
+		new(domain.DHCP4Lease),
+	)
+	if err != nil {
+		t.Errorf("migration failed: %v", err)
+	}
+	logger := logrus.New()
+	db := tpLinkServiceTester.db
+	config := &domain.AppConfig{}
+	config.EthernetSwitch.TelnetPort = tpLinkServiceTester.simulator.Port()
+	config.EthernetSwitch.DriftCheckInterval = 3600
+	config.Credentials.Key = "test_credentials_key"
+	cipher, err := infrastructure.NewAESSecretCipher(config)
+	if err != nil {
+		t.Errorf("create secrets cipher failed: %v", err)
+	}
+	switchRepo := infrastructure.NewGormEthernetSwitchRepository(db, cipher, logger)
+	tpLinkServiceTester.service, err = services.NewEthernetSwitchService(switchRepo,
+		infrastructure.NewGormEthernetSwitchPortRepository(db, logger),
+		infrastructure.NewGormEthernetSwitchVLANRepository(db, logger),
+		infrastructure.NewGormEthernetSwitchLAGRepository(db, logger),
+		infrastructure.NewGormEthernetSwitchConfigBackupRepository(db, logger),
+		infrastructure.NewGormDHCP4LeaseRepository(db, logger),
+		infrastructure.NewEthernetSwitchManagerProvider(switchRepo, config), config, logger)
+	if err != nil {
+		t.Errorf("create switch service failed: %v", err)
+	}
+	err = services.EthernetSwitchServiceInit(tpLinkServiceTester.service)
+	if err != nil {
+		t.Errorf("init service failed:  %q", err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_CreateAndDiscover(t *testing.T) {
+	ctx := context.Background()
+	ethSwitch, err := tpLinkServiceTester.service.Create(ctx, dtos.EthernetSwitchCreateDto{
+		EthernetSwitchBaseDto: dtos.EthernetSwitchBaseDto{
+			Name:        "TPLinkSwitch",
+			Serial:      "tplink_serial",
+			SwitchModel: "tl-sg2210mp",
+			Address:     "127.0.0.1",
+			Username:    SimulatorLogin,
+		},
+		Password: SimulatorPassword,
+	})
+	if err != nil {
+		t.Fatalf("create switch failed: %v", err)
+	}
+	tpLinkServiceTester.switchID = ethSwitch.ID
+	discovery, err := tpLinkServiceTester.service.Discover(ctx, ethSwitch.ID)
+	if err != nil {
+		t.Fatalf("discover failed: %v", err)
+	}
+	if len(discovery.ImportedPorts) != tpLinkSimulatorPortsCount || len(discovery.Conflicts) != 0 {
+		t.Fatalf("unexpected discovery result: %d ports, %d conflicts", len(discovery.ImportedPorts), len(discovery.Conflicts))
+	}
+	for _, port := range discovery.ImportedPorts {
+		tpLinkServiceTester.portIDs[port.Name] = port.ID
+	}
+	if len(discovery.ImportedVLANs) != 0 {
+		t.Errorf("default VLAN is imported: %+v", discovery.ImportedVLANs)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_DiscoverVLANs(t *testing.T) {
+	ctx := context.Background()
+	switchID := tpLinkServiceTester.switchID
+	console := infrastructure.NewTPLinkEthernetSwitchManager(tpLinkServiceTester.simulator.Address(), SimulatorLogin,
+		SimulatorPassword, domain.EthernetSwitchSessionConfig{})
+	defer console.Close()
+	err := console.CreateVLAN(ctx, 70)
+	if err != nil {
+		t.Fatalf("create VLAN on the console failed: %v", err)
+	}
+	err = console.AddTaggedVLANOnPort(ctx, "Gi1/0/3", 70)
+	if err != nil {
+		t.Fatalf("add VLAN to the port on the console failed: %v", err)
+	}
+	discovery, err := tpLinkServiceTester.service.Discover(ctx, switchID)
+	if err != nil {
+		t.Fatalf("discover failed: %v", err)
+	}
+	if len(discovery.ImportedPorts) != 0 || len(discovery.Conflicts) != 0 || len(discovery.ImportedVLANs) != 1 {
+		t.Fatalf("unexpected discovery result: %+v", discovery)
+	}
+	vlan := discovery.ImportedVLANs[0]
+	if vlan.VlanID != 70 || !reflect.DeepEqual(vlan.TaggedPorts, []uuid.UUID{tpLinkServiceTester.portIDs["Gi1/0/3"]}) {
+		t.Errorf("unexpected imported VLAN: %+v", vlan)
+	}
+	//only the imported VLAN is removed from the switch, default VLAN stays unmanaged
+	err = tpLinkServiceTester.service.DeleteVLAN(ctx, switchID, vlan.ID)
+	if err != nil {
+		t.Fatalf("delete imported VLAN failed: %v", err)
+	}
+	if !reflect.DeepEqual(tpLinkServiceTester.simulator.VLANs(), []int{1}) {
+		t.Errorf("unexpected switch VLANs after the imported VLAN delete: %v", tpLinkServiceTester.simulator.VLANs())
+	}
+	drift, err := tpLinkServiceTester.service.CheckDrift(ctx, switchID)
+	if err != nil || !drift.InSync {
+		t.Errorf("switch is not in sync after the discovery: %+v, %v", drift, err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_CreateVLAN(t *testing.T) {
+	vlan, err := tpLinkServiceTester.service.CreateVLAN(context.Background(), tpLinkServiceTester.switchID, dtos.EthernetSwitchVLANCreateDto{
+		EthernetSwitchVLANBaseDto: dtos.EthernetSwitchVLANBaseDto{
+			TaggedPorts:   []uuid.UUID{tpLinkServiceTester.portIDs["Gi1/0/1"]},
+			UntaggedPorts: []uuid.UUID{tpLinkServiceTester.portIDs["Gi1/0/2"]},
+		},
+		VlanID: 10,
+	})
+	if err != nil {
+		t.Fatalf("create VLAN failed: %v", err)
+	}
+	tpLinkServiceTester.vlanID = vlan.ID
+	if !reflect.DeepEqual(tpLinkServiceTester.simulator.VLANs(), []int{1, 10}) {
+		t.Errorf("VLAN is not created on the switch: %v", tpLinkServiceTester.simulator.VLANs())
+	}
+	tagged, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/1")
+	untagged, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/2")
+	//unmanaged default VLAN 1 stays untagged on the ports
+	if !reflect.DeepEqual(tagged.TaggedVLANs, []int{10}) || !reflect.DeepEqual(untagged.UntaggedVLANs, []int{1, 10}) {
+		t.Errorf("VLAN ports are not configured on the switch: %+v, %+v", tagged, untagged)
+	}
+	if tpLinkServiceTester.simulator.SavesCount() == 0 {
+		t.Errorf("switch configuration is not saved")
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_CreateVLANRollback(t *testing.T) {
+	ctx := context.Background()
+	tpLinkServiceTester.simulator.FailCommands("switchport general allowed vlan 20")
+	defer tpLinkServiceTester.simulator.ClearFailures()
+	_, err := tpLinkServiceTester.service.CreateVLAN(ctx, tpLinkServiceTester.switchID, dtos.EthernetSwitchVLANCreateDto{
+		EthernetSwitchVLANBaseDto: dtos.EthernetSwitchVLANBaseDto{TaggedPorts: []uuid.UUID{tpLinkServiceTester.portIDs["Gi1/0/3"]}},
+		VlanID:                    20,
+	})
+	if err == nil {
+		t.Fatalf("switch error is not reported")
+	}
+	if !reflect.DeepEqual(tpLinkServiceTester.simulator.VLANs(), []int{1, 10}) {
+		t.Errorf("VLAN is not removed from the switch after the failure: %v", tpLinkServiceTester.simulator.VLANs())
+	}
+	vlans, err := tpLinkServiceTester.service.GetVLANs(ctx, tpLinkServiceTester.switchID, "", "", "", 1, 10)
+	//only VLAN 10, default VLAN is not discovered
+	if err != nil || len(vlans.Items) != 1 {
+		t.Errorf("VLAN is saved after the switch failure: %v", err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_UpdatePort(t *testing.T) {
+	ctx := context.Background()
+	portID := tpLinkServiceTester.portIDs["Gi1/0/4"]
+	port, err := tpLinkServiceTester.service.GetPortByID(ctx, tpLinkServiceTester.switchID, portID)
+	if err != nil {
+		t.Fatalf("get port failed: %v", err)
+	}
+	base := port.EthernetSwitchPortBaseDto
+	base.POEEnabled = false
+	base.Description = "camera"
+	_, err = tpLinkServiceTester.service.UpdatePort(ctx, tpLinkServiceTester.switchID, portID, dtos.EthernetSwitchPortUpdateDto{
+		EthernetSwitchPortBaseDto: base,
+	})
+	if err != nil {
+		t.Fatalf("update port failed: %v", err)
+	}
+	state, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/4")
+	if state.POEEnabled || state.Description != "camera" {
+		t.Errorf("port is not configured on the switch: %+v", state)
+	}
+	drift, err := tpLinkServiceTester.service.CheckDrift(ctx, tpLinkServiceTester.switchID)
+	if err != nil || len(drift.Differences) != 0 {
+		t.Errorf("switch configuration differs from the stored one: %+v, %v", drift, err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_RestoreConfigBackup(t *testing.T) {
+	ctx := context.Background()
+	backup, err := tpLinkServiceTester.service.BackupConfig(ctx, tpLinkServiceTester.switchID)
+	if err != nil {
+		t.Fatalf("backup config failed: %v", err)
+	}
+	portID := tpLinkServiceTester.portIDs["Gi1/0/4"]
+	port, err := tpLinkServiceTester.service.GetPortByID(ctx, tpLinkServiceTester.switchID, portID)
+	if err != nil {
+		t.Fatalf("get port failed: %v", err)
+	}
+	base := port.EthernetSwitchPortBaseDto
+	base.Description = "printer"
+	_, err = tpLinkServiceTester.service.UpdatePort(ctx, tpLinkServiceTester.switchID, portID, dtos.EthernetSwitchPortUpdateDto{
+		EthernetSwitchPortBaseDto: base,
+	})
+	if err != nil {
+		t.Fatalf("update port failed: %v", err)
+	}
+	_, err = tpLinkServiceTester.service.RestoreConfigBackup(ctx, tpLinkServiceTester.switchID, backup.ID)
+	if err != nil {
+		t.Fatalf("restore config backup failed: %v", err)
+	}
+	state, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/4")
+	if state.Description != "camera" {
+		t.Errorf("port description is not restored on the switch: %+v", state)
+	}
+	port, err = tpLinkServiceTester.service.GetPortByID(ctx, tpLinkServiceTester.switchID, portID)
+	if err != nil || port.Description != "camera" {
+		t.Errorf("stored port is not updated after the restore: %+v, %v", port, err)
+	}
+	drift, err := tpLinkServiceTester.service.CheckDrift(ctx, tpLinkServiceTester.switchID)
+	if err != nil || len(drift.Differences) != 0 {
+		t.Errorf("switch configuration differs from the stored one after the restore: %+v, %v", drift, err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_UpdatePortSaveFailure(t *testing.T) {
+	ctx := context.Background()
+	//manual change on the console must not be stored when the port update is restored
+	console := infrastructure.NewTPLinkEthernetSwitchManager(tpLinkServiceTester.simulator.Address(), SimulatorLogin,
+		SimulatorPassword, domain.EthernetSwitchSessionConfig{})
+	defer console.Close()
+	err := console.CreateVLAN(ctx, 60)
+	if err != nil {
+		t.Fatalf("create VLAN on the console failed: %v", err)
+	}
+	tpLinkServiceTester.simulator.FailCommands("copy running-config")
+	defer tpLinkServiceTester.simulator.ClearFailures()
+	portID := tpLinkServiceTester.portIDs["Gi1/0/4"]
+	port, err := tpLinkServiceTester.service.GetPortByID(ctx, tpLinkServiceTester.switchID, portID)
+	if err != nil {
+		t.Fatalf("get port failed: %v", err)
+	}
+	base := port.EthernetSwitchPortBaseDto
+	base.Description = "scanner"
+	_, err = tpLinkServiceTester.service.UpdatePort(ctx, tpLinkServiceTester.switchID, portID, dtos.EthernetSwitchPortUpdateDto{
+		EthernetSwitchPortBaseDto: base,
+	})
+	if err == nil {
+		t.Fatalf("switch save error is not reported")
+	}
+	state, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/4")
+	if state.Description != "camera" {
+		t.Errorf("port is not reverted on the switch: %+v", state)
+	}
+	port, err = tpLinkServiceTester.service.GetPortByID(ctx, tpLinkServiceTester.switchID, portID)
+	if err != nil || port.Description != "camera" {
+		t.Errorf("stored port is not reverted: %+v, %v", port, err)
+	}
+	vlans, err := tpLinkServiceTester.service.GetVLANs(ctx, tpLinkServiceTester.switchID, "", "", "", 1, 100)
+	if err != nil {
+		t.Fatalf("get VLANs failed: %v", err)
+	}
+	for _, vlan := range vlans.Items {
+		if vlan.VlanID == 60 {
+			t.Errorf("VLAN that is not changed by the port update is stored")
+		}
+	}
+	tpLinkServiceTester.simulator.ClearFailures()
+	err = console.DeleteVLAN(ctx, 60)
+	if err != nil {
+		t.Errorf("delete VLAN on the console failed: %v", err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_Drift(t *testing.T) {
+	ctx := context.Background()
+	switchID := tpLinkServiceTester.switchID
+	drift, err := tpLinkServiceTester.service.CheckDrift(ctx, switchID)
+	if err != nil || !drift.InSync {
+		t.Fatalf("switch is not in sync before the manual changes: %+v, %v", drift, err)
+	}
+	//switch is changed manually on the console
+	console := infrastructure.NewTPLinkEthernetSwitchManager(tpLinkServiceTester.simulator.Address(), SimulatorLogin,
+		SimulatorPassword, domain.EthernetSwitchSessionConfig{})
+	err = console.CreateVLAN(ctx, 50)
+	if err != nil {
+		t.Fatalf("create VLAN on the console failed: %v", err)
+	}
+	err = console.SetPortPVID(ctx, "Gi1/0/1", 10)
+	if err != nil {
+		t.Fatalf("set PVID on the console failed: %v", err)
+	}
+	//the last result is returned until the next check
+	drift, err = tpLinkServiceTester.service.GetDrift(ctx, switchID)
+	if err != nil || !drift.InSync {
+		t.Errorf("last drift result is not returned: %+v, %v", drift, err)
+	}
+	drift, err = tpLinkServiceTester.service.CheckDrift(ctx, switchID)
+	if err != nil || drift.InSync || len(drift.Differences) != 2 {
+		t.Fatalf("manual changes are not detected: %+v, %v", drift, err)
+	}
+	drift, err = tpLinkServiceTester.service.ApplyDBToSwitch(ctx, switchID)
+	if err != nil || !drift.InSync {
+		t.Fatalf("apply DB to switch failed: %+v, %v", drift, err)
+	}
+	port, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/1")
+	if !reflect.DeepEqual(tpLinkServiceTester.simulator.VLANs(), []int{1, 10}) || port.PVID != 1 {
+		t.Errorf("manual changes are not reverted: %v, %+v", tpLinkServiceTester.simulator.VLANs(), port)
+	}
+	err = console.SetPortDescription(ctx, "Gi1/0/5", "manual")
+	if err != nil {
+		t.Fatalf("set description on the console failed: %v", err)
+	}
+	drift, err = tpLinkServiceTester.service.AdoptSwitchIntoDB(ctx, switchID)
+	if err != nil || !drift.InSync {
+		t.Fatalf("adopt switch into DB failed: %+v, %v", drift, err)
+	}
+	stored, err := tpLinkServiceTester.service.GetPortByID(ctx, switchID, tpLinkServiceTester.portIDs["Gi1/0/5"])
+	if err != nil || stored.Description != "manual" {
+		t.Errorf("manual change is not adopted: %+v, %v", stored, err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_DriftErrors(t *testing.T) {
+	ctx := context.Background()
+	switchID := tpLinkServiceTester.switchID
+	tpLinkServiceTester.simulator.FailCommands("show vlan")
+	defer tpLinkServiceTester.simulator.ClearFailures()
+	drift, err := tpLinkServiceTester.service.CheckDrift(ctx, switchID)
+	if err != nil || drift.Error == "" || drift.InSync {
+		t.Errorf("switch reading error is not returned in the drift: %+v, %v", drift, err)
+	}
+	_, err = tpLinkServiceTester.service.ApplyDBToSwitch(ctx, switchID)
+	if err == nil {
+		t.Errorf("apply DB to switch doesn't fail without the switch configuration")
+	}
+	_, err = tpLinkServiceTester.service.AdoptSwitchIntoDB(ctx, switchID)
+	if err == nil {
+		t.Errorf("adopt switch into DB doesn't fail without the switch configuration")
+	}
+	_, err = tpLinkServiceTester.service.CheckDrift(ctx, uuid.New())
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("drift of the nonexistent switch is checked: %v", err)
+	}
+	tpLinkServiceTester.simulator.ClearFailures()
+	drift, err = tpLinkServiceTester.service.CheckDrift(ctx, switchID)
+	if err != nil || !drift.InSync {
+		t.Errorf("switch is not in sync after the failures: %+v, %v", drift, err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_PortNeighbors(t *testing.T) {
+	ctx := context.Background()
+	leases := map[string]uuid.UUID{}
+	for i, mac := range []string{"00:0a:0b:0c:0d:01", "00:0a:0b:0c:0d:02", "00:0a:0b:0c:0d:04"} {
+		lease := domain.DHCP4Lease{IP: fmt.Sprintf("10.0.0.%d", i+10), MAC: mac, Expires: time.Now().Add(time.Hour)}
+		lease.ID = uuid.New()
+		if err := tpLinkServiceTester.db.Create(&lease).Error; err != nil {
+			t.Fatalf("create DHCP v4 lease failed: %v", err)
+		}
+		leases[mac] = lease.ID
+	}
+	simulator := tpLinkServiceTester.simulator
+	simulator.AddLLDPNeighbor(domain.EthernetSwitchLLDPNeighbor{PortName: "Gi1/0/6", ChassisID: "00:0a:0b:0c:0d:01",
+		PortID: "eth0", SystemName: "node1", ManagementAddress: "10.0.0.10"})
+	simulator.AddMACEntry(domain.EthernetSwitchMACEntry{MAC: "00:0a:0b:0c:0d:01", VLANID: 1, PortName: "Gi1/0/6", Type: "dynamic"})
+	simulator.AddMACEntry(domain.EthernetSwitchMACEntry{MAC: "00:0a:0b:0c:0d:02", VLANID: 1, PortName: "Gi1/0/7", Type: "dynamic"})
+	simulator.AddMACEntry(domain.EthernetSwitchMACEntry{MAC: "00:0a:0b:0c:0d:03", VLANID: 1, PortName: "Gi1/0/7", Type: "dynamic"})
+	simulator.AddMACEntry(domain.EthernetSwitchMACEntry{MAC: "00:0a:0b:0c:0d:04", VLANID: 1, PortName: "Gi1/0/8", Type: "static"})
+
+	neighbors, err := tpLinkServiceTester.service.GetPortNeighbors(ctx, tpLinkServiceTester.switchID, tpLinkServiceTester.portIDs["Gi1/0/6"])
+	if err != nil {
+		t.Fatalf("get port neighbors failed: %v", err)
+	}
+	if len(neighbors.LLDPNeighbors) != 1 || neighbors.LLDPNeighbors[0].SystemName != "node1" ||
+		neighbors.LLDPNeighbors[0].ManagementAddress != "10.0.0.10" {
+		t.Errorf("unexpected LLDP neighbors: %+v", neighbors.LLDPNeighbors)
+	}
+	if len(neighbors.MACAddresses) != 1 || neighbors.MACAddresses[0].MAC != "00:0a:0b:0c:0d:01" ||
+		len(neighbors.MACAddresses[0].Leases) != 1 {
+		t.Errorf("unexpected MAC addresses: %+v", neighbors.MACAddresses)
+	}
+	if len(neighbors.Suggestions) != 1 || neighbors.Suggestions[0].LeaseID != leases["00:0a:0b:0c:0d:01"] ||
+		neighbors.Suggestions[0].Confidence != "high" || !strings.Contains(neighbors.Suggestions[0].Reason, "LLDP") {
+		t.Errorf("LLDP neighbor is not suggested once: %+v", neighbors.Suggestions)
+	}
+
+	neighbors, err = tpLinkServiceTester.service.GetPortNeighbors(ctx, tpLinkServiceTester.switchID, tpLinkServiceTester.portIDs["Gi1/0/7"])
+	if err != nil {
+		t.Fatalf("get port neighbors failed: %v", err)
+	}
+	if len(neighbors.LLDPNeighbors) != 0 || len(neighbors.MACAddresses) != 2 {
+		t.Errorf("unexpected neighbors of the port with two MAC addresses: %+v", neighbors)
+	}
+	if len(neighbors.Suggestions) != 1 || neighbors.Suggestions[0].LeaseID != leases["00:0a:0b:0c:0d:02"] ||
+		neighbors.Suggestions[0].Confidence != "low" {
+		t.Errorf("suggestion of the port with two MAC addresses is not low: %+v", neighbors.Suggestions)
+	}
+
+	neighbors, err = tpLinkServiceTester.service.GetPortNeighbors(ctx, tpLinkServiceTester.switchID, tpLinkServiceTester.portIDs["Gi1/0/8"])
+	if err != nil {
+		t.Fatalf("get port neighbors failed: %v", err)
+	}
+	if len(neighbors.MACAddresses) != 1 || neighbors.MACAddresses[0].Type != "static" || len(neighbors.Suggestions) != 1 ||
+		neighbors.Suggestions[0].IP != "10.0.0.12" || neighbors.Suggestions[0].Confidence != "high" {
+		t.Errorf("the only MAC address of the port is not suggested: %+v", neighbors)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_PortNeighborsErrors(t *testing.T) {
+	ctx := context.Background()
+	switchID := tpLinkServiceTester.switchID
+	_, err := tpLinkServiceTester.service.GetPortNeighbors(ctx, switchID, uuid.New())
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("neighbors of the nonexistent port are returned: %v", err)
+	}
+	_, err = tpLinkServiceTester.service.GetPortNeighbors(ctx, uuid.New(), tpLinkServiceTester.portIDs["Gi1/0/6"])
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("neighbors of the nonexistent switch are returned: %v", err)
+	}
+	defer tpLinkServiceTester.simulator.ClearFailures()
+	for _, command := range []string{"show lldp", "show mac"} {
+		tpLinkServiceTester.simulator.ClearFailures()
+		tpLinkServiceTester.simulator.FailCommands(command)
+		_, err = tpLinkServiceTester.service.GetPortNeighbors(ctx, switchID, tpLinkServiceTester.portIDs["Gi1/0/6"])
+		if err == nil {
+			t.Errorf("get port neighbors doesn't fail when %q fails", command)
+		}
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_PortStatus(t *testing.T) {
+	ctx := context.Background()
+	switchID := tpLinkServiceTester.switchID
+	portID := tpLinkServiceTester.portIDs["Gi1/0/3"]
+	tpLinkServiceTester.simulator.SetLinkUp("Gi1/0/3", true)
+	tpLinkServiceTester.simulator.SetPortCounters("Gi1/0/3", 1000, 2000, 3, 4)
+	status, err := tpLinkServiceTester.service.GetPortStatus(ctx, switchID, portID)
+	if err != nil {
+		t.Fatalf("get port status failed: %v", err)
+	}
+	if !status.AdminUp || !status.OperUp || status.Speed != "1000M" || status.Duplex != "Full" {
+		t.Errorf("unexpected link status: %+v", status)
+	}
+	if status.RxBytes != 1000 || status.TxBytes != 2000 || status.RxErrors != 3 || status.TxErrors != 4 {
+		t.Errorf("unexpected port counters: %+v", status)
+	}
+	if status.POEPower != 4.2 {
+		t.Errorf("unexpected POE power: %v", status.POEPower)
+	}
+	//status is cached until the port is changed
+	tpLinkServiceTester.simulator.SetPortCounters("Gi1/0/3", 5000, 6000, 0, 0)
+	cached, err := tpLinkServiceTester.service.GetPortStatus(ctx, switchID, portID)
+	if err != nil || cached.RxBytes != 1000 || !cached.ReadAt.Equal(status.ReadAt) {
+		t.Errorf("cached port status is not returned: %+v, %v", cached, err)
+	}
+	port, err := tpLinkServiceTester.service.GetPortByID(ctx, switchID, portID)
+	if err != nil {
+		t.Fatalf("get port failed: %v", err)
+	}
+	base := port.EthernetSwitchPortBaseDto
+	base.Shutdown = true
+	_, err = tpLinkServiceTester.service.UpdatePort(ctx, switchID, portID, dtos.EthernetSwitchPortUpdateDto{
+		EthernetSwitchPortBaseDto: base,
+	})
+	if err != nil {
+		t.Fatalf("update port failed: %v", err)
+	}
+	status, err = tpLinkServiceTester.service.GetPortStatus(ctx, switchID, portID)
+	if err != nil {
+		t.Fatalf("get port status failed: %v", err)
+	}
+	if status.AdminUp || status.OperUp || status.Speed != "" || status.RxBytes != 5000 || status.POEPower != 0 {
+		t.Errorf("port status is not read again after the port update: %+v", status)
+	}
+	//port without POE support
+	tpLinkServiceTester.simulator.SetLinkUp("Gi1/0/10", true)
+	status, err = tpLinkServiceTester.service.GetPortStatus(ctx, switchID, tpLinkServiceTester.portIDs["Gi1/0/10"])
+	if err != nil || !status.OperUp || status.POEPower != 0 {
+		t.Errorf("unexpected status of the port without POE: %+v, %v", status, err)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_PortStatusErrors(t *testing.T) {
+	ctx := context.Background()
+	switchID := tpLinkServiceTester.switchID
+	_, err := tpLinkServiceTester.service.GetPortStatus(ctx, switchID, uuid.New())
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("status of the nonexistent port is returned: %v", err)
+	}
+	_, err = tpLinkServiceTester.service.GetPortStatus(ctx, uuid.New(), tpLinkServiceTester.portIDs["Gi1/0/9"])
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("status of the port of the nonexistent switch is returned: %v", err)
+	}
+	defer tpLinkServiceTester.simulator.ClearFailures()
+	for _, command := range []string{"show interface configuration", "show interface status", "show interface counters"} {
+		tpLinkServiceTester.simulator.ClearFailures()
+		tpLinkServiceTester.simulator.FailCommands(command)
+		_, err = tpLinkServiceTester.service.GetPortStatus(ctx, switchID, tpLinkServiceTester.portIDs["Gi1/0/9"])
+		if err == nil {
+			t.Errorf("get port status doesn't fail when %q fails", command)
+		}
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_DeleteVLAN(t *testing.T) {
+	err := tpLinkServiceTester.service.DeleteVLAN(context.Background(), tpLinkServiceTester.switchID, tpLinkServiceTester.vlanID)
+	if err != nil {
+		t.Fatalf("delete VLAN failed: %v", err)
+	}
+	if !reflect.DeepEqual(tpLinkServiceTester.simulator.VLANs(), []int{1}) {
+		t.Errorf("VLAN is not deleted on the switch: %v", tpLinkServiceTester.simulator.VLANs())
+	}
+	port, _ := tpLinkServiceTester.simulator.GetPort("Gi1/0/1")
+	if len(port.TaggedVLANs) != 0 {
+		t.Errorf("VLAN is not removed from the port: %+v", port)
+	}
+}
+
+func Test_EthernetSwitchServiceTPLink_CloseConnectionAndRemoveDb(t *testing.T) {
+	tpLinkServiceTester.simulator.Close()
+	sqlDb, err := tpLinkServiceTester.db.DB()
+	if err != nil {
+		t.Errorf("get db failed: %v", err)
+	}
+	err = sqlDb.Close()
+	if err != nil {
+		t.Errorf("close db failed: %v", err)
+	}
+	err = os.Remove(tpLinkServiceTester.dbPath)
+	if err != nil {
+		t.Errorf("remove db failed: %v", err)
+	}
+}
@@ -44,21 +44,33 @@ func Test_EthernetSwitchServiceVLAN_Prepare(t *testing.T) {
 		new(domain.EthernetSwitch),
 		new(domain.EthernetSwitchPort),
 		new(domain.EthernetSwitchVLAN),
+		new(domain.EthernetSwitchLAG),
+		new(domain.EthernetSwitchConfigBackup),
+		new(domain.DHCP4Lease),
 	)
 	if err != nil {
 		t.Errorf("migration failed: %v", err)
 	}
 
 	logger := logrus.New()
-	switchRepo := infrastructure.NewGormEthernetSwitchRepository(testGenDb, logger)
+	config := &domain.AppConfig{}
+	config.Credentials.Key = "test_credentials_key"
+	cipher, err := infrastructure.NewAESSecretCipher(config)
+	if err != nil {
+		t.Errorf("create secrets cipher failed: %v", err)
+	}
+	switchRepo := infrastructure.NewGormEthernetSwitchRepository(testGenDb, cipher, logger)
 	portRepo := infrastructure.NewGormEthernetSwitchPortRepository(testGenDb, logger)
 	vlanRepo := infrastructure.NewGormEthernetSwitchVLANRepository(testGenDb, logger)
 	ethSwitchServiceTester.switchRepo = switchRepo
 	ethSwitchServiceTester.portRepo = portRepo
 	ethSwitchServiceTester.vlanRepo = vlanRepo
 
-	getter := infrastructure.NewEthernetSwitchManagerProvider(switchRepo)
-	service, _ := services.NewEthernetSwitchService(switchRepo, portRepo, vlanRepo, getter)
+	lagRepo := infrastructure.NewGormEthernetSwitchLAGRepository(testGenDb, logger)
+	backupRepo := infrastructure.NewGormEthernetSwitchConfigBackupRepository(testGenDb, logger)
+	leasesRepo := infrastructure.NewGormDHCP4LeaseRepository(testGenDb, logger)
+	getter := infrastructure.NewEthernetSwitchManagerProvider(switchRepo, &domain.AppConfig{})
+	service, _ := services.NewEthernetSwitchService(switchRepo, portRepo, vlanRepo, lagRepo, backupRepo, leasesRepo, getter, &domain.AppConfig{}, logger)
 	ethSwitchServiceTester.service = service
 	err = services.EthernetSwitchServiceInit(ethSwitchServiceTester.service)
 	if err != nil {
@@ -251,6 +263,59 @@ func Test_EthernetSwitchServiceVLAN_Delete(t *testing.T) {
 	}
 }
 
+func Test_EthernetSwitchServiceVLAN_LAGAsPort(t *testing.T) {
+	ctx := context.Background()
+	lagDto := dtos.EthernetSwitchLAGCreateDto{
+		EthernetSwitchLAGBaseDto: dtos.EthernetSwitchLAGBaseDto{
+			Mode:        "active",
+			MemberPorts: []uuid.UUID{ethSwitchServiceTester.portID},
+		},
+		GroupID: 1,
+	}
+	lag, err := ethSwitchServiceTester.service.CreateLAG(ctx, ethSwitchServiceTester.switchID, lagDto)
+	if err != nil {
+		t.Errorf("failed to create switch LAG: %q", err)
+	}
+	if lag.Name != "Po1" {
+		t.Errorf("unexpected LAG name: %s", lag.Name)
+	}
+	lagDto.GroupID = 2
+	_, err = ethSwitchServiceTester.service.CreateLAG(ctx, ethSwitchServiceTester.switchID, lagDto)
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Error("port is added to the second LAG")
+	}
+	vlanDto := dtos.EthernetSwitchVLANCreateDto{
+		EthernetSwitchVLANBaseDto: dtos.EthernetSwitchVLANBaseDto{
+			UntaggedPorts: []uuid.UUID{},
+			TaggedPorts:   []uuid.UUID{lag.ID},
+		},
+		VlanID: 3,
+	}
+	vlan, err := ethSwitchServiceTester.service.CreateVLAN(ctx, ethSwitchServiceTester.switchID, vlanDto)
+	if err != nil {
+		t.Errorf("failed to create switch vlan with LAG: %q", err)
+	}
+	err = ethSwitchServiceTester.service.DeletePort(ctx, ethSwitchServiceTester.switchID, ethSwitchServiceTester.portID)
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Error("LAG member port is deleted")
+	}
+	err = ethSwitchServiceTester.service.DeleteLAG(ctx, ethSwitchServiceTester.switchID, lag.ID)
+	if err != nil {
+		t.Errorf("failed to delete switch LAG: %q", err)
+	}
+	vlan, err = ethSwitchServiceTester.service.GetVLANByID(ctx, ethSwitchServiceTester.switchID, vlan.ID)
+	if err != nil {
+		t.Errorf("failed to get switch vlan: %q", err)
+	}
+	if len(vlan.TaggedPorts) != 0 {
+		t.Error("deleted LAG is not removed from the vlan")
+	}
+	err = ethSwitchServiceTester.service.DeleteVLAN(ctx, ethSwitchServiceTester.switchID, vlan.ID)
+	if err != nil {
+		t.Errorf("failed to delete switch vlan: %q", err)
+	}
+}
+
 func Test_EthernetSwitchServiceVLAN_Create20(t *testing.T) {
 	for i := 1; i <= 20; i++ {
 		dto := dtos.EthernetSwitchVLANCreateDto{
@@ -286,6 +351,71 @@ func Test_EthernetSwitchServiceVLAN_GetList(t *testing.T) {
 	}
 }
 
+func Test_EthernetSwitchServiceVLAN_PortsBulkOperation(t *testing.T) {
+	ctx := context.Background()
+	service := ethSwitchServiceTester.service
+	switchID := ethSwitchServiceTester.switchID
+	secondPort, err := service.CreatePort(ctx, switchID, dtos.EthernetSwitchPortCreateDto{
+		EthernetSwitchPortBaseDto: dtos.EthernetSwitchPortBaseDto{POEType: "poe", Name: "Gi2"}})
+	if err != nil {
+		t.Fatalf("create switch port failed:  %q", err)
+	}
+	_, err = service.SwitchPortsBulkOperation(ctx, switchID, dtos.EthernetSwitchPortBulkOperationDto{Operation: "poe-on"})
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Error("bulk operation without selector is applied")
+	}
+	results, err := service.SwitchPortsBulkOperation(ctx, switchID, dtos.EthernetSwitchPortBulkOperationDto{
+		Selector:  dtos.EthernetSwitchPortBulkSelectorDto{NamePattern: "gi*"},
+		Operation: "poe-on",
+	})
+	if err != nil {
+		t.Fatalf("bulk POE operation failed: %q", err)
+	}
+	if len(results) != 2 || results[0].Status != "changed" || results[1].Status != "changed" {
+		t.Errorf("unexpected bulk POE results: %+v", results)
+	}
+	port, err := service.GetPortByID(ctx, switchID, secondPort.ID)
+	if err != nil || !port.POEEnabled {
+		t.Error("POE is not enabled by bulk operation")
+	}
+	//only the first port is a member of VLAN 1
+	results, err = service.SwitchPortsBulkOperation(ctx, switchID, dtos.EthernetSwitchPortBulkOperationDto{
+		Selector:  dtos.EthernetSwitchPortBulkSelectorDto{MemberOfVLAN: 1},
+		Operation: "add-vlan",
+		VLANID:    5,
+		Tagged:    true,
+	})
+	if err != nil {
+		t.Fatalf("bulk VLAN operation failed: %q", err)
+	}
+	if len(results) != 1 || results[0].PortID != ethSwitchServiceTester.portID || results[0].Status != "changed" {
+		t.Errorf("unexpected bulk VLAN results: %+v", results)
+	}
+	vlans, err := service.GetVLANs(ctx, switchID, "", "VlanID", "asc", 1, 20)
+	if err != nil {
+		t.Fatalf("failed to get switch vlan list: %q", err)
+	}
+	vlan := vlans.Items[4]
+	if len(vlan.TaggedPorts) != 1 || len(vlan.UntaggedPorts) != 0 {
+		t.Errorf("port is not moved to tagged ports of VLAN 5: %+v", vlan)
+	}
+	_, err = service.PortsBulkOperation(ctx, dtos.EthernetSwitchPortBulkOperationDto{
+		Selector:  dtos.EthernetSwitchPortBulkSelectorDto{SwitchIDs: []uuid.UUID{uuid.New()}, NamePattern: "*"},
+		Operation: "poe-off",
+	})
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Error("bulk operation is applied to nonexistent switch")
+	}
+	results, err = service.PortsBulkOperation(ctx, dtos.EthernetSwitchPortBulkOperationDto{
+		Selector:  dtos.EthernetSwitchPortBulkSelectorDto{PortIDs: []uuid.UUID{secondPort.ID}},
+		Operation: "set-pvid",
+		PVID:      5,
+	})
+	if err != nil || len(results) != 1 || results[0].Status != "changed" {
+		t.Errorf("unexpected bulk PVID results: %+v, %v", results, err)
+	}
+}
+
 func Test_EthernetSwitchServiceVLAN_RemoveDb(t *testing.T) {
 	if err := ethSwitchServiceTester.switchRepo.Dispose(); err != nil {
 		t.Errorf("close db failed:  %s", err)
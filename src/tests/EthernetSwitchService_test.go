@@ -47,6 +47,9 @@ func Test_EthernetSwitchService_Prepare(t *testing.T) {
 		new(domain.EthernetSwitch),
 		new(domain.EthernetSwitchPort),
 		new(domain.EthernetSwitchVLAN),
+		new(domain.EthernetSwitchLAG),
+		new(domain.EthernetSwitchConfigBackup),
+		new(domain.DHCP4Lease),
 	)
 	if err != nil {
 		t.Errorf("migration failed: %v", err)
@@ -56,8 +59,11 @@ func Test_EthernetSwitchService_Prepare(t *testing.T) {
 	ethSwitchRepo = infrastructure.NewGormGenericRepository[uuid.UUID, domain.EthernetSwitch](testGenDb, logger)
 	ethSwitchPortRepo = infrastructure.NewGormGenericRepository[uuid.UUID, domain.EthernetSwitchPort](testGenDb, logger)
 	ethSwitchVlanRepo = infrastructure.NewGormGenericRepository[uuid.UUID, domain.EthernetSwitchVLAN](testGenDb, logger)
-	getter := infrastructure.NewEthernetSwitchManagerProvider(ethSwitchRepo)
-	ethSwitchService, err = services.NewEthernetSwitchService(ethSwitchRepo, ethSwitchPortRepo, ethSwitchVlanRepo, getter)
+	lagRepo := infrastructure.NewGormEthernetSwitchLAGRepository(testGenDb, logger)
+	backupRepo := infrastructure.NewGormEthernetSwitchConfigBackupRepository(testGenDb, logger)
+	leasesRepo := infrastructure.NewGormDHCP4LeaseRepository(testGenDb, logger)
+	getter := infrastructure.NewEthernetSwitchManagerProvider(ethSwitchRepo, &domain.AppConfig{})
+	ethSwitchService, err = services.NewEthernetSwitchService(ethSwitchRepo, ethSwitchPortRepo, ethSwitchVlanRepo, lagRepo, backupRepo, leasesRepo, getter, &domain.AppConfig{}, logger)
 	if err != nil {
 		t.Errorf("create new service failed:  %q", err)
 	}
@@ -320,11 +326,31 @@ func Test_EthernetSwitchService_CreatePortFailByBadPOEType(t *testing.T) {
 	}
 }
 
+func Test_EthernetSwitchService_CreatePortFailByBadSpeed(t *testing.T) {
+	ctx := context.TODO()
+	dto := dtos.EthernetSwitchPortCreateDto{EthernetSwitchPortBaseDto: dtos.EthernetSwitchPortBaseDto{
+		POEType: "poe",
+		Name:    "AutoPort3",
+		Speed:   "1G",
+	}}
+	_, err := ethSwitchService.CreatePort(ctx, ethSwitchID, dto)
+	if !errors.As(err, errors.Validation) {
+		t.Errorf("unexpected error %v, expected: dto Speed field validation error", err)
+	}
+	if _, ok := errors.GetErrorContext(err)["Speed"]; !ok {
+		t.Errorf("expected Speed field error, got %v", errors.GetErrorContext(err))
+	}
+}
+
 func Test_EthernetSwitchService_UpdatePort(t *testing.T) {
 	ctx := context.TODO()
 	dto := dtos.EthernetSwitchPortUpdateDto{EthernetSwitchPortBaseDto: dtos.EthernetSwitchPortBaseDto{
-		POEType: "poe",
-		Name:    "AutoPort2.0",
+		POEType:     "poe",
+		Name:        "AutoPort2.0",
+		Shutdown:    true,
+		Description: "node 1",
+		Speed:       "100M",
+		Duplex:      "full",
 	}}
 	_, err := ethSwitchService.UpdatePort(ctx, ethSwitchID, createdEthSwitchPortID, dto)
 	if err != nil {
@@ -338,6 +364,9 @@ func Test_EthernetSwitchService_UpdatePort(t *testing.T) {
 	if port.Name != "AutoPort2.0" {
 		t.Errorf("update port failed: unexpected name, got '%s', expect 'AutoPort2.0'", port.Name)
 	}
+	if !port.Shutdown || port.Description != "node 1" || port.Speed != "100M" || port.Duplex != "full" {
+		t.Errorf("update port failed: unexpected port settings %+v", port.EthernetSwitchPortBaseDto)
+	}
 }
 
 func Test_EthernetSwitchService_GetPorts(t *testing.T) {
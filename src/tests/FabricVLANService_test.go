@@ -0,0 +1,270 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	customErrors "rol/app/errors"
+	"rol/app/services"
+	"rol/domain"
+	"rol/dtos"
+	"rol/infrastructure"
+	"testing"
+)
+
+type tFabricVLANService struct {
+	service       *services.FabricVLANService
+	switchService *services.EthernetSwitchService
+	db            *gorm.DB
+	dbPath        string
+	switchIDs     []uuid.UUID
+	//portIDs - two ports of each switch
+	portIDs      map[uuid.UUID][]uuid.UUID
+	fabricVLANID uuid.UUID
+}
+
+var fabricVLANTester *tFabricVLANService
+
+func Test_FabricVLANService_Prepare(t *testing.T) {
+	fabricVLANTester = &tFabricVLANService{
+		dbPath:  "fabricVlanService_test.db",
+		portIDs: map[uuid.UUID][]uuid.UUID{},
+	}
+	if _, err := os.Stat(fabricVLANTester.dbPath); err == nil {
+		err = os.Remove(fabricVLANTester.dbPath)
+		if err != nil {
+			t.Errorf("remove db failed:  %q", err)
+		}
+	}
+	var err error
+	fabricVLANTester.db, err = gorm.Open(sqlite.Open(fabricVLANTester.dbPath), &gorm.Config{})
+	if err != nil {
+		t.Errorf("creating db failed: %v", err)
+	}
+	err = fabricVLANTester.db.AutoMigrate(
+		new(domain.EthernetSwitch),
+		new(domain.EthernetSwitchPort),
+		new(domain.EthernetSwitchVLAN),
+		new(domain.EthernetSwitchLAG),
+		new(domain.EthernetSwitchConfigBackup),
+		new(domain.DHCP4Lease),
+		new(domain.FabricVLAN),
+		new(domain.FabricVLANMember),
+	)
+	if err != nil {
+		t.Errorf("migration failed: %v", err)
+	}
+	logger := logrus.New()
+	db := fabricVLANTester.db
+	cipherConfig := &domain.AppConfig{}
+	cipherConfig.Credentials.Key = "test_credentials_key"
+	cipher, err := infrastructure.NewAESSecretCipher(cipherConfig)
+	if err != nil {
+		t.Errorf("create secrets cipher failed: %v", err)
+	}
+	switchRepo := infrastructure.NewGormEthernetSwitchRepository(db, cipher, logger)
+	switchService, err := services.NewEthernetSwitchService(switchRepo,
+		infrastructure.NewGormEthernetSwitchPortRepository(db, logger),
+		infrastructure.NewGormEthernetSwitchVLANRepository(db, logger),
+		infrastructure.NewGormEthernetSwitchLAGRepository(db, logger),
+		infrastructure.NewGormEthernetSwitchConfigBackupRepository(db, logger),
+		infrastructure.NewGormDHCP4LeaseRepository(db, logger),
+		infrastructure.NewEthernetSwitchManagerProvider(switchRepo, &domain.AppConfig{}), &domain.AppConfig{}, logger)
+	if err != nil {
+		t.Errorf("create switch service failed: %v", err)
+	}
+	err = services.EthernetSwitchServiceInit(switchService)
+	if err != nil {
+		t.Errorf("init service failed:  %q", err)
+	}
+	fabricVLANTester.switchService = switchService
+	//host network manager is not used while the host parent interface is not set
+	fabricVLANTester.service = services.NewFabricVLANService(infrastructure.NewGormFabricVLANRepository(db, logger),
+		infrastructure.NewGormFabricVLANMemberRepository(db, logger), switchService, services.NewHostNetworkService(nil, nil), logger)
+}
+
+func Test_FabricVLANService_CreateRelatedEntities(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		createdSwitch, err := fabricVLANTester.switchService.Create(context.Background(), dtos.EthernetSwitchCreateDto{
+			EthernetSwitchBaseDto: dtos.EthernetSwitchBaseDto{
+				Name:        fmt.Sprintf("FabricSwitch%d", i),
+				Serial:      fmt.Sprintf("fabric_serial_%d", i),
+				SwitchModel: "unifi_switch_us-24-250w",
+				Address:     fmt.Sprintf("10.0.0.%d", i+1),
+				Username:    "Test",
+			},
+			//  pragma: allowlist nextline secret
+			Password: "TestTest",
+		})
+		if err != nil {
+			t.Fatalf("create switch failed:  %q", err)
+		}
+		fabricVLANTester.switchIDs = append(fabricVLANTester.switchIDs, createdSwitch.ID)
+		for j := 1; j <= 2; j++ {
+			port, err := fabricVLANTester.switchService.CreatePort(context.Background(), createdSwitch.ID, dtos.EthernetSwitchPortCreateDto{
+				EthernetSwitchPortBaseDto: dtos.EthernetSwitchPortBaseDto{POEType: "poe", Name: fmt.Sprintf("gi%d", j)},
+			})
+			if err != nil {
+				t.Fatalf("create switch port failed:  %q", err)
+			}
+			fabricVLANTester.portIDs[createdSwitch.ID] = append(fabricVLANTester.portIDs[createdSwitch.ID], port.ID)
+		}
+	}
+	//VLAN already exist on the first switch with the second port
+	firstSwitch := fabricVLANTester.switchIDs[0]
+	_, err := fabricVLANTester.switchService.CreateVLAN(context.Background(), firstSwitch, dtos.EthernetSwitchVLANCreateDto{
+		EthernetSwitchVLANBaseDto: dtos.EthernetSwitchVLANBaseDto{TaggedPorts: []uuid.UUID{fabricVLANTester.portIDs[firstSwitch][1]}},
+		VlanID:                    30,
+	})
+	if err != nil {
+		t.Fatalf("create switch VLAN failed:  %q", err)
+	}
+}
+
+func Test_FabricVLANService_CreateFailByNonExistentPort(t *testing.T) {
+	_, err := fabricVLANTester.service.Create(context.Background(), dtos.FabricVLANCreateDto{
+		Name:   "fabric",
+		VlanID: 30,
+		Members: []dtos.FabricVLANMemberBaseDto{{
+			EthernetSwitchID: fabricVLANTester.switchIDs[0],
+			PortID:           fabricVLANTester.portIDs[fabricVLANTester.switchIDs[1]][0],
+		}},
+	})
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Errorf("port of the other switch is accepted: %v", err)
+	}
+	count, _ := infrastructure.NewGormFabricVLANRepository(fabricVLANTester.db, logrus.New()).Count(context.Background(), nil)
+	if count != 0 {
+		t.Errorf("fabric VLAN is saved after the failed validation")
+	}
+}
+
+func Test_FabricVLANService_CreateOK(t *testing.T) {
+	members := []dtos.FabricVLANMemberBaseDto{}
+	for _, switchID := range fabricVLANTester.switchIDs {
+		members = append(members, dtos.FabricVLANMemberBaseDto{EthernetSwitchID: switchID, PortID: fabricVLANTester.portIDs[switchID][0], Tagged: true})
+	}
+	secondSwitch := fabricVLANTester.switchIDs[1]
+	members = append(members, dtos.FabricVLANMemberBaseDto{EthernetSwitchID: secondSwitch, PortID: fabricVLANTester.portIDs[secondSwitch][1]})
+	dto, err := fabricVLANTester.service.Create(context.Background(), dtos.FabricVLANCreateDto{
+		Name:    "fabric",
+		VlanID:  30,
+		Members: members,
+	})
+	if err != nil {
+		t.Fatalf("create fabric VLAN failed: %v", err)
+	}
+	fabricVLANTester.fabricVLANID = dto.ID
+	if dto.Status != "configured" || len(dto.Members) != 3 {
+		t.Errorf("unexpected fabric VLAN status %s with %d members", dto.Status, len(dto.Members))
+	}
+	for _, member := range dto.Members {
+		if member.Status != "configured" {
+			t.Errorf("member %s status is %s: %s", member.PortID, member.Status, member.Error)
+		}
+	}
+	for _, switchID := range fabricVLANTester.switchIDs {
+		vlans, err := fabricVLANTester.switchService.GetVLANs(context.Background(), switchID, "", "", "", 1, 10)
+		if err != nil || len(vlans.Items) != 1 {
+			t.Fatalf("switch VLAN is not created: %v", err)
+		}
+		vlan := vlans.Items[0]
+		if vlan.VlanID != 30 || len(vlan.TaggedPorts)+len(vlan.UntaggedPorts) != 2 {
+			t.Errorf("unexpected switch VLAN ports: %+v", vlan)
+		}
+	}
+	_, err = fabricVLANTester.service.Create(context.Background(), dtos.FabricVLANCreateDto{Name: "fabric", VlanID: 30, Members: members[:1]})
+	if !customErrors.As(err, customErrors.Validation) {
+		t.Errorf("fabric VLAN ID uniqueness is not checked: %v", err)
+	}
+}
+
+func Test_FabricVLANService_GetList(t *testing.T) {
+	list, err := fabricVLANTester.service.GetList(context.Background(), "", "", "", 1, 10)
+	if err != nil || len(list.Items) != 1 {
+		t.Fatalf("get list failed: %v", err)
+	}
+	if len(list.Items[0].Members) != 3 || list.Items[0].Status != "configured" {
+		t.Errorf("members are not returned in the list")
+	}
+}
+
+func Test_FabricVLANService_Delete(t *testing.T) {
+	err := fabricVLANTester.service.Delete(context.Background(), fabricVLANTester.fabricVLANID)
+	if err != nil {
+		t.Fatalf("delete fabric VLAN failed: %v", err)
+	}
+	_, err = fabricVLANTester.service.GetByID(context.Background(), fabricVLANTester.fabricVLANID)
+	if !customErrors.As(err, customErrors.NotFound) {
+		t.Errorf("fabric VLAN is not deleted: %v", err)
+	}
+	//VLAN is kept on the first switch with the port that was not a member of the fabric VLAN
+	firstSwitch := fabricVLANTester.switchIDs[0]
+	vlans, err := fabricVLANTester.switchService.GetVLANs(context.Background(), firstSwitch, "", "", "", 1, 10)
+	if err != nil || len(vlans.Items) != 1 {
+		t.Fatalf("switch VLAN is deleted: %v", err)
+	}
+	if len(vlans.Items[0].TaggedPorts) != 1 || vlans.Items[0].TaggedPorts[0] != fabricVLANTester.portIDs[firstSwitch][1] {
+		t.Errorf("unexpected switch VLAN ports: %+v", vlans.Items[0])
+	}
+	vlans, err = fabricVLANTester.switchService.GetVLANs(context.Background(), fabricVLANTester.switchIDs[1], "", "", "", 1, 10)
+	if err != nil || len(vlans.Items) != 0 {
+		t.Errorf("switch VLAN is not deleted: %v", err)
+	}
+}
+
+func Test_FabricVLANService_DeleteKeepsReusedVLAN(t *testing.T) {
+	ctx := context.Background()
+	firstSwitch, secondSwitch := fabricVLANTester.switchIDs[0], fabricVLANTester.switchIDs[1]
+	//VLAN without ports already exist on the first switch
+	_, err := fabricVLANTester.switchService.CreateVLAN(ctx, firstSwitch, dtos.EthernetSwitchVLANCreateDto{VlanID: 40})
+	if err != nil {
+		t.Fatalf("create switch VLAN failed:  %q", err)
+	}
+	dto, err := fabricVLANTester.service.Create(ctx, dtos.FabricVLANCreateDto{
+		Name:   "reused",
+		VlanID: 40,
+		Members: []dtos.FabricVLANMemberBaseDto{
+			{EthernetSwitchID: firstSwitch, PortID: fabricVLANTester.portIDs[firstSwitch][0], Tagged: true},
+			{EthernetSwitchID: secondSwitch, PortID: fabricVLANTester.portIDs[secondSwitch][0], Tagged: true},
+		},
+	})
+	if err != nil || dto.Status != "configured" {
+		t.Fatalf("create fabric VLAN failed: %v", err)
+	}
+	err = fabricVLANTester.service.Delete(ctx, dto.ID)
+	if err != nil {
+		t.Fatalf("delete fabric VLAN failed: %v", err)
+	}
+	vlans, err := fabricVLANTester.switchService.GetVLANs(ctx, firstSwitch, "", "VlanID", "asc", 1, 10)
+	//VLAN 30 from the previous steps and the reused VLAN 40
+	if err != nil || len(vlans.Items) != 2 || vlans.Items[1].VlanID != 40 {
+		t.Fatalf("reused switch VLAN is deleted: %v", err)
+	}
+	if len(vlans.Items[1].TaggedPorts)+len(vlans.Items[1].UntaggedPorts) != 0 {
+		t.Errorf("fabric VLAN port is not removed from the reused switch VLAN: %+v", vlans.Items[1])
+	}
+	vlans, err = fabricVLANTester.switchService.GetVLANs(ctx, secondSwitch, "", "", "", 1, 10)
+	if err != nil || len(vlans.Items) != 0 {
+		t.Errorf("created switch VLAN is not deleted: %v", err)
+	}
+}
+
+func Test_FabricVLANService_CloseConnectionAndRemoveDb(t *testing.T) {
+	sqlDb, err := fabricVLANTester.db.DB()
+	if err != nil {
+		t.Errorf("get db failed: %v", err)
+	}
+	err = sqlDb.Close()
+	if err != nil {
+		t.Errorf("close db failed: %v", err)
+	}
+	err = os.Remove(fabricVLANTester.dbPath)
+	if err != nil {
+		t.Errorf("remove db failed: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	"rol/app/interfaces"
+	"rol/domain"
+	"rol/infrastructure"
+	"strings"
+	"testing"
+)
+
+const (
+	encryptedRepoDbFileName = "encryptedRepository_test.db"
+	encryptedRepoPassword   = "switch_password" // pragma: allowlist secret
+	encryptedRepoOldKey     = "old_credentials_key"
+	encryptedRepoNewKey     = "new_credentials_key"
+)
+
+var (
+	encryptedRepoDb       *gorm.DB
+	encryptedRepoSwitchID uuid.UUID
+	encryptedRepoLegacyID uuid.UUID
+)
+
+func newEncryptedSwitchRepo(t *testing.T, key string, previousKeys ...string) interfaces.IGenericRepository[uuid.UUID, domain.EthernetSwitch] {
+	config := &domain.AppConfig{}
+	config.Credentials.Key = key
+	config.Credentials.PreviousKeys = previousKeys
+	cipher, err := infrastructure.NewAESSecretCipher(config)
+	if err != nil {
+		t.Fatalf("create secrets cipher failed: %v", err)
+	}
+	return infrastructure.NewGormEthernetSwitchRepository(encryptedRepoDb, cipher, logrus.New())
+}
+
+func getStoredPassword(t *testing.T, id uuid.UUID) string {
+	stored := domain.EthernetSwitch{}
+	err := encryptedRepoDb.First(&stored, id).Error
+	if err != nil {
+		t.Fatalf("failed to read stored switch: %v", err)
+	}
+	return stored.Password
+}
+
+func Test_GormEncryptedRepository_Prepare(t *testing.T) {
+	if _, err := os.Stat(encryptedRepoDbFileName); err == nil {
+		err = os.Remove(encryptedRepoDbFileName)
+		if err != nil {
+			t.Errorf("remove db failed:  %q", err)
+		}
+	}
+	var err error
+	encryptedRepoDb, err = gorm.Open(sqlite.Open(encryptedRepoDbFileName), &gorm.Config{})
+	if err != nil {
+		t.Errorf("creating db failed: %v", err)
+	}
+	err = encryptedRepoDb.AutoMigrate(new(domain.EthernetSwitch))
+	if err != nil {
+		t.Errorf("migration failed: %v", err)
+	}
+}
+
+func Test_GormEncryptedRepository_PasswordIsEncryptedAtRest(t *testing.T) {
+	repo := newEncryptedSwitchRepo(t, encryptedRepoOldKey)
+	created, err := repo.Insert(context.Background(), domain.EthernetSwitch{Name: "encrypted", Password: encryptedRepoPassword})
+	if err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	encryptedRepoSwitchID = created.ID
+	if created.Password != encryptedRepoPassword {
+		t.Errorf("inserted entity has not decrypted password")
+	}
+	stored := getStoredPassword(t, created.ID)
+	if stored == encryptedRepoPassword || !strings.HasPrefix(stored, "enc:v1:") {
+		t.Errorf("password is stored in plaintext")
+	}
+	got, err := repo.GetByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if got.Password != encryptedRepoPassword {
+		t.Errorf("password is not decrypted: %s", got.Password)
+	}
+	list, err := repo.GetList(context.Background(), "", "", 1, 10, nil)
+	if err != nil || len(list) != 1 || list[0].Password != encryptedRepoPassword {
+		t.Errorf("password in list is not decrypted")
+	}
+}
+
+func Test_GormEncryptedRepository_LegacyPlaintextIsReadable(t *testing.T) {
+	legacy := domain.EthernetSwitch{Name: "legacy", Password: encryptedRepoPassword}
+	err := encryptedRepoDb.Create(&legacy).Error
+	if err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	encryptedRepoLegacyID = legacy.ID
+	repo := newEncryptedSwitchRepo(t, encryptedRepoOldKey)
+	got, err := repo.GetByID(context.Background(), legacy.ID)
+	if err != nil || got.Password != encryptedRepoPassword {
+		t.Errorf("plaintext password is not readable: %v", err)
+	}
+}
+
+func Test_GormEncryptedRepository_UnknownKeyFails(t *testing.T) {
+	repo := newEncryptedSwitchRepo(t, encryptedRepoNewKey)
+	_, err := repo.GetByID(context.Background(), encryptedRepoSwitchID)
+	if err == nil {
+		t.Errorf("password encrypted with unknown key is decrypted")
+	}
+}
+
+func Test_GormEncryptedRepository_KeyRotation(t *testing.T) {
+	repo := newEncryptedSwitchRepo(t, encryptedRepoNewKey, encryptedRepoOldKey)
+	oldStored := getStoredPassword(t, encryptedRepoSwitchID)
+	updated, err := repo.(interfaces.ISecretsReEncryptor).ReEncryptSecrets(context.Background())
+	if err != nil {
+		t.Fatalf("re-encryption failed: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("unexpected re-encrypted count: %d, expected 2", updated)
+	}
+	for _, id := range []uuid.UUID{encryptedRepoSwitchID, encryptedRepoLegacyID} {
+		stored := getStoredPassword(t, id)
+		if stored == oldStored || !strings.HasPrefix(stored, "enc:") {
+			t.Errorf("password of %s is not re-encrypted", id)
+		}
+	}
+	//previous key is not needed after the re-encryption
+	repo = newEncryptedSwitchRepo(t, encryptedRepoNewKey)
+	for _, id := range []uuid.UUID{encryptedRepoSwitchID, encryptedRepoLegacyID} {
+		got, err := repo.GetByID(context.Background(), id)
+		if err != nil || got.Password != encryptedRepoPassword {
+			t.Errorf("password of %s is not decrypted with the new key: %v", id, err)
+		}
+	}
+	updated, err = repo.(interfaces.ISecretsReEncryptor).ReEncryptSecrets(context.Background())
+	if err != nil || updated != 0 {
+		t.Errorf("secrets are re-encrypted twice: %d, %v", updated, err)
+	}
+}
+
+func Test_GormEncryptedRepository_KeyIsRequired(t *testing.T) {
+	_, err := infrastructure.NewAESSecretCipher(&domain.AppConfig{})
+	if err == nil {
+		t.Errorf("cipher is created without the key")
+	}
+	config := &domain.AppConfig{}
+	config.Credentials.AllowPlaintext = true
+	secretCipher, err := infrastructure.NewAESSecretCipher(config)
+	if err != nil {
+		t.Fatalf("cipher with allowed plaintext is not created: %v", err)
+	}
+	secret, err := secretCipher.Encrypt(encryptedRepoPassword)
+	if err != nil || secret != encryptedRepoPassword {
+		t.Errorf("secret is not kept in plaintext: %s, %v", secret, err)
+	}
+}
+
+func Test_GormEncryptedRepository_CloseConnectionAndRemoveDb(t *testing.T) {
+	sqlDb, err := encryptedRepoDb.DB()
+	if err != nil {
+		t.Errorf("get db failed: %v", err)
+	}
+	err = sqlDb.Close()
+	if err != nil {
+		t.Errorf("close db failed: %v", err)
+	}
+	err = os.Remove(encryptedRepoDbFileName)
+	if err != nil {
+		t.Errorf("remove db failed: %v", err)
+	}
+}
@@ -3,13 +3,18 @@
 package tests
 
 import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"net"
 	"os"
 	"path/filepath"
+	"rol/app/errors"
 	"rol/app/interfaces"
 	"rol/domain"
 	"rol/infrastructure"
+	"sync"
 	"testing"
+	"time"
 )
 
 type networkManagerTester struct {
@@ -17,6 +22,7 @@ type networkManagerTester struct {
 	vlanName       string
 	vlanID         int
 	manager        interfaces.IHostNetworkManager
+	trafficBackend interfaces.ITrafficRuleBackend
 	storage        interfaces.IHostNetworkConfigStorage
 	configFilePath string
 }
@@ -30,9 +36,14 @@ func Test_HostNetworkManager_Prepare(t *testing.T) {
 	netManagerTester.configFilePath = filepath.Join(filepath.Dir(filePath), "hostNetworkConfig.yaml")
 	netManagerTester.storage = infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{
 		RootPath: filepath.Dir(netManagerTester.configFilePath),
-	})
+	}, &domain.AppConfig{})
 	var err error
-	netManagerTester.manager, err = infrastructure.NewHostNetworkManager(netManagerTester.storage)
+	netManagerTester.trafficBackend, err = infrastructure.NewIPTablesTrafficRuleBackend()
+	if err != nil {
+		t.Errorf("error while creating traffic rule backend: %s", err)
+	}
+	netManagerTester.manager, err = infrastructure.NewHostNetworkManager(netManagerTester.storage, netManagerTester.trafficBackend,
+		&domain.AppConfig{}, logrus.New())
 	if err != nil {
 		t.Errorf("error while creating host network manager: %s", err)
 	}
@@ -52,7 +63,7 @@ func Test_HostNetworkManager_GetList(t *testing.T) {
 	if !loExist {
 		t.Errorf("localhost not found")
 	}
-	err = netManagerTester.manager.SaveConfiguration()
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
 	if err != nil {
 		t.Errorf("error saving configuration: %s", err.Error())
 	}
@@ -159,7 +170,7 @@ func Test_HostNetworkManager_VlanAddrAdd(t *testing.T) {
 }
 
 func Test_HostNetworkManager_SaveConfiguration(t *testing.T) {
-	err := netManagerTester.manager.SaveConfiguration()
+	err := netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
 	if err != nil {
 		t.Errorf("failed saving configuration: %s", err.Error())
 	}
@@ -323,13 +334,558 @@ func Test_HostNetworkManager_RestoreFromBackup(t *testing.T) {
 	}
 }
 
+func Test_HostNetworkManager_TrafficRuleRoundTrip(t *testing.T) {
+	rule := domain.HostNetworkTrafficRule{
+		Chain:            "INPUT",
+		Action:           "ACCEPT",
+		Source:           "10.10.10.0/24",
+		Protocol:         "udp",
+		DestinationPorts: "67,69",
+		InInterface:      "rol.test",
+		State:            "NEW",
+		Comment:          "rol test rule",
+	}
+	_, err := netManagerTester.manager.CreateTrafficRule("filter", rule)
+	if err != nil {
+		t.Fatalf("create traffic rule failed: %s", err.Error())
+	}
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+	err = netManagerTester.manager.DeleteTrafficRule("filter", rule)
+	if err != nil {
+		t.Errorf("delete traffic rule failed: %s", err.Error())
+	}
+	err = netManagerTester.manager.ResetChanges()
+	if err != nil {
+		t.Errorf("failed reset configuration to state from configuration storage: %s", err.Error())
+	}
+	rules, err := netManagerTester.manager.GetChainRules("filter", "INPUT")
+	if err != nil {
+		t.Errorf("get chain rules failed: %s", err.Error())
+	}
+	ruleFound := false
+	for _, chainRule := range rules {
+		chainRule.Position = 0
+		//rule is created in the RoL chain
+		chainRule.Chain = "INPUT"
+		if chainRule == rule {
+			ruleFound = true
+		}
+	}
+	if !ruleFound {
+		t.Errorf("traffic rule is not restored as is: %+v", rules)
+	}
+	err = netManagerTester.manager.DeleteTrafficRule("filter", rule)
+	if err != nil {
+		t.Errorf("delete traffic rule failed: %s", err.Error())
+	}
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkManager_ForeignTrafficRules(t *testing.T) {
+	foreignRule := domain.HostNetworkTrafficRule{
+		Chain:   "INPUT",
+		Action:  "ACCEPT",
+		Source:  "10.10.20.0/24",
+		Comment: "foreign test rule",
+	}
+	err := netManagerTester.trafficBackend.CreateRule("filter", foreignRule)
+	if err != nil {
+		t.Fatalf("create foreign traffic rule failed: %s", err.Error())
+	}
+	_, err = netManagerTester.manager.CreateTrafficRule("filter", domain.HostNetworkTrafficRule{
+		Chain:  "FORWARD",
+		Action: "DROP",
+		Source: "10.10.30.0/24",
+	})
+	if err != nil {
+		t.Errorf("create traffic rule failed: %s", err.Error())
+	}
+	err = netManagerTester.manager.ResetChanges()
+	if err != nil {
+		t.Errorf("failed reset configuration to state from configuration storage: %s", err.Error())
+	}
+	rules, err := netManagerTester.manager.GetChainRules("filter", "FORWARD")
+	if err != nil || len(rules) != 0 {
+		t.Errorf("unsaved RoL chain rule is not removed: %+v, %v", rules, err)
+	}
+	builtinRules, err := netManagerTester.trafficBackend.GetChainRules("filter", "INPUT")
+	if err != nil {
+		t.Errorf("get chain rules failed: %s", err.Error())
+	}
+	foreignFound := false
+	jumpFound := false
+	for _, rule := range builtinRules {
+		foreignFound = foreignFound || rule.Comment == foreignRule.Comment
+		jumpFound = jumpFound || rule.Action == "ROL-INPUT"
+	}
+	if !foreignFound {
+		t.Error("foreign traffic rule is removed by reset changes")
+	}
+	if !jumpFound {
+		t.Error("jump to RoL chain not found")
+	}
+	err = netManagerTester.trafficBackend.DeleteRule("filter", foreignRule)
+	if err != nil {
+		t.Errorf("delete foreign traffic rule failed: %s", err.Error())
+	}
+}
+
+//waitChangesReset waits until the unsaved changes of the manager are reset, false if they are not reset until the timeout
+func waitChangesReset(manager interfaces.IHostNetworkManager, timeout time.Duration) bool {
+	timeoutTime := time.Now().Add(timeout)
+	for time.Now().Before(timeoutTime) {
+		if !manager.HasUnsavedChanges() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return !manager.HasUnsavedChanges()
+}
+
+func Test_HostNetworkManager_ConfirmTimeout(t *testing.T) {
+	confirmTimeout := 200 * time.Millisecond
+	manager, err := infrastructure.NewHostNetworkManagerWithConfirmTimeout(netManagerTester.storage, netManagerTester.trafficBackend,
+		confirmTimeout, logrus.New())
+	if err != nil {
+		t.Fatalf("error while creating host network manager: %s", err)
+	}
+	if !manager.GetConfirmDeadline().IsZero() {
+		t.Error("confirm deadline is set without changes")
+	}
+	bridgeName, err := manager.CreateBridge("confirm")
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err.Error())
+	}
+	if manager.GetConfirmDeadline().IsZero() {
+		t.Error("confirm deadline is not set after the change")
+	}
+	if !waitChangesReset(manager, 10*time.Second) {
+		t.Error("unconfirmed changes are not reset after the confirm timeout")
+	}
+	bridge, err := manager.GetByName(bridgeName)
+	if err == nil && bridge != nil {
+		t.Error("unconfirmed bridge is not removed after the confirm timeout")
+	}
+	if manager.HasUnsavedChanges() || !manager.GetConfirmDeadline().IsZero() {
+		t.Error("unconfirmed changes are not reset")
+	}
+	bridgeName, err = manager.CreateBridge("confirm")
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err.Error())
+	}
+	err = manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+	if !manager.GetConfirmDeadline().IsZero() {
+		t.Error("confirm deadline is not cleared after the changes are saved")
+	}
+	time.Sleep(2 * confirmTimeout)
+	_, err = manager.GetByName(bridgeName)
+	if err != nil {
+		t.Errorf("confirmed bridge is removed: %s", err.Error())
+	}
+	err = manager.DeleteLinkByName(bridgeName)
+	if err != nil {
+		t.Errorf("delete bridge failed: %s", err.Error())
+	}
+	err = manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+}
+
+//failingHostNetworkConfigStorage config storage that fails to get the config when it is broken
+type failingHostNetworkConfigStorage struct {
+	interfaces.IHostNetworkConfigStorage
+	mutex  sync.Mutex
+	broken bool
+}
+
+func (s *failingHostNetworkConfigStorage) setBroken(broken bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.broken = broken
+}
+
+func (s *failingHostNetworkConfigStorage) GetConfig() (domain.HostNetworkConfig, error) {
+	s.mutex.Lock()
+	broken := s.broken
+	s.mutex.Unlock()
+	if broken {
+		return domain.HostNetworkConfig{}, errors.Internal.New("storage is broken")
+	}
+	return s.IHostNetworkConfigStorage.GetConfig()
+}
+
+func Test_HostNetworkManager_ConfirmTimeoutResetFailure(t *testing.T) {
+	confirmTimeout := 200 * time.Millisecond
+	storage := &failingHostNetworkConfigStorage{IHostNetworkConfigStorage: netManagerTester.storage}
+	manager, err := infrastructure.NewHostNetworkManagerWithConfirmTimeout(storage, netManagerTester.trafficBackend,
+		confirmTimeout, logrus.New())
+	if err != nil {
+		t.Fatalf("error while creating host network manager: %s", err)
+	}
+	storage.setBroken(true)
+	bridgeName, err := manager.CreateBridge("confirm")
+	if err != nil {
+		t.Fatalf("error creating bridge: %s", err.Error())
+	}
+	firstDeadline := manager.GetConfirmDeadline()
+	if !waitForCondition(10*time.Second, func() bool { return manager.GetConfirmResetError() != nil }) {
+		t.Fatal("failed reset of unconfirmed changes is not reported")
+	}
+	if !manager.HasUnsavedChanges() {
+		t.Error("unsaved changes flag is cleared after the failed reset")
+	}
+	deadline := manager.GetConfirmDeadline()
+	if !deadline.After(firstDeadline) {
+		t.Errorf("confirm deadline is not refreshed after the failed reset: %s", deadline)
+	}
+	//reset is retried with the new deadline
+	storage.setBroken(false)
+	if !waitChangesReset(manager, 10*time.Second) {
+		t.Error("failed reset of unconfirmed changes is not retried")
+	}
+	if manager.GetConfirmResetError() != nil || !manager.GetConfirmDeadline().IsZero() {
+		t.Error("failed reset state is not cleared after the successful reset")
+	}
+	bridge, err := manager.GetByName(bridgeName)
+	if err == nil && bridge != nil {
+		t.Error("unconfirmed bridge is not removed after the retried reset")
+	}
+}
+
+func Test_HostNetworkManager_PlanAndApplyConfiguration(t *testing.T) {
+	savedConfig, err := netManagerTester.storage.GetConfig()
+	if err != nil {
+		t.Fatalf("error getting saved configuration: %s", err.Error())
+	}
+	config := savedConfig
+	config.Bridges = append([]domain.HostNetworkBridge{}, savedConfig.Bridges...)
+	_, address, _ := net.ParseCIDR("123.123.126.1/24")
+	address.IP = net.ParseIP("123.123.126.1")
+	bridge := domain.HostNetworkBridge{}
+	bridge.Name = "rol.br.plan"
+	bridge.Type = "bridge"
+	bridge.Addresses = []net.IPNet{*address}
+	config.Bridges = append(config.Bridges, bridge)
+	plan, err := netManagerTester.manager.PlanConfiguration(config)
+	if err != nil {
+		t.Fatalf("plan configuration failed: %s", err.Error())
+	}
+	if len(plan.CreateLinks) != 1 || plan.CreateLinks[0].Name != bridge.Name || len(plan.Addresses) != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	_, err = netManagerTester.manager.GetByName(bridge.Name)
+	if err == nil {
+		t.Error("host network is changed by plan")
+	}
+	_, err = netManagerTester.manager.ApplyConfiguration(config)
+	if err != nil {
+		t.Fatalf("apply configuration failed: %s", err.Error())
+	}
+	if !netManagerTester.manager.HasUnsavedChanges() {
+		t.Error("applied changes are not marked as unsaved")
+	}
+	link, err := netManagerTester.manager.GetByName(bridge.Name)
+	if err != nil {
+		t.Fatalf("bridge is not created by apply: %s", err.Error())
+	}
+	if len(link.GetAddresses()) != 1 || link.GetAddresses()[0].String() != address.String() {
+		t.Errorf("bridge address is not set by apply: %+v", link.GetAddresses())
+	}
+	plan, err = netManagerTester.manager.PlanConfiguration(config)
+	if err != nil {
+		t.Errorf("plan configuration failed: %s", err.Error())
+	}
+	if !plan.IsEmpty() {
+		t.Errorf("plan of the applied configuration is not empty: %+v", plan)
+	}
+	plan, err = netManagerTester.manager.ApplyConfiguration(savedConfig)
+	if err != nil {
+		t.Fatalf("apply saved configuration failed: %s", err.Error())
+	}
+	if len(plan.DeleteLinks) != 1 || plan.DeleteLinks[0].Name != bridge.Name {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkManager_BondModeChange(t *testing.T) {
+	savedConfig, err := netManagerTester.storage.GetConfig()
+	if err != nil {
+		t.Fatalf("error getting saved configuration: %s", err.Error())
+	}
+	config := savedConfig
+	_, address, _ := net.ParseCIDR("123.123.125.1/24")
+	address.IP = net.ParseIP("123.123.125.1")
+	bond := domain.HostNetworkBond{Mode: "active-backup", Miimon: 100}
+	bond.Name = "rol.bond.mode"
+	bond.Type = "bond"
+	bond.Addresses = []net.IPNet{*address}
+	config.Bonds = append(append([]domain.HostNetworkBond{}, savedConfig.Bonds...), bond)
+	_, err = netManagerTester.manager.ApplyConfiguration(config)
+	if err != nil {
+		t.Fatalf("apply configuration failed: %s", err.Error())
+	}
+	bond.Mode = "balance-alb"
+	config.Bonds = append(append([]domain.HostNetworkBond{}, savedConfig.Bonds...), bond)
+	plan, err := netManagerTester.manager.PlanConfiguration(config)
+	if err != nil {
+		t.Fatalf("plan configuration failed: %s", err.Error())
+	}
+	if len(plan.ReplaceLinks) != 1 || len(plan.CreateLinks) != 1 || plan.CreateLinks[0].Mode != bond.Mode ||
+		len(plan.Addresses) != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	_, err = netManagerTester.manager.ApplyConfiguration(config)
+	if err != nil {
+		t.Fatalf("apply configuration failed: %s", err.Error())
+	}
+	link, err := netManagerTester.manager.GetByName(bond.Name)
+	if err != nil {
+		t.Fatalf("bond is not created again by apply: %s", err.Error())
+	}
+	if link.(domain.HostNetworkBond).Mode != bond.Mode || len(link.GetAddresses()) != 1 {
+		t.Errorf("bond mode or address is not set by apply: %+v", link)
+	}
+	plan, err = netManagerTester.manager.PlanConfiguration(config)
+	if err != nil {
+		t.Errorf("plan configuration failed: %s", err.Error())
+	}
+	if !plan.IsEmpty() {
+		t.Errorf("plan of the applied configuration is not empty: %+v", plan)
+	}
+	_, err = netManagerTester.manager.ApplyConfiguration(savedConfig)
+	if err != nil {
+		t.Fatalf("apply saved configuration failed: %s", err.Error())
+	}
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkManager_NamespacesAndVeths(t *testing.T) {
+	savedConfig, err := netManagerTester.storage.GetConfig()
+	if err != nil {
+		t.Fatalf("error getting saved configuration: %s", err.Error())
+	}
+	config := savedConfig
+	_, vethAddress, _ := net.ParseCIDR("123.123.127.1/24")
+	vethAddress.IP = net.ParseIP("123.123.127.1")
+	_, peerAddress, _ := net.ParseCIDR("123.123.127.2/24")
+	peerAddress.IP = net.ParseIP("123.123.127.2")
+	veth := domain.HostNetworkVeth{}
+	veth.Name = "rol.veth.test"
+	veth.Type = "veth"
+	veth.Addresses = []net.IPNet{*vethAddress}
+	config.Veths = append(append([]domain.HostNetworkVeth{}, savedConfig.Veths...), veth)
+	namespace := domain.HostNetworkNamespace{
+		Name:  "rol.ns.test",
+		Links: []domain.HostNetworkLink{{Name: "rol.peer.test", Addresses: []net.IPNet{*peerAddress}}},
+	}
+	config.Namespaces = append(append([]domain.HostNetworkNamespace{}, savedConfig.Namespaces...), namespace)
+	_, err = netManagerTester.manager.ApplyConfiguration(config)
+	if err != nil {
+		t.Fatalf("apply configuration failed: %s", err.Error())
+	}
+	link, err := netManagerTester.manager.GetByName(veth.Name)
+	if err != nil {
+		t.Fatalf("veth is not created by apply: %s", err.Error())
+	}
+	if len(link.GetAddresses()) != 1 || link.GetAddresses()[0].String() != vethAddress.String() {
+		t.Errorf("veth address is not set by apply: %+v", link.GetAddresses())
+	}
+	_, err = netManagerTester.manager.GetByName("rol.peer.test")
+	if err == nil {
+		t.Error("veth peer is not moved into the namespace")
+	}
+	namespaces, err := netManagerTester.manager.GetNamespaces()
+	if err != nil {
+		t.Fatalf("get namespaces failed: %s", err.Error())
+	}
+	namespaceFound := false
+	for _, ns := range namespaces {
+		if ns.Name != namespace.Name {
+			continue
+		}
+		namespaceFound = true
+		if len(ns.Links) != 1 || ns.Links[0].Name != "rol.peer.test" || len(ns.Links[0].Addresses) != 1 ||
+			ns.Links[0].Addresses[0].String() != peerAddress.String() {
+			t.Errorf("unexpected namespace links: %+v", ns.Links)
+		}
+	}
+	if !namespaceFound {
+		t.Errorf("namespace %s is not created by apply", namespace.Name)
+	}
+	plan, err := netManagerTester.manager.PlanConfiguration(config)
+	if err != nil {
+		t.Errorf("plan configuration failed: %s", err.Error())
+	}
+	if !plan.IsEmpty() {
+		t.Errorf("plan of the applied configuration is not empty: %+v", plan)
+	}
+	plan, err = netManagerTester.manager.ApplyConfiguration(savedConfig)
+	if err != nil {
+		t.Fatalf("apply saved configuration failed: %s", err.Error())
+	}
+	if len(plan.DeleteNamespaces) != 1 || plan.DeleteNamespaces[0] != namespace.Name || len(plan.DeleteLinks) != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	_, err = netManagerTester.manager.GetByName(veth.Name)
+	if err == nil {
+		t.Error("veth is not deleted by apply")
+	}
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkManager_IPv6AddressesAndRoutes(t *testing.T) {
+	savedConfig, err := netManagerTester.storage.GetConfig()
+	if err != nil {
+		t.Fatalf("error getting saved configuration: %s", err.Error())
+	}
+	config := savedConfig
+	_, ipv4Address, _ := net.ParseCIDR("123.123.128.1/24")
+	ipv4Address.IP = net.ParseIP("123.123.128.1").To4()
+	_, ipv6Address, _ := net.ParseCIDR("fd00:123::1/64")
+	ipv6Address.IP = net.ParseIP("fd00:123::1")
+	bridge := domain.HostNetworkBridge{}
+	bridge.Name = "rol.br.route"
+	bridge.Type = "bridge"
+	bridge.Addresses = []net.IPNet{*ipv4Address, *ipv6Address}
+	config.Bridges = append(append([]domain.HostNetworkBridge{}, savedConfig.Bridges...), bridge)
+	_, ipv4Destination, _ := net.ParseCIDR("10.123.0.0/16")
+	_, ipv6Destination, _ := net.ParseCIDR("fd00:124::/64")
+	_, linkDestination, _ := net.ParseCIDR("10.124.0.0/16")
+	routes := []domain.HostNetworkRoute{{
+		Destination: *ipv4Destination,
+		Gateway:     net.ParseIP("123.123.128.254"),
+		Metric:      100,
+	}, {
+		Destination: *ipv6Destination,
+		Gateway:     net.ParseIP("fd00:123::254"),
+		Interface:   bridge.Name,
+	}, {
+		Destination: *linkDestination,
+		Interface:   bridge.Name,
+		Table:       100,
+	}}
+	config.Routes = append(append([]domain.HostNetworkRoute{}, savedConfig.Routes...), routes...)
+	_, err = netManagerTester.manager.ApplyConfiguration(config)
+	if err != nil {
+		t.Fatalf("apply configuration failed: %s", err.Error())
+	}
+	link, err := netManagerTester.manager.GetByName(bridge.Name)
+	if err != nil {
+		t.Fatalf("bridge is not created by apply: %s", err.Error())
+	}
+	if len(link.GetAddresses()) != 2 {
+		t.Errorf("bridge IPv4 and IPv6 addresses are not set by apply: %+v", link.GetAddresses())
+	}
+	hostRoutes, err := netManagerTester.manager.GetRoutes()
+	if err != nil {
+		t.Fatalf("get routes failed: %s", err.Error())
+	}
+	if len(hostRoutes) != len(config.Routes) {
+		t.Errorf("unexpected routes: %+v", hostRoutes)
+	}
+	for _, route := range hostRoutes {
+		if route.Interface != bridge.Name {
+			t.Errorf("route to %s is not bound to the bridge: %+v", route.Destination.String(), route)
+		}
+	}
+	plan, err := netManagerTester.manager.PlanConfiguration(config)
+	if err != nil {
+		t.Errorf("plan configuration failed: %s", err.Error())
+	}
+	if !plan.IsEmpty() {
+		t.Errorf("plan of the applied configuration is not empty: %+v", plan)
+	}
+	plan, err = netManagerTester.manager.ApplyConfiguration(savedConfig)
+	if err != nil {
+		t.Fatalf("apply saved configuration failed: %s", err.Error())
+	}
+	if len(plan.DeleteRoutes) != len(routes) || len(plan.DeleteLinks) != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	hostRoutes, err = netManagerTester.manager.GetRoutes()
+	if err != nil {
+		t.Fatalf("get routes failed: %s", err.Error())
+	}
+	if len(hostRoutes) != len(savedConfig.Routes) {
+		t.Errorf("routes are not deleted by apply: %+v", hostRoutes)
+	}
+	err = netManagerTester.manager.DeleteRoute(routes[0])
+	if !errors.As(err, errors.NotFound) {
+		t.Errorf("deletion of not existing route returned unexpected error: %v", err)
+	}
+	err = netManagerTester.manager.SaveConfiguration("test", uuid.Nil)
+	if err != nil {
+		t.Errorf("error saving configuration: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkManager_ConfigRevisions(t *testing.T) {
+	revisions, err := netManagerTester.storage.GetRevisions()
+	if err != nil {
+		t.Fatalf("error getting configuration revisions: %s", err.Error())
+	}
+	if len(revisions) < 2 {
+		t.Fatalf("expected at least 2 revisions, got %d", len(revisions))
+	}
+	if revisions[0].Author != "test" || revisions[0].CreatedAt.Before(revisions[1].CreatedAt) {
+		t.Errorf("unexpected latest revision: %+v", revisions[0])
+	}
+	revision, err := netManagerTester.storage.GetRevision(revisions[1].ID)
+	if err != nil {
+		t.Errorf("error getting configuration revision: %s", err.Error())
+	}
+	if revision.ID != revisions[1].ID {
+		t.Errorf("unexpected revision %s, expected %s", revision.ID, revisions[1].ID)
+	}
+	_, err = netManagerTester.storage.GetRevision(uuid.New())
+	if err == nil {
+		t.Error("not existing revision was found")
+	}
+	config := &domain.AppConfig{}
+	config.HostNetwork.HistorySize = 2
+	storage := infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{
+		RootPath: filepath.Dir(netManagerTester.configFilePath),
+	}, config)
+	err = storage.SaveConfig(revisions[0].Config, "retention", uuid.Nil)
+	if err != nil {
+		t.Fatalf("error saving configuration: %s", err.Error())
+	}
+	revisions, err = storage.GetRevisions()
+	if err != nil {
+		t.Fatalf("error getting configuration revisions: %s", err.Error())
+	}
+	if len(revisions) != 2 || revisions[0].Author != "retention" {
+		t.Errorf("expected 2 revisions after the retention, got %d", len(revisions))
+	}
+}
+
 func Test_HostNetworkManager_CleaningAfterTests(t *testing.T) {
 	err := os.Remove(netManagerTester.configFilePath)
 	if err != nil {
 		t.Errorf("remove network config file failed:  %q", err)
 	}
-	err = os.Remove(netManagerTester.configFilePath + ".back")
+	err = os.RemoveAll(filepath.Join(filepath.Dir(netManagerTester.configFilePath), "hostNetworkConfigHistory"))
 	if err != nil {
-		t.Errorf("remove network backup config file failed:  %q", err)
+		t.Errorf("remove network config history failed:  %q", err)
 	}
 }
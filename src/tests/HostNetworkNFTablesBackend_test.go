@@ -0,0 +1,182 @@
+//go:build linux
+
+package tests
+
+import (
+	"github.com/google/nftables"
+	"rol/app/errors"
+	"rol/app/interfaces"
+	"rol/domain"
+	"rol/infrastructure"
+	"testing"
+)
+
+var nftablesBackend interfaces.ITrafficRuleBackend
+
+var nftablesTestRules = []domain.HostNetworkTrafficRule{
+	{
+		Chain:            "INPUT",
+		Action:           "ACCEPT",
+		Source:           "10.10.10.0/24",
+		Protocol:         "udp",
+		DestinationPorts: "67,69",
+		InInterface:      "rol.br.test",
+		State:            "NEW",
+		Comment:          "rol test rule",
+	},
+	{
+		Chain:            "PREROUTING",
+		Action:           "DNAT",
+		Protocol:         "tcp",
+		DestinationPorts: "8080",
+		InInterface:      "!rol.br.test",
+		To:               "10.10.10.2:80",
+	},
+	{
+		Chain:        "POSTROUTING",
+		Action:       "MASQUERADE",
+		Source:       "10.10.10.0/24",
+		OutInterface: "eth+",
+	},
+}
+
+func Test_HostNetworkNFTablesBackend_Prepare(t *testing.T) {
+	//remove rules that are left by other tests, the table may not exist
+	_ = deleteNFTablesRolTable()
+	var err error
+	nftablesBackend, err = infrastructure.NewNFTablesTrafficRuleBackend()
+	if err != nil {
+		t.Fatalf("create nftables backend failed: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkNFTablesBackend_CreateRules(t *testing.T) {
+	tables := []string{"filter", "nat", "nat"}
+	for i, rule := range nftablesTestRules {
+		err := nftablesBackend.CreateRule(tables[i], rule)
+		if err != nil {
+			t.Errorf("create rule failed: %s", err.Error())
+		}
+		//the same rule is not duplicated
+		err = nftablesBackend.CreateRule(tables[i], rule)
+		if err != nil {
+			t.Errorf("create existing rule failed: %s", err.Error())
+		}
+	}
+}
+
+func Test_HostNetworkNFTablesBackend_RoundTrip(t *testing.T) {
+	filterRules, err := nftablesBackend.GetChainRules("filter", "INPUT")
+	if err != nil {
+		t.Fatalf("get chain rules failed: %s", err.Error())
+	}
+	if len(filterRules) != 1 {
+		t.Fatalf("unexpected filter rules count %d", len(filterRules))
+	}
+	expected := nftablesTestRules[0]
+	expected.Position = 1
+	if filterRules[0] != expected {
+		t.Errorf("rule is not read back as is: %+v", filterRules[0])
+	}
+	natRules, err := nftablesBackend.GetTableRules("nat")
+	if err != nil {
+		t.Fatalf("get table rules failed: %s", err.Error())
+	}
+	for _, rule := range nftablesTestRules[1:] {
+		found := false
+		for _, natRule := range natRules {
+			natRule.Position = 0
+			if natRule == rule {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("rule %+v is not read back as is: %+v", rule, natRules)
+		}
+	}
+}
+
+func Test_HostNetworkNFTablesBackend_InsertAtPosition(t *testing.T) {
+	rule := domain.HostNetworkTrafficRule{Chain: "INPUT", Action: "DROP", Protocol: "icmp", Position: 1}
+	err := nftablesBackend.CreateRule("filter", rule)
+	if err != nil {
+		t.Fatalf("create rule failed: %s", err.Error())
+	}
+	rules, err := nftablesBackend.GetChainRules("filter", "INPUT")
+	if err != nil || len(rules) != 2 {
+		t.Fatalf("get chain rules failed: %v", err)
+	}
+	if rules[0] != rule {
+		t.Errorf("rule is not inserted at the first position: %+v", rules)
+	}
+	err = nftablesBackend.DeleteRule("filter", rule)
+	if err != nil {
+		t.Errorf("delete rule failed: %s", err.Error())
+	}
+}
+
+func Test_HostNetworkNFTablesBackend_PortRangesList(t *testing.T) {
+	cases := []struct {
+		ports    string
+		expected string
+	}{
+		{"8000:8010,22,8011:8020,443", "22,443,8000:8020"},
+		{"!1:1024,65000:65535", "!1:1024,65000:65535"},
+		{"0:10,5,12", "0:10,12"},
+	}
+	for _, testCase := range cases {
+		rule := domain.HostNetworkTrafficRule{Chain: "INPUT", Action: "ACCEPT", Protocol: "tcp",
+			DestinationPorts: testCase.ports}
+		err := nftablesBackend.CreateRule("filter", rule)
+		if err != nil {
+			t.Fatalf("create rule with ports %s failed: %s", testCase.ports, err.Error())
+		}
+		//the same rule is not duplicated
+		err = nftablesBackend.CreateRule("filter", rule)
+		if err != nil {
+			t.Errorf("create existing rule with ports %s failed: %s", testCase.ports, err.Error())
+		}
+		rules, err := nftablesBackend.GetChainRules("filter", "INPUT")
+		if err != nil || len(rules) != 2 {
+			t.Fatalf("get chain rules failed: %v, %+v", err, rules)
+		}
+		if rules[1].DestinationPorts != testCase.expected {
+			t.Errorf("ports %s are read back as %s", testCase.ports, rules[1].DestinationPorts)
+		}
+		err = nftablesBackend.DeleteRule("filter", rule)
+		if err != nil {
+			t.Errorf("delete rule with ports %s failed: %s", testCase.ports, err.Error())
+		}
+	}
+}
+
+func Test_HostNetworkNFTablesBackend_DeleteRules(t *testing.T) {
+	tables := []string{"filter", "nat", "nat"}
+	for i, rule := range nftablesTestRules {
+		err := nftablesBackend.DeleteRule(tables[i], rule)
+		if err != nil {
+			t.Errorf("delete rule failed: %s", err.Error())
+		}
+		err = nftablesBackend.DeleteRule(tables[i], rule)
+		if !errors.As(err, errors.NotFound) {
+			t.Errorf("expected not found error for deleted rule, got: %v", err)
+		}
+	}
+}
+
+func Test_HostNetworkNFTablesBackend_CloseAll(t *testing.T) {
+	err := deleteNFTablesRolTable()
+	if err != nil {
+		t.Errorf("delete rol table failed: %s", err.Error())
+	}
+}
+
+//deleteNFTablesRolTable deletes 'rol' nftables table with all chains and rules
+func deleteNFTablesRolTable() error {
+	conn, err := nftables.New()
+	if err != nil {
+		return err
+	}
+	conn.DelTable(&nftables.Table{Name: "rol", Family: nftables.TableFamilyIPv4})
+	return conn.Flush()
+}
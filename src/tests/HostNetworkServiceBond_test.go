@@ -0,0 +1,195 @@
+//go:build linux
+
+package tests
+
+import (
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"rol/app/errors"
+	"rol/app/services"
+	"rol/domain"
+	"rol/dtos"
+	"rol/infrastructure"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type bondServiceTester struct {
+	service             *services.HostNetworkService
+	configFilePath      string
+	createdBondName     string
+	vlanName            string
+	vlanMasterInterface string
+}
+
+var bondTester *bondServiceTester
+
+func Test_HostNetworkBondService_Prepare(t *testing.T) {
+	bondTester = &bondServiceTester{}
+	_, filePath, _, _ := runtime.Caller(0)
+	bondTester.configFilePath = filepath.Join(filepath.Dir(filePath), "hostNetworkConfig.yaml")
+	configStorage := infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{RootPath: filepath.Dir(bondTester.configFilePath)}, &domain.AppConfig{})
+	trafficBackend, err := infrastructure.NewIPTablesTrafficRuleBackend()
+	if err != nil {
+		t.Errorf("error to create traffic rule backend: %s", err.Error())
+	}
+	networkManager, err := infrastructure.NewHostNetworkManager(configStorage, trafficBackend, &domain.AppConfig{}, logrus.New())
+	if err != nil {
+		t.Error("error to create host network manager")
+	}
+	bondTester.service = services.NewHostNetworkService(networkManager, configStorage)
+
+	links, err := networkManager.GetList()
+	if err != nil {
+		t.Errorf("error getting list: %s", err.Error())
+	}
+	for _, link := range links {
+		if link.GetName() != "lo" && link.GetType() != "vlan" {
+			bondTester.vlanMasterInterface = link.GetName()
+			break
+		}
+	}
+
+	createDto := dtos.HostNetworkVlanCreateDto{
+		VlanID: 133,
+		Parent: bondTester.vlanMasterInterface,
+	}
+	dto, err := bondTester.service.CreateVlan(createDto)
+	if err != nil {
+		t.Errorf("error creating vlan: %s", err.Error())
+	}
+	bondTester.vlanName = dto.Name
+}
+
+func Test_HostNetworkBondService_CreateBond(t *testing.T) {
+	createDto := dtos.HostNetworkBondCreateDto{
+		Name:   "test",
+		Mode:   "active-backup",
+		Miimon: 100,
+		HostNetworkBondBaseDto: dtos.HostNetworkBondBaseDto{
+			Addresses: []string{
+				"123.123.126.126/24",
+			},
+			Slaves: []string{
+				bondTester.vlanName,
+			},
+		},
+	}
+	dto, err := bondTester.service.CreateBond(createDto)
+	if err != nil {
+		t.Fatalf("error creating bond: %s", err.Error())
+	}
+	bondTester.createdBondName = dto.Name
+	if !strings.Contains(bondTester.createdBondName, "rol.bond.") {
+		t.Errorf("wrong bond name: %s, expect rol.bond.{%s}", dto.Name, createDto.Name)
+	}
+	if dto.Mode != createDto.Mode || dto.Miimon != createDto.Miimon {
+		t.Errorf("wrong bond parameters: %s %d, expect: %s %d", dto.Mode, dto.Miimon, createDto.Mode, createDto.Miimon)
+	}
+	if len(dto.Slaves) != 1 || dto.Slaves[0] != bondTester.vlanName {
+		t.Errorf("bond slaves are not set: %v", dto.Slaves)
+	}
+}
+
+func Test_HostNetworkBondService_CreateBondWithIncorrectMode(t *testing.T) {
+	createDto := dtos.HostNetworkBondCreateDto{
+		Name: "wrong",
+		Mode: "broadcast",
+	}
+	dto, err := bondTester.service.CreateBond(createDto)
+	if err != nil {
+		if !errors.As(err, errors.Validation) {
+			t.Errorf("expected error is not Validation error: %s", err.Error())
+		}
+	} else {
+		_ = bondTester.service.DeleteBond(dto.Name)
+		t.Error("successfully created bond with unsupported mode")
+	}
+}
+
+func Test_HostNetworkBondService_CreateBondWithNonexistentSlave(t *testing.T) {
+	createDto := dtos.HostNetworkBondCreateDto{
+		Name: "wrong",
+		Mode: "802.3ad",
+		HostNetworkBondBaseDto: dtos.HostNetworkBondBaseDto{
+			Slaves: []string{"nonexistent"},
+		},
+	}
+	dto, err := bondTester.service.CreateBond(createDto)
+	if err != nil {
+		if !errors.As(err, errors.Validation) {
+			t.Errorf("expected error is not Validation error: %s", err.Error())
+		}
+	} else {
+		_ = bondTester.service.DeleteBond(dto.Name)
+		t.Error("successfully created bond with nonexistent slave")
+	}
+}
+
+func Test_HostNetworkBondService_UpdateBond(t *testing.T) {
+	updateDto := dtos.HostNetworkBondUpdateDto{
+		HostNetworkBondBaseDto: dtos.HostNetworkBondBaseDto{
+			Addresses: []string{
+				"123.123.127.127/24",
+			},
+		},
+	}
+	_, err := bondTester.service.UpdateBond(bondTester.createdBondName, updateDto)
+	if err != nil {
+		t.Errorf("error updating bond: %s", err.Error())
+	}
+	bond, err := bondTester.service.GetBondByName(bondTester.createdBondName)
+	if err != nil {
+		t.Errorf("get bond by name failed: %s", err.Error())
+	}
+	if len(bond.Addresses) != 1 || bond.Addresses[0] != "123.123.127.127/24" {
+		t.Errorf("failed to update bond addresses: %v", bond.Addresses)
+	}
+	if len(bond.Slaves) != 0 {
+		t.Errorf("failed to update bond slaves: %v", bond.Slaves)
+	}
+}
+
+func Test_HostNetworkBondService_GetList(t *testing.T) {
+	bonds, err := bondTester.service.GetBondList()
+	if err != nil {
+		t.Errorf("get list failed: %s", err.Error())
+	}
+	bondFound := false
+	for _, bond := range bonds {
+		if bond.Name == bondTester.createdBondName {
+			bondFound = true
+		}
+	}
+	if !bondFound {
+		t.Error("created bond was not found")
+	}
+}
+
+func Test_HostNetworkBondService_Delete(t *testing.T) {
+	err := bondTester.service.DeleteBond(bondTester.createdBondName)
+	if err != nil {
+		t.Errorf("delete bond failed: %s", err.Error())
+	}
+	_, err = bondTester.service.GetBondByName(bondTester.createdBondName)
+	if err == nil {
+		t.Error("deleted bond was received")
+	}
+}
+
+func Test_HostNetworkBondService_CleaningAfterTests(t *testing.T) {
+	err := bondTester.service.DeleteVlan(bondTester.vlanName)
+	if err != nil {
+		return
+	}
+	err = os.Remove(bondTester.configFilePath)
+	if err != nil {
+		t.Errorf("remove network config file failed:  %q", err)
+	}
+	err = os.RemoveAll(filepath.Join(filepath.Dir(bondTester.configFilePath), "hostNetworkConfigHistory"))
+	if err != nil {
+		t.Errorf("remove network config history failed:  %q", err)
+	}
+}
@@ -3,6 +3,7 @@
 package tests
 
 import (
+	"github.com/sirupsen/logrus"
 	"os"
 	"path/filepath"
 	"rol/app/errors"
@@ -29,12 +30,16 @@ func Test_HostNetworkBridgeService_Prepare(t *testing.T) {
 	bridgeTester = &bridgeServiceTester{}
 	_, filePath, _, _ := runtime.Caller(0)
 	bridgeTester.configFilePath = filepath.Join(filepath.Dir(filePath), "hostNetworkConfig.yaml")
-	configStorage := infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{RootPath: filepath.Dir(bridgeTester.configFilePath)})
-	networkManager, err := infrastructure.NewHostNetworkManager(configStorage)
+	configStorage := infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{RootPath: filepath.Dir(bridgeTester.configFilePath)}, &domain.AppConfig{})
+	trafficBackend, err := infrastructure.NewIPTablesTrafficRuleBackend()
+	if err != nil {
+		t.Errorf("error to create traffic rule backend: %s", err.Error())
+	}
+	networkManager, err := infrastructure.NewHostNetworkManager(configStorage, trafficBackend, &domain.AppConfig{}, logrus.New())
 	if err != nil {
 		t.Error("error to create host network manager")
 	}
-	bridgeTester.service = services.NewHostNetworkService(networkManager)
+	bridgeTester.service = services.NewHostNetworkService(networkManager, configStorage)
 
 	links, err := networkManager.GetList()
 	if err != nil {
@@ -187,11 +192,65 @@ func Test_HostNetworkBridgeService_GetList(t *testing.T) {
 	}
 }
 
+func Test_HostNetworkBridgeService_SetNat(t *testing.T) {
+	updateDto := dtos.HostNetworkBridgeUpdateDto{
+		HostNetworkBridgeBaseDto: dtos.HostNetworkBridgeBaseDto{
+			Addresses:         []string{"123.123.125.125/24"},
+			Slaves:            []string{bridgeTester.vlanName},
+			Nat:               true,
+			UpstreamInterface: bridgeTester.vlanMasterInterface,
+		},
+	}
+	_, err := bridgeTester.service.UpdateBridge(bridgeTester.createdBridgeName, updateDto)
+	if err != nil {
+		t.Fatalf("set bridge NAT failed: %s", err.Error())
+	}
+	bridge, err := bridgeTester.service.GetBridgeByName(bridgeTester.createdBridgeName)
+	if err != nil {
+		t.Errorf("get bridge by name failed: %s", err.Error())
+	}
+	if !bridge.Nat || bridge.UpstreamInterface != bridgeTester.vlanMasterInterface {
+		t.Errorf("bridge NAT is not set: %+v", bridge)
+	}
+	if !bridgeMasqueradeExist(t) {
+		t.Error("bridge masquerade rule not found")
+	}
+}
+
+func Test_HostNetworkBridgeService_SetNatWithoutUpstream(t *testing.T) {
+	updateDto := dtos.HostNetworkBridgeUpdateDto{
+		HostNetworkBridgeBaseDto: dtos.HostNetworkBridgeBaseDto{
+			Addresses: []string{"123.123.125.125/24"},
+			Nat:       true,
+		},
+	}
+	_, err := bridgeTester.service.UpdateBridge(bridgeTester.createdBridgeName, updateDto)
+	if err == nil || !errors.As(err, errors.Validation) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+}
+
+func bridgeMasqueradeExist(t *testing.T) bool {
+	rules, err := bridgeTester.service.GetTableRules("nat")
+	if err != nil {
+		t.Errorf("get table rules failed: %s", err.Error())
+	}
+	for _, rule := range rules {
+		if rule.Action == "MASQUERADE" && rule.Source == "123.123.125.0/24" {
+			return true
+		}
+	}
+	return false
+}
+
 func Test_HostNetworkBridgeService_Delete(t *testing.T) {
 	err := bridgeTester.service.DeleteBridge(bridgeTester.createdBridgeName)
 	if err != nil {
 		t.Errorf("delete bridge failed: %s", err.Error())
 	}
+	if bridgeMasqueradeExist(t) {
+		t.Error("bridge masquerade rule is not removed with the bridge")
+	}
 	_, err = bridgeTester.service.GetBridgeByName(bridgeTester.createdBridgeName)
 	if err == nil {
 		t.Error("deleted bridge was received")
@@ -207,4 +266,8 @@ func Test_HostNetworkBridgeService_CleaningAfterTests(t *testing.T) {
 	if err != nil {
 		t.Errorf("remove network config file failed:  %q", err)
 	}
+	err = os.RemoveAll(filepath.Join(filepath.Dir(bridgeTester.configFilePath), "hostNetworkConfigHistory"))
+	if err != nil {
+		t.Errorf("remove network config history failed:  %q", err)
+	}
 }
@@ -3,6 +3,7 @@
 package tests
 
 import (
+	"github.com/sirupsen/logrus"
 	"os"
 	"path/filepath"
 	"rol/app/errors"
@@ -28,12 +29,16 @@ func Test_HostNetworkVlanService_Prepare(t *testing.T) {
 	vlanTester = &vlanServiceTester{}
 	_, filePath, _, _ := runtime.Caller(0)
 	vlanTester.configFilePath = filepath.Join(filepath.Dir(filePath), "hostNetworkConfig.yaml")
-	configStorage := infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{RootPath: filepath.Dir(vlanTester.configFilePath)})
-	networkManager, err := infrastructure.NewHostNetworkManager(configStorage)
+	configStorage := infrastructure.NewYamlHostNetworkConfigStorage(domain.GlobalDIParameters{RootPath: filepath.Dir(vlanTester.configFilePath)}, &domain.AppConfig{})
+	trafficBackend, err := infrastructure.NewIPTablesTrafficRuleBackend()
+	if err != nil {
+		t.Errorf("error to create traffic rule backend: %s", err.Error())
+	}
+	networkManager, err := infrastructure.NewHostNetworkManager(configStorage, trafficBackend, &domain.AppConfig{}, logrus.New())
 	if err != nil {
 		t.Error("error to create host network manager")
 	}
-	vlanTester.service = services.NewHostNetworkService(networkManager)
+	vlanTester.service = services.NewHostNetworkService(networkManager, configStorage)
 
 	links, err := networkManager.GetList()
 	if err != nil {
@@ -202,4 +207,8 @@ func Test_HostNetworkVlanService_CleaningAfterTests(t *testing.T) {
 	if err != nil {
 		t.Errorf("remove network config file failed:  %q", err)
 	}
+	err = os.RemoveAll(filepath.Join(filepath.Dir(vlanTester.configFilePath), "hostNetworkConfigHistory"))
+	if err != nil {
+		t.Errorf("remove network config history failed:  %q", err)
+	}
 }
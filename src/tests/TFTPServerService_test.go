@@ -61,7 +61,7 @@ func Test_TFTPServerService_Prepare(t *testing.T) {
 	tftpTester.configRepo = infrastructure.NewGormGenericRepository[uuid.UUID, domain.TFTPConfig](testGenDb, logger)
 	tftpTester.pathsRepo = infrastructure.NewGormGenericRepository[uuid.UUID, domain.TFTPPathRatio](testGenDb, logger)
 	factory, _ := infrastructure.NewPinTFTPServerFactory()
-	tftpTester.service = services.NewTFTPServerService(tftpTester.configRepo, tftpTester.pathsRepo, factory, logger)
+	tftpTester.service = services.NewTFTPServerService(tftpTester.configRepo, tftpTester.pathsRepo, factory, nil, logger)
 	if err != nil {
 		t.Errorf("create new service failed: %q", err)
 	}
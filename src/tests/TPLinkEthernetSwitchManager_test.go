@@ -0,0 +1,403 @@
+package tests
+
+import (
+	"context"
+	"reflect"
+	"rol/app/interfaces"
+	"rol/domain"
+	"rol/infrastructure"
+	"strings"
+	"testing"
+)
+
+const (
+	tpLinkSimulatorPortsCount    = 10
+	tpLinkSimulatorPOEPortsCount = 8
+)
+
+var (
+	tpLinkSimulator *TPLinkSwitchSimulator
+	tpLinkManager   interfaces.IEthernetSwitchManager
+)
+
+func Test_TPLinkEthernetSwitchManager_Prepare(t *testing.T) {
+	var err error
+	tpLinkSimulator, err = NewTPLinkSwitchSimulator(tpLinkSimulatorPortsCount, tpLinkSimulatorPOEPortsCount)
+	if err != nil {
+		t.Fatalf("start switch simulator failed: %v", err)
+	}
+	tpLinkManager = infrastructure.NewTPLinkEthernetSwitchManager(tpLinkSimulator.Address(), SimulatorLogin, SimulatorPassword,
+		domain.EthernetSwitchSessionConfig{})
+}
+
+func Test_TPLinkEthernetSwitchManager_WrongPassword(t *testing.T) {
+	manager := infrastructure.NewTPLinkEthernetSwitchManager(tpLinkSimulator.Address(), SimulatorLogin, "wrong",
+		domain.EthernetSwitchSessionConfig{})
+	_, err := manager.GetVLANs(context.Background())
+	if err == nil {
+		t.Errorf("switch session is opened with the wrong password")
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_VLANs(t *testing.T) {
+	ctx := context.Background()
+	err := tpLinkManager.CreateVLAN(ctx, 10)
+	if err != nil {
+		t.Fatalf("create VLAN failed: %v", err)
+	}
+	vlans, err := tpLinkManager.GetVLANs(ctx)
+	if err != nil || !reflect.DeepEqual(vlans, []int{1, 10}) {
+		t.Errorf("unexpected VLANs %v: %v", vlans, err)
+	}
+	err = tpLinkManager.AddTaggedVLANOnPort(ctx, "Gi1/0/1", 10)
+	if err != nil {
+		t.Fatalf("add tagged VLAN failed: %v", err)
+	}
+	err = tpLinkManager.SetPortPVID(ctx, "Gi1/0/1", 10)
+	if err != nil {
+		t.Fatalf("set PVID failed: %v", err)
+	}
+	untagged, tagged, err := tpLinkManager.GetVLANsOnPort(ctx, "Gi1/0/1")
+	if err != nil || untagged != 1 || !reflect.DeepEqual(tagged, []int{10}) {
+		t.Errorf("unexpected port VLANs %d %v: %v", untagged, tagged, err)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/1")
+	if port.PVID != 10 || !reflect.DeepEqual(port.TaggedVLANs, []int{10}) {
+		t.Errorf("VLAN is not configured on the switch port: %+v", port)
+	}
+	err = tpLinkManager.AddUntaggedVLANOnPort(ctx, "Gi1/0/2", 20)
+	if err == nil {
+		t.Errorf("nonexistent VLAN is added on the port")
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_POE(t *testing.T) {
+	ctx := context.Background()
+	err := tpLinkManager.DisablePOEPort(ctx, "Gi1/0/2")
+	if err != nil {
+		t.Fatalf("disable POE failed: %v", err)
+	}
+	status, err := tpLinkManager.GetPOEPortStatus(ctx, "Gi1/0/2")
+	if err != nil || status != "disable" {
+		t.Errorf("unexpected POE status %s: %v", status, err)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/2")
+	if port.POEEnabled {
+		t.Errorf("POE is not disabled on the switch port")
+	}
+	err = tpLinkManager.EnablePOEPort(ctx, "Gi1/0/9", "poe")
+	if err == nil {
+		t.Errorf("POE is enabled on the port without POE support")
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_GetConfig(t *testing.T) {
+	config, err := tpLinkManager.GetConfig(context.Background())
+	if err != nil {
+		t.Fatalf("get config failed: %v", err)
+	}
+	if len(config.Ports) != tpLinkSimulatorPortsCount || !reflect.DeepEqual(config.VLANs, []int{1, 10}) {
+		t.Fatalf("unexpected switch config: %+v", config)
+	}
+	first, _ := config.GetPort("Gi1/0/1")
+	if first.PVID != 10 || !reflect.DeepEqual(first.TaggedVLANs, []int{10}) || !first.POEEnabled || first.Speed != "auto" {
+		t.Errorf("unexpected first port config: %+v", first)
+	}
+	second, _ := config.GetPort("Gi1/0/2")
+	if second.POEEnabled || second.POEType != "poe+" {
+		t.Errorf("unexpected second port config: %+v", second)
+	}
+	sfp, _ := config.GetPort("Gi1/0/9")
+	if sfp.POEType != "none" {
+		t.Errorf("port without POE support has POE type %s", sfp.POEType)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_PortStatus(t *testing.T) {
+	tpLinkSimulator.SetLinkUp("Gi1/0/1", true)
+	status, err := tpLinkManager.GetPortStatus(context.Background(), "Gi1/0/1")
+	if err != nil {
+		t.Fatalf("get port status failed: %v", err)
+	}
+	if !status.AdminUp || !status.OperUp || status.Speed != "1000M" || status.POEPower == 0 {
+		t.Errorf("unexpected port status: %+v", status)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_TransactionCommit(t *testing.T) {
+	ctx := context.Background()
+	savesCount := tpLinkSimulator.SavesCount()
+	tx, err := tpLinkManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin failed: %v", err)
+	}
+	pvid := 20
+	description := "uplink port"
+	err = tx.Apply(ctx, domain.EthernetSwitchChangeset{
+		CreateVLANs: []int{20},
+		Ports: []domain.EthernetSwitchPortChanges{
+			{Name: "Gi1/0/3", AddUntaggedVLANs: []int{20}, RemoveVLANs: []int{1}, PVID: &pvid, Description: &description},
+		},
+	})
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/3")
+	if port.PVID != 20 || !reflect.DeepEqual(port.UntaggedVLANs, []int{20}) || port.Description != description {
+		t.Errorf("changeset is not applied: %+v", port)
+	}
+	if tpLinkSimulator.SavesCount() != savesCount+1 {
+		t.Errorf("configuration is not saved on commit")
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_TransactionRollback(t *testing.T) {
+	ctx := context.Background()
+	tpLinkSimulator.FailCommands("switchport pvid 30")
+	defer tpLinkSimulator.ClearFailures()
+	tx, err := tpLinkManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin failed: %v", err)
+	}
+	pvid := 30
+	shutdown := true
+	err = tx.Apply(ctx, domain.EthernetSwitchChangeset{
+		CreateVLANs: []int{30},
+		Ports: []domain.EthernetSwitchPortChanges{
+			{Name: "Gi1/0/9", AddTaggedVLANs: []int{30}, Shutdown: &shutdown},
+			{Name: "Gi1/0/4", AddUntaggedVLANs: []int{30}, PVID: &pvid},
+		},
+	})
+	if err == nil {
+		t.Fatalf("failed command is not reported")
+	}
+	err = tx.Rollback(ctx)
+	if err != nil {
+		t.Fatalf("rollback failed: %v", err)
+	}
+	if !reflect.DeepEqual(tpLinkSimulator.VLANs(), []int{1, 10, 20}) {
+		t.Errorf("created VLAN is not deleted on rollback: %v", tpLinkSimulator.VLANs())
+	}
+	for _, name := range []string{"Gi1/0/4", "Gi1/0/9"} {
+		port, _ := tpLinkSimulator.GetPort(name)
+		if port.PVID != 1 || port.Shutdown || !reflect.DeepEqual(port.UntaggedVLANs, []int{1}) || len(port.TaggedVLANs) != 0 {
+			t.Errorf("port %s is not reverted: %+v", name, port)
+		}
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_TransactionCommitFailure(t *testing.T) {
+	ctx := context.Background()
+	tpLinkSimulator.FailCommands("copy running-config")
+	defer tpLinkSimulator.ClearFailures()
+	tx, err := tpLinkManager.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin failed: %v", err)
+	}
+	description := "not saved"
+	err = tx.Apply(ctx, domain.EthernetSwitchChangeset{
+		CreateVLANs: []int{40},
+		Ports: []domain.EthernetSwitchPortChanges{
+			{Name: "Gi1/0/8", AddTaggedVLANs: []int{40}, Description: &description},
+		},
+	})
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	err = tx.Commit(ctx)
+	if err == nil {
+		t.Fatalf("failed save is not reported")
+	}
+	err = tx.Rollback(ctx)
+	if err != nil {
+		t.Fatalf("rollback after the failed commit failed: %v", err)
+	}
+	if !reflect.DeepEqual(tpLinkSimulator.VLANs(), []int{1, 10, 20}) {
+		t.Errorf("created VLAN is not deleted on rollback: %v", tpLinkSimulator.VLANs())
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/8")
+	if port.Description != "" || len(port.TaggedVLANs) != 0 {
+		t.Errorf("port is not reverted: %+v", port)
+	}
+	err = tx.Rollback(ctx)
+	if err == nil {
+		t.Errorf("finished transaction is rolled back again")
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_RunningConfig(t *testing.T) {
+	ctx := context.Background()
+	config, err := tpLinkManager.GetRunningConfig(ctx)
+	if err != nil {
+		t.Fatalf("get running config failed: %v", err)
+	}
+	if !strings.Contains(config, "vlan 20") || !strings.Contains(config, "interface gigabitEthernet 1/0/10") {
+		t.Fatalf("running config is not complete: %s", config)
+	}
+	err = tpLinkManager.DeleteVLAN(ctx, 10)
+	if err != nil {
+		t.Fatalf("delete VLAN failed: %v", err)
+	}
+	err = tpLinkManager.RestoreRunningConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("restore running config failed: %v", err)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/1")
+	if port.PVID != 10 || !reflect.DeepEqual(port.TaggedVLANs, []int{10}) {
+		t.Errorf("port configuration is not restored: %+v", port)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_RunningConfigRevertsChanges(t *testing.T) {
+	ctx := context.Background()
+	config, err := tpLinkManager.GetRunningConfig(ctx)
+	if err != nil {
+		t.Fatalf("get running config failed: %v", err)
+	}
+	vlans := tpLinkSimulator.VLANs()
+	backupPort, _ := tpLinkSimulator.GetPort("Gi1/0/3")
+	err = tpLinkManager.CreateVLAN(ctx, 30)
+	if err != nil {
+		t.Fatalf("create VLAN failed: %v", err)
+	}
+	err = tpLinkManager.AddTaggedVLANOnPort(ctx, "Gi1/0/3", 30)
+	if err != nil {
+		t.Fatalf("add tagged VLAN failed: %v", err)
+	}
+	err = tpLinkManager.SetPortDescription(ctx, "Gi1/0/3", "uplink")
+	if err != nil {
+		t.Fatalf("set port description failed: %v", err)
+	}
+	err = tpLinkManager.SetPortShutdown(ctx, "Gi1/0/3", true)
+	if err != nil {
+		t.Fatalf("shutdown port failed: %v", err)
+	}
+	err = tpLinkManager.SetPortSpeed(ctx, "Gi1/0/3", "100M", "full")
+	if err != nil {
+		t.Fatalf("set port speed failed: %v", err)
+	}
+	err = tpLinkManager.DisablePOEPort(ctx, "Gi1/0/3")
+	if err != nil {
+		t.Fatalf("disable POE failed: %v", err)
+	}
+	err = tpLinkManager.CreateLAG(ctx, 2, "active", []string{"Gi1/0/5", "Gi1/0/6"})
+	if err != nil {
+		t.Fatalf("create LAG failed: %v", err)
+	}
+	err = tpLinkManager.RestoreRunningConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("restore running config failed: %v", err)
+	}
+	if !reflect.DeepEqual(tpLinkSimulator.VLANs(), vlans) {
+		t.Errorf("VLANs are not restored: %v, expected %v", tpLinkSimulator.VLANs(), vlans)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/3")
+	if !reflect.DeepEqual(port, backupPort) {
+		t.Errorf("port configuration is not restored: %+v, expected %+v", port, backupPort)
+	}
+	for _, name := range []string{"Gi1/0/5", "Gi1/0/6"} {
+		port, _ = tpLinkSimulator.GetPort(name)
+		if port.LAG != 0 {
+			t.Errorf("port %s is left in the LAG %d", name, port.LAG)
+		}
+	}
+	if _, found := tpLinkSimulator.GetPort("Po2"); found {
+		t.Errorf("LAG is not removed")
+	}
+	//nothing to change
+	commandsCount := len(tpLinkSimulator.Commands())
+	err = tpLinkManager.RestoreRunningConfig(ctx, config)
+	if err != nil || len(tpLinkSimulator.Commands()) != commandsCount {
+		t.Errorf("restore of the current config changed the switch: %v", err)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_RunningConfigRestoreErrors(t *testing.T) {
+	ctx := context.Background()
+	config, err := tpLinkManager.GetRunningConfig(ctx)
+	if err != nil {
+		t.Fatalf("get running config failed: %v", err)
+	}
+	err = tpLinkManager.RestoreRunningConfig(ctx, "!TL-SG2210MP\n#\nend")
+	if err == nil {
+		t.Errorf("configuration without interfaces is restored")
+	}
+	err = tpLinkManager.RestoreRunningConfig(ctx, strings.Replace(config, "vlan 20", "vlan twenty", 1))
+	if err == nil {
+		t.Errorf("configuration with the wrong VLAN is restored")
+	}
+	err = tpLinkManager.SetPortDescription(ctx, "Gi1/0/4", "changed")
+	if err != nil {
+		t.Fatalf("set port description failed: %v", err)
+	}
+	tpLinkSimulator.FailCommands("no description")
+	defer tpLinkSimulator.ClearFailures()
+	err = tpLinkManager.RestoreRunningConfig(ctx, config)
+	if err == nil {
+		t.Errorf("failed restore returns no error")
+	}
+	tpLinkSimulator.ClearFailures()
+	err = tpLinkManager.RestoreRunningConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("restore running config failed: %v", err)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/4")
+	if port.Description != "" {
+		t.Errorf("port description is not restored: %s", port.Description)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_PortSpeed(t *testing.T) {
+	ctx := context.Background()
+	before, _ := tpLinkSimulator.GetPort("Gi1/0/7")
+	err := tpLinkManager.SetPortSpeed(ctx, "Gi1/0/7", "", "half")
+	if err != nil {
+		t.Fatalf("set port duplex failed: %v", err)
+	}
+	port, _ := tpLinkSimulator.GetPort("Gi1/0/7")
+	if port.Speed != before.Speed || port.Duplex != "Half" {
+		t.Errorf("unexpected port speed and duplex: %s, %s", port.Speed, port.Duplex)
+	}
+	commandsCount := len(tpLinkSimulator.Commands())
+	err = tpLinkManager.SetPortSpeed(ctx, "Gi1/0/7", "", "")
+	if err != nil || len(tpLinkSimulator.Commands()) != commandsCount {
+		t.Errorf("empty port speed and duplex are sent to the switch: %v", err)
+	}
+	err = tpLinkManager.SetPortSpeed(ctx, "Gi1/0/7", "100M", "")
+	if err != nil {
+		t.Fatalf("set port speed failed: %v", err)
+	}
+	port, _ = tpLinkSimulator.GetPort("Gi1/0/7")
+	if port.Speed != "100M" || port.Duplex != "Half" {
+		t.Errorf("unexpected port speed and duplex: %s, %s", port.Speed, port.Duplex)
+	}
+	err = tpLinkManager.SetPortSpeed(ctx, "Gi1/0/7", "auto", "auto")
+	if err != nil {
+		t.Errorf("reset port speed failed: %v", err)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_Firmware(t *testing.T) {
+	ctx := context.Background()
+	info, err := tpLinkManager.GetFirmwareInfo(ctx)
+	if err != nil || info.FirmwareVersion != SimulatorFirmwareVersion || info.HardwareVersion != "TL-SG2210MP 1.0" {
+		t.Errorf("unexpected firmware info %+v: %v", info, err)
+	}
+	err = tpLinkManager.UpgradeFirmware(ctx, "127.0.0.1", "firmware.bin")
+	if err != nil {
+		t.Errorf("firmware upgrade failed: %v", err)
+	}
+	//the session is reopened after the switch reboot
+	_, err = tpLinkManager.GetVLANs(ctx)
+	if err != nil {
+		t.Errorf("switch is not available after the reboot: %v", err)
+	}
+}
+
+func Test_TPLinkEthernetSwitchManager_Close(t *testing.T) {
+	tpLinkSimulator.Close()
+}
@@ -0,0 +1,976 @@
+package tests
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"rol/domain"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//SimulatorLogin login of the simulated switch
+	SimulatorLogin = "admin"
+	//SimulatorPassword password of the simulated switch
+	SimulatorPassword = "simulator" // pragma: allowlist secret
+	//SimulatorFirmwareVersion firmware version of the simulated switch
+	SimulatorFirmwareVersion = "2.0.0 Build 20220301 Rel.50122"
+
+	simulatorHostname    = "TL-SG2210MP"
+	simulatorPager       = "Press any key to continue (Q to quit)"
+	simulatorBadCommand  = "Error: Bad command"
+	simulatorDefaultVLAN = 1
+)
+
+const (
+	simulatorModeUser = iota
+	simulatorModePrivileged
+	simulatorModeConfig
+	simulatorModeInterface
+	simulatorModeVLAN
+)
+
+//SimulatedPortState state of the simulated switch port or LAG
+type SimulatedPortState struct {
+	//PVID port VLAN ID
+	PVID int
+	//TaggedVLANs sorted tagged VLANs of the port
+	TaggedVLANs []int
+	//UntaggedVLANs sorted untagged VLANs of the port
+	UntaggedVLANs []int
+	//POESupported port supports POE
+	POESupported bool
+	//POEEnabled POE is enabled on the port
+	POEEnabled bool
+	//Shutdown port is administratively down
+	Shutdown bool
+	//Description port description
+	Description string
+	//Speed port speed like "Auto" or "1000M"
+	Speed string
+	//Duplex port duplex: "Auto", "Full" or "Half"
+	Duplex string
+	//LAG number of the port channel of the port, 0 if the port isn't a member of the LAG
+	LAG int
+}
+
+type simulatedPort struct {
+	name     string
+	state    SimulatedPortState
+	vlans    map[int]string
+	linkUp   bool
+	counters simulatedPortCounters
+}
+
+//simulatedPortCounters traffic counters of the simulated port
+type simulatedPortCounters struct {
+	rxBytes  uint64
+	txBytes  uint64
+	rxErrors uint64
+	txErrors uint64
+}
+
+func newSimulatedPort(name string, poeSupported bool) *simulatedPort {
+	return &simulatedPort{
+		name: name,
+		state: SimulatedPortState{
+			PVID:         simulatorDefaultVLAN,
+			POESupported: poeSupported,
+			POEEnabled:   poeSupported,
+			Speed:        "Auto",
+			Duplex:       "Auto",
+		},
+		vlans: map[int]string{simulatorDefaultVLAN: "Untagged"},
+	}
+}
+
+//TPLinkSwitchSimulator in-process fake of the TP-Link switch CLI available by telnet. The simulator keeps VLANs,
+//PVID, POE and port settings in memory and answers the commands used by infrastructure.TPLinkEthernetSwitchManager,
+//so the switch manager and the services can be tested without the real switch.
+type TPLinkSwitchSimulator struct {
+	listener net.Listener
+	//pageLines count of the output lines after which the pager prompt is shown
+	pageLines int
+	mutex     sync.Mutex
+	vlans     map[int]bool
+	ports     []*simulatedPort
+	lags      map[int]*simulatedPort
+	//failing prefixes of the commands that are answered with the error
+	failing     []string
+	commands    []string
+	savesCount  int
+	connections map[net.Conn]bool
+	closed      bool
+	//lldpNeighbors neighbors returned by the lldp neighbor-information command
+	lldpNeighbors []domain.EthernetSwitchLLDPNeighbor
+	//macTable entries of the MAC address table
+	macTable []domain.EthernetSwitchMACEntry
+	//firmwareVersion current firmware version
+	firmwareVersion string
+	//upgradeVersion firmware version after the upgrade, the switch doesn't reboot on upgrade if it's empty
+	upgradeVersion string
+	//rebootDuration time while the switch doesn't accept connections after the upgrade
+	rebootDuration time.Duration
+	rebootingUntil time.Time
+}
+
+//NewTPLinkSwitchSimulator creates the simulator with ports named like "Gi1/0/1" and starts it on the random
+//port of the loopback interface
+//
+//Params:
+//	portsCount - count of the physical ports
+//	poePortsCount - count of the first physical ports that support POE
+//Return:
+//	*TPLinkSwitchSimulator - started simulator
+//	error - if an error occurs, otherwise nil
+func NewTPLinkSwitchSimulator(portsCount, poePortsCount int) (*TPLinkSwitchSimulator, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	simulator := &TPLinkSwitchSimulator{
+		listener:    listener,
+		pageLines:   20,
+		vlans:       map[int]bool{simulatorDefaultVLAN: true},
+		lags:        map[int]*simulatedPort{},
+		connections: map[net.Conn]bool{},
+
+		firmwareVersion: SimulatorFirmwareVersion,
+	}
+	for i := 1; i <= portsCount; i++ {
+		simulator.ports = append(simulator.ports, newSimulatedPort(fmt.Sprintf("Gi1/0/%d", i), i <= poePortsCount))
+	}
+	go simulator.serve()
+	return simulator, nil
+}
+
+//Address of the simulator with the port
+func (s *TPLinkSwitchSimulator) Address() string {
+	return s.listener.Addr().String()
+}
+
+//Port TCP port of the simulator
+func (s *TPLinkSwitchSimulator) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+//Close stops the simulator and closes all connections
+func (s *TPLinkSwitchSimulator) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	_ = s.listener.Close()
+	for conn := range s.connections {
+		_ = conn.Close()
+	}
+}
+
+//VLANs gets sorted IDs of the switch VLANs
+func (s *TPLinkSwitchSimulator) VLANs() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return sortedKeys(s.vlans)
+}
+
+//GetPort gets state of the physical port like "Gi1/0/1" or LAG like "Po1"
+//
+//Params:
+//	name - port name
+//Return:
+//	SimulatedPortState - port state
+//	bool - true if the port exists
+func (s *TPLinkSwitchSimulator) GetPort(name string) (SimulatedPortState, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	port := s.findPort(name)
+	if port == nil {
+		return SimulatedPortState{}, false
+	}
+	state := port.state
+	state.TaggedVLANs = []int{}
+	state.UntaggedVLANs = []int{}
+	for _, vlanID := range sortedKeys(port.vlans) {
+		if port.vlans[vlanID] == "Tagged" {
+			state.TaggedVLANs = append(state.TaggedVLANs, vlanID)
+		} else {
+			state.UntaggedVLANs = append(state.UntaggedVLANs, vlanID)
+		}
+	}
+	return state, true
+}
+
+//SetLinkUp sets the link state of the physical port
+func (s *TPLinkSwitchSimulator) SetLinkUp(name string, up bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if port := s.findPort(name); port != nil {
+		port.linkUp = up
+	}
+}
+
+//SetPortCounters sets the traffic counters of the physical port
+func (s *TPLinkSwitchSimulator) SetPortCounters(name string, rxBytes, txBytes, rxErrors, txErrors uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if port := s.findPort(name); port != nil {
+		port.counters = simulatedPortCounters{rxBytes: rxBytes, txBytes: txBytes, rxErrors: rxErrors, txErrors: txErrors}
+	}
+}
+
+//AddLLDPNeighbor adds neighbor discovered by LLDP on the port
+func (s *TPLinkSwitchSimulator) AddLLDPNeighbor(neighbor domain.EthernetSwitchLLDPNeighbor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lldpNeighbors = append(s.lldpNeighbors, neighbor)
+}
+
+//AddMACEntry adds entry to the MAC address table, MAC address is shown in the switch format like 00-0A-0B-0C-0D-0E
+func (s *TPLinkSwitchSimulator) AddMACEntry(entry domain.EthernetSwitchMACEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.macTable = append(s.macTable, entry)
+}
+
+//SetUpgrade makes the switch reboot on the firmware upgrade: all connections are closed, new connections are
+//refused during the reboot and then the switch reports the new firmware version
+func (s *TPLinkSwitchSimulator) SetUpgrade(version string, rebootDuration time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.upgradeVersion = version
+	s.rebootDuration = rebootDuration
+}
+
+//FirmwareVersion gets current firmware version
+func (s *TPLinkSwitchSimulator) FirmwareVersion() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.firmwareVersion
+}
+
+//FailCommands makes the simulator answer with the error to all commands that start with the prefix
+func (s *TPLinkSwitchSimulator) FailCommands(prefix string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failing = append(s.failing, prefix)
+}
+
+//ClearFailures removes all prefixes of the failing commands
+func (s *TPLinkSwitchSimulator) ClearFailures() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failing = nil
+}
+
+//Commands gets all successfully executed configuration commands
+func (s *TPLinkSwitchSimulator) Commands() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.commands...)
+}
+
+//SavesCount gets count of the running configuration saves to the startup configuration
+func (s *TPLinkSwitchSimulator) SavesCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.savesCount
+}
+
+func (s *TPLinkSwitchSimulator) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+			_ = conn.Close()
+			return
+		}
+		if time.Now().Before(s.rebootingUntil) {
+			s.mutex.Unlock()
+			_ = conn.Close()
+			continue
+		}
+		s.connections[conn] = true
+		s.mutex.Unlock()
+		go s.handle(conn)
+	}
+}
+
+//simulatorSession state of one CLI connection
+type simulatorSession struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mode   int
+	//iface - ports of the interface configuration mode
+	iface *simulatedPort
+}
+
+func (s *TPLinkSwitchSimulator) handle(conn net.Conn) {
+	defer func() {
+		s.mutex.Lock()
+		delete(s.connections, conn)
+		s.mutex.Unlock()
+		_ = conn.Close()
+	}()
+	session := &simulatorSession{conn: conn, reader: bufio.NewReader(conn), mode: simulatorModeUser}
+	if !s.logIn(session) {
+		return
+	}
+	for {
+		line, err := session.reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		if strings.HasPrefix(command, "firmware upgrade ") && !s.isFailing(command) {
+			s.upgradeFirmware(session, command)
+			return
+		}
+		output := s.execute(session, command)
+		if !s.write(session, command, output) {
+			return
+		}
+	}
+}
+
+func (s *TPLinkSwitchSimulator) logIn(session *simulatorSession) bool {
+	for {
+		_, err := session.conn.Write([]byte("\r\nUser Access Verification\r\n\r\nLogin:"))
+		if err != nil {
+			return false
+		}
+		login, err := session.reader.ReadString('\n')
+		if err != nil {
+			return false
+		}
+		_, err = session.conn.Write([]byte("Password:"))
+		if err != nil {
+			return false
+		}
+		password, err := session.reader.ReadString('\n')
+		if err != nil {
+			return false
+		}
+		if strings.TrimSpace(login) == SimulatorLogin && strings.TrimSpace(password) == SimulatorPassword {
+			_, err = session.conn.Write([]byte("\r\n" + s.prompt(session)))
+			return err == nil
+		}
+		_, err = session.conn.Write([]byte("\r\nLogin invalid.\r\n"))
+		if err != nil {
+			return false
+		}
+	}
+}
+
+func (s *TPLinkSwitchSimulator) upgradeFirmware(session *simulatorSession, command string) {
+	_, err := session.conn.Write([]byte(command + "\r\nIt will only upgrade the backup image. Continue? (Y/N):"))
+	if err != nil {
+		return
+	}
+	answer, err := session.reader.ReadString('\n')
+	if err != nil {
+		return
+	}
+	if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+		s.write(session, "", []string{"Upgrade is canceled."})
+		return
+	}
+	//the switch closes the connection when it starts rebooting
+	_, _ = session.conn.Write([]byte("Operation OK!\r\nRebooting...\r\n"))
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.upgradeVersion == "" {
+		return
+	}
+	s.firmwareVersion = s.upgradeVersion
+	s.rebootingUntil = time.Now().Add(s.rebootDuration)
+	for conn := range s.connections {
+		_ = conn.Close()
+	}
+}
+
+//write writes the command echo, the output with the pager and the prompt
+func (s *TPLinkSwitchSimulator) write(session *simulatorSession, command string, output []string) bool {
+	out := command + "\r\n"
+	for i, line := range output {
+		if s.pageLines > 0 && i > 0 && i%s.pageLines == 0 {
+			_, err := session.conn.Write([]byte(out + simulatorPager))
+			if err != nil {
+				return false
+			}
+			key, err := session.reader.ReadByte()
+			if err != nil {
+				return false
+			}
+			out = "\r\n"
+			if key == 'q' || key == 'Q' {
+				break
+			}
+		}
+		out += line + "\r\n"
+	}
+	_, err := session.conn.Write([]byte(out + "\r\n" + s.prompt(session)))
+	return err == nil
+}
+
+func (s *TPLinkSwitchSimulator) prompt(session *simulatorSession) string {
+	switch session.mode {
+	case simulatorModeUser:
+		return simulatorHostname + ">"
+	case simulatorModeConfig:
+		return simulatorHostname + "(config)#"
+	case simulatorModeInterface:
+		return simulatorHostname + "(config-if)#"
+	case simulatorModeVLAN:
+		return simulatorHostname + "(config-vlan)#"
+	}
+	return simulatorHostname + "#"
+}
+
+func (s *TPLinkSwitchSimulator) isFailing(command string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, prefix := range s.failing {
+		if strings.HasPrefix(command, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+//execute executes the command and returns its output lines
+func (s *TPLinkSwitchSimulator) execute(session *simulatorSession, command string) []string {
+	if command == "" {
+		return nil
+	}
+	if s.isFailing(command) {
+		return []string{"Error: Simulated failure of the command."}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if strings.HasPrefix(command, "show ") {
+		return s.show(strings.TrimPrefix(command, "show "))
+	}
+	var err error
+	switch session.mode {
+	case simulatorModeUser:
+		if command != "enable" {
+			return []string{simulatorBadCommand}
+		}
+		session.mode = simulatorModePrivileged
+		return nil
+	case simulatorModePrivileged:
+		err = s.executePrivileged(session, command)
+	case simulatorModeConfig:
+		err = s.executeConfig(session, command)
+	case simulatorModeInterface:
+		err = s.executeInterface(session, command)
+	case simulatorModeVLAN:
+		err = s.executeVLAN(session, command)
+	}
+	if err != nil {
+		return []string{"Error: " + err.Error()}
+	}
+	if session.mode != simulatorModePrivileged && command != "exit" && command != "end" {
+		s.commands = append(s.commands, command)
+	}
+	return nil
+}
+
+func (s *TPLinkSwitchSimulator) executePrivileged(session *simulatorSession, command string) error {
+	switch command {
+	case "enable":
+		return nil
+	case "config", "configure":
+		session.mode = simulatorModeConfig
+		return nil
+	case "copy running-config startup-config":
+		s.savesCount++
+		s.commands = append(s.commands, command)
+		return nil
+	}
+	return fmt.Errorf("Bad command")
+}
+
+func (s *TPLinkSwitchSimulator) executeConfig(session *simulatorSession, command string) error {
+	fields := strings.Fields(command)
+	switch {
+	case command == "end" || command == "exit":
+		session.mode = simulatorModePrivileged
+	case len(fields) == 2 && fields[0] == "vlan":
+		vlanID, err := parseVLANID(fields[1])
+		if err != nil {
+			return err
+		}
+		s.vlans[vlanID] = true
+		session.mode = simulatorModeVLAN
+	case len(fields) == 3 && fields[0] == "no" && fields[1] == "vlan":
+		vlanID, err := parseVLANID(fields[2])
+		if err != nil {
+			return err
+		}
+		if vlanID == simulatorDefaultVLAN || !s.vlans[vlanID] {
+			return fmt.Errorf("VLAN %d can't be deleted", vlanID)
+		}
+		delete(s.vlans, vlanID)
+		for _, port := range s.allPorts() {
+			delete(port.vlans, vlanID)
+			if port.state.PVID == vlanID {
+				port.state.PVID = simulatorDefaultVLAN
+			}
+		}
+	case len(fields) == 3 && fields[0] == "interface" && fields[1] == "gigabitEthernet":
+		port := s.findPort("Gi" + fields[2])
+		if port == nil {
+			return fmt.Errorf("Invalid port %s", fields[2])
+		}
+		session.iface = port
+		session.mode = simulatorModeInterface
+	case len(fields) == 3 && fields[0] == "interface" && fields[1] == "port-channel":
+		port := s.findPort("Po" + fields[2])
+		if port == nil {
+			return fmt.Errorf("Port-channel %s does not exist", fields[2])
+		}
+		session.iface = port
+		session.mode = simulatorModeInterface
+	default:
+		return fmt.Errorf("Bad command")
+	}
+	return nil
+}
+
+func (s *TPLinkSwitchSimulator) executeVLAN(session *simulatorSession, command string) error {
+	switch {
+	case command == "exit":
+		session.mode = simulatorModeConfig
+	case command == "end":
+		session.mode = simulatorModePrivileged
+	case strings.HasPrefix(command, "name "):
+	default:
+		return fmt.Errorf("Bad command")
+	}
+	return nil
+}
+
+func (s *TPLinkSwitchSimulator) executeInterface(session *simulatorSession, command string) error {
+	port := session.iface
+	fields := strings.Fields(command)
+	_, isLAG := s.lagOf(port)
+	switch {
+	case command == "exit":
+		session.mode = simulatorModeConfig
+		session.iface = nil
+	case command == "end":
+		session.mode = simulatorModePrivileged
+		session.iface = nil
+	case len(fields) == 6 && strings.HasPrefix(command, "switchport general allowed vlan "):
+		vlanID, err := parseVLANID(fields[4])
+		if err != nil {
+			return err
+		}
+		if !s.vlans[vlanID] {
+			return fmt.Errorf("VLAN %d does not exist", vlanID)
+		}
+		switch fields[5] {
+		case "tagged":
+			port.vlans[vlanID] = "Tagged"
+		case "untagged":
+			port.vlans[vlanID] = "Untagged"
+		default:
+			return fmt.Errorf("Bad command")
+		}
+	case len(fields) == 6 && strings.HasPrefix(command, "no switchport general allowed vlan "):
+		vlanID, err := parseVLANID(fields[5])
+		if err != nil {
+			return err
+		}
+		delete(port.vlans, vlanID)
+	case len(fields) == 3 && fields[0] == "switchport" && fields[1] == "pvid":
+		vlanID, err := parseVLANID(fields[2])
+		if err != nil {
+			return err
+		}
+		if !s.vlans[vlanID] {
+			return fmt.Errorf("VLAN %d does not exist", vlanID)
+		}
+		port.state.PVID = vlanID
+	case command == "shutdown" || command == "no shutdown":
+		port.state.Shutdown = command == "shutdown"
+	case command == "no description":
+		port.state.Description = ""
+	case strings.HasPrefix(command, "description "):
+		port.state.Description = strings.Trim(strings.TrimPrefix(command, "description "), "\"")
+	case len(fields) == 2 && fields[0] == "speed" && !isLAG:
+		port.state.Speed = fields[1] + "M"
+		if fields[1] == "auto" {
+			port.state.Speed = "Auto"
+		}
+	case len(fields) == 2 && fields[0] == "duplex" && !isLAG:
+		port.state.Duplex = strings.Title(fields[1])
+	case strings.HasPrefix(command, "power inline ") && !isLAG:
+		return s.executePOE(port, fields[2:])
+	case len(fields) == 4 && fields[0] == "channel-group" && fields[2] == "mode" && !isLAG:
+		groupID, err := strconv.Atoi(fields[1])
+		if err != nil || groupID < 1 {
+			return fmt.Errorf("Invalid port-channel %s", fields[1])
+		}
+		if s.lags[groupID] == nil {
+			s.lags[groupID] = newSimulatedPort(fmt.Sprintf("Po%d", groupID), false)
+		}
+		port.state.LAG = groupID
+	case command == "no channel-group" && !isLAG:
+		groupID := port.state.LAG
+		port.state.LAG = 0
+		for _, member := range s.ports {
+			if member.state.LAG == groupID {
+				return nil
+			}
+		}
+		//the port channel is removed with the last member
+		delete(s.lags, groupID)
+	default:
+		return fmt.Errorf("Bad command")
+	}
+	return nil
+}
+
+func (s *TPLinkSwitchSimulator) executePOE(port *simulatedPort, args []string) error {
+	if !port.state.POESupported {
+		return fmt.Errorf("This port does not support PoE")
+	}
+	switch {
+	case len(args) == 2 && args[0] == "supply" && (args[1] == "enable" || args[1] == "disable"):
+		port.state.POEEnabled = args[1] == "enable"
+	case len(args) == 2 && args[0] == "consumption":
+	default:
+		return fmt.Errorf("Bad command")
+	}
+	return nil
+}
+
+//show returns output of the show command
+func (s *TPLinkSwitchSimulator) show(args string) []string {
+	fields := strings.Fields(args)
+	switch {
+	case args == "vlan":
+		return s.showVLANs(sortedKeys(s.vlans))
+	case len(fields) == 3 && fields[0] == "vlan" && fields[1] == "id":
+		vlanID, err := parseVLANID(fields[2])
+		if err != nil || !s.vlans[vlanID] {
+			return []string{fmt.Sprintf("Error: VLAN %s does not exist.", fields[2])}
+		}
+		return s.showVLANs([]int{vlanID})
+	case args == "system-info":
+		return []string{
+			" System Description     - JetStream 8-Port Gigabit L2+ Managed Switch with 2 SFP Slots",
+			" System Name            - " + simulatorHostname,
+			" Hardware Version       - TL-SG2210MP 1.0",
+			" Firmware Version       - " + s.firmwareVersion,
+		}
+	case args == "running-config":
+		return s.runningConfig()
+	case args == "lldp neighbor-information":
+		return s.showLLDPNeighbors()
+	case args == "mac address-table":
+		return s.showMACTable()
+	}
+	if len(fields) < 2 || fields[0] != "interface" && fields[0] != "power" {
+		return []string{simulatorBadCommand}
+	}
+	ports, err := s.showPorts(fields)
+	if err != nil {
+		return []string{"Error: " + err.Error()}
+	}
+	switch {
+	case fields[0] == "interface" && fields[1] == "status":
+		return s.showInterfaceStatus(ports)
+	case fields[0] == "interface" && fields[1] == "configuration":
+		return s.showInterfaceConfiguration(ports)
+	case fields[0] == "interface" && fields[1] == "switchport" && len(ports) == 1:
+		return s.showSwitchport(ports[0])
+	case fields[0] == "interface" && fields[1] == "counters" && len(ports) == 1:
+		return s.showCounters(ports[0])
+	case strings.HasPrefix(args, "power inline configuration interface ") && len(ports) == 1:
+		return s.showPOE(ports[0], false)
+	case strings.HasPrefix(args, "power inline information interface ") && len(ports) == 1:
+		return s.showPOE(ports[0], true)
+	}
+	return []string{simulatorBadCommand}
+}
+
+//showPorts gets ports of the show command, all physical ports if the interface isn't set
+func (s *TPLinkSwitchSimulator) showPorts(fields []string) ([]*simulatedPort, error) {
+	for i := 0; i < len(fields)-1; i++ {
+		prefix := ""
+		switch fields[i] {
+		case "gigabitEthernet":
+			prefix = "Gi"
+		case "port-channel":
+			prefix = "Po"
+		default:
+			continue
+		}
+		port := s.findPort(prefix + fields[i+1])
+		if port == nil {
+			return nil, fmt.Errorf("Invalid port %s", fields[i+1])
+		}
+		return []*simulatedPort{port}, nil
+	}
+	return s.ports, nil
+}
+
+func (s *TPLinkSwitchSimulator) showVLANs(vlanIDs []int) []string {
+	out := []string{
+		"VLAN  Name                 Status    Ports",
+		"----- -------------------- --------- ----------------------------------------",
+	}
+	for _, vlanID := range vlanIDs {
+		members := []string{}
+		for _, port := range s.allPorts() {
+			if _, member := port.vlans[vlanID]; member && port.state.LAG == 0 {
+				members = append(members, port.name)
+			}
+		}
+		out = append(out, fmt.Sprintf("%-5d %-20s %-9s %s", vlanID, simulatorVLANName(vlanID), "active", strings.Join(members, ", ")))
+	}
+	return out
+}
+
+func (s *TPLinkSwitchSimulator) showLLDPNeighbors() []string {
+	out := []string{}
+	for _, port := range s.ports {
+		index := 0
+		for _, neighbor := range s.lldpNeighbors {
+			if neighbor.PortName != port.name {
+				continue
+			}
+			if index == 0 {
+				out = append(out, "LLDP Neighbor Information of port "+port.name)
+			}
+			index++
+			out = append(out,
+				fmt.Sprintf("  Neighbor index %d:", index),
+				"    Chassis ID:          "+neighbor.ChassisID,
+				"    Port ID:             "+neighbor.PortID,
+				"    System name:         "+neighbor.SystemName,
+				"    Port description:    "+neighbor.PortDescription,
+				"    Management address:  "+neighbor.ManagementAddress,
+			)
+		}
+	}
+	return out
+}
+
+func (s *TPLinkSwitchSimulator) showMACTable() []string {
+	out := []string{
+		"MAC Address        VLAN  Port     Type     Aging",
+		"-----------------  ----  -------  -------  -----",
+	}
+	for _, entry := range s.macTable {
+		mac := strings.ToUpper(strings.ReplaceAll(entry.MAC, ":", "-"))
+		out = append(out, fmt.Sprintf("%-18s %-5d %-8s %-8s %s", mac, entry.VLANID, entry.PortName, entry.Type, "Aging"))
+	}
+	return out
+}
+
+func (s *TPLinkSwitchSimulator) showCounters(port *simulatedPort) []string {
+	return []string{
+		"Port: " + port.name,
+		"Rx:",
+		fmt.Sprintf("  Octets: %d", port.counters.rxBytes),
+		fmt.Sprintf("  CRC Errors: %d", port.counters.rxErrors),
+		"Tx:",
+		fmt.Sprintf("  Octets: %d", port.counters.txBytes),
+		fmt.Sprintf("  Tx Errors: %d", port.counters.txErrors),
+	}
+}
+
+func (s *TPLinkSwitchSimulator) showInterfaceStatus(ports []*simulatedPort) []string {
+	out := []string{
+		"Port      Status     Speed    Duplex    FlowCtrl   Active-Medium",
+		"--------  ---------  -------  --------  --------   -------------",
+	}
+	for _, port := range ports {
+		if port.linkUp && !port.state.Shutdown {
+			out = append(out, fmt.Sprintf("%-9s %-10s %-8s %-9s %-10s %s", port.name, "LinkUp", "1000M", "Full", "Disable", "Copper"))
+		} else {
+			out = append(out, fmt.Sprintf("%-9s %-10s %-8s %-9s %-10s %s", port.name, "LinkDown", "N/A", "N/A", "N/A", "Copper"))
+		}
+	}
+	return out
+}
+
+func (s *TPLinkSwitchSimulator) showInterfaceConfiguration(ports []*simulatedPort) []string {
+	out := []string{
+		"Port      State    Speed   Duplex  FlowCtrl  Description",
+		"-------   -------  ------  ------  --------  -----------",
+	}
+	for _, port := range ports {
+		state := "Enable"
+		if port.state.Shutdown {
+			state = "Disable"
+		}
+		out = append(out, strings.TrimRight(fmt.Sprintf("%-9s %-8s %-7s %-7s %-9s %s", port.name, state,
+			port.state.Speed, port.state.Duplex, "Disable", port.state.Description), " "))
+	}
+	return out
+}
+
+func (s *TPLinkSwitchSimulator) showSwitchport(port *simulatedPort) []string {
+	lag := "N/A"
+	if port.state.LAG != 0 {
+		lag = fmt.Sprintf("Po%d", port.state.LAG)
+	}
+	out := []string{
+		"Port: " + port.name,
+		"Type: General",
+		fmt.Sprintf("PVID: %d", port.state.PVID),
+		"Acceptable frame type: All",
+		"Ingress Checking: Enable",
+		"Link Type: General",
+		"Member in LAG: " + lag,
+		"",
+		"Vlan   Name                 Egress-rule",
+		"------ -------------------- -----------",
+	}
+	for _, vlanID := range sortedKeys(port.vlans) {
+		out = append(out, fmt.Sprintf("%-6d %-20s %s", vlanID, simulatorVLANName(vlanID), port.vlans[vlanID]))
+	}
+	return out
+}
+
+func (s *TPLinkSwitchSimulator) showPOE(port *simulatedPort, information bool) []string {
+	if !port.state.POESupported {
+		return []string{"Error: This port does not support PoE."}
+	}
+	if information {
+		powered := port.state.POEEnabled && port.linkUp && !port.state.Shutdown
+		line := fmt.Sprintf("%-10s %-13s %-9s %-9s %-12s %s", port.name, "OFF", "Class0", "0.0", "0", "0.0")
+		if powered {
+			line = fmt.Sprintf("%-10s %-13s %-9s %-9s %-12s %s", port.name, "ON", "Class4", "4.2", "80", "53.1")
+		}
+		return []string{
+			"Interface  Power-Status  PD-Class  Power(W)  Current(mA)  Voltage(V)",
+			"---------  ------------  --------  --------  -----------  ----------",
+			line,
+		}
+	}
+	status := "disable"
+	if port.state.POEEnabled {
+		status = "enable"
+	}
+	return []string{
+		"Interface  PoE-Status  PoE-Prority  Power-Limit(W)  Time-Range  PD-Class",
+		"---------  ----------  -----------  --------------  ----------  --------",
+		fmt.Sprintf("%-10s %-11s %-12s %-15s %-11s %s", port.name, status, "Low", "Class4", "No-Limit", "Auto"),
+	}
+}
+
+//runningConfig returns configuration commands that reproduce the current state
+func (s *TPLinkSwitchSimulator) runningConfig() []string {
+	out := []string{"!" + simulatorHostname, "#"}
+	for _, vlanID := range sortedKeys(s.vlans) {
+		if vlanID == simulatorDefaultVLAN {
+			continue
+		}
+		out = append(out, fmt.Sprintf("vlan %d", vlanID), fmt.Sprintf(" name \"%s\"", simulatorVLANName(vlanID)), "#")
+	}
+	for _, port := range s.allPorts() {
+		out = append(out, "interface "+simulatorInterface(port.name))
+		if port.state.LAG != 0 {
+			out = append(out, fmt.Sprintf("  channel-group %d mode active", port.state.LAG))
+		}
+		if _, member := port.vlans[simulatorDefaultVLAN]; !member {
+			out = append(out, fmt.Sprintf("  no switchport general allowed vlan %d", simulatorDefaultVLAN))
+		}
+		for _, vlanID := range sortedKeys(port.vlans) {
+			if vlanID != simulatorDefaultVLAN || port.vlans[vlanID] != "Untagged" {
+				out = append(out, fmt.Sprintf("  switchport general allowed vlan %d %s", vlanID, strings.ToLower(port.vlans[vlanID])))
+			}
+		}
+		if port.state.PVID != simulatorDefaultVLAN {
+			out = append(out, fmt.Sprintf("  switchport pvid %d", port.state.PVID))
+		}
+		if port.state.POESupported && !port.state.POEEnabled {
+			out = append(out, "  power inline supply disable")
+		}
+		if port.state.Speed != "Auto" {
+			out = append(out, "  speed "+strings.TrimSuffix(port.state.Speed, "M"))
+		}
+		if port.state.Duplex != "Auto" {
+			out = append(out, "  duplex "+strings.ToLower(port.state.Duplex))
+		}
+		if port.state.Description != "" {
+			out = append(out, fmt.Sprintf("  description \"%s\"", port.state.Description))
+		}
+		if port.state.Shutdown {
+			out = append(out, "  shutdown")
+		}
+		out = append(out, "#")
+	}
+	return append(out, "end")
+}
+
+//allPorts returns physical ports and LAGs sorted by the group number
+func (s *TPLinkSwitchSimulator) allPorts() []*simulatedPort {
+	ports := append([]*simulatedPort{}, s.ports...)
+	for _, groupID := range sortedKeys(s.lags) {
+		ports = append(ports, s.lags[groupID])
+	}
+	return ports
+}
+
+func (s *TPLinkSwitchSimulator) findPort(name string) *simulatedPort {
+	for _, port := range s.allPorts() {
+		if port.name == name {
+			return port
+		}
+	}
+	return nil
+}
+
+func (s *TPLinkSwitchSimulator) lagOf(port *simulatedPort) (int, bool) {
+	for groupID, lag := range s.lags {
+		if lag == port {
+			return groupID, true
+		}
+	}
+	return 0, false
+}
+
+func simulatorInterface(name string) string {
+	if strings.HasPrefix(name, "Po") {
+		return "port-channel " + strings.TrimPrefix(name, "Po")
+	}
+	return "gigabitEthernet " + strings.TrimPrefix(name, "Gi")
+}
+
+func simulatorVLANName(vlanID int) string {
+	if vlanID == simulatorDefaultVLAN {
+		return "System-VLAN"
+	}
+	return fmt.Sprintf("VLAN%04d", vlanID)
+}
+
+func parseVLANID(value string) (int, error) {
+	vlanID, err := strconv.Atoi(value)
+	if err != nil || vlanID < 1 || vlanID > 4094 {
+		return 0, fmt.Errorf("Invalid VLAN ID %s", value)
+	}
+	return vlanID, nil
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := []int{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
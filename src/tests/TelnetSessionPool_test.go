@@ -0,0 +1,309 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"rol/domain"
+	"rol/infrastructure"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const telnetPoolTestPrompt = "> "
+
+//telnetPoolTestServer line based CLI that answers each command with the echo and the prompt
+type telnetPoolTestServer struct {
+	listener    net.Listener
+	mutex       sync.Mutex
+	connections map[net.Conn]bool
+	opened      int
+	commands    []string
+}
+
+func newTelnetPoolTestServer(t *testing.T) *telnetPoolTestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("start CLI server failed: %v", err)
+	}
+	server := &telnetPoolTestServer{listener: listener, connections: map[net.Conn]bool{}}
+	go server.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		server.closeConnections()
+	})
+	return server
+}
+
+func (s *telnetPoolTestServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mutex.Lock()
+		s.connections[conn] = true
+		s.opened++
+		s.mutex.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *telnetPoolTestServer) handle(conn net.Conn) {
+	defer func() {
+		s.mutex.Lock()
+		delete(s.connections, conn)
+		s.mutex.Unlock()
+		_ = conn.Close()
+	}()
+	_, err := conn.Write([]byte(telnetPoolTestPrompt))
+	if err != nil {
+		return
+	}
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		s.mutex.Lock()
+		s.commands = append(s.commands, command)
+		s.mutex.Unlock()
+		_, err = conn.Write([]byte(command + "\r\n" + telnetPoolTestPrompt))
+		if err != nil {
+			return
+		}
+	}
+}
+
+//closeConnections closes all connections on the server side, so the pool sessions become broken
+func (s *telnetPoolTestServer) closeConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for conn := range s.connections {
+		_ = conn.Close()
+	}
+}
+
+func (s *telnetPoolTestServer) openedCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.opened
+}
+
+func (s *telnetPoolTestServer) connectionsCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.connections)
+}
+
+func (s *telnetPoolTestServer) commandsCount(command string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	count := 0
+	for _, executed := range s.commands {
+		if executed == command {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *telnetPoolTestServer) newPool(config domain.EthernetSwitchSessionConfig) *infrastructure.TelnetSessionPool {
+	isPrompt := func(out string) bool {
+		return strings.HasSuffix(out, telnetPoolTestPrompt)
+	}
+	open := func(ctx context.Context) (*infrastructure.TelnetSession, error) {
+		conn := infrastructure.NewTelnetConnection()
+		err := conn.Connect(ctx, s.listener.Addr().String())
+		if err != nil {
+			return nil, err
+		}
+		_, err = conn.ReadUntil(isPrompt)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return infrastructure.NewTelnetSession(conn, isPrompt, nil), nil
+	}
+	ping := func(session *infrastructure.TelnetSession) error {
+		_, err := session.Exec("ping")
+		return err
+	}
+	return infrastructure.NewTelnetSessionPool(config, open, ping)
+}
+
+//waitForCondition waits until the condition is true or the timeout is expired
+func waitForCondition(timeout time.Duration, condition func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return condition()
+}
+
+func Test_TelnetSessionPool_ConcurrentAcquire(t *testing.T) {
+	server := newTelnetPoolTestServer(t)
+	pool := server.newPool(domain.EthernetSwitchSessionConfig{PoolSize: 2})
+	defer pool.Close()
+	mutex := sync.Mutex{}
+	active, maxActive := 0, 0
+	wg := sync.WaitGroup{}
+	errorsChan := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session, err := pool.Acquire(context.Background())
+			if err != nil {
+				errorsChan <- err
+				return
+			}
+			mutex.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mutex.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			_, err = session.Exec("show")
+			mutex.Lock()
+			active--
+			mutex.Unlock()
+			pool.Release(session)
+			if err != nil {
+				errorsChan <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errorsChan)
+	for err := range errorsChan {
+		t.Errorf("concurrent session usage failed: %v", err)
+	}
+	if maxActive != 2 {
+		t.Errorf("unexpected count of simultaneously used sessions: %d", maxActive)
+	}
+	if server.openedCount() != 2 || server.commandsCount("show") != 8 {
+		t.Errorf("sessions are not reused: %d opened, %d commands", server.openedCount(), server.commandsCount("show"))
+	}
+	//all sessions are busy, so the caller waits until the context is done
+	first, _ := pool.Acquire(context.Background())
+	second, _ := pool.Acquire(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := pool.Acquire(ctx)
+	if err == nil {
+		t.Errorf("session is acquired when all sessions are busy")
+	}
+	pool.Release(first)
+	pool.Release(second)
+}
+
+func Test_TelnetSessionPool_DoRetriesBrokenSession(t *testing.T) {
+	server := newTelnetPoolTestServer(t)
+	pool := server.newPool(domain.EthernetSwitchSessionConfig{})
+	defer pool.Close()
+	exec := func(session *infrastructure.TelnetSession) error {
+		_, err := session.Exec("show")
+		return err
+	}
+	err := pool.Do(context.Background(), exec)
+	if err != nil {
+		t.Fatalf("do failed: %v", err)
+	}
+	//idle session is broken by the device, the action is retried with the new session
+	server.closeConnections()
+	err = pool.Do(context.Background(), exec)
+	if err != nil {
+		t.Errorf("action is not retried with the new session: %v", err)
+	}
+	if server.openedCount() != 2 {
+		t.Errorf("unexpected count of opened sessions: %d", server.openedCount())
+	}
+	//error that doesn't break the session is not retried
+	calls := 0
+	actionErr := errors.New("action failed")
+	err = pool.Do(context.Background(), func(session *infrastructure.TelnetSession) error {
+		calls++
+		return actionErr
+	})
+	if err != actionErr || calls != 1 {
+		t.Errorf("action error is retried: %v, %d calls", err, calls)
+	}
+	//new session is not retried, only the reused one can be outdated
+	freshPool := server.newPool(domain.EthernetSwitchSessionConfig{})
+	defer freshPool.Close()
+	calls = 0
+	err = freshPool.Do(context.Background(), func(session *infrastructure.TelnetSession) error {
+		calls++
+		session.Close()
+		return actionErr
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("action with the new session is retried: %v, %d calls", err, calls)
+	}
+}
+
+func Test_TelnetSessionPool_IdleExpiryAndKeepalive(t *testing.T) {
+	server := newTelnetPoolTestServer(t)
+	pool := server.newPool(domain.EthernetSwitchSessionConfig{IdleTimeout: 2, KeepaliveInterval: 1})
+	defer pool.Close()
+	err := pool.Do(context.Background(), func(session *infrastructure.TelnetSession) error {
+		_, err := session.Exec("show")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("do failed: %v", err)
+	}
+	if !waitForCondition(1500*time.Millisecond, func() bool { return server.commandsCount("ping") > 0 }) {
+		t.Errorf("idle session is not pinged")
+	}
+	if server.connectionsCount() != 1 {
+		t.Errorf("pinged session is closed before the idle timeout")
+	}
+	if !waitForCondition(3*time.Second, func() bool { return server.connectionsCount() == 0 }) {
+		t.Fatalf("idle session is not closed after the idle timeout")
+	}
+	err = pool.Do(context.Background(), func(session *infrastructure.TelnetSession) error {
+		_, err := session.Exec("show")
+		return err
+	})
+	if err != nil || server.openedCount() != 2 {
+		t.Errorf("new session is not opened after the idle session expiry: %v, %d opened", err, server.openedCount())
+	}
+}
+
+func Test_TelnetSessionPool_Close(t *testing.T) {
+	server := newTelnetPoolTestServer(t)
+	pool := server.newPool(domain.EthernetSwitchSessionConfig{PoolSize: 2, KeepaliveInterval: 1})
+	idle, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	busy, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	pool.Release(idle)
+	pool.Close()
+	if !waitForCondition(time.Second, func() bool { return server.connectionsCount() == 1 }) {
+		t.Errorf("idle session is not closed")
+	}
+	//session that is released after the pool closing is closed too
+	pool.Release(busy)
+	if !waitForCondition(time.Second, func() bool { return server.connectionsCount() == 0 }) {
+		t.Errorf("session released after the pool closing is not closed")
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if server.commandsCount("ping") != 0 {
+		t.Errorf("keepalive is not stopped after the pool closing")
+	}
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/webapi"
+)
+
+//EthernetSwitchConfigBackupGinController ethernet switch configuration backup GIN controller
+type EthernetSwitchConfigBackupGinController struct {
+	service *services.EthernetSwitchService
+	logger  *logrus.Logger
+}
+
+//NewEthernetSwitchConfigBackupGinController ethernet switch configuration backup controller constructor.
+//Parameters pass through DI
+//Params
+//	service - ethernet switch service
+//	log - logrus logger
+//Return
+//	*EthernetSwitchConfigBackupGinController - instance of ethernet switch configuration backup controller
+func NewEthernetSwitchConfigBackupGinController(service *services.EthernetSwitchService, log *logrus.Logger) *EthernetSwitchConfigBackupGinController {
+	return &EthernetSwitchConfigBackupGinController{
+		service: service,
+		logger:  log,
+	}
+}
+
+//RegisterEthernetSwitchConfigBackupGinController registers controller for ethernet switch configuration backups via api
+func RegisterEthernetSwitchConfigBackupGinController(controller *EthernetSwitchConfigBackupGinController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+	groupRoute.GET("/ethernet-switch/:id/backup/", controller.GetList)
+	groupRoute.POST("/ethernet-switch/:id/backup/", controller.Create)
+	groupRoute.GET("/ethernet-switch/:id/backup/:backupID", controller.GetByID)
+	groupRoute.GET("/ethernet-switch/:id/backup/:backupID/diff/:toBackupID", controller.Diff)
+	groupRoute.POST("/ethernet-switch/:id/backup/:backupID/restore", controller.Restore)
+}
+
+//GetList get list of switch configuration versions with pagination
+//	Params
+//	ctx - gin context
+// @Summary Get paginated list of switch configuration versions
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce json
+// @param 	 id 			 path   string  true "Ethernet switch ID"
+// @param	 orderBy		 query	string	false	"Order by field"
+// @param	 orderDirection	 query	string	false	"'asc' or 'desc' for ascending or descending order"
+// @param	 page			 query	int		false	"Page number"
+// @param	 pageSize		 query	int		false	"Number of entities per page"
+// @Success 200 {object} dtos.PaginatedItemsDto[dtos.EthernetSwitchConfigBackupDto]
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/backup [get]
+func (e *EthernetSwitchConfigBackupGinController) GetList(ctx *gin.Context) {
+	req := newPaginatedRequestStructForParsing(1, 10, "CreatedAt", "desc", "")
+	err := parseGinRequest(ctx, &req)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	paginatedList, err := e.service.GetConfigBackups(ctx, switchID, req.OrderBy, req.OrderDirection, req.Page, req.PageSize)
+	handleWithData(ctx, err, paginatedList)
+}
+
+//Create backup of the switch running configuration
+//	Params
+//	ctx - gin context
+// @Summary Download running configuration from the switch and store it as a new version if it's changed
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce json
+// @Param 	id 		path 		string true "Ethernet switch ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchConfigBackupDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/backup [post]
+func (e *EthernetSwitchConfigBackupGinController) Create(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.BackupConfig(ctx, switchID)
+	handleWithData(ctx, err, dto)
+}
+
+//GetByID get switch configuration version with configuration text
+//	Params
+//	ctx - gin context
+// @Summary Get ethernet switch configuration version by id
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id			path		string		true	"Ethernet switch ID"
+// @param	backupID	path		string		true	"Configuration backup ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchConfigBackupContentDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/backup/{backupID} [get]
+func (e *EthernetSwitchConfigBackupGinController) GetByID(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	backupID, err := parseUUIDParam(ctx, "backupID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetConfigBackupByID(ctx, switchID, backupID)
+	handleWithData(ctx, err, dto)
+}
+
+//Diff compare two switch configuration versions
+//	Params
+//	ctx - gin context
+// @Summary Get unified diff between two ethernet switch configuration versions
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id			path		string		true	"Ethernet switch ID"
+// @param	backupID	path		string		true	"Original configuration backup ID"
+// @param	toBackupID	path		string		true	"Changed configuration backup ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchConfigBackupDiffDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/backup/{backupID}/diff/{toBackupID} [get]
+func (e *EthernetSwitchConfigBackupGinController) Diff(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	fromID, err := parseUUIDParam(ctx, "backupID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	toID, err := parseUUIDParam(ctx, "toBackupID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.DiffConfigBackups(ctx, switchID, fromID, toID)
+	handleWithData(ctx, err, dto)
+}
+
+//Restore apply switch configuration version to the switch
+//	Params
+//	ctx - gin context
+// @Summary Restore ethernet switch configuration version, current configuration is backed up before
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id			path		string		true	"Ethernet switch ID"
+// @param	backupID	path		string		true	"Configuration backup ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchConfigBackupDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/backup/{backupID}/restore [post]
+func (e *EthernetSwitchConfigBackupGinController) Restore(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	backupID, err := parseUUIDParam(ctx, "backupID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.RestoreConfigBackup(ctx, switchID, backupID)
+	handleWithData(ctx, err, dto)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//EthernetSwitchFirmwareGinController ethernet switch firmware GIN controller
+type EthernetSwitchFirmwareGinController struct {
+	service *services.EthernetSwitchFirmwareService
+	logger  *logrus.Logger
+}
+
+//NewEthernetSwitchFirmwareGinController ethernet switch firmware controller constructor.
+//Parameters pass through DI
+//Params
+//	service - ethernet switch firmware service
+//	log - logrus logger
+//Return
+//	*EthernetSwitchFirmwareGinController - instance of ethernet switch firmware controller
+func NewEthernetSwitchFirmwareGinController(service *services.EthernetSwitchFirmwareService, log *logrus.Logger) *EthernetSwitchFirmwareGinController {
+	return &EthernetSwitchFirmwareGinController{
+		service: service,
+		logger:  log,
+	}
+}
+
+//RegisterEthernetSwitchFirmwareGinController registers controller for ethernet switch firmware via api
+func RegisterEthernetSwitchFirmwareGinController(controller *EthernetSwitchFirmwareGinController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+	groupRoute.GET("/ethernet-switch/:id/firmware", controller.Get)
+	groupRoute.POST("/ethernet-switch/:id/firmware/upgrade", controller.StartUpgrade)
+	groupRoute.GET("/ethernet-switch/:id/firmware/upgrade", controller.GetUpgradeJob)
+}
+
+//Get switch firmware version and hardware revision
+//	Params
+//	ctx - gin context
+// @Summary Get ethernet switch firmware version and hardware revision
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchFirmwareDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/firmware [get]
+func (e *EthernetSwitchFirmwareGinController) Get(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetFirmware(ctx, switchID)
+	handleWithData(ctx, err, dto)
+}
+
+//StartUpgrade start switch firmware upgrade job
+//	Params
+//	ctx - gin context
+// @Summary Stage firmware image on the TFTP server and start ethernet switch firmware upgrade job
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Param 	request body 		dtos.EthernetSwitchFirmwareUpgradeDto true "Firmware upgrade parameters"
+// @Success 200 	{object} 	dtos.EthernetSwitchFirmwareJobDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/firmware/upgrade [post]
+func (e *EthernetSwitchFirmwareGinController) StartUpgrade(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.EthernetSwitchFirmwareUpgradeDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.StartUpgrade(ctx, switchID, reqDto)
+	handleWithData(ctx, err, dto)
+}
+
+//GetUpgradeJob get the last switch firmware upgrade job
+//	Params
+//	ctx - gin context
+// @Summary Get stage and progress of the last ethernet switch firmware upgrade job
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchFirmwareJobDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/firmware/upgrade [get]
+func (e *EthernetSwitchFirmwareGinController) GetUpgradeJob(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetUpgradeJob(ctx, switchID)
+	handleWithData(ctx, err, dto)
+}
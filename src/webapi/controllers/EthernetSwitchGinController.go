@@ -25,6 +25,10 @@ func RegisterEthernetSwitchController(controller *EthernetSwitchGinController, s
 	groupRoute.POST("/ethernet-switch", controller.Create)
 	groupRoute.PUT("/ethernet-switch/:id", controller.Update)
 	groupRoute.DELETE("/ethernet-switch/:id", controller.Delete)
+	groupRoute.POST("/ethernet-switch/:id/discover", controller.Discover)
+	groupRoute.GET("/ethernet-switch/:id/drift", controller.GetDrift)
+	groupRoute.POST("/ethernet-switch/:id/drift/apply-db", controller.ApplyDBToSwitch)
+	groupRoute.POST("/ethernet-switch/:id/drift/adopt-switch", controller.AdoptSwitchIntoDB)
 }
 
 //NewEthernetSwitchGinController ethernet switch controller constructor. Parameters pass through DI
@@ -187,3 +191,99 @@ func (e *EthernetSwitchGinController) GetSupportedModels(ctx *gin.Context) {
 	modelsDtoSlice := e.service.GetSupportedModels()
 	ctx.JSON(http.StatusOK, modelsDtoSlice)
 }
+
+//Discover import ports and VLANs from the switch
+//	Params
+//	ctx - gin context
+// @Summary	Import ports and VLANs from the ethernet switch configuration
+// @version	1.0
+// @Tags	ethernet-switch
+// @Accept	json
+// @Produce	json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Success	200		{object}	dtos.EthernetSwitchDiscoveryDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/discover [post]
+func (e *EthernetSwitchGinController) Discover(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.Discover(ctx, id)
+	handleWithData(ctx, err, dto)
+}
+
+//GetDrift get drift of the switch configuration
+//	Params
+//	ctx - gin context
+// @Summary	Get differences between the ethernet switch configuration and the stored one
+// @version	1.0
+// @Tags	ethernet-switch
+// @Accept	json
+// @Produce	json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Success	200		{object}	dtos.EthernetSwitchDriftDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/drift [get]
+func (e *EthernetSwitchGinController) GetDrift(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetDrift(ctx, id)
+	handleWithData(ctx, err, dto)
+}
+
+//ApplyDBToSwitch apply stored ports and VLANs to the switch
+//	Params
+//	ctx - gin context
+// @Summary	Change the ethernet switch configuration to match the stored ports and VLANs
+// @version	1.0
+// @Tags	ethernet-switch
+// @Accept	json
+// @Produce	json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Success	200		{object}	dtos.EthernetSwitchDriftDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/drift/apply-db [post]
+func (e *EthernetSwitchGinController) ApplyDBToSwitch(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.ApplyDBToSwitch(ctx, id)
+	handleWithData(ctx, err, dto)
+}
+
+//AdoptSwitchIntoDB adopt the switch configuration into stored ports and VLANs
+//	Params
+//	ctx - gin context
+// @Summary	Change the stored ports and VLANs to match the ethernet switch configuration
+// @version	1.0
+// @Tags	ethernet-switch
+// @Accept	json
+// @Produce	json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @Success	200		{object}	dtos.EthernetSwitchDriftDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/drift/adopt-switch [post]
+func (e *EthernetSwitchGinController) AdoptSwitchIntoDB(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.AdoptSwitchIntoDB(ctx, id)
+	handleWithData(ctx, err, dto)
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//EthernetSwitchLAGGinController ethernet switch LAG GIN controller
+type EthernetSwitchLAGGinController struct {
+	service *services.EthernetSwitchService
+	logger  *logrus.Logger
+}
+
+//NewEthernetSwitchLAGGinController ethernet switch LAG controller constructor. Parameters pass through DI
+//Params
+//	service - ethernet switch service
+//	log - logrus logger
+//Return
+//	*EthernetSwitchLAGGinController - instance of ethernet switch LAG controller
+func NewEthernetSwitchLAGGinController(service *services.EthernetSwitchService, log *logrus.Logger) *EthernetSwitchLAGGinController {
+	ethernetSwitchLAGController := &EthernetSwitchLAGGinController{
+		service: service,
+		logger:  log,
+	}
+	return ethernetSwitchLAGController
+}
+
+//RegisterEthernetSwitchLAGGinController registers controller for ethernet switch LAGs via api
+func RegisterEthernetSwitchLAGGinController(controller *EthernetSwitchLAGGinController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+	groupRoute.GET("/ethernet-switch/:id/lag/", controller.GetList)
+	groupRoute.GET("/ethernet-switch/:id/lag/:lagID", controller.GetByID)
+	groupRoute.POST("/ethernet-switch/:id/lag/", controller.Create)
+	groupRoute.PUT("/ethernet-switch/:id/lag/:lagID", controller.Update)
+	groupRoute.DELETE("/ethernet-switch/:id/lag/:lagID", controller.Delete)
+}
+
+//GetList get list of switch LAGs with search and pagination
+//	Params
+//	ctx - gin context
+// @Summary Get paginated list of switch LAGs
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce json
+// @param 	 id 			 path   string  true "Ethernet switch ID"
+// @param	 orderBy		 query	string	false	"Order by field"
+// @param	 orderDirection	 query	string	false	"'asc' or 'desc' for ascending or descending order"
+// @param	 search			 query	string	false	"Searchable value in entity"
+// @param	 page			 query	int		false	"Page number"
+// @param	 pageSize		 query	int		false	"Number of entities per page"
+// @Success 200 {object} dtos.PaginatedItemsDto[dtos.EthernetSwitchLAGDto]
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/lag [get]
+func (e *EthernetSwitchLAGGinController) GetList(ctx *gin.Context) {
+	req := newPaginatedRequestStructForParsing(1, 10, "GroupID", "asc", "")
+	err := parseGinRequest(ctx, &req)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	paginatedList, err := e.service.GetLAGs(ctx, switchID, req.Search, req.OrderBy, req.OrderDirection,
+		req.Page, req.PageSize)
+	handleWithData(ctx, err, paginatedList)
+}
+
+//GetByID get switch LAG by id
+//	Params
+//	ctx - gin context
+// @Summary Get ethernet switch LAG by id
+// @version 1.0
+// @Tags 	ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @param	lagID	path		string		true	"Ethernet switch LAG ID"
+// @Success 200 	{object} 	dtos.EthernetSwitchLAGDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/lag/{lagID} [get]
+func (e *EthernetSwitchLAGGinController) GetByID(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	lagID, err := parseUUIDParam(ctx, "lagID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetLAGByID(ctx, switchID, lagID)
+	handleWithData(ctx, err, dto)
+}
+
+//Create new switch LAG
+//	Params
+//	ctx - gin context
+// @Summary Create new ethernet switch LAG, LAG ID can be used as a port in VLANs
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce json
+// @Param 	id 		path 		string true "Ethernet switch ID"
+// @Param 	request body 		dtos.EthernetSwitchLAGCreateDto true "Ethernet switch LAG fields"
+// @Success 200 	{object} 	dtos.EthernetSwitchLAGDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/lag [post]
+func (e *EthernetSwitchLAGGinController) Create(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.EthernetSwitchLAGCreateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.CreateLAG(ctx, switchID, reqDto)
+	handleWithData(ctx, err, dto)
+}
+
+//Update switch LAG by id
+//	Params
+//	ctx - gin context
+// @Summary Updates ethernet switch LAG by id
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce  json
+// @param id path string true "Ethernet switch ID"
+// @param lagID path string true "Ethernet switch LAG ID"
+// @Param request body dtos.EthernetSwitchLAGUpdateDto true "Ethernet switch LAG fields"
+// @Success 200 {object} dtos.EthernetSwitchLAGDto
+// @Failure		400		{object}	dtos.ValidationErrorDto
+// @Failure		404		"Not Found"
+// @Failure		500		"Internal Server Error"
+// @router /ethernet-switch/{id}/lag/{lagID} [put]
+func (e *EthernetSwitchLAGGinController) Update(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.EthernetSwitchLAGUpdateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	lagID, err := parseUUIDParam(ctx, "lagID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.UpdateLAG(ctx, switchID, lagID, reqDto)
+	handleWithData(ctx, err, dto)
+}
+
+//Delete switch LAG, LAG is removed from VLANs
+//	Params
+//	ctx - gin context
+// @Summary Delete ethernet switch LAG by id
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce	json
+// @param	id		path	string		true	"Ethernet switch ID"
+// @param	lagID	path	string		true	"Ethernet switch LAG ID"
+// @Success 204 	"OK, but No Content"
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/lag/{lagID} [delete]
+func (e *EthernetSwitchLAGGinController) Delete(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	lagID, err := parseUUIDParam(ctx, "lagID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	err = e.service.DeleteLAG(ctx, switchID, lagID)
+	handle(ctx, err)
+}
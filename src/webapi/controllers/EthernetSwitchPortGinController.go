@@ -39,6 +39,10 @@ func RegisterEthernetSwitchPortController(controller *EthernetSwitchPortGinContr
 	groupRoute.POST("/ethernet-switch/:id/port/", controller.CreatePort)
 	groupRoute.PUT("/ethernet-switch/:id/port/:portID", controller.UpdatePort)
 	groupRoute.DELETE("/ethernet-switch/:id/port/:portID", controller.DeletePort)
+	groupRoute.GET("/ethernet-switch/:id/port/:portID/neighbors", controller.GetPortNeighbors)
+	groupRoute.GET("/ethernet-switch/:id/port/:portID/status", controller.GetPortStatus)
+	groupRoute.POST("/ethernet-switch/:id/port/bulk", controller.SwitchPortsBulkOperation)
+	groupRoute.POST("/ethernet-switch/port/bulk", controller.PortsBulkOperation)
 }
 
 //GetPortByID Get ethernet switch port by id
@@ -202,3 +206,116 @@ func (e *EthernetSwitchPortGinController) DeletePort(ctx *gin.Context) {
 	err = e.service.DeletePort(ctx, switchID, portID)
 	handle(ctx, err)
 }
+
+//GetPortNeighbors Get devices connected to the ethernet switch port
+//Params
+//	ctx - gin context
+// @Summary Gets LLDP neighbors and MAC addresses on the ethernet switch port with suggested devices from DHCP leases
+// @version	1.0
+// @Tags	ethernet-switch
+// @Accept	json
+// @Produce	json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @param	portID	path		string		true	"Ethernet switch port ID"
+// @Success	200		{object}	dtos.EthernetSwitchPortNeighborsDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/port/{portID}/neighbors [get]
+func (e *EthernetSwitchPortGinController) GetPortNeighbors(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	portID, err := parseUUIDParam(ctx, "portID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetPortNeighbors(ctx, switchID, portID)
+	handleWithData(ctx, err, dto)
+}
+
+//GetPortStatus Get actual state of the ethernet switch port
+//Params
+//	ctx - gin context
+// @Summary Gets link status, speed, counters and POE power of the ethernet switch port
+// @version	1.0
+// @Tags	ethernet-switch
+// @Accept	json
+// @Produce	json
+// @param	id		path		string		true	"Ethernet switch ID"
+// @param	portID	path		string		true	"Ethernet switch port ID"
+// @Success	200		{object}	dtos.EthernetSwitchPortStatusDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/port/{portID}/status [get]
+func (e *EthernetSwitchPortGinController) GetPortStatus(ctx *gin.Context) {
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	portID, err := parseUUIDParam(ctx, "portID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := e.service.GetPortStatus(ctx, switchID, portID)
+	handleWithData(ctx, err, dto)
+}
+
+//SwitchPortsBulkOperation apply operation to the selected ports of the switch
+//	Params
+//	ctx - gin context
+// @Summary Apply POE, PVID or VLAN operation to the selected ports of the switch within one switch session
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce json
+// @param	id		path	string		true	"Ethernet switch ID"
+// @Param	request	body	dtos.EthernetSwitchPortBulkOperationDto	true	"Ports selector and operation"
+// @Success	200		{array}		dtos.EthernetSwitchPortBulkResultDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/{id}/port/bulk [post]
+func (e *EthernetSwitchPortGinController) SwitchPortsBulkOperation(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.EthernetSwitchPortBulkOperationDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	switchID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	results, err := e.service.SwitchPortsBulkOperation(ctx, switchID, reqDto)
+	handleWithData(ctx, err, results)
+}
+
+//PortsBulkOperation apply operation to the selected ports of many switches
+//	Params
+//	ctx - gin context
+// @Summary Apply POE, PVID or VLAN operation to the selected ports of many switches, one switch session per switch
+// @version 1.0
+// @Tags ethernet-switch
+// @Accept  json
+// @Produce json
+// @Param	request	body	dtos.EthernetSwitchPortBulkOperationDto	true	"Ports selector with switches and operation"
+// @Success	200		{array}		dtos.EthernetSwitchPortBulkResultDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router /ethernet-switch/port/bulk [post]
+func (e *EthernetSwitchPortGinController) PortsBulkOperation(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.EthernetSwitchPortBulkOperationDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	results, err := e.service.PortsBulkOperation(ctx, reqDto)
+	handleWithData(ctx, err, results)
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//FabricVLANGinController fabric VLAN GIN controller
+type FabricVLANGinController struct {
+	service *services.FabricVLANService
+	logger  *logrus.Logger
+}
+
+//NewFabricVLANGinController fabric VLAN controller constructor. Parameters pass through DI
+//Params
+//	service - fabric VLAN service
+//	log - logrus logger
+//Return
+//	*FabricVLANGinController - instance of fabric VLAN controller
+func NewFabricVLANGinController(service *services.FabricVLANService, log *logrus.Logger) *FabricVLANGinController {
+	return &FabricVLANGinController{
+		service: service,
+		logger:  log,
+	}
+}
+
+//RegisterFabricVLANGinController registers controller for fabric VLANs via api
+func RegisterFabricVLANGinController(controller *FabricVLANGinController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+	groupRoute.GET("/fabric-vlan/", controller.GetList)
+	groupRoute.GET("/fabric-vlan/:id", controller.GetByID)
+	groupRoute.POST("/fabric-vlan/", controller.Create)
+	groupRoute.DELETE("/fabric-vlan/:id", controller.Delete)
+}
+
+//GetList get list of fabric VLANs with search and pagination
+//	Params
+//	ctx - gin context
+// @Summary Get paginated list of fabric VLANs
+// @version 1.0
+// @Tags fabric-vlan
+// @Accept  json
+// @Produce json
+// @param	 orderBy		 query	string	false	"Order by field"
+// @param	 orderDirection	 query	string	false	"'asc' or 'desc' for ascending or descending order"
+// @param	 search			 query	string	false	"Searchable value in entity"
+// @param	 page			 query	int		false	"Page number"
+// @param	 pageSize		 query	int		false	"Number of entities per page"
+// @Success 200 {object} dtos.PaginatedItemsDto[dtos.FabricVLANDto]
+// @Failure	500		"Internal Server Error"
+// @router /fabric-vlan [get]
+func (f *FabricVLANGinController) GetList(ctx *gin.Context) {
+	req := newPaginatedRequestStructForParsing(1, 10, "VlanID", "asc", "")
+	err := parseGinRequest(ctx, &req)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	paginatedList, err := f.service.GetList(ctx, req.Search, req.OrderBy, req.OrderDirection, req.Page, req.PageSize)
+	handleWithData(ctx, err, paginatedList)
+}
+
+//GetByID get fabric VLAN by id
+//	Params
+//	ctx - gin context
+// @Summary Get fabric VLAN with members statuses by id
+// @version 1.0
+// @Tags 	fabric-vlan
+// @Accept  json
+// @Produce json
+// @param	id		path		string		true	"Fabric VLAN ID"
+// @Success 200 	{object} 	dtos.FabricVLANDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /fabric-vlan/{id} [get]
+func (f *FabricVLANGinController) GetByID(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := f.service.GetByID(ctx, id)
+	handleWithData(ctx, err, dto)
+}
+
+//Create new fabric VLAN
+//	Params
+//	ctx - gin context
+// @Summary Create VLAN on the switches ports and on the host parent interface, result of each member is returned in its status
+// @version 1.0
+// @Tags fabric-vlan
+// @Accept  json
+// @Produce json
+// @Param 	request body 		dtos.FabricVLANCreateDto true "Fabric VLAN fields"
+// @Success 200 	{object} 	dtos.FabricVLANDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router /fabric-vlan [post]
+func (f *FabricVLANGinController) Create(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.FabricVLANCreateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	dto, err := f.service.Create(ctx, reqDto)
+	handleWithData(ctx, err, dto)
+}
+
+//Delete fabric VLAN from all switches and from the host
+//	Params
+//	ctx - gin context
+// @Summary Delete fabric VLAN from all switches and from the host, failed members are kept with their errors
+// @version 1.0
+// @Tags fabric-vlan
+// @Accept  json
+// @Produce	json
+// @param	id		path	string		true	"Fabric VLAN ID"
+// @Success 204 	"OK, but No Content"
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router /fabric-vlan/{id} [delete]
+func (f *FabricVLANGinController) Delete(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	err = f.service.Delete(ctx, id)
+	handle(ctx, err)
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"net/http"
 	"rol/app/errors"
 	"rol/dtos"
+	"strings"
 )
 
 func parseUUIDParam(ctx *gin.Context, paramName string) (uuid.UUID, error) {
@@ -20,6 +22,22 @@ func parseUUIDParam(ctx *gin.Context, paramName string) (uuid.UUID, error) {
 	return uuid, nil
 }
 
+//getRequestAuthorAndID gets author of the request from the X-Author header or the client IP
+//and the request ID set by the logger middleware
+func getRequestAuthorAndID(ctx *gin.Context) (string, uuid.UUID) {
+	author := ctx.GetHeader("X-Author")
+	if author == "" {
+		author = ctx.ClientIP()
+	}
+	requestID := uuid.Nil
+	if value, exists := ctx.Get("requestID"); exists {
+		if id, ok := value.(uuid.UUID); ok {
+			requestID = id
+		}
+	}
+	return author, requestID
+}
+
 //getRequestDtoAndRestoreBody parse json body to dto object and restore body in context
 //for logging it later in middleware
 func getRequestDtoAndRestoreBody[reqDtoType any](ctx *gin.Context) (reqDtoType, error) {
@@ -36,6 +54,39 @@ func getRequestDtoAndRestoreBody[reqDtoType any](ctx *gin.Context) (reqDtoType,
 	return *reqDto, nil
 }
 
+//isYAMLMediaType checks that the media type of the Content-Type or Accept header is yaml
+func isYAMLMediaType(mediaType string) bool {
+	switch strings.TrimSpace(strings.Split(mediaType, ";")[0]) {
+	case "application/x-yaml", "application/yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+	return false
+}
+
+//getRequestDtoFromJSONOrYAMLAndRestoreBody same as getRequestDtoAndRestoreBody, but the body with yaml
+//content type is parsed as yaml, yaml keys are matched to dto fields case-insensitive like json keys
+func getRequestDtoFromJSONOrYAMLAndRestoreBody[reqDtoType any](ctx *gin.Context) (reqDtoType, error) {
+	if !isYAMLMediaType(ctx.ContentType()) {
+		return getRequestDtoAndRestoreBody[reqDtoType](ctx)
+	}
+	reqDto := new(reqDtoType)
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return *reqDto, errors.Internal.New("failed to read request body")
+	}
+	var document interface{}
+	err = yaml.Unmarshal(body, &document)
+	if err != nil {
+		return *reqDto, errors.Validation.New("incorrect yaml")
+	}
+	buf, err := json.Marshal(document)
+	if err != nil {
+		return *reqDto, errors.Validation.New("incorrect yaml")
+	}
+	ctx.Request.Body = ioutil.NopCloser(bytes.NewBuffer(buf))
+	return getRequestDtoAndRestoreBody[reqDtoType](ctx)
+}
+
 func validationErrorToValidationErrorDto(err error) dtos.ValidationErrorDto {
 	validationErrorDto := dtos.ValidationErrorDto{
 		Message: err.Error(),
@@ -94,3 +145,19 @@ func handleWithData(ctx *gin.Context, err error, data interface{}) {
 	}
 	ctx.JSON(http.StatusOK, data)
 }
+
+//handleWithJSONOrYAMLData same as handleWithData, but
+//response body is yaml if the client accepts yaml
+func handleWithJSONOrYAMLData(ctx *gin.Context, err error, data interface{}) {
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	for _, mediaType := range strings.Split(ctx.GetHeader("Accept"), ",") {
+		if isYAMLMediaType(mediaType) {
+			ctx.YAML(http.StatusOK, data)
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//HostNetworkBondController host network bond API controller
+type HostNetworkBondController struct {
+	service *services.HostNetworkService
+	logger  *logrus.Logger
+}
+
+//NewHostNetworkBondController host network bond controller constructor. Parameters pass through DI
+//
+//Params:
+//	bondService - bond service
+//	log - logrus logger
+//Return:
+//	*HostNetworkBondController - instance of host network bond controller
+func NewHostNetworkBondController(bondService *services.HostNetworkService, log *logrus.Logger) *HostNetworkBondController {
+	return &HostNetworkBondController{
+		service: bondService,
+		logger:  log,
+	}
+}
+
+//RegisterHostNetworkBondController registers controller for getting host network bonds via api
+func RegisterHostNetworkBondController(controller *HostNetworkBondController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+
+	groupRoute.GET("/host/network/bond/", controller.GetList)
+	groupRoute.GET("/host/network/bond/:name", controller.GetByName)
+	groupRoute.POST("/host/network/bond/", controller.Create)
+	groupRoute.PUT("/host/network/bond/:name", controller.Update)
+	groupRoute.DELETE("/host/network/bond/:name", controller.Delete)
+}
+
+//GetList get list of host network bonds
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get list of host network bonds
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Success	200		{object}	[]dtos.HostNetworkBondDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/bond/	[get]
+func (h *HostNetworkBondController) GetList(ctx *gin.Context) {
+	bondList, err := h.service.GetBondList()
+	handleWithData(ctx, err, bondList)
+}
+
+//GetByName get bond by name
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Gets bond by name
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	name	path		string	true	"Bond name"
+// @Success	200		{object}	dtos.HostNetworkBondDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/bond/{name}	[get]
+func (h *HostNetworkBondController) GetByName(ctx *gin.Context) {
+	name := ctx.Param("name")
+	bond, err := h.service.GetBondByName(name)
+	handleWithData(ctx, err, bond)
+}
+
+//Create new host bond
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Create new host bond
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Param	request	body		dtos.HostNetworkBondCreateDto	true	"Host bond fields"
+// @Success	200		{object}	dtos.HostNetworkBondDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/bond/	[post]
+func (h *HostNetworkBondController) Create(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkBondCreateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+
+	bondDto, err := h.service.CreateBond(reqDto)
+	handleWithData(ctx, err, bondDto)
+}
+
+//Update host network bond
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary update host network bond
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Param	name	path		string							true	"Bond name"
+// @Param	request	body		dtos.HostNetworkBondUpdateDto	true	"Host bond fields"
+// @Success	200		{object}	dtos.HostNetworkBondDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/bond/{name}	[put]
+func (h *HostNetworkBondController) Update(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkBondUpdateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	name := ctx.Param("name")
+	bondDto, err := h.service.UpdateBond(name, reqDto)
+	handleWithData(ctx, err, bondDto)
+}
+
+//Delete host network bond
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Delete host network bond by name
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	name	path		string	true	"Bond name"
+// @Success	204		"OK, but No Content"
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/bond/{name}	[delete]
+func (h *HostNetworkBondController) Delete(ctx *gin.Context) {
+	name := ctx.Param("name")
+	err := h.service.DeleteBond(name)
+	handle(ctx, err)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/gin-gonic/gin"
 	"rol/app/services"
+	"rol/dtos"
 	"rol/webapi"
 )
 
@@ -21,6 +22,15 @@ func RegisterHostNetworkController(controller *HostNetworkController, server *we
 	groupRoute := server.Engine.Group("/api/v1")
 
 	groupRoute.GET("/host/network/ping", controller.Ping)
+	groupRoute.POST("/host/network/confirm", controller.Confirm)
+	groupRoute.GET("/host/network/status", controller.GetStatus)
+	groupRoute.GET("/host/network/config", controller.GetConfig)
+	groupRoute.POST("/host/network/plan", controller.Plan)
+	groupRoute.POST("/host/network/apply", controller.Apply)
+	groupRoute.GET("/host/network/revision/", controller.GetRevisions)
+	groupRoute.GET("/host/network/revision/:id", controller.GetRevisionByID)
+	groupRoute.GET("/host/network/revision/:id/diff/:toID", controller.DiffRevisions)
+	groupRoute.POST("/host/network/revision/:id/restore", controller.RestoreRevision)
 }
 
 //Ping calls the backend to notify that the current setting does not break the connection
@@ -28,13 +38,212 @@ func RegisterHostNetworkController(controller *HostNetworkController, server *we
 //Params:
 //	ctx - gin context
 //
-// @Summary Call the backend to notify that the current setting does not break the connection
+// @Summary Call the backend to notify that the current setting does not break the connection and confirm the changes
 // @version	1.0
 // @Tags	host
+// @Param	X-Author	header	string	false	"Author of the saved configuration revision, client IP if not set"
 // @Success	204
 // @Failure	500		"Internal Server Error"
 // @router	/host/network/ping	[get]
 func (c *HostNetworkController) Ping(ctx *gin.Context) {
-	err := c.service.Ping()
+	author, requestID := getRequestAuthorAndID(ctx)
+	err := c.service.Ping(author, requestID)
 	handle(ctx, err)
 }
+
+//Confirm confirms applied host network changes, so they are saved and not reset after the confirm timeout
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Confirm applied host network changes
+// @version	1.0
+// @Tags	host
+// @Param	X-Author	header	string	false	"Author of the saved configuration revision, client IP if not set"
+// @Success	204
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/confirm	[post]
+func (c *HostNetworkController) Confirm(ctx *gin.Context) {
+	author, requestID := getRequestAuthorAndID(ctx)
+	err := c.service.Ping(author, requestID)
+	handle(ctx, err)
+}
+
+//GetStatus gets status of the host network changes with the deadline of the changes confirmation
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get status of the host network changes
+// @version	1.0
+// @Tags	host
+// @Produce	json
+// @Success	200		{object}	dtos.HostNetworkStatusDto
+// @router	/host/network/status	[get]
+func (c *HostNetworkController) GetStatus(ctx *gin.Context) {
+	handleWithData(ctx, nil, c.service.GetStatus())
+}
+
+//Plan compares the desired host network configuration with the host network without changing it
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get changes that converge the host network to the desired configuration
+// @version	1.0
+// @Tags	host
+// @Accept	json,application/x-yaml
+// @Produce	json
+// @Param	request	body		dtos.HostNetworkConfigDto	true	"Desired host network configuration in json or yaml"
+// @Success	200		{object}	dtos.HostNetworkPlanDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/plan	[post]
+func (c *HostNetworkController) Plan(ctx *gin.Context) {
+	reqDto, err := getRequestDtoFromJSONOrYAMLAndRestoreBody[dtos.HostNetworkConfigDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	plan, err := c.service.PlanConfiguration(reqDto)
+	handleWithData(ctx, err, plan)
+}
+
+//Apply converges the host network to the desired configuration, applying of the same configuration again changes
+//nothing, so the configuration can be kept in the version control and applied after every change.
+//Changes must be confirmed before the confirm timeout
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Apply the desired host network configuration
+// @version	1.0
+// @Tags	host
+// @Accept	json,application/x-yaml
+// @Produce	json
+// @Param	request	body		dtos.HostNetworkConfigDto	true	"Desired host network configuration in json or yaml"
+// @Success	200		{object}	dtos.HostNetworkPlanDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/apply	[post]
+func (c *HostNetworkController) Apply(ctx *gin.Context) {
+	reqDto, err := getRequestDtoFromJSONOrYAMLAndRestoreBody[dtos.HostNetworkConfigDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	plan, err := c.service.ApplyConfiguration(reqDto)
+	handleWithData(ctx, err, plan)
+}
+
+//GetConfig gets current RoL host network configuration in the format of the apply request
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get current host network configuration
+// @version	1.0
+// @Tags	host
+// @Produce	json,application/x-yaml
+// @Success	200		{object}	dtos.HostNetworkConfigDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/config	[get]
+func (c *HostNetworkController) GetConfig(ctx *gin.Context) {
+	config, err := c.service.GetConfiguration()
+	handleWithJSONOrYAMLData(ctx, err, config)
+}
+
+//GetRevisions gets saved host network configuration revisions from the latest to the oldest
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get saved host network configuration revisions
+// @version	1.0
+// @Tags	host
+// @Produce	json
+// @Success	200		{object}	[]dtos.HostNetworkConfigRevisionDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/revision/	[get]
+func (c *HostNetworkController) GetRevisions(ctx *gin.Context) {
+	revisions, err := c.service.GetConfigRevisions()
+	handleWithData(ctx, err, revisions)
+}
+
+//GetRevisionByID gets saved host network configuration revision with the configuration in the format of the apply request
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get saved host network configuration revision by id
+// @version	1.0
+// @Tags	host
+// @Produce	json,application/x-yaml
+// @Param	id		path		string		true	"Revision ID"
+// @Success	200		{object}	dtos.HostNetworkConfigRevisionContentDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/revision/{id}	[get]
+func (c *HostNetworkController) GetRevisionByID(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	revision, err := c.service.GetConfigRevisionByID(id)
+	handleWithJSONOrYAMLData(ctx, err, revision)
+}
+
+//DiffRevisions compares two saved host network configuration revisions
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get unified diff of two saved host network configuration revisions
+// @version	1.0
+// @Tags	host
+// @Produce	json
+// @Param	id		path		string		true	"Original revision ID"
+// @Param	toID	path		string		true	"Changed revision ID"
+// @Success	200		{object}	dtos.HostNetworkConfigRevisionDiffDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/revision/{id}/diff/{toID}	[get]
+func (c *HostNetworkController) DiffRevisions(ctx *gin.Context) {
+	fromID, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	toID, err := parseUUIDParam(ctx, "toID")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	diff, err := c.service.DiffConfigRevisions(fromID, toID)
+	handleWithData(ctx, err, diff)
+}
+
+//RestoreRevision applies saved host network configuration revision, changes must be confirmed before the confirm timeout
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Restore saved host network configuration revision
+// @version	1.0
+// @Tags	host
+// @Produce	json
+// @Param	id		path		string		true	"Revision ID"
+// @Success	200		{object}	dtos.HostNetworkPlanDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/revision/{id}/restore	[post]
+func (c *HostNetworkController) RestoreRevision(ctx *gin.Context) {
+	id, err := parseUUIDParam(ctx, "id")
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	plan, err := c.service.RestoreConfigRevision(id)
+	handleWithData(ctx, err, plan)
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//HostNetworkNamespaceController host network namespace API controller
+type HostNetworkNamespaceController struct {
+	service *services.HostNetworkService
+	logger  *logrus.Logger
+}
+
+//NewHostNetworkNamespaceController host network namespace controller constructor. Parameters pass through DI
+//
+//Params:
+//	namespaceService - namespace service
+//	log - logrus logger
+//Return:
+//	*HostNetworkNamespaceController - instance of host network namespace controller
+func NewHostNetworkNamespaceController(namespaceService *services.HostNetworkService, log *logrus.Logger) *HostNetworkNamespaceController {
+	return &HostNetworkNamespaceController{
+		service: namespaceService,
+		logger:  log,
+	}
+}
+
+//RegisterHostNetworkNamespaceController registers controller for getting host network namespaces via api
+func RegisterHostNetworkNamespaceController(controller *HostNetworkNamespaceController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+
+	groupRoute.GET("/host/network/namespace/", controller.GetList)
+	groupRoute.GET("/host/network/namespace/:name", controller.GetByName)
+	groupRoute.POST("/host/network/namespace/", controller.Create)
+	groupRoute.PUT("/host/network/namespace/:name", controller.Update)
+	groupRoute.DELETE("/host/network/namespace/:name", controller.Delete)
+}
+
+//GetList get list of host network namespaces
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get list of host network namespaces
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Success	200		{object}	[]dtos.HostNetworkNamespaceDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/namespace/	[get]
+func (h *HostNetworkNamespaceController) GetList(ctx *gin.Context) {
+	namespaceList, err := h.service.GetNamespaceList()
+	handleWithData(ctx, err, namespaceList)
+}
+
+//GetByName get network namespace by name
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Gets network namespace by name
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	name	path		string	true	"Namespace name"
+// @Success	200		{object}	dtos.HostNetworkNamespaceDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/namespace/{name}	[get]
+func (h *HostNetworkNamespaceController) GetByName(ctx *gin.Context) {
+	name := ctx.Param("name")
+	namespace, err := h.service.GetNamespaceByName(name)
+	handleWithData(ctx, err, namespace)
+}
+
+//Create new host network namespace
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Create new host network namespace
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Param	request	body		dtos.HostNetworkNamespaceCreateDto	true	"Host namespace fields"
+// @Success	200		{object}	dtos.HostNetworkNamespaceDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/namespace/	[post]
+func (h *HostNetworkNamespaceController) Create(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkNamespaceCreateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+
+	namespaceDto, err := h.service.CreateNamespace(reqDto)
+	handleWithData(ctx, err, namespaceDto)
+}
+
+//Update host network namespace
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary update host network namespace
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Param	name	path		string							true	"Namespace name"
+// @Param	request	body		dtos.HostNetworkNamespaceUpdateDto	true	"Host namespace fields"
+// @Success	200		{object}	dtos.HostNetworkNamespaceDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/namespace/{name}	[put]
+func (h *HostNetworkNamespaceController) Update(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkNamespaceUpdateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	name := ctx.Param("name")
+	namespaceDto, err := h.service.UpdateNamespace(name, reqDto)
+	handleWithData(ctx, err, namespaceDto)
+}
+
+//Delete host network namespace
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Delete host network namespace by name
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	name	path		string	true	"Namespace name"
+// @Success	204		"OK, but No Content"
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/namespace/{name}	[delete]
+func (h *HostNetworkNamespaceController) Delete(ctx *gin.Context) {
+	name := ctx.Param("name")
+	err := h.service.DeleteNamespace(name)
+	handle(ctx, err)
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//HostNetworkRouteController host network static routes API controller
+type HostNetworkRouteController struct {
+	service *services.HostNetworkService
+	logger  *logrus.Logger
+}
+
+//NewHostNetworkRouteController host network route controller constructor. Parameters pass through DI
+//
+//Params:
+//	routeService - route service
+//	log - logrus logger
+//Return:
+//	*HostNetworkRouteController - instance of host network route controller
+func NewHostNetworkRouteController(routeService *services.HostNetworkService, log *logrus.Logger) *HostNetworkRouteController {
+	return &HostNetworkRouteController{
+		service: routeService,
+		logger:  log,
+	}
+}
+
+//RegisterHostNetworkRouteController registers controller for managing host static routes via api
+func RegisterHostNetworkRouteController(controller *HostNetworkRouteController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+
+	groupRoute.GET("/host/network/route/", controller.GetList)
+	groupRoute.POST("/host/network/route/", controller.Create)
+	groupRoute.DELETE("/host/network/route/", controller.Delete)
+}
+
+//GetList get list of host static routes that are managed by RoL
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get list of host static routes that are managed by RoL
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Success	200		{object}	[]dtos.HostNetworkRouteDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/route/	[get]
+func (h *HostNetworkRouteController) GetList(ctx *gin.Context) {
+	routes, err := h.service.GetRouteList()
+	handleWithData(ctx, err, routes)
+}
+
+//Create new host static route
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Create new host static route
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	request	body		dtos.HostNetworkRouteCreateDto	true	"Host route fields"
+// @Success	200		{object}	dtos.HostNetworkRouteDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/route/	[post]
+func (h *HostNetworkRouteController) Create(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkRouteCreateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+
+	routeDto, err := h.service.CreateRoute(reqDto)
+	handleWithData(ctx, err, routeDto)
+}
+
+//Delete host static route
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Delete host static route
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	request	body		dtos.HostNetworkRouteDeleteDto	true	"Host route fields"
+// @Success	204		"OK, but No Content"
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/route/	[delete]
+func (h *HostNetworkRouteController) Delete(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkRouteDeleteDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	err = h.service.DeleteRoute(reqDto)
+	handle(ctx, err)
+}
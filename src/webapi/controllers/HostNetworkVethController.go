@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"rol/app/services"
+	"rol/dtos"
+	"rol/webapi"
+)
+
+//HostNetworkVethController host network veth API controller
+type HostNetworkVethController struct {
+	service *services.HostNetworkService
+	logger  *logrus.Logger
+}
+
+//NewHostNetworkVethController host network veth controller constructor. Parameters pass through DI
+//
+//Params:
+//	vethService - veth service
+//	log - logrus logger
+//Return:
+//	*HostNetworkVethController - instance of host network veth controller
+func NewHostNetworkVethController(vethService *services.HostNetworkService, log *logrus.Logger) *HostNetworkVethController {
+	return &HostNetworkVethController{
+		service: vethService,
+		logger:  log,
+	}
+}
+
+//RegisterHostNetworkVethController registers controller for getting host network veths via api
+func RegisterHostNetworkVethController(controller *HostNetworkVethController, server *webapi.GinHTTPServer) {
+	groupRoute := server.Engine.Group("/api/v1")
+
+	groupRoute.GET("/host/network/veth/", controller.GetList)
+	groupRoute.GET("/host/network/veth/:name", controller.GetByName)
+	groupRoute.POST("/host/network/veth/", controller.Create)
+	groupRoute.PUT("/host/network/veth/:name", controller.Update)
+	groupRoute.DELETE("/host/network/veth/:name", controller.Delete)
+}
+
+//GetList get list of host network veths
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary Get list of host network veths
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Success	200		{object}	[]dtos.HostNetworkVethDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/veth/	[get]
+func (h *HostNetworkVethController) GetList(ctx *gin.Context) {
+	vethList, err := h.service.GetVethList()
+	handleWithData(ctx, err, vethList)
+}
+
+//GetByName get veth pair end by name
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Gets veth pair end by name
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	name	path		string	true	"Veth pair end name"
+// @Success	200		{object}	dtos.HostNetworkVethDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/veth/{name}	[get]
+func (h *HostNetworkVethController) GetByName(ctx *gin.Context) {
+	name := ctx.Param("name")
+	veth, err := h.service.GetVethByName(name)
+	handleWithData(ctx, err, veth)
+}
+
+//Create new host veth pair
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Create new host veth pair
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Param	request	body		dtos.HostNetworkVethCreateDto	true	"Host veth fields"
+// @Success	200		{object}	dtos.HostNetworkVethDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/veth/	[post]
+func (h *HostNetworkVethController) Create(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkVethCreateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+
+	vethDto, err := h.service.CreateVeth(reqDto)
+	handleWithData(ctx, err, vethDto)
+}
+
+//Update host network veth
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary update host network veth
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @Param	name	path		string							true	"Veth pair end name"
+// @Param	request	body		dtos.HostNetworkVethUpdateDto	true	"Host veth fields"
+// @Success	200		{object}	dtos.HostNetworkVethDto
+// @Failure	400		{object}	dtos.ValidationErrorDto
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/veth/{name}	[put]
+func (h *HostNetworkVethController) Update(ctx *gin.Context) {
+	reqDto, err := getRequestDtoAndRestoreBody[dtos.HostNetworkVethUpdateDto](ctx)
+	if err != nil {
+		abortWithStatusByErrorType(ctx, err)
+		return
+	}
+	name := ctx.Param("name")
+	vethDto, err := h.service.UpdateVeth(name, reqDto)
+	handleWithData(ctx, err, vethDto)
+}
+
+//Delete host network veth
+//
+//Params:
+//	ctx - gin context
+//
+// @Summary	Delete host network veth by name
+// @version	1.0
+// @Tags	host
+// @Accept	json
+// @Produce	json
+// @param	name	path		string	true	"Veth pair end name"
+// @Success	204		"OK, but No Content"
+// @Failure	404		"Not Found"
+// @Failure	500		"Internal Server Error"
+// @router	/host/network/veth/{name}	[delete]
+func (h *HostNetworkVethController) Delete(ctx *gin.Context) {
+	name := ctx.Param("name")
+	err := h.service.DeleteVeth(name)
+	handle(ctx, err)
+}
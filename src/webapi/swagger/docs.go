@@ -613,6 +613,51 @@ const docTemplate = `{
                 }
             }
         },
+        "/ethernet-switch/port/bulk": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ethernet-switch"
+                ],
+                "summary": "Apply POE, PVID or VLAN operation to the selected ports of many switches, one switch session per switch",
+                "parameters": [
+                    {
+                        "description": "Ports selector with switches and operation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortBulkOperationDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.EthernetSwitchPortBulkResultDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/ethernet-switch/{id}": {
             "get": {
                 "consumes": [
@@ -732,7 +777,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/ethernet-switch/{id}/port/": {
+        "/ethernet-switch/{id}/backup": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -743,7 +788,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Gets paginated list of ethernet switch ports",
+                "summary": "Get paginated list of switch configuration versions",
                 "parameters": [
                     {
                         "type": "string",
@@ -754,31 +799,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Order by field, default - Name",
+                        "description": "Order by field",
                         "name": "orderBy",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "'asc' or 'desc' for ascending or descending order, default - asc",
+                        "description": "'asc' or 'desc' for ascending or descending order",
                         "name": "orderDirection",
                         "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "Searchable value in entity, default - ",
-                        "name": "search",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "Page number, default - 1",
+                        "description": "Page number",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Number of entities per page, default - 10",
+                        "description": "Number of entities per page",
                         "name": "pageSize",
                         "in": "query"
                     }
@@ -787,7 +826,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_EthernetSwitchPortDto"
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_EthernetSwitchConfigBackupDto"
                         }
                     },
                     "404": {
@@ -808,7 +847,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Creates new ethernet switch port",
+                "summary": "Download running configuration from the switch and store it as a new version if it's changed",
                 "parameters": [
                     {
                         "type": "string",
@@ -816,22 +855,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Ethernet switch port fields",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchPortCreateDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchConfigBackupDto"
                         }
                     },
                     "400": {
@@ -849,7 +879,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/ethernet-switch/{id}/port/{portID}": {
+        "/ethernet-switch/{id}/backup/{backupID}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -860,7 +890,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Gets ethernet switch port by id",
+                "summary": "Get ethernet switch configuration version by id",
                 "parameters": [
                     {
                         "type": "string",
@@ -871,8 +901,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ethernet switch port ID",
-                        "name": "portID",
+                        "description": "Configuration backup ID",
+                        "name": "backupID",
                         "in": "path",
                         "required": true
                     }
@@ -881,7 +911,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchConfigBackupContentDto"
                         }
                     },
                     "404": {
@@ -891,8 +921,10 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/ethernet-switch/{id}/backup/{backupID}/diff/{toBackupID}": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -902,7 +934,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Updates ethernet switch port by id",
+                "summary": "Get unified diff between two ethernet switch configuration versions",
                 "parameters": [
                     {
                         "type": "string",
@@ -913,32 +945,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ethernet switch port ID",
-                        "name": "portID",
+                        "description": "Original configuration backup ID",
+                        "name": "backupID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Ethernet switch port fields",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchPortUpdateDto"
-                        }
+                        "type": "string",
+                        "description": "Changed configuration backup ID",
+                        "name": "toBackupID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchConfigBackupDiffDto"
                         }
                     },
                     "404": {
@@ -948,8 +972,10 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/ethernet-switch/{id}/backup/{backupID}/restore": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -959,7 +985,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Delete ethernet switch port by id",
+                "summary": "Restore ethernet switch configuration version, current configuration is backed up before",
                 "parameters": [
                     {
                         "type": "string",
@@ -970,15 +996,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ethernet switch port ID",
-                        "name": "portID",
+                        "description": "Configuration backup ID",
+                        "name": "backupID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "OK, but No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchConfigBackupDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
                     },
                     "404": {
                         "description": "Not Found"
@@ -989,8 +1024,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/ethernet-switch/{id}/vlan": {
-            "get": {
+        "/ethernet-switch/{id}/discover": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1000,7 +1035,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Get paginated list of switch VLANs",
+                "summary": "Import ports and VLANs from the ethernet switch configuration",
                 "parameters": [
                     {
                         "type": "string",
@@ -1008,51 +1043,32 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order by field",
-                        "name": "orderBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "'asc' or 'desc' for ascending or descending order",
-                        "name": "orderDirection",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Searchable value in entity",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of entities per page",
-                        "name": "pageSize",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_EthernetSwitchVLANDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchDiscoveryDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/ethernet-switch/{id}/drift": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1062,7 +1078,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Create new ethernet switch VLAN",
+                "summary": "Get differences between the ethernet switch configuration and the stored one",
                 "parameters": [
                     {
                         "type": "string",
@@ -1070,22 +1086,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Ethernet switch VLAN fields",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchVLANCreateDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchDriftDto"
                         }
                     },
                     "400": {
@@ -1094,14 +1101,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/ethernet-switch/{id}/vlan/{vlanUUID}": {
-            "get": {
+        "/ethernet-switch/{id}/drift/adopt-switch": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1111,7 +1121,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Get ethernet switch VLAN by id",
+                "summary": "Change the stored ports and VLANs to match the ethernet switch configuration",
                 "parameters": [
                     {
                         "type": "string",
@@ -1119,20 +1129,19 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ethernet switch VLAN UUID",
-                        "name": "vlanUUID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchDriftDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
                     "404": {
@@ -1142,8 +1151,10 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/ethernet-switch/{id}/drift/apply-db": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1153,7 +1164,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Updates ethernet switch VLAN by id",
+                "summary": "Change the ethernet switch configuration to match the stored ports and VLANs",
                 "parameters": [
                     {
                         "type": "string",
@@ -1161,29 +1172,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ethernet switch VLAN UUID",
-                        "name": "vlanUUID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Ethernet switch fields",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchVLANUpdateDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchDriftDto"
                         }
                     },
                     "400": {
@@ -1199,8 +1194,10 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/ethernet-switch/{id}/firmware": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1210,7 +1207,7 @@ const docTemplate = `{
                 "tags": [
                     "ethernet-switch"
                 ],
-                "summary": "Delete ethernet switch VLAN by id",
+                "summary": "Get ethernet switch firmware version and hardware revision",
                 "parameters": [
                     {
                         "type": "string",
@@ -1218,18 +1215,20 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ethernet switch VLAN UUID",
-                        "name": "vlanUUID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "OK, but No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchFirmwareDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
                     },
                     "404": {
                         "description": "Not Found"
@@ -1240,7 +1239,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/host/network/bridge/": {
+        "/ethernet-switch/{id}/firmware/upgrade": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1249,19 +1248,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Get list of host network bridges",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
-                            }
+                "summary": "Get stage and progress of the last ethernet switch firmware upgrade job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchFirmwareJobDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
@@ -1275,17 +1283,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Create new host bridge",
+                "summary": "Stage firmware image on the TFTP server and start ethernet switch firmware upgrade job",
                 "parameters": [
                     {
-                        "description": "Host bridge fields",
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Firmware upgrade parameters",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkBridgeCreateDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchFirmwareUpgradeDto"
                         }
                     }
                 ],
@@ -1293,7 +1308,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchFirmwareJobDto"
                         }
                     },
                     "400": {
@@ -1302,13 +1317,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/host/network/bridge/{name}": {
+        "/ethernet-switch/{id}/lag": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1317,23 +1335,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets bridge port by name",
+                "summary": "Get paginated list of switch LAGs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bridge name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Searchable value in entity",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entities per page",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_EthernetSwitchLAGDto"
                         }
                     },
                     "404": {
@@ -1344,7 +1392,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1352,24 +1400,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "update host network bridge",
+                "summary": "Create new ethernet switch LAG, LAG ID can be used as a port in VLANs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bridge name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Host bridge fields",
+                        "description": "Ethernet switch LAG fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkBridgeUpdateDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchLAGCreateDto"
                         }
                     }
                 ],
@@ -1377,7 +1425,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchLAGDto"
                         }
                     },
                     "400": {
@@ -1393,8 +1441,10 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/ethernet-switch/{id}/lag/{lagID}": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,64 +1452,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Delete host network bridge by name",
+                "summary": "Get ethernet switch LAG by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bridge name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "OK, but No Content"
-                    },
-                    "404": {
-                        "description": "Not Found"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/host/network/ping": {
-            "get": {
-                "tags": [
-                    "host"
-                ],
-                "summary": "Call the backend to notify that the current setting does not break the connection",
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/host/network/traffic/{table}": {
-            "get": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "host"
-                ],
-                "summary": "Get selected netfilter table rules",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Table name",
-                        "name": "table",
+                        "description": "Ethernet switch LAG ID",
+                        "name": "lagID",
                         "in": "path",
                         "required": true
                     }
@@ -1468,18 +1475,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
-                            }
+                            "$ref": "#/definitions/dtos.EthernetSwitchLAGDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             },
-            "post": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1487,24 +1494,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Create new traffic rule in specified table",
+                "summary": "Updates ethernet switch LAG by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Table name",
-                        "name": "table",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Host traffic rule fields",
+                        "type": "string",
+                        "description": "Ethernet switch LAG ID",
+                        "name": "lagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ethernet switch LAG fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkTrafficRuleCreateDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchLAGUpdateDto"
                         }
                     }
                 ],
@@ -1512,7 +1526,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchLAGDto"
                         }
                     },
                     "400": {
@@ -1521,6 +1535,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
@@ -1534,25 +1551,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Delete netfilter traffic rule in specified table",
+                "summary": "Delete ethernet switch LAG by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Table name",
-                        "name": "table",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Host traffic rule fields",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDeleteDto"
-                        }
+                        "type": "string",
+                        "description": "Ethernet switch LAG ID",
+                        "name": "lagID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1568,7 +1583,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/host/network/vlan/": {
+        "/ethernet-switch/{id}/port/": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1577,19 +1592,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
+                ],
+                "summary": "Gets paginated list of ethernet switch ports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field, default - Name",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order, default - asc",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Searchable value in entity, default - ",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, default - 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entities per page, default - 10",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get list of host network VLAN's",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.HostNetworkVlanDto"
-                            }
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_EthernetSwitchPortDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
@@ -1603,17 +1657,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Create new host vlan",
+                "summary": "Creates new ethernet switch port",
                 "parameters": [
                     {
-                        "description": "Host vlan fields",
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ethernet switch port fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkVlanCreateDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortCreateDto"
                         }
                     }
                 ],
@@ -1621,7 +1682,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
                         }
                     },
                     "400": {
@@ -1630,13 +1691,71 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/host/network/vlan/{name}": {
+        "/ethernet-switch/{id}/port/bulk": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ethernet-switch"
+                ],
+                "summary": "Apply POE, PVID or VLAN operation to the selected ports of the switch within one switch session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ports selector and operation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortBulkOperationDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.EthernetSwitchPortBulkResultDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/ethernet-switch/{id}/port/{portID}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1645,14 +1764,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets vlan port by name",
+                "summary": "Gets ethernet switch port by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vlan name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch port ID",
+                        "name": "portID",
                         "in": "path",
                         "required": true
                     }
@@ -1661,7 +1787,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
                         }
                     },
                     "404": {
@@ -1680,24 +1806,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "update host network vlan",
+                "summary": "Updates ethernet switch port by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vlan name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Host vlan fields",
+                        "type": "string",
+                        "description": "Ethernet switch port ID",
+                        "name": "portID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ethernet switch port fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkVlanUpdateDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortUpdateDto"
                         }
                     }
                 ],
@@ -1705,7 +1838,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
                         }
                     },
                     "400": {
@@ -1730,14 +1863,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "host"
+                    "ethernet-switch"
                 ],
-                "summary": "Delete host network vlan by name",
+                "summary": "Delete ethernet switch port by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vlan name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch port ID",
+                        "name": "portID",
                         "in": "path",
                         "required": true
                     }
@@ -1755,7 +1895,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/log/app/": {
+        "/ethernet-switch/{id}/port/{portID}/neighbors": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1764,55 +1904,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "log"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets paginated list of app logs",
+                "summary": "Gets LLDP neighbors and MAC addresses on the ethernet switch port with suggested devices from DHCP leases",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order by field",
-                        "name": "orderBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "'asc' or 'desc' for ascending or descending order",
-                        "name": "orderDirection",
-                        "in": "query"
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "searchable value in entity",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "number of entities per page",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "Ethernet switch port ID",
+                        "name": "portID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_AppLogDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortNeighborsDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/log/app/{id}": {
+        "/ethernet-switch/{id}/port/{portID}/status": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1821,23 +1954,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "log"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets http app by id",
+                "summary": "Gets link status, speed, counters and POE power of the ethernet switch port",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "log id",
+                        "description": "Ethernet switch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch port ID",
+                        "name": "portID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.AppLogDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchPortStatusDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
                     "404": {
@@ -1849,7 +1995,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/log/http/": {
+        "/ethernet-switch/{id}/vlan": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1858,10 +2004,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "log"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets paginated list of http logs",
+                "summary": "Get paginated list of switch VLANs",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Order by field",
@@ -1876,19 +2029,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "searchable value in entity",
+                        "description": "Searchable value in entity",
                         "name": "search",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "page number",
+                        "description": "Page number",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "number of entities per page",
+                        "description": "Number of entities per page",
                         "name": "pageSize",
                         "in": "query"
                     }
@@ -1897,17 +2050,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_HTTPLogDto"
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_EthernetSwitchVLANDto"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/log/http/{id}": {
-            "get": {
+            },
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1915,27 +2066,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "log"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets http log by id",
+                "summary": "Create new ethernet switch VLAN",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "log id",
+                        "description": "Ethernet switch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Ethernet switch VLAN fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchVLANCreateDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.HTTPLogDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
                         }
                     },
-                    "404": {
-                        "description": "Not Found"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -1943,7 +2106,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/template/device/": {
+        "/ethernet-switch/{id}/vlan/{vlanUUID}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -1952,56 +2115,98 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "template"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets paginated list of device templates",
+                "summary": "Get ethernet switch VLAN by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order by field",
-                        "name": "orderBy",
-                        "in": "query"
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "'asc' or 'desc' for ascending or descending order",
-                        "name": "orderDirection",
-                        "in": "query"
+                        "description": "Ethernet switch VLAN UUID",
+                        "name": "vlanUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "searchable value in entity",
-                        "name": "search",
-                        "in": "query"
+                    "404": {
+                        "description": "Not Found"
                     },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ethernet-switch"
+                ],
+                "summary": "Updates ethernet switch VLAN by id",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "number of entities per page",
-                        "name": "pageSize",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Ethernet switch VLAN UUID",
+                        "name": "vlanUUID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ethernet switch fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EthernetSwitchVLANUpdateDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_DeviceTemplateDto"
+                            "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/template/device/{name}": {
-            "get": {
+            },
+            "delete": {
                 "consumes": [
                     "application/json"
                 ],
@@ -2009,24 +2214,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "template"
+                    "ethernet-switch"
                 ],
-                "summary": "Gets device template by its name",
+                "summary": "Delete ethernet switch VLAN by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "device template name",
-                        "name": "name",
+                        "description": "Ethernet switch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ethernet switch VLAN UUID",
+                        "name": "vlanUUID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.DeviceTemplateDto"
-                        }
+                    "204": {
+                        "description": "OK, but No Content"
                     },
                     "404": {
                         "description": "Not Found"
@@ -2037,7 +2246,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/tftp/": {
+        "/fabric-vlan": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -2046,9 +2255,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "fabric-vlan"
                 ],
-                "summary": "Get paginated list of tftp servers",
+                "summary": "Get paginated list of fabric VLANs",
                 "parameters": [
                     {
                         "type": "string",
@@ -2085,7 +2294,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_TFTPServerDto"
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_FabricVLANDto"
                         }
                     },
                     "500": {
@@ -2101,17 +2310,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "fabric-vlan"
                 ],
-                "summary": "Create new TFTP server",
+                "summary": "Create VLAN on the switches ports and on the host parent interface, result of each member is returned in its status",
                 "parameters": [
                     {
-                        "description": "TFTP server fields",
+                        "description": "Fabric VLAN fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPServerCreateDto"
+                            "$ref": "#/definitions/dtos.FabricVLANCreateDto"
                         }
                     }
                 ],
@@ -2119,7 +2328,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPServerDto"
+                            "$ref": "#/definitions/dtos.FabricVLANDto"
                         }
                     },
                     "400": {
@@ -2134,7 +2343,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/tftp/{id}": {
+        "/fabric-vlan/{id}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -2143,13 +2352,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "fabric-vlan"
                 ],
-                "summary": "Get TFTP server by id",
+                "summary": "Get fabric VLAN with members statuses by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "TFTP server ID",
+                        "description": "Fabric VLAN ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2159,7 +2368,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPServerDto"
+                            "$ref": "#/definitions/dtos.FabricVLANDto"
                         }
                     },
                     "404": {
@@ -2170,7 +2379,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "consumes": [
                     "application/json"
                 ],
@@ -2178,24 +2387,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "fabric-vlan"
                 ],
-                "summary": "Updates TFTP server by id",
+                "summary": "Delete fabric VLAN from all switches and from the host, failed members are kept with their errors",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "TFTP server ID",
+                        "description": "Fabric VLAN ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
                     },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/apply": {
+            "post": {
+                "consumes": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Apply the desired host network configuration",
+                "parameters": [
                     {
-                        "description": "TFTP server fields",
+                        "description": "Desired host network configuration in json or yaml",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPServerUpdateDto"
+                            "$ref": "#/definitions/dtos.HostNetworkConfigDto"
                         }
                     }
                 ],
@@ -2203,7 +2440,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPServerDto"
+                            "$ref": "#/definitions/dtos.HostNetworkPlanDto"
                         }
                     },
                     "400": {
@@ -2212,15 +2449,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ValidationErrorDto"
                         }
                     },
-                    "404": {
-                        "description": "Not Found"
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/bond/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get list of host network bonds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkBondDto"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -2228,24 +2490,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "host"
                 ],
-                "summary": "Delete TFTP server by id",
+                "summary": "Create new host bond",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "TFTP server ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Host bond fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBondCreateDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "OK, but No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBondDto"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -2253,7 +2523,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/tftp/{id}/path/": {
+        "/host/network/bond/{name}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -2262,47 +2532,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "host"
                 ],
-                "summary": "Gets paginated list of TFTP server paths",
+                "summary": "Gets bond by name",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "TFTP server ID",
-                        "name": "id",
+                        "description": "Bond name",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order by field",
-                        "name": "orderBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "'asc' or 'desc' for ascending or descending order",
-                        "name": "orderDirection",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of entities per page",
-                        "name": "pageSize",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_TFTPPathDto"
+                            "$ref": "#/definitions/dtos.HostNetworkBondDto"
                         }
                     },
                     "404": {
@@ -2313,7 +2559,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -2321,24 +2567,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "host"
                 ],
-                "summary": "Creates new TFTP server path",
+                "summary": "update host network bond",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "TFTP server ID",
-                        "name": "id",
+                        "description": "Bond name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "TFTP server path fields",
+                        "description": "Host bond fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPPathCreateDto"
+                            "$ref": "#/definitions/dtos.HostNetworkBondUpdateDto"
                         }
                     }
                 ],
@@ -2346,7 +2592,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.TFTPPathDto"
+                            "$ref": "#/definitions/dtos.HostNetworkBondDto"
                         }
                     },
                     "400": {
@@ -2362,9 +2608,7 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/tftp/{id}/path/{pathID}": {
+            },
             "delete": {
                 "consumes": [
                     "application/json"
@@ -2373,21 +2617,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tftp"
+                    "host"
                 ],
-                "summary": "Delete TFTP server path by id",
+                "summary": "Delete host network bond by name",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "TFTP server ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "TFTP server path ID",
-                        "name": "pathID",
+                        "description": "Bond name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
@@ -2404,202 +2641,3338 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dtos.AppLogDto": {
-            "type": "object",
-            "properties": {
-                "actionID": {
-                    "type": "string"
-                },
+        },
+        "/host/network/bridge/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get list of host network bridges",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Create new host bridge",
+                "parameters": [
+                    {
+                        "description": "Host bridge fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBridgeCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/bridge/{name}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Gets bridge port by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bridge name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "update host network bridge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bridge name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host bridge fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBridgeUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Delete host network bridge by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bridge name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/config": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get current host network configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkConfigDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/confirm": {
+            "post": {
+                "tags": [
+                    "host"
+                ],
+                "summary": "Confirm applied host network changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Author of the saved configuration revision, client IP if not set",
+                        "name": "X-Author",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/namespace/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get list of host network namespaces",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkNamespaceDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Create new host network namespace",
+                "parameters": [
+                    {
+                        "description": "Host namespace fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkNamespaceCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkNamespaceDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/namespace/{name}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Gets network namespace by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkNamespaceDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "update host network namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host namespace fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkNamespaceUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkNamespaceDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Delete host network namespace by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/ping": {
+            "get": {
+                "tags": [
+                    "host"
+                ],
+                "summary": "Call the backend to notify that the current setting does not break the connection and confirm the changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Author of the saved configuration revision, client IP if not set",
+                        "name": "X-Author",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/plan": {
+            "post": {
+                "consumes": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get changes that converge the host network to the desired configuration",
+                "parameters": [
+                    {
+                        "description": "Desired host network configuration in json or yaml",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkConfigDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkPlanDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/revision/": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get saved host network configuration revisions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkConfigRevisionDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/revision/{id}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get saved host network configuration revision by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkConfigRevisionContentDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/revision/{id}/diff/{toID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get unified diff of two saved host network configuration revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Original revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changed revision ID",
+                        "name": "toID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkConfigRevisionDiffDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/revision/{id}/restore": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Restore saved host network configuration revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkPlanDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/route/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get list of host static routes that are managed by RoL",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkRouteDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Create new host static route",
+                "parameters": [
+                    {
+                        "description": "Host route fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkRouteCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkRouteDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Delete host static route",
+                "parameters": [
+                    {
+                        "description": "Host route fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkRouteDeleteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/status": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get status of the host network changes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkStatusDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/host/network/traffic/{table}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get selected netfilter table rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table name",
+                        "name": "table",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Create new traffic rule in specified table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table name",
+                        "name": "table",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host traffic rule fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkTrafficRuleCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Delete netfilter traffic rule in specified table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Table name",
+                        "name": "table",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host traffic rule fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDeleteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/veth/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get list of host network veths",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkVethDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Create new host veth pair",
+                "parameters": [
+                    {
+                        "description": "Host veth fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVethCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVethDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/veth/{name}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Gets veth pair end by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Veth pair end name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVethDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "update host network veth",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Veth pair end name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host veth fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVethUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVethDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Delete host network veth by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Veth pair end name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/vlan/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Get list of host network VLAN's",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Create new host vlan",
+                "parameters": [
+                    {
+                        "description": "Host vlan fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVlanCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/host/network/vlan/{name}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Gets vlan port by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vlan name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "update host network vlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vlan name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Host vlan fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVlanUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "host"
+                ],
+                "summary": "Delete host network vlan by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vlan name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/log/app/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "log"
+                ],
+                "summary": "Gets paginated list of app logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "searchable value in entity",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_AppLogDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/log/app/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "log"
+                ],
+                "summary": "Gets http app by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "log id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AppLogDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/log/http/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "log"
+                ],
+                "summary": "Gets paginated list of http logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "searchable value in entity",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_HTTPLogDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/log/http/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "log"
+                ],
+                "summary": "Gets http log by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "log id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HTTPLogDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/template/device/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Gets paginated list of device templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "searchable value in entity",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_DeviceTemplateDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/template/device/{name}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Gets device template by its name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "device template name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DeviceTemplateDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/tftp/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Get paginated list of tftp servers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Searchable value in entity",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entities per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_TFTPServerDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Create new TFTP server",
+                "parameters": [
+                    {
+                        "description": "TFTP server fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPServerCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPServerDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/tftp/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Get TFTP server by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TFTP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPServerDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Updates TFTP server by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TFTP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "TFTP server fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPServerUpdateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPServerDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Delete TFTP server by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TFTP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/tftp/{id}/path/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Gets paginated list of TFTP server paths",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TFTP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "'asc' or 'desc' for ascending or descending order",
+                        "name": "orderDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entities per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedItemsDto-dtos_TFTPPathDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Creates new TFTP server path",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TFTP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "TFTP server path fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPPathCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TFTPPathDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/tftp/{id}/path/{pathID}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tftp"
+                ],
+                "summary": "Delete TFTP server path by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TFTP server ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "TFTP server path ID",
+                        "name": "pathID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK, but No Content"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.AppLogDto": {
+            "type": "object",
+            "properties": {
+                "actionID": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "Level - level of the log",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message - log message",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source - method from which the log was obtained",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DHCP4LeaseCreateDto": {
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "description": "Expires datetime",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP address in ipv4 format",
+                    "type": "string"
+                },
+                "mac": {
+                    "description": "MAC address in format like this 00-00-00-00-00",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DHCP4LeaseDto": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "expires": {
+                    "description": "Expires datetime",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP address in ipv4 format",
+                    "type": "string"
+                },
+                "mac": {
+                    "description": "MAC address in format like this 00-00-00-00-00",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DHCP4LeaseUpdateDto": {
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "description": "Expires datetime",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP address in ipv4 format",
+                    "type": "string"
+                },
+                "mac": {
+                    "description": "MAC address in format like this 00-00-00-00-00",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DHCP4ServerCreateDto": {
+            "type": "object",
+            "properties": {
+                "dns": {
+                    "description": "DNS servers, separated by \";\"",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled server or no",
+                    "type": "boolean"
+                },
+                "gateway": {
+                    "description": "Gateway in ipv4 format",
+                    "type": "string"
+                },
+                "interface": {
+                    "description": "Interface name",
+                    "type": "string"
+                },
+                "leaseTime": {
+                    "description": "LeaseTime for dhcp v4 server leases",
+                    "type": "integer"
+                },
+                "mask": {
+                    "description": "Mask for dhcp leases, for example: \"255.255.255.0\"",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace network namespace name where the server is running, empty for the host network namespace",
+                    "type": "string"
+                },
+                "ntp": {
+                    "description": "NTP IP address or dns name of NTP server",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "Port of DHCP server",
+                    "type": "integer"
+                },
+                "range": {
+                    "description": "Range of ip's for this dhcp v4 server, separated by \"-\", for example: \"10.10.10.2-10.10.10.22\"",
+                    "type": "string"
+                },
+                "serverID": {
+                    "description": "ServerID is a server_id dhcp option. Correct format is ipv4.",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DHCP4ServerDto": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "dns": {
+                    "description": "DNS servers, separated by \";\"",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled server or no",
+                    "type": "boolean"
+                },
+                "gateway": {
+                    "description": "Gateway in ipv4 format",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "interface": {
+                    "description": "Interface name",
+                    "type": "string"
+                },
+                "leaseTime": {
+                    "description": "LeaseTime for dhcp v4 server leases",
+                    "type": "integer"
+                },
+                "mask": {
+                    "description": "Mask for dhcp leases, for example: \"255.255.255.0\"",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Namespace network namespace name where the server is running, empty for the host network namespace",
+                    "type": "string"
+                },
+                "ntp": {
+                    "description": "NTP IP address or dns name of NTP server",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "Port of DHCP server",
+                    "type": "integer"
+                },
+                "range": {
+                    "description": "Range of ip's for this dhcp v4 server, separated by \"-\", for example: \"10.10.10.2-10.10.10.22\"",
+                    "type": "string"
+                },
+                "serverID": {
+                    "description": "ServerID is a server_id dhcp option. Correct format is ipv4.",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State current state of dhcp v4 server",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DHCP4ServerUpdateDto": {
+            "type": "object",
+            "properties": {
+                "dns": {
+                    "description": "DNS servers, separated by \";\"",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled server or no",
+                    "type": "boolean"
+                },
+                "leaseTime": {
+                    "description": "LeaseTime for dhcp v4 server leases",
+                    "type": "integer"
+                },
+                "namespace": {
+                    "description": "Namespace network namespace name where the server is running, empty for the host network namespace",
+                    "type": "string"
+                },
+                "ntp": {
+                    "description": "NTP IP address or dns name of NTP server",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "Port of DHCP server",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.DeviceTemplateBootStageDto": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action for this boot stage.\nCan be: File, CheckPowerSwitch, EmergencyPowerOff,\nPowerOff, EmergencyPowerOn, PowerOn,\nCheckManagement\n\nFor File action:\n\tA stage can only be marked complete if all files have\n\tbeen downloaded by the device via TFTP or DHCP,\n\tafter which the next step can be loaded.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description of boot stage template",
+                    "type": "string"
+                },
+                "files": {
+                    "description": "Files slice of files for boot stage",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageFileDto"
+                    }
+                },
+                "name": {
+                    "description": "Name of boot stage template",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DeviceTemplateBootStageFileDto": {
+            "type": "object",
+            "properties": {
+                "existingFileName": {
+                    "description": "ExistingFileName file name is a real full file path with name on the disk.\nThis path is relative from app directory",
+                    "type": "string"
+                },
+                "virtualFileName": {
+                    "description": "VirtualFileName virtual file name is relative from /\u003cmac-address\u003e/",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DeviceTemplateControlDto": {
+            "type": "object",
+            "properties": {
+                "emergency": {
+                    "description": "Emergency how to control device power in case of emergency. As example: POE(For Rpi4), IPMI, ILO or PowerSwitch",
+                    "type": "string"
+                },
+                "nextBoot": {
+                    "description": "NextBoot how to change next boot device. As example: IPMI, ILO or NONE.\nFor example, NONE is used for Rpi4, we control next boot by u-boot files in boot stages.",
+                    "type": "string"
+                },
+                "power": {
+                    "description": "Power how to control device power. As example: POE(For Rpi4), IPMI, ILO or PowerSwitch",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DeviceTemplateDto": {
+            "type": "object",
+            "properties": {
+                "control": {
+                    "description": "Control describes how we control the device",
+                    "$ref": "#/definitions/dtos.DeviceTemplateControlDto"
+                },
+                "cpucount": {
+                    "description": "CPUCount count of cpus",
+                    "type": "integer"
+                },
+                "cpumodel": {
+                    "description": "CPUModel model of cpu",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description template description",
+                    "type": "string"
+                },
+                "discBootStages": {
+                    "description": "DiscBootStages slice of boot stage templates for disk boot",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageDto"
+                    }
+                },
+                "manufacturer": {
+                    "description": "Manufacturer device manufacturer",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "Model device model",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name template name",
+                    "type": "string"
+                },
+                "netBootStages": {
+                    "description": "NetBootStages slice of boot stage templates for net boot",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageDto"
+                    }
+                },
+                "networkInterfaces": {
+                    "description": "NetworkInterfaces slice of device network interfaces",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DeviceTemplateNetworkDto"
+                    }
+                },
+                "ram": {
+                    "description": "RAM the amount of RAM in GB",
+                    "type": "integer"
+                },
+                "usbbootStages": {
+                    "description": "USBBootStages slice of boot stage templates for usb boot",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageDto"
+                    }
+                }
+            }
+        },
+        "dtos.DeviceTemplateNetworkDto": {
+            "type": "object",
+            "properties": {
+                "management": {
+                    "description": "Management only one network interface can be mark as management",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Name of network interface. This field is unique within device template network interfaces",
+                    "type": "string"
+                },
+                "netBoot": {
+                    "description": "NetBoot flags whether the interface can be loaded over the network",
+                    "type": "boolean"
+                },
+                "poein": {
+                    "description": "POEIn only one network interface can be mark as POEIn",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.EthernetSwitchConfigBackupContentDto": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "Config running configuration text",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Hash SHA-256 hash of the configuration in hex",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size configuration size in bytes",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchConfigBackupDiffDto": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "description": "Diff unified diff of the configurations",
+                    "type": "string"
+                },
+                "equal": {
+                    "description": "Equal true if configurations are the same",
+                    "type": "boolean"
+                },
+                "fromID": {
+                    "description": "FromID ID of the original backup",
+                    "type": "string"
+                },
+                "toID": {
+                    "description": "ToID ID of the changed backup",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchConfigBackupDto": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Hash SHA-256 hash of the configuration in hex",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size configuration size in bytes",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchConfigDiffDto": {
+            "type": "object",
+            "properties": {
+                "entityID": {
+                    "description": "EntityID - ID of the stored entity, empty if entity is not stored",
+                    "type": "string"
+                },
+                "entityType": {
+                    "description": "EntityType - type of the entity: \"port\" or \"vlan\"",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Field - different field name",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name - port name or VLAN ID",
+                    "type": "string"
+                },
+                "storedValue": {
+                    "description": "StoredValue - value of the stored entity",
+                    "type": "string"
+                },
+                "switchValue": {
+                    "description": "SwitchValue - value on the switch",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchCreateDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address - switch ip address",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name - switch name",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password - ethernet switch management password",
+                    "type": "string"
+                },
+                "serial": {
+                    "description": "Serial - switch serial number",
+                    "type": "string"
+                },
+                "switchModel": {
+                    "description": "SwitchModel - switch model",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Username - switch admin username",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchDiscoveryDto": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "Conflicts - differences between the switch and already existing entities, existing entities are not changed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchConfigDiffDto"
+                    }
+                },
+                "importedPorts": {
+                    "description": "ImportedPorts - ports created from the switch configuration",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchPortDto"
+                    }
+                },
+                "importedVLANs": {
+                    "description": "ImportedVLANs - VLANs created from the switch configuration",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchVLANDto"
+                    }
+                }
+            }
+        },
+        "dtos.EthernetSwitchDriftDto": {
+            "type": "object",
+            "properties": {
+                "checkedAt": {
+                    "description": "CheckedAt - time of the comparison",
+                    "type": "string"
+                },
+                "differences": {
+                    "description": "Differences - differences between the switch configuration and the stored entities",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchConfigDiffDto"
+                    }
+                },
+                "error": {
+                    "description": "Error - error that occurred while reading the switch configuration, empty if there is no error",
+                    "type": "string"
+                },
+                "inSync": {
+                    "description": "InSync - true if the switch configuration matches the stored one",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.EthernetSwitchDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address - switch ip address",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name - switch name",
+                    "type": "string"
+                },
+                "serial": {
+                    "description": "Serial - switch serial number",
+                    "type": "string"
+                },
+                "switchModel": {
+                    "description": "SwitchModel - switch model",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Username - switch admin username",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchFirmwareDto": {
+            "type": "object",
+            "properties": {
+                "firmwareVersion": {
+                    "description": "FirmwareVersion installed firmware version",
+                    "type": "string"
+                },
+                "hardwareVersion": {
+                    "description": "HardwareVersion hardware revision",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchFirmwareJobDto": {
+            "type": "object",
+            "properties": {
+                "currentVersion": {
+                    "description": "CurrentVersion firmware version after the upgrade",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error job error, empty if there is no error",
+                    "type": "string"
+                },
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "description": "FinishedAt job finish time, zero if the job is running",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID job ID",
+                    "type": "string"
+                },
+                "previousVersion": {
+                    "description": "PreviousVersion firmware version before the upgrade",
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "Progress job progress in percents",
+                    "type": "integer"
+                },
+                "rebootDetected": {
+                    "description": "RebootDetected true if the switch was unreachable or its firmware version changed after the upgrade started",
+                    "type": "boolean"
+                },
+                "stage": {
+                    "description": "Stage current job stage: \"staging\", \"transferring\", \"rebooting\", \"verifying\", \"completed\" or \"failed\"",
+                    "type": "string"
+                },
+                "startedAt": {
+                    "description": "StartedAt job start time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchFirmwareUpgradeDto": {
+            "type": "object",
+            "properties": {
+                "imagePath": {
+                    "description": "ImagePath path to the firmware image file on the RoL host",
+                    "type": "string"
+                },
+                "tftpaddress": {
+                    "description": "TFTPAddress IP address of the TFTP server reachable from the switch, TFTP server address is used if empty",
+                    "type": "string"
+                },
+                "tftpserverID": {
+                    "description": "TFTPServerID ID of the RoL TFTP server used to stage the image",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchLAGCreateDto": {
+            "type": "object",
+            "properties": {
+                "groupID": {
+                    "description": "GroupID number of the port channel on the switch",
+                    "type": "integer"
+                },
+                "memberPorts": {
+                    "description": "MemberPorts slice of member ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "description": "Mode LACP mode: \"active\", \"passive\" or \"static\" for the group without LACP",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchLAGDto": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
+                    "type": "string"
+                },
+                "groupID": {
+                    "description": "GroupID number of the port channel on the switch",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "memberPorts": {
+                    "description": "MemberPorts slice of member ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "description": "Mode LACP mode: \"active\", \"passive\" or \"static\" for the group without LACP",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name LAG name in the switch configuration",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchLAGUpdateDto": {
+            "type": "object",
+            "properties": {
+                "memberPorts": {
+                    "description": "MemberPorts slice of member ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "description": "Mode LACP mode: \"active\", \"passive\" or \"static\" for the group without LACP",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchLLDPNeighborDto": {
+            "type": "object",
+            "properties": {
+                "chassisID": {
+                    "description": "ChassisID - neighbor chassis ID, usually MAC address",
+                    "type": "string"
+                },
+                "managementAddress": {
+                    "description": "ManagementAddress - neighbor management address",
+                    "type": "string"
+                },
+                "portDescription": {
+                    "description": "PortDescription - neighbor port description",
+                    "type": "string"
+                },
+                "portID": {
+                    "description": "PortID - neighbor port ID, can be MAC address or interface name",
+                    "type": "string"
+                },
+                "systemName": {
+                    "description": "SystemName - neighbor system name",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchMACAddressDto": {
+            "type": "object",
+            "properties": {
+                "leases": {
+                    "description": "Leases - DHCP v4 leases of this MAC address",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DHCP4LeaseDto"
+                    }
+                },
+                "mac": {
+                    "description": "MAC - MAC address in the format like 00:00:00:00:00:00",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type - entry type, for example \"dynamic\" or \"static\"",
+                    "type": "string"
+                },
+                "vlanid": {
+                    "description": "VLANID - VLAN where the address is learned",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.EthernetSwitchModelDto": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code - unique switch model code",
+                    "type": "string"
+                },
+                "manufacturer": {
+                    "description": "Manufacturer - Switch model manufacturer",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "Model - Switch model",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortBulkOperationDto": {
+            "type": "object",
+            "properties": {
+                "operation": {
+                    "description": "Operation can be: \"poe-on\", \"poe-off\", \"poe-cycle\", \"set-pvid\", \"add-vlan\", \"remove-vlan\"",
+                    "type": "string"
+                },
+                "pvid": {
+                    "description": "PVID new ports PVID for \"set-pvid\" operation",
+                    "type": "integer"
+                },
+                "selector": {
+                    "description": "Selector ports selector",
+                    "$ref": "#/definitions/dtos.EthernetSwitchPortBulkSelectorDto"
+                },
+                "tagged": {
+                    "description": "Tagged add ports to the VLAN as tagged for \"add-vlan\" operation",
+                    "type": "boolean"
+                },
+                "vlanid": {
+                    "description": "VLANID 802.1Q VLAN ID for \"add-vlan\" and \"remove-vlan\" operations, VLAN must exist on the switch",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortBulkResultDto": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error reason of the skipped or failed operation",
+                    "type": "string"
+                },
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
+                    "type": "string"
+                },
+                "portID": {
+                    "description": "PortID port ID",
+                    "type": "string"
+                },
+                "portName": {
+                    "description": "PortName port name",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status can be: \"changed\", \"unchanged\", \"skipped\", \"failed\"",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortBulkSelectorDto": {
+            "type": "object",
+            "properties": {
+                "memberOfVLAN": {
+                    "description": "MemberOfVLAN 802.1Q ID of the VLAN that contains the port as tagged or untagged",
+                    "type": "integer"
+                },
+                "namePattern": {
+                    "description": "NamePattern shell pattern of the port name, for example \"gi1/0/1*\"",
+                    "type": "string"
+                },
+                "portIDs": {
+                    "description": "PortIDs IDs of the ports",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "switchIDs": {
+                    "description": "SwitchIDs IDs of the switches, all switches if empty. Ignored when the switch is set in the request path",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortCreateDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description port description on the switch",
+                    "type": "string"
+                },
+                "duplex": {
+                    "description": "Duplex port duplex: \"auto\", \"full\", \"half\", empty if not managed",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name for this port",
+                    "type": "string"
+                },
+                "poeenabled": {
+                    "description": "POEEnabled POE status on a port",
+                    "type": "boolean"
+                },
+                "poetype": {
+                    "description": "POEType type of PoE for this port\ncan be: \"poe\", \"poe+\", \"passive24\", \"none\"",
+                    "type": "string"
+                },
+                "pvid": {
+                    "description": "PVID default VLAN id",
+                    "type": "integer"
+                },
+                "shutdown": {
+                    "description": "Shutdown true if the port is administratively disabled",
+                    "type": "boolean"
+                },
+                "speed": {
+                    "description": "Speed port speed: \"auto\", \"10M\", \"100M\", \"1000M\", empty if not managed",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortDto": {
+            "type": "object",
+            "properties": {
                 "createdAt": {
                     "description": "CreatedAt - entity create time",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID - unique identifier",
+                "description": {
+                    "description": "Description port description on the switch",
+                    "type": "string"
+                },
+                "duplex": {
+                    "description": "Duplex port duplex: \"auto\", \"full\", \"half\", empty if not managed",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name for this port",
+                    "type": "string"
+                },
+                "poeenabled": {
+                    "description": "POEEnabled POE status on a port",
+                    "type": "boolean"
+                },
+                "poetype": {
+                    "description": "POEType type of PoE for this port\ncan be: \"poe\", \"poe+\", \"passive24\", \"none\"",
+                    "type": "string"
+                },
+                "pvid": {
+                    "description": "PVID default VLAN id",
+                    "type": "integer"
+                },
+                "shutdown": {
+                    "description": "Shutdown true if the port is administratively disabled",
+                    "type": "boolean"
+                },
+                "speed": {
+                    "description": "Speed port speed: \"auto\", \"10M\", \"100M\", \"1000M\", empty if not managed",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortNeighborsDto": {
+            "type": "object",
+            "properties": {
+                "lldpneighbors": {
+                    "description": "LLDPNeighbors - neighbors discovered by LLDP on the port",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchLLDPNeighborDto"
+                    }
+                },
+                "macaddresses": {
+                    "description": "MACAddresses - MAC addresses learned on the port",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchMACAddressDto"
+                    }
+                },
+                "portID": {
+                    "description": "PortID - ethernet switch port ID",
                     "type": "string"
                 },
-                "level": {
-                    "description": "Level - level of the log",
+                "portName": {
+                    "description": "PortName - ethernet switch port name",
                     "type": "string"
                 },
-                "message": {
-                    "description": "Message - log message",
+                "suggestions": {
+                    "description": "Suggestions - devices that are probably connected to the port",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchPortSuggestionDto"
+                    }
+                }
+            }
+        },
+        "dtos.EthernetSwitchPortStatusDto": {
+            "type": "object",
+            "properties": {
+                "adminUp": {
+                    "description": "AdminUp - true if the port is enabled by configuration",
+                    "type": "boolean"
+                },
+                "duplex": {
+                    "description": "Duplex - negotiated duplex, for example \"Full\", empty if link is down",
                     "type": "string"
                 },
-                "source": {
-                    "description": "Source - method from which the log was obtained",
+                "operUp": {
+                    "description": "OperUp - true if the port link is up",
+                    "type": "boolean"
+                },
+                "poepower": {
+                    "description": "POEPower - power drawn by the powered device in watts",
+                    "type": "number"
+                },
+                "readAt": {
+                    "description": "ReadAt - time when the status was read from the switch",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "description": "UpdatedAt - entity update time",
+                "rxBytes": {
+                    "description": "RxBytes - received bytes counter",
+                    "type": "integer"
+                },
+                "rxErrors": {
+                    "description": "RxErrors - receive errors counter",
+                    "type": "integer"
+                },
+                "speed": {
+                    "description": "Speed - negotiated speed, for example \"1000M\", empty if link is down",
                     "type": "string"
+                },
+                "txBytes": {
+                    "description": "TxBytes - transmitted bytes counter",
+                    "type": "integer"
+                },
+                "txErrors": {
+                    "description": "TxErrors - transmit errors counter",
+                    "type": "integer"
                 }
             }
         },
-        "dtos.DHCP4LeaseCreateDto": {
+        "dtos.EthernetSwitchPortSuggestionDto": {
             "type": "object",
             "properties": {
-                "expires": {
-                    "description": "Expires datetime",
+                "confidence": {
+                    "description": "Confidence - \"high\" if the device is the only one seen on the port, otherwise \"low\"",
                     "type": "string"
                 },
                 "ip": {
-                    "description": "IP address in ipv4 format",
+                    "description": "IP - device IP address from the DHCP v4 lease",
+                    "type": "string"
+                },
+                "leaseID": {
+                    "description": "LeaseID - ID of the DHCP v4 lease of the device",
                     "type": "string"
                 },
                 "mac": {
-                    "description": "MAC address in format like this 00-00-00-00-00",
+                    "description": "MAC - device MAC address",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason - why the device is suggested",
                     "type": "string"
                 }
             }
         },
-        "dtos.DHCP4LeaseDto": {
+        "dtos.EthernetSwitchPortUpdateDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description port description on the switch",
+                    "type": "string"
+                },
+                "duplex": {
+                    "description": "Duplex port duplex: \"auto\", \"full\", \"half\", empty if not managed",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name for this port",
+                    "type": "string"
+                },
+                "poeenabled": {
+                    "description": "POEEnabled POE status on a port",
+                    "type": "boolean"
+                },
+                "poetype": {
+                    "description": "POEType type of PoE for this port\ncan be: \"poe\", \"poe+\", \"passive24\", \"none\"",
+                    "type": "string"
+                },
+                "pvid": {
+                    "description": "PVID default VLAN id",
+                    "type": "integer"
+                },
+                "shutdown": {
+                    "description": "Shutdown true if the port is administratively disabled",
+                    "type": "boolean"
+                },
+                "speed": {
+                    "description": "Speed port speed: \"auto\", \"10M\", \"100M\", \"1000M\", empty if not managed",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchUpdateDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address - switch ip address",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name - switch name",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password - ethernet switch management password",
+                    "type": "string"
+                },
+                "serial": {
+                    "description": "Serial - switch serial number",
+                    "type": "string"
+                },
+                "switchModel": {
+                    "description": "SwitchModel - switch model",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Username - switch admin username",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EthernetSwitchVLANCreateDto": {
+            "type": "object",
+            "properties": {
+                "taggedPorts": {
+                    "description": "TaggedPorts slice of tagged ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "untaggedPorts": {
+                    "description": "UntaggedPorts slice of untagged ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "vlanID": {
+                    "description": "VlanID VLAN ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.EthernetSwitchVLANDto": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "description": "CreatedAt - entity create time",
                     "type": "string"
                 },
-                "expires": {
-                    "description": "Expires datetime",
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
                     "type": "string"
                 },
                 "id": {
                     "description": "ID - unique identifier",
                     "type": "string"
                 },
-                "ip": {
-                    "description": "IP address in ipv4 format",
-                    "type": "string"
+                "taggedPorts": {
+                    "description": "TaggedPorts slice of tagged ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "mac": {
-                    "description": "MAC address in format like this 00-00-00-00-00",
-                    "type": "string"
+                "untaggedPorts": {
+                    "description": "UntaggedPorts slice of untagged ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "updatedAt": {
                     "description": "UpdatedAt - entity update time",
                     "type": "string"
+                },
+                "vlanID": {
+                    "description": "VlanID VLAN ID",
+                    "type": "integer"
                 }
             }
         },
-        "dtos.DHCP4LeaseUpdateDto": {
+        "dtos.EthernetSwitchVLANUpdateDto": {
             "type": "object",
             "properties": {
-                "expires": {
-                    "description": "Expires datetime",
-                    "type": "string"
+                "taggedPorts": {
+                    "description": "TaggedPorts slice of tagged ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "ip": {
-                    "description": "IP address in ipv4 format",
+                "untaggedPorts": {
+                    "description": "UntaggedPorts slice of untagged ports IDs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.FabricVLANCreateDto": {
+            "type": "object",
+            "properties": {
+                "hostAddresses": {
+                    "description": "HostAddresses addresses of the host VLAN interface",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hostParent": {
+                    "description": "HostParent name of the host parent interface, empty if the VLAN is not needed on the host",
                     "type": "string"
                 },
-                "mac": {
-                    "description": "MAC address in format like this 00-00-00-00-00",
+                "members": {
+                    "description": "Members trunk and access ports of the switches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FabricVLANMemberBaseDto"
+                    }
+                },
+                "name": {
+                    "description": "Name VLAN name",
                     "type": "string"
+                },
+                "vlanID": {
+                    "description": "VlanID VLAN ID on the switches and on the host",
+                    "type": "integer"
                 }
             }
         },
-        "dtos.DHCP4ServerCreateDto": {
+        "dtos.FabricVLANDto": {
             "type": "object",
             "properties": {
-                "dns": {
-                    "description": "DNS servers, separated by \";\"",
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
                     "type": "string"
                 },
-                "enabled": {
-                    "description": "Enabled server or no",
-                    "type": "boolean"
+                "hostAddresses": {
+                    "description": "HostAddresses addresses of the host VLAN interface",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "gateway": {
-                    "description": "Gateway in ipv4 format",
+                "hostError": {
+                    "description": "HostError reason of the failed host VLAN configuration",
                     "type": "string"
                 },
-                "interface": {
-                    "description": "Interface name",
+                "hostInterface": {
+                    "description": "HostInterface name of the host VLAN interface",
                     "type": "string"
                 },
-                "leaseTime": {
-                    "description": "LeaseTime for dhcp v4 server leases",
+                "hostParent": {
+                    "description": "HostParent name of the host parent interface",
+                    "type": "string"
+                },
+                "hostStatus": {
+                    "description": "HostStatus can be: \"\", \"pending\", \"configured\", \"failed\"",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID - unique identifier",
+                    "type": "string"
+                },
+                "members": {
+                    "description": "Members trunk and access ports of the switches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FabricVLANMemberDto"
+                    }
+                },
+                "name": {
+                    "description": "Name VLAN name",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status can be: \"pending\", \"configured\", \"partial\", \"failed\"",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
+                    "type": "string"
+                },
+                "vlanID": {
+                    "description": "VlanID VLAN ID on the switches and on the host",
                     "type": "integer"
+                }
+            }
+        },
+        "dtos.FabricVLANMemberBaseDto": {
+            "type": "object",
+            "properties": {
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
+                    "type": "string"
                 },
-                "mask": {
-                    "description": "Mask for dhcp leases, for example: \"255.255.255.0\"",
+                "portID": {
+                    "description": "PortID ethernet switch port or LAG ID",
+                    "type": "string"
+                },
+                "tagged": {
+                    "description": "Tagged port is a trunk port of the VLAN, otherwise it is an access port",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.FabricVLANMemberDto": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt - entity create time",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error reason of the failed port configuration",
+                    "type": "string"
+                },
+                "ethernetSwitchID": {
+                    "description": "EthernetSwitchID ethernet switch ID",
                     "type": "string"
                 },
-                "ntp": {
-                    "description": "NTP IP address or dns name of NTP server",
+                "id": {
+                    "description": "ID - unique identifier",
                     "type": "string"
                 },
-                "port": {
-                    "description": "Port of DHCP server",
-                    "type": "integer"
+                "portID": {
+                    "description": "PortID ethernet switch port or LAG ID",
+                    "type": "string"
                 },
-                "range": {
-                    "description": "Range of ip's for this dhcp v4 server, separated by \"-\", for example: \"10.10.10.2-10.10.10.22\"",
+                "status": {
+                    "description": "Status can be: \"pending\", \"configured\", \"failed\"",
                     "type": "string"
                 },
-                "serverID": {
-                    "description": "ServerID is a server_id dhcp option. Correct format is ipv4.",
+                "tagged": {
+                    "description": "Tagged port is a trunk port of the VLAN, otherwise it is an access port",
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt - entity update time",
                     "type": "string"
                 }
             }
         },
-        "dtos.DHCP4ServerDto": {
+        "dtos.HTTPLogDto": {
             "type": "object",
             "properties": {
+                "clientIP": {
+                    "description": "ClientIP - client IP address",
+                    "type": "string"
+                },
                 "createdAt": {
                     "description": "CreatedAt - entity create time",
                     "type": "string"
                 },
-                "dns": {
-                    "description": "DNS servers, separated by \";\"",
+                "customRequestHeaders": {
+                    "description": "CustomRequestHeaders - custom headers of the request",
                     "type": "string"
                 },
-                "enabled": {
-                    "description": "Enabled server or no",
-                    "type": "boolean"
+                "domain": {
+                    "description": "Domain - domain that processed the request",
+                    "type": "string"
                 },
-                "gateway": {
-                    "description": "Gateway in ipv4 format",
+                "httpmethod": {
+                    "description": "HTTPMethod - http method",
                     "type": "string"
                 },
                 "id": {
                     "description": "ID - unique identifier",
                     "type": "string"
                 },
-                "interface": {
-                    "description": "Interface name",
-                    "type": "string"
-                },
-                "leaseTime": {
-                    "description": "LeaseTime for dhcp v4 server leases",
+                "latency": {
+                    "description": "Latency - latency in milliseconds",
                     "type": "integer"
                 },
-                "mask": {
-                    "description": "Mask for dhcp leases, for example: \"255.255.255.0\"",
+                "queryParams": {
+                    "description": "QueryParams - query params passed",
                     "type": "string"
                 },
-                "ntp": {
-                    "description": "NTP IP address or dns name of NTP server",
+                "relativePath": {
+                    "description": "RelativePath - path to the endpoint",
                     "type": "string"
                 },
-                "port": {
-                    "description": "Port of DHCP server",
-                    "type": "integer"
-                },
-                "range": {
-                    "description": "Range of ip's for this dhcp v4 server, separated by \"-\", for example: \"10.10.10.2-10.10.10.22\"",
+                "requestBody": {
+                    "description": "RequestBody - body of the request",
                     "type": "string"
                 },
-                "serverID": {
-                    "description": "ServerID is a server_id dhcp option. Correct format is ipv4.",
+                "requestHeaders": {
+                    "description": "RequestHeaders - headers of the request",
                     "type": "string"
                 },
-                "state": {
-                    "description": "State current state of dhcp v4 server",
+                "responseBody": {
+                    "description": "ResponseBody - body of the response",
                     "type": "string"
                 },
                 "updatedAt": {
@@ -2608,558 +5981,626 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.DHCP4ServerUpdateDto": {
+        "dtos.HostNetworkAddressChangeDto": {
             "type": "object",
             "properties": {
-                "dns": {
-                    "description": "DNS servers, separated by \";\"",
+                "action": {
+                    "description": "Action \"add\" or \"delete\"",
                     "type": "string"
                 },
-                "enabled": {
-                    "description": "Enabled server or no",
-                    "type": "boolean"
-                },
-                "leaseTime": {
-                    "description": "LeaseTime for dhcp v4 server leases",
-                    "type": "integer"
+                "address": {
+                    "description": "Address address in CIDR notation",
+                    "type": "string"
                 },
-                "ntp": {
-                    "description": "NTP IP address or dns name of NTP server",
+                "linkName": {
+                    "description": "LinkName link name",
                     "type": "string"
                 },
-                "port": {
-                    "description": "Port of DHCP server",
-                    "type": "integer"
+                "namespace": {
+                    "description": "Namespace network namespace of the link, empty for the host links",
+                    "type": "string"
                 }
             }
         },
-        "dtos.DeviceTemplateBootStageDto": {
+        "dtos.HostNetworkBondCreateDto": {
             "type": "object",
             "properties": {
-                "action": {
-                    "description": "Action for this boot stage.\nCan be: File, CheckPowerSwitch, EmergencyPowerOff,\nPowerOff, EmergencyPowerOn, PowerOn,\nCheckManagement\n\nFor File action:\n\tA stage can only be marked complete if all files have\n\tbeen downloaded by the device via TFTP or DHCP,\n\tafter which the next step can be loaded.",
+                "addresses": {
+                    "description": "Addresses list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "miimon": {
+                    "description": "Miimon MII link monitoring frequency in milliseconds, 0 disables the monitoring",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Mode bonding mode: \"active-backup\", \"802.3ad\" or \"balance-alb\"",
                     "type": "string"
                 },
-                "description": {
-                    "description": "Description of boot stage template",
+                "name": {
+                    "description": "Name interface name, bond will be named rol.bond.{Name}",
                     "type": "string"
                 },
-                "files": {
-                    "description": "Files slice of files for boot stage",
+                "slaves": {
+                    "description": "Slaves slice of slaves interfaces names",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageFileDto"
+                        "type": "string"
                     }
-                },
-                "name": {
-                    "description": "Name of boot stage template",
-                    "type": "string"
                 }
             }
         },
-        "dtos.DeviceTemplateBootStageFileDto": {
+        "dtos.HostNetworkBondDto": {
             "type": "object",
             "properties": {
-                "existingFileName": {
-                    "description": "ExistingFileName file name is a real full file path with name on the disk.\nThis path is relative from app directory",
+                "addresses": {
+                    "description": "Addresses list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "miimon": {
+                    "description": "Miimon MII link monitoring frequency in milliseconds",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Mode bonding mode",
                     "type": "string"
                 },
-                "virtualFileName": {
-                    "description": "VirtualFileName virtual file name is relative from /\u003cmac-address\u003e/",
+                "name": {
+                    "description": "Name interface full name",
                     "type": "string"
+                },
+                "slaves": {
+                    "description": "Slaves slice of slaves interfaces names",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dtos.DeviceTemplateControlDto": {
+        "dtos.HostNetworkBondUpdateDto": {
             "type": "object",
             "properties": {
-                "emergency": {
-                    "description": "Emergency how to control device power in case of emergency. As example: POE(For Rpi4), IPMI, ILO or PowerSwitch",
-                    "type": "string"
-                },
-                "nextBoot": {
-                    "description": "NextBoot how to change next boot device. As example: IPMI, ILO or NONE.\nFor example, NONE is used for Rpi4, we control next boot by u-boot files in boot stages.",
-                    "type": "string"
+                "addresses": {
+                    "description": "Addresses list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "power": {
-                    "description": "Power how to control device power. As example: POE(For Rpi4), IPMI, ILO or PowerSwitch",
-                    "type": "string"
+                "slaves": {
+                    "description": "Slaves slice of slaves interfaces names",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dtos.DeviceTemplateDto": {
+        "dtos.HostNetworkBridgeCreateDto": {
             "type": "object",
             "properties": {
-                "control": {
-                    "description": "Control describes how we control the device",
-                    "$ref": "#/definitions/dtos.DeviceTemplateControlDto"
-                },
-                "cpucount": {
-                    "description": "CPUCount count of cpus",
-                    "type": "integer"
-                },
-                "cpumodel": {
-                    "description": "CPUModel model of cpu",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "Description template description",
-                    "type": "string"
-                },
-                "discBootStages": {
-                    "description": "DiscBootStages slice of boot stage templates for disk boot",
+                "addresses": {
+                    "description": "Addresses list",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageDto"
+                        "type": "string"
                     }
                 },
-                "manufacturer": {
-                    "description": "Manufacturer device manufacturer",
-                    "type": "string"
-                },
-                "model": {
-                    "description": "Model device model",
-                    "type": "string"
-                },
                 "name": {
-                    "description": "Name template name",
+                    "description": "Name interface full name",
                     "type": "string"
                 },
-                "netBootStages": {
-                    "description": "NetBootStages slice of boot stage templates for net boot",
+                "nat": {
+                    "description": "Nat give the bridge networks an internet access through masquerade on the upstream interface",
+                    "type": "boolean"
+                },
+                "slaves": {
+                    "description": "Slaves slice of slaves interfaces names",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageDto"
+                        "type": "string"
                     }
                 },
-                "networkInterfaces": {
-                    "description": "NetworkInterfaces slice of device network interfaces",
+                "upstreamInterface": {
+                    "description": "UpstreamInterface name of the interface through which NAT traffic of the bridge is sent, required for NAT",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HostNetworkBridgeDto": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "Addresses list",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.DeviceTemplateNetworkDto"
+                        "type": "string"
                     }
                 },
-                "ram": {
-                    "description": "RAM the amount of RAM in GB",
-                    "type": "integer"
+                "name": {
+                    "description": "Name interface full name",
+                    "type": "string"
                 },
-                "usbbootStages": {
-                    "description": "USBBootStages slice of boot stage templates for usb boot",
+                "nat": {
+                    "description": "Nat give the bridge networks an internet access through masquerade on the upstream interface",
+                    "type": "boolean"
+                },
+                "slaves": {
+                    "description": "Slaves slice of slaves interfaces names",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.DeviceTemplateBootStageDto"
+                        "type": "string"
                     }
+                },
+                "upstreamInterface": {
+                    "description": "UpstreamInterface name of the interface through which NAT traffic of the bridge is sent, required for NAT",
+                    "type": "string"
                 }
             }
         },
-        "dtos.DeviceTemplateNetworkDto": {
+        "dtos.HostNetworkBridgeUpdateDto": {
             "type": "object",
             "properties": {
-                "management": {
-                    "description": "Management only one network interface can be mark as management",
-                    "type": "boolean"
-                },
-                "name": {
-                    "description": "Name of network interface. This field is unique within device template network interfaces",
-                    "type": "string"
+                "addresses": {
+                    "description": "Addresses list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "netBoot": {
-                    "description": "NetBoot flags whether the interface can be loaded over the network",
+                "nat": {
+                    "description": "Nat give the bridge networks an internet access through masquerade on the upstream interface",
                     "type": "boolean"
                 },
-                "poein": {
-                    "description": "POEIn only one network interface can be mark as POEIn",
-                    "type": "boolean"
+                "slaves": {
+                    "description": "Slaves slice of slaves interfaces names",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "upstreamInterface": {
+                    "description": "UpstreamInterface name of the interface through which NAT traffic of the bridge is sent, required for NAT",
+                    "type": "string"
                 }
             }
         },
-        "dtos.EthernetSwitchCreateDto": {
+        "dtos.HostNetworkConfigDto": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "Address - switch ip address",
-                    "type": "string"
+                "bonds": {
+                    "description": "Bonds RoL bonds, bond name is rol.bond.{name}",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkBondDto"
+                    }
                 },
-                "name": {
-                    "description": "Name - switch name",
-                    "type": "string"
+                "bridges": {
+                    "description": "Bridges RoL bridges, bridge name is rol.br.{name}",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkBridgeDto"
+                    }
                 },
-                "password": {
-                    "description": "Password - ethernet switch management password",
-                    "type": "string"
+                "namespaces": {
+                    "description": "Namespaces RoL network namespaces with the links that are moved into them, namespace name is rol.ns.{name}",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkNamespaceDto"
+                    }
                 },
-                "serial": {
-                    "description": "Serial - switch serial number",
-                    "type": "string"
+                "routes": {
+                    "description": "Routes static routes that are managed by RoL, routes of other protocols are not listed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkRouteDto"
+                    }
                 },
-                "switchModel": {
-                    "description": "SwitchModel - switch model",
-                    "type": "string"
+                "trafficRules": {
+                    "description": "TrafficRules traffic rules of the RoL chains separated by tables, rules with rol.nat:{bridge} comments\nare generated from the bridges NAT settings, so they are ignored",
+                    "$ref": "#/definitions/dtos.HostNetworkTrafficRulesDto"
                 },
-                "username": {
-                    "description": "Username - switch admin username",
-                    "type": "string"
+                "veths": {
+                    "description": "Veths RoL veth pairs ends that are left on the host, ends names are rol.veth.{name} and rol.peer.{name}",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkVethDto"
+                    }
+                },
+                "vlans": {
+                    "description": "Vlans RoL vlans, vlan name is rol.{Parent}.{VlanID}",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkVlanDto"
+                    }
                 }
             }
         },
-        "dtos.EthernetSwitchDto": {
+        "dtos.HostNetworkConfigRevisionContentDto": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "Address - switch ip address",
+                "author": {
+                    "description": "Author who confirmed and saved the configuration",
                     "type": "string"
                 },
+                "config": {
+                    "description": "Config saved configuration, it can be applied with /host/network/apply",
+                    "$ref": "#/definitions/dtos.HostNetworkConfigDto"
+                },
                 "createdAt": {
-                    "description": "CreatedAt - entity create time",
+                    "description": "CreatedAt time when the configuration was saved",
                     "type": "string"
                 },
                 "id": {
-                    "description": "ID - unique identifier",
-                    "type": "string"
-                },
-                "name": {
-                    "description": "Name - switch name",
-                    "type": "string"
-                },
-                "serial": {
-                    "description": "Serial - switch serial number",
-                    "type": "string"
-                },
-                "switchModel": {
-                    "description": "SwitchModel - switch model",
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "description": "UpdatedAt - entity update time",
+                    "description": "ID revision ID",
                     "type": "string"
                 },
-                "username": {
-                    "description": "Username - switch admin username",
+                "requestID": {
+                    "description": "RequestID ID of the API request that saved the configuration, empty if it was saved by RoL itself",
                     "type": "string"
                 }
             }
         },
-        "dtos.EthernetSwitchModelDto": {
+        "dtos.HostNetworkConfigRevisionDiffDto": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Code - unique switch model code",
+                "diff": {
+                    "description": "Diff unified diff of the configurations in yaml",
                     "type": "string"
                 },
-                "manufacturer": {
-                    "description": "Manufacturer - Switch model manufacturer",
+                "equal": {
+                    "description": "Equal true if configurations are the same",
+                    "type": "boolean"
+                },
+                "fromID": {
+                    "description": "FromID ID of the original revision",
                     "type": "string"
                 },
-                "model": {
-                    "description": "Model - Switch model",
+                "toID": {
+                    "description": "ToID ID of the changed revision",
                     "type": "string"
                 }
             }
         },
-        "dtos.EthernetSwitchPortCreateDto": {
+        "dtos.HostNetworkConfigRevisionDto": {
             "type": "object",
             "properties": {
-                "name": {
-                    "description": "Name for this port",
+                "author": {
+                    "description": "Author who confirmed and saved the configuration",
                     "type": "string"
                 },
-                "poeenabled": {
-                    "description": "POEEnabled POE status on a port",
-                    "type": "boolean"
+                "createdAt": {
+                    "description": "CreatedAt time when the configuration was saved",
+                    "type": "string"
                 },
-                "poetype": {
-                    "description": "POEType type of PoE for this port\ncan be: \"poe\", \"poe+\", \"passive24\", \"none\"",
+                "id": {
+                    "description": "ID revision ID",
                     "type": "string"
                 },
-                "pvid": {
-                    "description": "PVID default VLAN id",
-                    "type": "integer"
+                "requestID": {
+                    "description": "RequestID ID of the API request that saved the configuration, empty if it was saved by RoL itself",
+                    "type": "string"
                 }
             }
         },
-        "dtos.EthernetSwitchPortDto": {
+        "dtos.HostNetworkLinkChangeDto": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "description": "CreatedAt - entity create time",
-                    "type": "string"
+                "miimon": {
+                    "description": "Miimon bond MII link monitoring frequency in milliseconds",
+                    "type": "integer"
                 },
-                "id": {
-                    "description": "ID - unique identifier",
+                "mode": {
+                    "description": "Mode bonding mode",
                     "type": "string"
                 },
                 "name": {
-                    "description": "Name for this port",
+                    "description": "Name full link name",
                     "type": "string"
                 },
-                "poeenabled": {
-                    "description": "POEEnabled POE status on a port",
-                    "type": "boolean"
+                "parent": {
+                    "description": "Parent vlan parent interface name",
+                    "type": "string"
                 },
-                "poetype": {
-                    "description": "POEType type of PoE for this port\ncan be: \"poe\", \"poe+\", \"passive24\", \"none\"",
+                "type": {
+                    "description": "Type link type: vlan, bridge, bond or veth",
                     "type": "string"
                 },
-                "pvid": {
-                    "description": "PVID default VLAN id",
+                "vlanID": {
+                    "description": "VlanID vlan id",
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "UpdatedAt - entity update time",
-                    "type": "string"
                 }
             }
         },
-        "dtos.EthernetSwitchPortUpdateDto": {
+        "dtos.HostNetworkNamespaceCreateDto": {
             "type": "object",
             "properties": {
-                "name": {
-                    "description": "Name for this port",
-                    "type": "string"
-                },
-                "poeenabled": {
-                    "description": "POEEnabled POE status on a port",
-                    "type": "boolean"
+                "links": {
+                    "description": "Links host links that are moved into the namespace",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkNamespaceLinkDto"
+                    }
                 },
-                "poetype": {
-                    "description": "POEType type of PoE for this port\ncan be: \"poe\", \"poe+\", \"passive24\", \"none\"",
+                "name": {
+                    "description": "Name namespace name, namespace full name will be rol.ns.{Name}",
                     "type": "string"
-                },
-                "pvid": {
-                    "description": "PVID default VLAN id",
-                    "type": "integer"
                 }
             }
         },
-        "dtos.EthernetSwitchUpdateDto": {
+        "dtos.HostNetworkNamespaceDto": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "Address - switch ip address",
-                    "type": "string"
+                "links": {
+                    "description": "Links host links that are moved into the namespace",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkNamespaceLinkDto"
+                    }
                 },
                 "name": {
-                    "description": "Name - switch name",
-                    "type": "string"
-                },
-                "password": {
-                    "description": "Password - ethernet switch management password",
+                    "description": "Name namespace full name",
                     "type": "string"
-                },
-                "serial": {
-                    "description": "Serial - switch serial number",
+                }
+            }
+        },
+        "dtos.HostNetworkNamespaceLinkChangeDto": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action \"add\" to move the link into the namespace or \"delete\" to move it back to the host",
                     "type": "string"
                 },
-                "switchModel": {
-                    "description": "SwitchModel - switch model",
+                "linkName": {
+                    "description": "LinkName link name",
                     "type": "string"
                 },
-                "username": {
-                    "description": "Username - switch admin username",
+                "namespace": {
+                    "description": "Namespace network namespace name",
                     "type": "string"
                 }
             }
         },
-        "dtos.EthernetSwitchVLANCreateDto": {
+        "dtos.HostNetworkNamespaceLinkDto": {
             "type": "object",
             "properties": {
-                "taggedPorts": {
-                    "description": "TaggedPorts slice of tagged ports IDs",
+                "addresses": {
+                    "description": "Addresses list of the link in the namespace",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "untaggedPorts": {
-                    "description": "UntaggedPorts slice of untagged ports IDs",
+                "name": {
+                    "description": "Name link name",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HostNetworkNamespaceUpdateDto": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "description": "Links host links that are moved into the namespace",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dtos.HostNetworkNamespaceLinkDto"
                     }
-                },
-                "vlanID": {
-                    "description": "VlanID VLAN ID",
-                    "type": "integer"
                 }
             }
         },
-        "dtos.EthernetSwitchVLANDto": {
+        "dtos.HostNetworkPlanDto": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "description": "CreatedAt - entity create time",
-                    "type": "string"
-                },
-                "ethernetSwitchID": {
-                    "description": "EthernetSwitchID ethernet switch ID",
-                    "type": "string"
+                "addresses": {
+                    "description": "Addresses addresses to add or delete",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkAddressChangeDto"
+                    }
                 },
-                "id": {
-                    "description": "ID - unique identifier",
-                    "type": "string"
+                "createLinks": {
+                    "description": "CreateLinks links to create, they are set up after creation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkLinkChangeDto"
+                    }
                 },
-                "taggedPorts": {
-                    "description": "TaggedPorts slice of tagged ports IDs",
+                "createNamespaces": {
+                    "description": "CreateNamespaces names of the network namespaces to create",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "untaggedPorts": {
-                    "description": "UntaggedPorts slice of untagged ports IDs",
+                "createRoutes": {
+                    "description": "CreateRoutes routes to create",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dtos.HostNetworkRouteDto"
                     }
                 },
-                "updatedAt": {
-                    "description": "UpdatedAt - entity update time",
-                    "type": "string"
+                "deleteLinks": {
+                    "description": "DeleteLinks links to delete",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkLinkChangeDto"
+                    }
                 },
-                "vlanID": {
-                    "description": "VlanID VLAN ID",
-                    "type": "integer"
-                }
-            }
-        },
-        "dtos.EthernetSwitchVLANUpdateDto": {
-            "type": "object",
-            "properties": {
-                "taggedPorts": {
-                    "description": "TaggedPorts slice of tagged ports IDs",
+                "deleteNamespaces": {
+                    "description": "DeleteNamespaces names of the network namespaces to delete",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "untaggedPorts": {
-                    "description": "UntaggedPorts slice of untagged ports IDs",
+                "deleteRoutes": {
+                    "description": "DeleteRoutes routes to delete",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dtos.HostNetworkRouteDto"
+                    }
+                },
+                "namespaceLinks": {
+                    "description": "NamespaceLinks links to move into namespaces or back to the host",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkNamespaceLinkChangeDto"
+                    }
+                },
+                "replaceLinks": {
+                    "description": "ReplaceLinks links which attributes can't be changed on the host, they are deleted and created again",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkLinkChangeDto"
+                    }
+                },
+                "slaves": {
+                    "description": "Slaves slaves to add to masters or remove from them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkSlaveChangeDto"
+                    }
+                },
+                "trafficRules": {
+                    "description": "TrafficRules traffic rules to create or delete",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkTrafficRuleChangeDto"
                     }
                 }
             }
         },
-        "dtos.HTTPLogDto": {
+        "dtos.HostNetworkRouteCreateDto": {
             "type": "object",
             "properties": {
-                "clientIP": {
-                    "description": "ClientIP - client IP address",
+                "destination": {
+                    "description": "Destination destination network in CIDR notation, 0.0.0.0/0 or ::/0 for the default route",
                     "type": "string"
                 },
-                "createdAt": {
-                    "description": "CreatedAt - entity create time",
+                "gateway": {
+                    "description": "Gateway next hop address of the same family as the destination, empty for the routes without gateway",
                     "type": "string"
                 },
-                "customRequestHeaders": {
-                    "description": "CustomRequestHeaders - custom headers of the request",
+                "interface": {
+                    "description": "Interface name of the outgoing interface, empty to resolve it by the gateway",
                     "type": "string"
                 },
-                "domain": {
-                    "description": "Domain - domain that processed the request",
+                "metric": {
+                    "description": "Metric route priority, 0 for the kernel default",
+                    "type": "integer"
+                },
+                "table": {
+                    "description": "Table routing table id, 0 for the main table",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.HostNetworkRouteDeleteDto": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "description": "Destination destination network in CIDR notation, 0.0.0.0/0 or ::/0 for the default route",
                     "type": "string"
                 },
-                "httpmethod": {
-                    "description": "HTTPMethod - http method",
+                "gateway": {
+                    "description": "Gateway next hop address of the same family as the destination, empty for the routes without gateway",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID - unique identifier",
+                "interface": {
+                    "description": "Interface name of the outgoing interface, empty to resolve it by the gateway",
                     "type": "string"
                 },
-                "latency": {
-                    "description": "Latency - latency in milliseconds",
+                "metric": {
+                    "description": "Metric route priority, 0 for the kernel default",
                     "type": "integer"
                 },
-                "queryParams": {
-                    "description": "QueryParams - query params passed",
-                    "type": "string"
-                },
-                "relativePath": {
-                    "description": "RelativePath - path to the endpoint",
+                "table": {
+                    "description": "Table routing table id, 0 for the main table",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.HostNetworkRouteDto": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "description": "Destination destination network in CIDR notation, 0.0.0.0/0 or ::/0 for the default route",
                     "type": "string"
                 },
-                "requestBody": {
-                    "description": "RequestBody - body of the request",
+                "gateway": {
+                    "description": "Gateway next hop address of the same family as the destination, empty for the routes without gateway",
                     "type": "string"
                 },
-                "requestHeaders": {
-                    "description": "RequestHeaders - headers of the request",
+                "interface": {
+                    "description": "Interface name of the outgoing interface, empty to resolve it by the gateway",
                     "type": "string"
                 },
-                "responseBody": {
-                    "description": "ResponseBody - body of the response",
-                    "type": "string"
+                "metric": {
+                    "description": "Metric route priority, 0 for the kernel default",
+                    "type": "integer"
                 },
-                "updatedAt": {
-                    "description": "UpdatedAt - entity update time",
-                    "type": "string"
+                "table": {
+                    "description": "Table routing table id, 0 for the main table",
+                    "type": "integer"
                 }
             }
         },
-        "dtos.HostNetworkBridgeCreateDto": {
+        "dtos.HostNetworkSlaveChangeDto": {
             "type": "object",
             "properties": {
-                "addresses": {
-                    "description": "Addresses list",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "action": {
+                    "description": "Action \"add\" to set the master or \"delete\" to remove the slave from the master",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "Name interface full name",
+                "master": {
+                    "description": "Master master link name",
                     "type": "string"
                 },
-                "slaves": {
-                    "description": "Slaves slice of slaves interfaces names",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "slave": {
+                    "description": "Slave slave link name",
+                    "type": "string"
                 }
             }
         },
-        "dtos.HostNetworkBridgeDto": {
+        "dtos.HostNetworkStatusDto": {
             "type": "object",
             "properties": {
-                "addresses": {
-                    "description": "Addresses list",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "name": {
-                    "description": "Name interface full name",
+                "confirmDeadline": {
+                    "description": "ConfirmDeadline time when unconfirmed changes will be reset to the saved configuration,\nnil if there is no pending reset",
                     "type": "string"
                 },
-                "slaves": {
-                    "description": "Slaves slice of slaves interfaces names",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "hasUnsavedChanges": {
+                    "description": "HasUnsavedChanges applied changes are not confirmed and saved yet",
+                    "type": "boolean"
+                },
+                "resetError": {
+                    "description": "ResetError error of the last failed automatic reset of unconfirmed changes, the reset is retried\nat the ConfirmDeadline, empty if the reset didn't fail",
+                    "type": "string"
                 }
             }
         },
-        "dtos.HostNetworkBridgeUpdateDto": {
+        "dtos.HostNetworkTrafficRuleChangeDto": {
             "type": "object",
             "properties": {
-                "addresses": {
-                    "description": "Addresses list",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "action": {
+                    "description": "Action \"add\" or \"delete\"",
+                    "type": "string"
                 },
-                "slaves": {
-                    "description": "Slaves slice of slaves interfaces names",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "rule": {
+                    "description": "Rule traffic rule, rule is created at its position",
+                    "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                },
+                "table": {
+                    "description": "Table netfilter table",
+                    "type": "string"
                 }
             }
         },
@@ -3171,16 +6612,52 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "chain": {
-                    "description": "Chain rule chain",
+                    "description": "Chain rule chain, the rule is placed in the RoL chain ROL-{Chain} that is jumped from the chain",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Comment rule comment",
                     "type": "string"
                 },
                 "destination": {
                     "description": "Destination packets destination",
                     "type": "string"
                 },
+                "destinationPorts": {
+                    "description": "DestinationPorts packets destination ports in the same format as SourcePorts",
+                    "type": "string"
+                },
+                "inInterface": {
+                    "description": "InInterface name of the interface via which a packet was received",
+                    "type": "string"
+                },
+                "outInterface": {
+                    "description": "OutInterface name of the interface via which a packet is going to be sent",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position 1-based rule position in the chain, 0 to append the rule to the end of the chain",
+                    "type": "integer"
+                },
+                "protocol": {
+                    "description": "Protocol packets protocol: tcp, udp or icmp, empty for any protocol",
+                    "type": "string"
+                },
                 "source": {
                     "description": "Source packets source",
                     "type": "string"
+                },
+                "sourcePorts": {
+                    "description": "SourcePorts packets source ports, single port \"67\", range \"1000:2000\" or list \"67,69\"",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State comma separated conntrack states like NEW, RELATED, ESTABLISHED",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To DNAT or SNAT target address like 192.168.1.10 or 192.168.1.10:80",
+                    "type": "string"
                 }
             }
         },
@@ -3192,16 +6669,52 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "chain": {
-                    "description": "Chain rule chain",
+                    "description": "Chain rule chain, the rule is placed in the RoL chain ROL-{Chain} that is jumped from the chain",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Comment rule comment",
                     "type": "string"
                 },
                 "destination": {
                     "description": "Destination packets destination",
                     "type": "string"
                 },
+                "destinationPorts": {
+                    "description": "DestinationPorts packets destination ports in the same format as SourcePorts",
+                    "type": "string"
+                },
+                "inInterface": {
+                    "description": "InInterface name of the interface via which a packet was received",
+                    "type": "string"
+                },
+                "outInterface": {
+                    "description": "OutInterface name of the interface via which a packet is going to be sent",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position 1-based rule position in the chain, 0 to append the rule to the end of the chain",
+                    "type": "integer"
+                },
+                "protocol": {
+                    "description": "Protocol packets protocol: tcp, udp or icmp, empty for any protocol",
+                    "type": "string"
+                },
                 "source": {
                     "description": "Source packets source",
                     "type": "string"
+                },
+                "sourcePorts": {
+                    "description": "SourcePorts packets source ports, single port \"67\", range \"1000:2000\" or list \"67,69\"",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State comma separated conntrack states like NEW, RELATED, ESTABLISHED",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To DNAT or SNAT target address like 192.168.1.10 or 192.168.1.10:80",
+                    "type": "string"
                 }
             }
         },
@@ -3213,16 +6726,140 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "chain": {
-                    "description": "Chain rule chain",
+                    "description": "Chain rule chain, the rule is placed in the RoL chain ROL-{Chain} that is jumped from the chain",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Comment rule comment",
                     "type": "string"
                 },
                 "destination": {
                     "description": "Destination packets destination",
                     "type": "string"
                 },
+                "destinationPorts": {
+                    "description": "DestinationPorts packets destination ports in the same format as SourcePorts",
+                    "type": "string"
+                },
+                "inInterface": {
+                    "description": "InInterface name of the interface via which a packet was received",
+                    "type": "string"
+                },
+                "outInterface": {
+                    "description": "OutInterface name of the interface via which a packet is going to be sent",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position 1-based rule position in the chain, 0 to append the rule to the end of the chain",
+                    "type": "integer"
+                },
+                "protocol": {
+                    "description": "Protocol packets protocol: tcp, udp or icmp, empty for any protocol",
+                    "type": "string"
+                },
                 "source": {
                     "description": "Source packets source",
                     "type": "string"
+                },
+                "sourcePorts": {
+                    "description": "SourcePorts packets source ports, single port \"67\", range \"1000:2000\" or list \"67,69\"",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State comma separated conntrack states like NEW, RELATED, ESTABLISHED",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To DNAT or SNAT target address like 192.168.1.10 or 192.168.1.10:80",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HostNetworkTrafficRulesDto": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "description": "Filter 'filter' table rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                    }
+                },
+                "mangle": {
+                    "description": "Mangle 'mangle' table rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                    }
+                },
+                "nat": {
+                    "description": "NAT 'nat' table rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                    }
+                },
+                "raw": {
+                    "description": "Raw 'raw' table rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                    }
+                },
+                "security": {
+                    "description": "Security 'security' table rules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HostNetworkTrafficRuleDto"
+                    }
+                }
+            }
+        },
+        "dtos.HostNetworkVethCreateDto": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "Addresses list of the rol.veth.{Name} end",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name veth pair name, ends of the pair will be rol.veth.{Name} and rol.peer.{Name}",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HostNetworkVethDto": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "Addresses list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name interface full name",
+                    "type": "string"
+                },
+                "peer": {
+                    "description": "Peer name of the other end of the pair",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HostNetworkVethUpdateDto": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "Addresses list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -3346,6 +6983,22 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.PaginatedItemsDto-dtos_EthernetSwitchConfigBackupDto": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items slice of items",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchConfigBackupDto"
+                    }
+                },
+                "pagination": {
+                    "description": "Pagination info about pagination",
+                    "$ref": "#/definitions/dtos.PaginationInfoDto"
+                }
+            }
+        },
         "dtos.PaginatedItemsDto-dtos_EthernetSwitchDto": {
             "type": "object",
             "properties": {
@@ -3362,6 +7015,22 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.PaginatedItemsDto-dtos_EthernetSwitchLAGDto": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items slice of items",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EthernetSwitchLAGDto"
+                    }
+                },
+                "pagination": {
+                    "description": "Pagination info about pagination",
+                    "$ref": "#/definitions/dtos.PaginationInfoDto"
+                }
+            }
+        },
         "dtos.PaginatedItemsDto-dtos_EthernetSwitchPortDto": {
             "type": "object",
             "properties": {
@@ -3394,6 +7063,22 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.PaginatedItemsDto-dtos_FabricVLANDto": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items slice of items",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FabricVLANDto"
+                    }
+                },
+                "pagination": {
+                    "description": "Pagination info about pagination",
+                    "$ref": "#/definitions/dtos.PaginationInfoDto"
+                }
+            }
+        },
         "dtos.PaginatedItemsDto-dtos_HTTPLogDto": {
             "type": "object",
             "properties": {
@@ -3512,6 +7197,10 @@ const docTemplate = `{
                     "description": "Enabled TFTP server startup status",
                     "type": "boolean"
                 },
+                "namespace": {
+                    "description": "Namespace network namespace name where the server is running, empty for the host network namespace",
+                    "type": "string"
+                },
                 "port": {
                     "description": "Port TFTP server port",
                     "type": "string"
@@ -3537,6 +7226,10 @@ const docTemplate = `{
                     "description": "ID - unique identifier",
                     "type": "string"
                 },
+                "namespace": {
+                    "description": "Namespace network namespace name where the server is running, empty for the host network namespace",
+                    "type": "string"
+                },
                 "port": {
                     "description": "Port TFTP server port",
                     "type": "string"
@@ -3562,6 +7255,10 @@ const docTemplate = `{
                     "description": "Enabled TFTP server startup status",
                     "type": "boolean"
                 },
+                "namespace": {
+                    "description": "Namespace network namespace name where the server is running, empty for the host network namespace",
+                    "type": "string"
+                },
                 "port": {
                     "description": "Port TFTP server port",
                     "type": "string"